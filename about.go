@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// --- About This Machine Panel ---
+//
+// A neofetch-style summary gathered once at startup. It complements the live
+// metrics in the System panel with things that don't change while running.
+
+const aboutCommandTimeout = 5 * time.Second
+
+var (
+	lspciGPULine   = regexp.MustCompile(`(?i)(VGA compatible controller|3D controller|Display controller): (.*)`)
+	macChipsetLine = regexp.MustCompile(`Chipset Model: (.*)`)
+	macDiskLine    = regexp.MustCompile(`Device / Media Name: (.*)`)
+)
+
+var osLogos = map[string][]string{
+	"linux": {
+		`    .--.   `,
+		`   |o_o |  `,
+		`   |:_/ |  `,
+		`  //   \ \ `,
+		` (|     | )`,
+		`/'\_   _/'\`,
+		`\___)=(___/`,
+	},
+	"darwin": {
+		`      .:'  `,
+		`  __ :'__  `,
+		`.'  '  '.` + "`" + `.`,
+		`:       :  `,
+		`:       :  `,
+		` '.__.__.' `,
+	},
+	"windows": {
+		`#### ####`,
+		`#### ####`,
+		`         `,
+		`#### ####`,
+		`#### ####`,
+	},
+	"": {
+		` _______ `,
+		`|       |`,
+		`| >_    |`,
+		`|_______|`,
+		`  _|_|_  `,
+	},
+}
+
+type MachineInfo struct {
+	Hostname string
+	OS       string
+	Kernel   string
+	CPU      string
+	RAM      string
+	GPUs     []string
+	Disks    []string
+	Booted   time.Time
+}
+
+// aboutEnabled reports whether ABOUT is switched on.
+func aboutEnabled() bool {
+	v := strings.ToLower(os.Getenv("ABOUT"))
+	return v == "true" || v == "1" || v == "yes"
+}
+
+// aboutCommand runs a detection command with a timeout, returning "" on failure.
+func aboutCommand(name string, args ...string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), aboutCommandTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+// gatherMachineInfo collects the static facts. Anything that can't be
+// detected is left empty and shown as "Unknown".
+func gatherMachineInfo() MachineInfo {
+	var info MachineInfo
+	info.Hostname, _ = os.Hostname()
+	if h, err := host.Info(); err == nil {
+		info.OS = strings.TrimSpace(fmt.Sprintf("%s %s", h.Platform, h.PlatformVersion))
+		info.Kernel = strings.TrimSpace(fmt.Sprintf("%s %s", h.KernelVersion, h.KernelArch))
+		info.Booted = time.Unix(int64(h.BootTime), 0)
+	}
+	if cpus, err := cpu.Info(); err == nil && len(cpus) > 0 {
+		info.CPU = strings.Join(strings.Fields(cpus[0].ModelName), " ")
+		if cores, err := cpu.Counts(true); err == nil {
+			info.CPU += fmt.Sprintf(" (%d)", cores)
+		}
+	}
+	if vm, err := mem.VirtualMemory(); err == nil {
+		info.RAM = formatBytes(int64(vm.Total))
+	}
+	info.GPUs = detectGPUs()
+	info.Disks = detectDisks()
+	return info
+}
+
+func detectGPUs() []string {
+	var gpus []string
+	switch runtime.GOOS {
+	case "linux":
+		for _, line := range strings.Split(aboutCommand("lspci"), "\n") {
+			if m := lspciGPULine.FindStringSubmatch(line); m != nil {
+				gpus = append(gpus, strings.TrimSpace(m[2]))
+			}
+		}
+	case "darwin":
+		for _, m := range macChipsetLine.FindAllStringSubmatch(aboutCommand("system_profiler", "SPDisplaysDataType"), -1) {
+			gpus = append(gpus, strings.TrimSpace(m[1]))
+		}
+	case "windows":
+		gpus = windowsCIMNames("Win32_VideoController")
+	}
+	return gpus
+}
+
+func detectDisks() []string {
+	var disks []string
+	switch runtime.GOOS {
+	case "linux":
+		models, _ := filepath.Glob("/sys/block/*/device/model")
+		for _, path := range models {
+			dev := filepath.Base(filepath.Dir(filepath.Dir(path)))
+			if strings.HasPrefix(dev, "loop") || strings.HasPrefix(dev, "ram") {
+				continue
+			}
+			if data, err := os.ReadFile(path); err == nil && strings.TrimSpace(string(data)) != "" {
+				disks = append(disks, fmt.Sprintf("%s (%s)", strings.TrimSpace(string(data)), dev))
+			}
+		}
+	case "darwin":
+		if m := macDiskLine.FindStringSubmatch(aboutCommand("diskutil", "info", "disk0")); m != nil {
+			disks = append(disks, strings.TrimSpace(m[1]))
+		}
+	case "windows":
+		disks = windowsCIMNames("Win32_DiskDrive")
+	}
+	return disks
+}
+
+// windowsCIMNames lists the Name/Model of every instance of a CIM class.
+func windowsCIMNames(class string) []string {
+	field := "Name"
+	if class == "Win32_DiskDrive" {
+		field = "Model"
+	}
+	out := aboutCommand("powershell", "-NoProfile", "-Command",
+		fmt.Sprintf("Get-CimInstance %s | ForEach-Object { $_.%s }", class, field))
+	var names []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names
+}
+
+func (b *Baseline) fetchAbout() {
+	info := gatherMachineInfo()
+	b.mu.Lock()
+	b.aboutInfo = &info
+	b.mu.Unlock()
+	b.updateAbout()
+}
+
+func (b *Baseline) updateAbout() {
+	b.mu.RLock()
+	info := b.aboutInfo
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	if info == nil {
+		b.setPanel(b.aboutPanel, fmt.Sprintf("%sDetecting hardware...[-:-:-]", dimC))
+		return
+	}
+
+	orUnknown := func(s string) string {
+		if s == "" {
+			return "Unknown"
+		}
+		return s
+	}
+	lines := []string{
+		fmt.Sprintf("%s%s[-:-:-]", brightC+"[::b]", tview.Escape(orUnknown(info.Hostname))),
+		fmt.Sprintf("%sOS:     %s%s[-:-:-]", dimC, mainC, tview.Escape(orUnknown(info.OS))),
+		fmt.Sprintf("%sKernel: %s%s[-:-:-]", dimC, mainC, tview.Escape(orUnknown(info.Kernel))),
+		fmt.Sprintf("%sCPU:    %s%s[-:-:-]", dimC, mainC, tview.Escape(orUnknown(info.CPU))),
+		fmt.Sprintf("%sRAM:    %s%s[-:-:-]", dimC, mainC, orUnknown(info.RAM)),
+	}
+	gpus, disks := info.GPUs, info.Disks
+	if len(gpus) == 0 {
+		gpus = []string{""}
+	}
+	if len(disks) == 0 {
+		disks = []string{""}
+	}
+	for _, gpu := range gpus {
+		lines = append(lines, fmt.Sprintf("%sGPU:    %s%s[-:-:-]", dimC, mainC, tview.Escape(orUnknown(gpu))))
+	}
+	for _, disk := range disks {
+		lines = append(lines, fmt.Sprintf("%sDisk:   %s%s[-:-:-]", dimC, mainC, tview.Escape(orUnknown(disk))))
+	}
+	if !info.Booted.IsZero() {
+		lines = append(lines, fmt.Sprintf("%sBooted: %s%s[-:-:-]", dimC, mainC, info.Booted.Format("2006-01-02 15:04")))
+	}
+
+	logo, ok := osLogos[runtime.GOOS]
+	if !ok {
+		logo = osLogos[""]
+	}
+	logoWidth := 0
+	for _, l := range logo {
+		logoWidth = max(logoWidth, len(l))
+	}
+
+	// Logo on the left, facts on the right, like neofetch
+	var sb strings.Builder
+	for i := 0; i < max(len(logo), len(lines)); i++ {
+		logoLine := ""
+		if i < len(logo) {
+			logoLine = logo[i]
+		}
+		sb.WriteString(fmt.Sprintf("%s%-*s[-:-:-]  ", brightC, logoWidth, logoLine))
+		if i < len(lines) {
+			sb.WriteString(lines[i])
+		}
+		sb.WriteString("\n")
+	}
+
+	b.setPanel(b.aboutPanel, sb.String())
+}
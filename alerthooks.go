@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// --- Alert Hook Commands ---
+//
+// ALERT_ON_FIRE / ALERT_ON_CLEAR run a shell command when any alert fires or
+// clears. ALERT_ON_FIRE_<KEY> / ALERT_ON_CLEAR_<KEY> override them for a single
+// alert, the key upper-cased with dashes as underscores (e.g. ALERT_ON_FIRE_DISK_FULL).
+// The alert is described to the command through BASELINE_ALERT_* variables.
+
+const alertHookTimeout = 30 * time.Second
+
+// alertHookCommand returns the command configured for event ("fire"/"clear") and key.
+func alertHookCommand(event, key string) string {
+	name := "ALERT_ON_" + strings.ToUpper(event)
+	suffix := strings.ToUpper(strings.NewReplacer("-", "_", ".", "_", " ", "_").Replace(key))
+	if cmd := os.Getenv(name + "_" + suffix); cmd != "" {
+		return cmd
+	}
+	return os.Getenv(name)
+}
+
+// runAlertHook runs the hook for an alert event, if one is configured. A
+// config.toml alert rule's on_fire/on_clear wins over the environment.
+func (b *Baseline) runAlertHook(event string, a Alert) {
+	command := alertHookCommand(event, a.Key)
+	if rule, ok := b.alertRule(a.Key); ok && rule.command(event) != "" {
+		command = rule.command(event)
+	}
+	if command == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), alertHookTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Env = append(os.Environ(),
+		"BASELINE_ALERT_EVENT="+event,
+		"BASELINE_ALERT_KEY="+a.Key,
+		"BASELINE_ALERT_SOURCE="+a.Source,
+		"BASELINE_ALERT_MESSAGE="+a.Message,
+		fmt.Sprintf("BASELINE_ALERT_VALUE=%g", a.Value),
+		fmt.Sprintf("BASELINE_ALERT_THRESHOLD=%g", a.Threshold),
+		"BASELINE_ALERT_SINCE="+a.Since.Format(time.RFC3339),
+	)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		slog.Error("Alert hook failed", "event", event, "alert", a.Key, "err", err, "output", strings.TrimSpace(string(out)))
+		b.notify("alerts", fmt.Sprintf("Alert %s hook failed: %v", event, err), "error")
+	}
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// --- Alert Rules ---
+//
+// [[alert]] tables in config.toml turn system metrics into critical alerts:
+//
+//	[[alert]]
+//	when = "cpu > 90"
+//	for = "30s"
+//	on_fire = "notify-send 'CPU busy'"
+//
+// A rule fires once its condition has held for the whole `for` duration and
+// clears as soon as it stops holding. Firing alerts behave like any other
+// critical alert (repeats, ack, snooze, ALERT_ON_* hooks); on_fire/on_clear
+// take precedence over the hooks for that rule. The panel showing the metric
+// flashes when a rule fires and keeps a red border until it clears.
+
+const (
+	alertFlashCount    = 6
+	alertFlashInterval = 400 * time.Millisecond
+)
+
+// alertMetrics are the values a rule can test, with how to display them.
+var alertMetrics = map[string]string{
+	"cpu":  "CPU %.1f%%",
+	"mem":  "Memory %.1f%%",
+	"disk": "Disk / %.1f%%",
+	"load": "Load %.2f",
+	"temp": "Temperature %.0f°C",
+}
+
+type alertRuleFile struct {
+	Name    string `toml:"name"`
+	When    string `toml:"when"`
+	For     string `toml:"for"`
+	OnFire  string `toml:"on_fire"`
+	OnClear string `toml:"on_clear"`
+}
+
+type AlertRule struct {
+	Name      string
+	Metric    string
+	Op        string
+	Threshold float64
+	For       time.Duration
+	OnFire    string
+	OnClear   string
+}
+
+// parseAlertRule validates one [[alert]] table.
+func parseAlertRule(f alertRuleFile) (AlertRule, error) {
+	fields := strings.Fields(f.When)
+	if len(fields) != 3 {
+		return AlertRule{}, fmt.Errorf("when = %q must look like \"cpu > 90\"", f.When)
+	}
+	r := AlertRule{
+		Name:    f.Name,
+		Metric:  strings.ToLower(fields[0]),
+		Op:      fields[1],
+		OnFire:  f.OnFire,
+		OnClear: f.OnClear,
+	}
+	if _, ok := alertMetrics[r.Metric]; !ok {
+		return AlertRule{}, fmt.Errorf("unknown metric %q (cpu, mem, disk, load or temp)", fields[0])
+	}
+	switch r.Op {
+	case ">", ">=", "<", "<=":
+	default:
+		return AlertRule{}, fmt.Errorf("unknown operator %q (>, >=, < or <=)", r.Op)
+	}
+	threshold, err := strconv.ParseFloat(strings.TrimSuffix(fields[2], "%"), 64)
+	if err != nil {
+		return AlertRule{}, fmt.Errorf("threshold %q is not a number", fields[2])
+	}
+	r.Threshold = threshold
+	if f.For != "" {
+		if r.For, err = time.ParseDuration(f.For); err != nil || r.For < 0 {
+			return AlertRule{}, fmt.Errorf("for = %q is not a duration (e.g. \"30s\")", f.For)
+		}
+	}
+	if r.Name == "" {
+		r.Name = fmt.Sprintf("%s-%g", r.Metric, r.Threshold) // e.g. cpu-90, alert key rule-cpu-90
+	}
+	return r, nil
+}
+
+// key identifies the rule's alert among the other critical alerts.
+func (r AlertRule) key() string {
+	return "rule-" + r.Name
+}
+
+func (r AlertRule) holds(value float64) bool {
+	switch r.Op {
+	case ">":
+		return value > r.Threshold
+	case ">=":
+		return value >= r.Threshold
+	case "<":
+		return value < r.Threshold
+	}
+	return value <= r.Threshold
+}
+
+// command returns the rule's own command for event ("fire"/"clear").
+func (r AlertRule) command(event string) string {
+	if event == "fire" {
+		return r.OnFire
+	}
+	return r.OnClear
+}
+
+func (r AlertRule) describe(value float64) string {
+	return fmt.Sprintf(alertMetrics[r.Metric], value) + fmt.Sprintf(" (%s %s %g)", r.Metric, r.Op, r.Threshold)
+}
+
+// alertRule finds the rule behind an alert key. The rules never change after
+// startup, so no lock is needed.
+func (b *Baseline) alertRule(key string) (AlertRule, bool) {
+	for _, r := range b.alertRules {
+		if r.key() == key {
+			return r, true
+		}
+	}
+	return AlertRule{}, false
+}
+
+// checkAlertRules evaluates every rule against this pass's metrics. Called
+// from updateSystemInfo with b.mu held.
+func (b *Baseline) checkAlertRules(metrics map[string]float64) {
+	now := time.Now()
+	for _, r := range b.alertRules {
+		if r.Metric == "temp" {
+			if _, ok := metrics["temp"]; !ok {
+				hottest := 0.0
+				for _, t := range readTemperatures() {
+					hottest = max(hottest, t)
+				}
+				metrics["temp"] = hottest
+			}
+		}
+		value, ok := metrics[r.Metric]
+		if !ok {
+			continue // Metric unavailable this pass, leave the rule as it is
+		}
+		if !r.holds(value) {
+			delete(b.rulePending, r.Name)
+			if b.ruleFiring[r.Name] {
+				delete(b.ruleFiring, r.Name)
+				b.resolveAlert(r.key(), "Cleared: "+r.describe(value))
+				b.flashPanel(false)
+			}
+			continue
+		}
+		since, pending := b.rulePending[r.Name]
+		if !pending {
+			since = now
+			b.rulePending[r.Name] = now
+		}
+		if now.Sub(since) < r.For {
+			continue
+		}
+		if !b.ruleFiring[r.Name] {
+			b.ruleFiring[r.Name] = true
+			b.flashPanel(true)
+		}
+		b.critical("alerts", r.key(), r.describe(value), value, r.Threshold)
+	}
+}
+
+// systemBorder is the system panel's border color: red (or the theme's error
+// color) while a rule is firing. Called with b.mu held.
+func (b *Baseline) systemBorder() tcell.Color {
+	if len(b.ruleFiring) == 0 {
+		return b.theme.border()
+	}
+	if b.theme.Error != tcell.ColorDefault {
+		return b.theme.Error
+	}
+	return tcell.ColorRed
+}
+
+// flashPanel blinks the system panel border when a rule fires, then leaves it
+// at systemBorder. With firing false it only restores the border. Called with
+// b.mu held.
+func (b *Baseline) flashPanel(firing bool) {
+	panel := b.systemPanel.Box
+	if !firing {
+		settle := b.systemBorder()
+		b.app.QueueUpdateDraw(func() { panel.SetBorderColor(settle) })
+		return
+	}
+	alertColor, normal := b.systemBorder(), b.theme.border()
+	go func() {
+		for i := 0; i < alertFlashCount; i++ {
+			color := alertColor
+			if i%2 == 1 {
+				color = normal
+			}
+			b.app.QueueUpdateDraw(func() { panel.SetBorderColor(color) })
+			time.Sleep(alertFlashInterval)
+		}
+		b.mu.RLock()
+		settle := b.systemBorder()
+		b.mu.RUnlock()
+		b.app.QueueUpdateDraw(func() { panel.SetBorderColor(settle) })
+	}()
+}
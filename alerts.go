@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// --- Active Alerts ---
+//
+// A critical condition stays "active" until its check reports it cleared.
+// Active alerts are repeated every alertRepeatInterval unless acknowledged
+// or snoozed from the notification center (or the ack/snooze commands).
+
+const (
+	alertRepeatInterval = 15 * time.Minute
+	alertSnoozeDefault  = 30 * time.Minute
+	diskCriticalDefault = 95.0 // Root filesystem usage that counts as "disk full"
+)
+
+type Alert struct {
+	Key          string
+	Source       string
+	Message      string
+	Value        float64 // Metric that triggered the alert, passed to hook commands
+	Threshold    float64
+	Since        time.Time
+	LastNotified time.Time
+	Acked        bool
+	SnoozedUntil time.Time
+}
+
+// State returns a short label for the alert list.
+func (a Alert) State() string {
+	switch {
+	case a.Acked:
+		return "acked"
+	case time.Now().Before(a.SnoozedUntil):
+		return "snoozed until " + a.SnoozedUntil.Format("15:04")
+	}
+	return "active"
+}
+
+// critical raises (or keeps raising) the alert identified by key. Checks call
+// it on every pass while the condition holds; the user is only notified when
+// the alert is new or due for a repeat, and never while acked or snoozed.
+// value and threshold describe the metric behind it (0 when there is none).
+func (b *Baseline) critical(source, key, message string, value, threshold float64) {
+	now := time.Now()
+
+	b.notifMu.Lock()
+	if b.alerts == nil {
+		b.alerts = map[string]*Alert{}
+	}
+	a, exists := b.alerts[key]
+	if !exists {
+		a = &Alert{Key: key, Source: source, Since: now}
+		b.alerts[key] = a
+	}
+	a.Message = message
+	a.Value, a.Threshold = value, threshold
+	alert := *a
+	due := !a.Acked && !now.Before(a.SnoozedUntil) && now.Sub(a.LastNotified) >= alertRepeatInterval
+	if due {
+		a.LastNotified = now
+	}
+	b.notifMu.Unlock()
+
+	if !exists {
+		go b.runAlertHook("fire", alert)
+	}
+	if !due {
+		return
+	}
+	if exists {
+		message += " (still active)"
+	}
+	b.notifyPriority(source, message, "error", PriorityHigh)
+	b.emailAlert(source, key, message)
+}
+
+// resolveAlert clears an active alert and announces recovery.
+func (b *Baseline) resolveAlert(key, message string) {
+	b.notifMu.Lock()
+	a, exists := b.alerts[key]
+	delete(b.alerts, key)
+	b.notifMu.Unlock()
+
+	if exists {
+		go b.runAlertHook("clear", *a)
+		b.notify(a.Source, message, "success")
+	}
+}
+
+// activeAlerts returns a snapshot of the active alerts, oldest first.
+func (b *Baseline) activeAlerts() []Alert {
+	b.notifMu.Lock()
+	defer b.notifMu.Unlock()
+
+	alerts := make([]Alert, 0, len(b.alerts))
+	for _, a := range b.alerts {
+		alerts = append(alerts, *a)
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].Since.Before(alerts[j].Since)
+	})
+	return alerts
+}
+
+// ackAlert toggles acknowledgement of the active alert with the given key.
+func (b *Baseline) ackAlert(key string) {
+	b.notifMu.Lock()
+	var alert Alert
+	a, ok := b.alerts[key]
+	if ok {
+		a.Acked = !a.Acked
+		a.SnoozedUntil = time.Time{}
+		alert = *a
+	}
+	b.notifMu.Unlock()
+
+	if !ok {
+		return
+	}
+	if alert.Acked {
+		b.notify(alert.Source, fmt.Sprintf("Acknowledged: %s", alert.Message), "info")
+	} else {
+		b.notify(alert.Source, fmt.Sprintf("Unacknowledged: %s", alert.Message), "info")
+	}
+}
+
+// snoozeAlert silences the alert with the given key for d.
+func (b *Baseline) snoozeAlert(key string, d time.Duration) {
+	b.notifMu.Lock()
+	var alert Alert
+	a, ok := b.alerts[key]
+	if ok {
+		a.Acked = false
+		a.SnoozedUntil = time.Now().Add(d)
+		a.LastNotified = time.Time{} // Repeat as soon as the snooze ends
+		alert = *a
+	}
+	b.notifMu.Unlock()
+
+	if ok {
+		b.notify(alert.Source, fmt.Sprintf("Snoozed for %s: %s", d, alert.Message), "info")
+	}
+}
+
+// alertCommand handles "ack <n>" and "snooze <n> [minutes]", n being the
+// 1-based position in the notification center's alert list.
+func (b *Baseline) alertCommand(cmd string, args []string) {
+	alerts := b.activeAlerts()
+	if len(alerts) == 0 {
+		b.addNotification("No active alerts", "info")
+		return
+	}
+	index := 1
+	if len(args) > 0 {
+		index, _ = strconv.Atoi(args[0])
+	}
+	if index < 1 || index > len(alerts) {
+		b.addNotification(fmt.Sprintf("Invalid alert index: %s (1-%d)", args[0], len(alerts)), "error")
+		return
+	}
+	key := alerts[index-1].Key
+
+	if cmd == "ack" {
+		b.ackAlert(key)
+		return
+	}
+	d := alertSnoozeDefault
+	if len(args) > 1 {
+		minutes, err := strconv.Atoi(args[1])
+		if err != nil || minutes <= 0 {
+			b.addNotification("Usage: snooze <n> [minutes]", "error")
+			return
+		}
+		d = time.Duration(minutes) * time.Minute
+	}
+	b.snoozeAlert(key, d)
+}
+
+// --- Disk Space Check ---
+
+// diskCriticalThreshold reads DISK_CRITICAL_PERCENT, falling back to 95%.
+func diskCriticalThreshold() float64 {
+	if v, err := strconv.ParseFloat(os.Getenv("DISK_CRITICAL_PERCENT"), 64); err == nil && v > 0 {
+		return v
+	}
+	return diskCriticalDefault
+}
+
+// checkDiskSpace keeps the "disk full" alert in line with root filesystem usage.
+// Called from updateSystemInfo with b.mu held.
+func (b *Baseline) checkDiskSpace(percent float64) {
+	if percent >= b.diskCritical {
+		b.critical("system", "disk-full", fmt.Sprintf("Disk almost full: / at %.1f%%", percent), percent, b.diskCritical)
+	} else {
+		b.resolveAlert("disk-full", fmt.Sprintf("Disk space recovered: / at %.1f%%", percent))
+	}
+}
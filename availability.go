@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+// --- Session Uptime And Availability ---
+//
+// availability.json keeps every Baseline session and every host boot seen.
+// A heartbeat moves LastSeen/End forward, so a crash or power cut still
+// leaves a close estimate of when the machine went down.
+
+const (
+	availabilityHeartbeat   = 1 * time.Minute
+	availabilityMaxSessions = 500
+	availabilityMaxBoots    = 500
+	bootTimeTolerance       = 2 * time.Minute // Reported boot times drift slightly
+)
+
+type BootRecord struct {
+	Boot     time.Time `json:"boot"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// Uptime is how long the host stayed up, as far as Baseline saw.
+func (r BootRecord) Uptime() time.Duration { return r.LastSeen.Sub(r.Boot) }
+
+type SessionRecord struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+type availabilityFile struct {
+	Boots    []BootRecord    `json:"boots"`
+	Sessions []SessionRecord `json:"sessions"`
+}
+
+// startAvailability records this session and the current boot. Called from NewBaseline.
+func (b *Baseline) startAvailability() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if data, err := os.ReadFile(filepath.Join(b.configDir, "availability.json")); err == nil {
+		if err := json.Unmarshal(data, &b.uptimeLog); err != nil {
+			b.notify("system", fmt.Sprintf("Error parsing availability.json: %v", err), "error")
+		}
+	}
+
+	now := time.Now()
+	b.uptimeLog.Sessions = append(b.uptimeLog.Sessions, SessionRecord{Start: now, End: now})
+	if bootSecs, err := host.BootTime(); err == nil {
+		boot := time.Unix(int64(bootSecs), 0)
+		n := len(b.uptimeLog.Boots)
+		if n > 0 && b.uptimeLog.Boots[n-1].Boot.Sub(boot).Abs() < bootTimeTolerance {
+			b.uptimeLog.Boots[n-1].LastSeen = now
+		} else {
+			b.uptimeLog.Boots = append(b.uptimeLog.Boots, BootRecord{Boot: boot, LastSeen: now})
+		}
+	}
+	b.saveAvailability()
+}
+
+// heartbeatAvailability moves the current session's end and boot's last-seen forward.
+func (b *Baseline) heartbeatAvailability() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if n := len(b.uptimeLog.Sessions); n > 0 {
+		b.uptimeLog.Sessions[n-1].End = now
+	}
+	if n := len(b.uptimeLog.Boots); n > 0 {
+		b.uptimeLog.Boots[n-1].LastSeen = now
+	}
+	b.saveAvailability()
+}
+
+func (b *Baseline) saveAvailability() {
+	// Called from within locked sections
+	if n := len(b.uptimeLog.Sessions); n > availabilityMaxSessions {
+		b.uptimeLog.Sessions = b.uptimeLog.Sessions[n-availabilityMaxSessions:]
+	}
+	if n := len(b.uptimeLog.Boots); n > availabilityMaxBoots {
+		b.uptimeLog.Boots = b.uptimeLog.Boots[n-availabilityMaxBoots:]
+	}
+	data, err := json.MarshalIndent(b.uptimeLog, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(b.configDir, "availability.json"), data, 0640); err != nil {
+		b.notify("system", fmt.Sprintf("Error saving availability: %v", err), "error")
+	}
+}
+
+// openAvailability shows host uptime history and Baseline sessions.
+// Must run on the UI goroutine.
+func (b *Baseline) openAvailability() {
+	view := newPanel(" Availability ")
+	view.SetBorderColor(b.theme.Bright)
+	view.SetTitleColor(b.theme.Bright)
+	view.SetTextColor(b.theme.Main)
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	b.mu.RLock()
+	boots := append([]BootRecord(nil), b.uptimeLog.Boots...)
+	sessions := append([]SessionRecord(nil), b.uptimeLog.Sessions...)
+	b.mu.RUnlock()
+
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	var longest BootRecord
+	rebootsThisMonth := 0
+	for i, r := range boots {
+		if r.Uptime() > longest.Uptime() {
+			longest = r
+		}
+		if i > 0 && !r.Boot.Before(monthStart) {
+			rebootsThisMonth++ // The first boot on record may not be a reboot we saw
+		}
+	}
+	var sessionTime time.Duration
+	sessionsThisMonth := 0
+	for _, s := range sessions {
+		if !s.Start.Before(monthStart) {
+			sessionTime += s.End.Sub(s.Start)
+			sessionsThisMonth++
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sHOST[-:-:-]\n", brightC+"[::b]"))
+	if len(boots) > 0 {
+		current := boots[len(boots)-1]
+		sb.WriteString(fmt.Sprintf("%sUp since:      %s%s (%s)[-:-:-]\n", mainC, brightC, current.Boot.Format("2006-01-02 15:04"), formatDuration(now.Sub(current.Boot))))
+		sb.WriteString(fmt.Sprintf("%sLongest up:    %s%s (from %s)[-:-:-]\n", mainC, brightC, formatDuration(longest.Uptime()), longest.Boot.Format("2006-01-02")))
+	}
+	sb.WriteString(fmt.Sprintf("%sReboots (%s): %s%d[-:-:-]\n", mainC, now.Format("Jan"), brightC, rebootsThisMonth))
+	sb.WriteString(fmt.Sprintf("%sBoots on record: %d, since %s[-:-:-]\n\n", dimC, len(boots), firstBootDate(boots)))
+
+	sb.WriteString(fmt.Sprintf("%sUPTIME STREAKS[-:-:-]\n", brightC+"[::b]"))
+	var peak time.Duration
+	for _, r := range boots {
+		peak = max(peak, r.Uptime())
+	}
+	for i := len(boots) - 1; i >= 0 && i >= len(boots)-10; i-- {
+		r := boots[i]
+		pct := 0.0
+		if peak > 0 {
+			pct = float64(r.Uptime()) / float64(peak) * 100
+		}
+		sb.WriteString(fmt.Sprintf("%s%s %s %s%s[-:-:-]\n", mainC, r.Boot.Format("2006-01-02 15:04"), createBar(pct, 20, b.theme), mainC, formatDuration(r.Uptime())))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%sBASELINE SESSIONS[-:-:-]\n", brightC+"[::b]"))
+	sb.WriteString(fmt.Sprintf("%sThis month: %s%d sessions, %s[-:-:-]\n", mainC, brightC, sessionsThisMonth, formatDuration(sessionTime)))
+	for i := len(sessions) - 1; i >= 0 && i >= len(sessions)-5; i-- {
+		s := sessions[i]
+		sb.WriteString(fmt.Sprintf("%s%s - %s  %s%s[-:-:-]\n", dimC, s.Start.Format("01-02 15:04"), s.End.Format("01-02 15:04"), mainC, formatDuration(s.End.Sub(s.Start))))
+	}
+	sb.WriteString(fmt.Sprintf("\n%sEsc close[-:-:-]", dimC))
+	view.SetText(sb.String())
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' {
+			b.closeOverlay("uptime")
+			return nil
+		}
+		return event
+	})
+	b.showOverlay("uptime", view, 64, 32)
+}
+
+func firstBootDate(boots []BootRecord) string {
+	if len(boots) == 0 {
+		return "-"
+	}
+	return boots[0].Boot.Format("2006-01-02")
+}
@@ -5,15 +5,13 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"math"
-	"net/http"
 	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
 	"runtime"
-	"sort" // <-- Added import for sort package
 	"strconv"
 	"strings"
 	"sync"
@@ -31,13 +29,12 @@ import (
 	"github.com/shirou/gopsutil/v3/load"
 	"github.com/shirou/gopsutil/v3/mem"
 	"github.com/shirou/gopsutil/v3/net"
-	"github.com/shirou/gopsutil/v3/process"
 )
 
 // --- Constants & Configuration ---
 const (
 	appName         = "Baseline"
-	refreshInterval = 2 * time.Second // How often to refresh data
+	refreshInterval = 2 * time.Second // Default refresh, see config.go
 	historyLimit    = 60              // Max data points for history
 )
 
@@ -46,6 +43,14 @@ type Theme struct {
 	Main   tcell.Color
 	Dim    tcell.Color
 	Bright tcell.Color
+	// Optional, set by custom themes (see themes.go); ColorDefault keeps the default
+	Background tcell.Color
+	Border     tcell.Color
+	Error      tcell.Color
+	Success    tcell.Color
+	Panel      tcell.Color // Panel background, Background when unset
+	Selection  tcell.Color // Selected row, Bright when unset
+	Status     tcell.Color // Footer text, Dim when unset
 }
 
 var themes = map[string]Theme{
@@ -64,20 +69,44 @@ var themes = map[string]Theme{
 		Dim:    tcell.NewHexColor(0x0099CC), // #0099CC
 		Bright: tcell.NewHexColor(0x99CCFF), // #99CCFF
 	},
+	"light": { // For light terminals
+		Main:       tcell.NewHexColor(0x24292F),
+		Dim:        tcell.NewHexColor(0x6E7781),
+		Bright:     tcell.NewHexColor(0x0550AE),
+		Background: tcell.NewHexColor(0xFFFFFF),
+		Border:     tcell.NewHexColor(0x8C959F),
+		Error:      tcell.NewHexColor(0xCF222E),
+		Success:    tcell.NewHexColor(0x1A7F37),
+		Panel:      tcell.NewHexColor(0xF6F8FA),
+		Selection:  tcell.NewHexColor(0x0969DA),
+		Status:     tcell.NewHexColor(0x57606A),
+	},
 }
 
 // --- Data Structures ---
 
 type TodoItem struct {
-	Text     string `json:"text"`
-	Done     bool   `json:"done"`
-	Priority string `json:"priority"` // "low", "medium", "high"
+	Text        string     `json:"text"`
+	Done        bool       `json:"done"`
+	Priority    string     `json:"priority"`               // "low", "medium", "high"
+	CompletedAt *time.Time `json:"completed_at,omitempty"` // For the journal's end-of-day summary
+	Due         *time.Time `json:"due,omitempty"`          // nil when the todo has no due date
+	Pomodoros   int        `json:"pomodoros,omitempty"`    // Finished pomodoros, see pomodoro.go
+	Tags        []string   `json:"tags,omitempty"`         // Besides #hashtags in Text, see todotags.go
+	SyncID      string     `json:"sync_id,omitempty"`      // Task id at the sync provider, see todosync.go
+	Modified    *time.Time `json:"modified,omitempty"`     // Last local edit not yet synced
+	ID          int        `json:"id,omitempty"`           // Set once it has subtasks, see todosubtasks.go
+	Parent      int        `json:"parent,omitempty"`       // ID of the task this is a subtask of, 0 at the top
+	Repeat      string     `json:"repeat,omitempty"`       // Recurrence rule, see todorepeat.go
 }
 
 type Notification struct {
-	Message string
-	Type    string // "info", "error", "success"
-	Time    time.Time
+	Message  string
+	Type     string // "info", "error", "success"
+	Source   string // Widget that raised it ("jira", "vpn", ...); empty for the core app
+	Time     time.Time
+	Priority Priority // Routing: low = history only, normal = footer, high = footer + desktop + webhook
+	Route    string   // [[route]] destination, see notifications.go
 }
 
 type SystemHistory struct {
@@ -92,66 +121,248 @@ type WeatherInfo struct {
 	Location    string
 	TempC       float64
 	Condition   string
+	Kind        WeatherKind
+	IsDay       bool
 	Humidity    int
 	WindKph     float64
+	PrecipMM    float64 // Current precipitation
 	Error       string
 	LastUpdated time.Time
+	Days        []ForecastDay
+	Hours       []ForecastHour // Upcoming hours, one per hour
+	Warnings    []WeatherWarning
+	Cached      bool `json:"-"` // From the weather cache, a fetch failed
 }
 
 // --- Baseline Application Struct ---
 
 type Baseline struct {
-	app *tview.Application // The TUI application
+	app    *tview.Application // The TUI application
+	screen tcell.Screen       // Captured after the first draw; only touch on the UI goroutine
 
 	// UI Components
+	pages        *tview.Pages    // Root: "main" layout plus overlays
+	overlay      tview.Primitive // Front overlay, keeps mouse clicks inside it
 	layout       *tview.Flex
 	header       *tview.TextView
 	systemPanel  *tview.TextView
 	weatherPanel *tview.TextView
 	timePanel    *tview.TextView
 	todoPanel    *tview.TextView
-	footer       *tview.TextView // For notifications
+	jiraPanel    *tview.TextView
+	piholePanel  *tview.TextView
+	haPanel      *tview.TextView
+	vpnPanel     *tview.TextView
+	connPanel    *tview.TextView
+	gitPanel     *tview.TextView
+	lanPanel     *tview.TextView
+	btPanel      *tview.TextView
+	notesPanel   *tview.TextView
+	clipPanel    *tview.TextView
+	quotePanel   *tview.TextView
+	newsPanel    *tview.TextView
+	marketsPanel *tview.TextView
+	habitsPanel  *tview.TextView
+	worldPanel   *tview.TextView
+	aboutPanel   *tview.TextView
+	cronPanel    *tview.TextView
+	ctrPanel     *tview.TextView
+	svcPanel     *tview.TextView
+	hostsPanel   *tview.TextView // Also added and removed at runtime by `host`
+	volumePanel  *tview.TextView
+	brightPanel  *tview.TextView
+	historyPanel *tview.TextView
+	procTable    *tview.Table
+	logPanel     *tview.TextView // Added at runtime by `log open`
+	mainContent  *tview.Flex
+	widgetColumn *tview.Flex // Optional widget panels, right of the main grid
+	widgetPanels []widgetPanel
+	panels       panelRenderer
+	footer       *tview.TextView   // For notifications
 	cmdInput     *tview.InputField // For command input
 
 	// State
 	mu              sync.RWMutex // Mutex for thread-safe access to shared state
+	life            *lifecycle   // Background jobs, cancelled on quit
+	notifMu         sync.Mutex   // Guards notifications only, so they can be raised while mu is held
+	notifLog        *os.File     // Append-only notification history
+	sessionID       string       // Start time of this run, tags logged notifications
+	alertSound      *AlertSound  // nil unless ALERT_SOUND is set
+	dndUntil        time.Time    // Do-not-disturb end (guarded by notifMu)
+	dndTimer        *time.Timer
+	dndHeld         int // Notifications held during the current DND period
+	notifFilter     notificationFilter
+	notifTTL        map[string]time.Duration // Footer display time per type, 0 = sticky
+	notifMax        int                      // In-memory notification buffer size
+	mailer          *Mailer                  // nil unless SMTP is configured
+	delivery        *Delivery                // Desktop/webhook channels for high priority
+	diskCritical    float64                  // Root filesystem usage that raises a critical alert
+	alerts          map[string]*Alert        // Active critical alerts by key (guarded by notifMu)
+	taskSync        *TaskSync                // nil unless TODO_SYNC is set
+	notesFile       string                   // Scratchpad file, "" when the notes widget is off
+	clipMax         int                      // Clipboard history size, 0 when off
+	clips           []ClipEntry              // Newest first
+	clipSel         int
+	clipError       string
+	quote           *QuoteSource
+	quoteInfo       Quote
+	news            *NewsConfig // nil unless NEWS_FEEDS is set
+	newsInfo        NewsInfo
+	newsSel         int // Selected headline, moved every NEWS_ROTATE
+	newsShown       time.Time
+	markets         *MarketsConfig // nil unless MARKETS_CRYPTO or MARKETS_STOCKS is set
+	marketsInfo     MarketsInfo
+	habits          []Habit // Configured habits, in display order
+	habitsRetired   []Habit // Saved habits no longer in HABITS, kept on save
+	habitSel        int
+	reminders       []*Reminder
+	remindPaused    bool
+	remindMeeting   bool // Hold reminders while a calendar event is running
+	logTail         *LogTail
+	journalAt       time.Time // JOURNAL_SUMMARY_TIME (clock only), zero when unset
+	journalTodos    bool      // Include completed todos in the summary
+	worldMap        bool      // WORLD_MAP
+	worldLocs       []WorldLocation
+	clockZones      []ClockZone
+	about           bool         // ABOUT
+	aboutInfo       *MachineInfo // Gathered once at startup, nil until then
+	focusLog        []FocusSession
+	focusActive     *FocusSession // Running focus session, nil when idle
+	pomodoro        *Pomodoro     // Running pomodoro cycle, nil when idle
+	pomodoroWork    time.Duration // POMODORO
+	pomodoroBreak   time.Duration
+	timerSound      *AlertSound // TIMER_SOUND
+	timers          []Timer     // Countdowns and alarms, soonest first, see timers.go
+	cronOn          bool        // CRON / CRON_SYSTEM
+	cronJobs        []CronJob   // Sorted by next run
+	cronError       string
+	volume          *VolumeControl // nil unless VOLUME is set
+	volumeInfo      VolumeInfo
+	brightness      *BrightnessControl // nil unless BRIGHTNESS is set
+	brightInfo      BrightnessInfo
+	rebootCheck     bool   // REBOOT_CHECK, on unless "false"
+	rebootReason    string // Why a reboot is pending, "" when none
+	uptimeLog       availabilityFile
 	configDir       string
+	config          Config                    // config.toml as last read
+	envFile         map[string]string         // .env as last read
+	refreshTickers  map[string][]*time.Ticker // By [refresh] setting, reset on reload
 	todoItems       []TodoItem
+	todoLines       []string        // Rendered todo lines, for mapping clicks to items
+	todoLineItems   []int           // Item index of each rendered line
+	todoCursor      int             // Selected todo, target of t/d/p
+	todoFilter      string          // Tag shown, "" for all
+	dueWindow       time.Duration   // TODO_DUE_WINDOW
+	dueNotified     map[string]bool // Todos already announced as due, by text and due time
 	notifications   []Notification
 	systemHistory   SystemHistory
+	latestSystem    SystemSnapshot
+	webURL          string
+	metrics         *MetricsStore // nil when metrics.db could not be opened
+	retention       HistoryRetention
+	procSampler     *ProcessSampler
+	procs           []ProcessInfo // All processes, refreshed by fetchProcesses
+	procSort        string        // Process table sort column
+	procDesc        bool          // Sort descending
+	procFilter      string        // Process name filter, "" for all
+	intervals       Intervals     // Refresh rates from config.toml
+	alertRules      []AlertRule   // [[alert]] rules from config.toml
+	rulePending     map[string]time.Time
+	ruleFiring      map[string]bool
+	historyGraph    bool
 	weatherInfo     WeatherInfo
+	weatherWarned   map[string]bool
+	weatherTemps    map[string][]tempSample
+	weatherLocs     []string               // All locations, weatherLocation is the one shown
+	weatherAll      []WeatherInfo          // Latest fetch, one per location
+	weatherCache    map[string]WeatherInfo // Last good report by location, see weathercache.go
+	weatherCoords   map[string]geoPlace    // Places picked from a search, see weathersearch.go
+	weatherIndex    int
+	weatherMode     string // "rotate" or "split"
+	weatherRotate   time.Duration
+	weatherUnits    WeatherUnits
+	weatherShown    time.Time // When the rotation last moved
 	lastNetIO       net.IOCountersStat
 	lastNetTime     time.Time
+	lastDiskIO      map[string]disk.IOCountersStat // Previous sample, see diskio.go
+	lastDiskTime    time.Time
 	currentFocus    string // "dashboard", "command", "todoInput" (maybe later)
 	commandHistory  []string
+	commands        []*Command // See commands.go
+	cmdListOpen     bool       // Tab completion list shown, UI goroutine only
 	theme           Theme
-	weatherAPIKey   string
+	weatherProvider WeatherProvider
+	weatherNeedsKey string // Setting to fill in when the provider has no API key
 	weatherLocation string
 	cpuCoreCount    int
+	cpuCores        bool               // System panel shows one bar per core
+	memDetail       bool               // System panel shows the memory breakdown
+	sensorOn        bool               // SENSORS, on unless "false"
+	sensorWarn      map[string]float64 // Warning threshold in °C per category
+	sensorHot       map[string]bool    // Categories over their threshold, notified once
+	gpus            []GPUReading       // Latest fetchGPU readings
+	gpuOn           bool
+	battery         *BatteryReading // nil without a battery
+	batteryOn       bool
+	batteryWarn     float64 // BATTERY_WARN, percent
+	batteryLow      bool    // Below the threshold while discharging, notified once
+	calendarSources []CalendarSource
+	calendarEvents  []CalendarEvent
+	calendarError   string
+	jira            *JiraClient // nil unless JIRA_URL/JIRA_TOKEN are set
+	jiraInfo        JiraInfo
+	pihole          *PiholeClient // nil unless PIHOLE_URL is set
+	piholeInfo      PiholeInfo
+	ha              *HAClient // nil unless HA_URL/HA_TOKEN/HA_ENTITIES are set
+	haInfo          HAInfo
+	vpn             *VPNConfig // nil unless VPN_STATUS is set
+	vpnInfo         VPNInfo
+	conn            *ConnectivityConfig // nil unless CONNECTIVITY is set
+	connInfo        ConnectivityInfo
+	gitRepos        []string // Paths from GIT_REPOS
+	gitStatuses     []GitRepoStatus
+	gitLastUpdated  time.Time
+	lanScan         bool                  // LAN_SCAN
+	lanDevices      map[string]*LANDevice // Every device ever seen, keyed by MAC
+	lanLastScan     time.Time
+	ouiVendors      map[string]string
+	btEnabled       bool // BLUETOOTH
+	btDevices       []BluetoothDevice
+	btError         string
+	btLastUpdated   time.Time
+	containers      *ContainerClient // nil unless CONTAINERS is set
+	containerInfo   ContainerInfo
+	services        []serviceSpec // SERVICES
+	serviceInfo     ServiceInfo
+	hosts           []RemoteHost // hosts.json, edited with `host add|remove`
+	hostStats       map[string]HostStats
+	plugins         []*Plugin     // [[plugin]] tables in config.toml
+	remote          *RemoteClient // Set by `baseline attach`
+	layoutSetting   string        // LAYOUT: auto, stacked, grid or wide
+	layoutShown     string        // Arrangement on screen
+	screenWidth     int
+
+	arrangement panelArrangement // layout.json: moved, resized and hidden panels
 }
 
 // --- Constructor ---
 
-func NewBaseline() *Baseline {
+// NewBaseline sets up the dashboard; with remote it shows that server's
+// system panels (see remote.go).
+func NewBaseline(remote *RemoteClient) *Baseline {
 	// Load .env - ignore error if it doesn't exist
 	_ = godotenv.Load()
+	envFile, _ := godotenv.Read() // Compared against on reload
 
 	// Determine config directory (~/.baseline)
-	usr, err := user.Current()
-	var configDir string
-	if err != nil {
-		log.Printf("Warning: Could not get user home directory: %v. Using current dir.", err)
-		configDir = ".baseline"
-	} else {
-		configDir = filepath.Join(usr.HomeDir, ".baseline")
-	}
+	configDir := defaultConfigDir()
 	// Create config dir if it doesn't exist
 	_ = os.MkdirAll(configDir, 0750)
 
 	// Get CPU core count
 	cpuCount, err := cpu.Counts(true) // Logical cores
 	if err != nil || cpuCount == 0 {
-		log.Printf("Warning: Could not get CPU count: %v. Defaulting to 1.", err)
+		slog.Warn("Could not get CPU count, defaulting to 1", "err", err)
 		cpuCount = 1
 	}
 
@@ -160,32 +371,125 @@ func NewBaseline() *Baseline {
 	if themeName == "" {
 		themeName = "amber"
 	}
+	for _, w := range loadCustomThemes(configDir) {
+		slog.Warn(w)
+	}
 	selectedTheme, ok := themes[themeName]
 	if !ok {
-		log.Printf("Warning: Theme '%s' not found. Defaulting to amber.", themeName)
+		slog.Warn("Theme not found, defaulting to amber", "theme", themeName)
 		selectedTheme = themes["amber"]
 	}
 
 	b := &Baseline{
 		app:             tview.NewApplication(),
 		configDir:       configDir,
+		envFile:         envFile,
+		taskSync:        newTaskSyncFromEnv(configDir),
+		remote:          remote,
+		procSampler:     newProcessSampler(),
+		layoutSetting:   layoutFromEnv(),
 		currentFocus:    "dashboard",
 		theme:           selectedTheme,
-		weatherAPIKey:   os.Getenv("WEATHER_API_KEY"),
 		weatherLocation: os.Getenv("WEATHER_LOCATION"),
 		cpuCoreCount:    cpuCount,
+		procSort:        "cpu",
+		sensorOn:        sensorsEnabled(),
+		gpuOn:           gpuEnabled(),
+		batteryOn:       batteryEnabled(),
+		batteryWarn:     batteryWarnFromEnv(),
+		sensorWarn:      sensorThresholdsFromEnv(),
+		sensorHot:       map[string]bool{},
+		dueWindow:       dueWindowFromEnv(),
+		dueNotified:     map[string]bool{},
+		procDesc:        true,
+		jira:            newJiraClientFromEnv(),
+		pihole:          newPiholeClientFromEnv(),
+		ha:              newHAClientFromEnv(),
+		vpn:             newVPNConfigFromEnv(),
+		conn:            newConnectivityFromEnv(),
+		gitRepos:        gitReposFromEnv(),
+		lanScan:         lanScanEnabled(),
+		btEnabled:       bluetoothEnabled(),
+		containers:      newContainerClientFromEnv(),
+		services:        servicesFromEnv(),
+		notesFile:       notesPath(configDir),
+		clipMax:         clipboardHistorySize(),
+		quote:           newQuoteSourceFromEnv(),
+		news:            newNewsFromEnv(),
+		markets:         newMarketsFromEnv(),
+		habits:          habitsFromEnv(),
+		reminders:       remindersFromEnv(),
+		remindMeeting:   pauseRemindersInMeetings(),
+		journalAt:       journalSummaryTimeFromEnv(),
+		journalTodos:    strings.ToLower(os.Getenv("JOURNAL_TODOS")) != "false",
+		worldMap:        worldMapEnabled(),
+		worldLocs:       worldLocationsFromEnv(),
+		clockZones:      clockZonesFromEnv(),
+		about:           aboutEnabled(),
+		cronOn:          cronEnabled(),
+		volume:          newVolumeControlFromEnv(),
+		brightness:      newBrightnessControlFromEnv(),
+		rebootCheck:     rebootCheckEnabled(),
+		historyGraph:    historyGraphEnabled(),
+		life:            newLifecycle(),
+		sessionID:       time.Now().Format("2006-01-02 15:04:05"),
+		alertSound:      newAlertSoundFromEnv(),
+		timerSound:      newTimerSoundFromEnv(),
+		notifFilter:     parseNotificationFilter(os.Getenv("NOTIFY_HISTORY_ONLY"), os.Getenv("NOTIFY_PRIORITY")),
+		notifTTL:        notificationDurationsFromEnv(),
+		notifMax:        notificationBufferFromEnv(),
+		mailer:          newMailerFromEnv(),
+		delivery:        newDeliveryFromEnv(),
+		diskCritical:    diskCriticalThreshold(),
+		weatherWarned:   map[string]bool{},
+		rulePending:     map[string]time.Time{},
+		ruleFiring:      map[string]bool{},
+	}
+	b.openNotificationLog()
+	cfg, configWarnings := loadConfig(configDir)
+	b.config = cfg
+	logLevel.Set(cfg.LogLevel)
+	b.intervals, b.alertRules = cfg.Intervals, cfg.Alerts
+	b.retention = cfg.History
+	b.plugins = newPlugins(cfg.Plugins)
+	b.registerCoreCommands()
+	b.registerPluginCommands()
+	b.pomodoroWork, b.pomodoroBreak = pomodoroDurationsFromEnv()
+	b.delivery = b.delivery.withDesktop(cfg.Desktop)
+	b.notifFilter.routes = cfg.Routes
+	for _, w := range configWarnings {
+		b.addNotification(w, "error")
 	}
 
 	if b.weatherLocation == "" {
 		b.weatherLocation = "Lahore" // Default location
 	}
-	if b.weatherAPIKey == "YOUR_API_KEY" || b.weatherAPIKey == "" {
-		b.weatherAPIKey = "" // Treat as unset
-		b.addNotification("Weather API key not set. Using sample data.", "info")
+	b.weatherUnits = weatherUnitsFor(cfg.Weather)
+	b.loadWeatherLocations()
+	b.loadWeatherCache()
+	b.loadHosts()
+	b.loadArrangement()
+	provider, needsKey, err := weatherProviderFor(cfg.Weather)
+	if err != nil {
+		b.addNotification(err.Error(), "error")
+	}
+	b.weatherProvider, b.weatherNeedsKey = provider, needsKey
+	if needsKey != "" {
+		b.addNotification(fmt.Sprintf("%s API key not set. Using sample data.", provider.Name()), "info")
 	}
 
 	b.loadTodos()
 	b.loadSystemHistory()
+	b.loadCalendarSources()
+	if b.lanScan {
+		b.loadLANDevices()
+	}
+	if len(b.habits) > 0 {
+		b.loadHabits()
+	}
+	b.loadFocus()
+	b.loadTimers()
+	b.startAvailability()
 	// Get initial network stats
 	ioc, err := net.IOCounters(false) // Get aggregate counters
 	if err == nil && len(ioc) > 0 {
@@ -198,6 +502,16 @@ func NewBaseline() *Baseline {
 
 // --- File I/O ---
 
+// defaultConfigDir returns ~/.baseline, or .baseline in the current dir if the home dir is unknown.
+func defaultConfigDir() string {
+	usr, err := user.Current()
+	if err != nil {
+		slog.Warn("Could not get user home directory, using current dir", "err", err)
+		return ".baseline"
+	}
+	return filepath.Join(usr.HomeDir, ".baseline")
+}
+
 func (b *Baseline) loadTodos() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -229,6 +543,7 @@ func (b *Baseline) loadTodos() {
 
 func (b *Baseline) saveTodos() {
 	// Called from within locked sections or needs its own lock if called externally
+	b.deriveTodoDone() // Tasks with subtasks follow them
 	filePath := filepath.Join(b.configDir, "todos.json")
 	data, err := json.MarshalIndent(b.todoItems, "", "  ") // Pretty print JSON
 	if err != nil {
@@ -240,64 +555,69 @@ func (b *Baseline) saveTodos() {
 	if err != nil {
 		b.addNotification(fmt.Sprintf("Error saving todos: %v", err), "error")
 	}
+	b.todosChanged()
 }
 
 func (b *Baseline) loadSystemHistory() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	filePath := filepath.Join(b.configDir, "system_history.json")
-	data, err := os.ReadFile(filePath)
+	b.systemHistory = SystemHistory{
+		CPU:        []float64{},
+		Memory:     []float64{},
+		Timestamps: []string{},
+		NetworkIn:  []uint64{},
+		NetworkOut: []uint64{},
+	}
+	b.weatherTemps = map[string][]tempSample{}
+	if b.remote != nil {
+		b.loadRemoteHistory() // metrics.db belongs to the server
+		return
+	}
+
+	store, err := openMetricsStore(filepath.Join(b.configDir, metricsFileName))
 	if err != nil {
-		if !os.IsNotExist(err) {
-			b.addNotification(fmt.Sprintf("Error loading history: %v", err), "error")
-		}
-		// Initialize if error or not exists
-		b.systemHistory = SystemHistory{
-			CPU:        []float64{},
-			Memory:     []float64{},
-			Timestamps: []string{},
-			NetworkIn:  []uint64{},
-			NetworkOut: []uint64{},
-		}
+		// Keep running with in-memory history only
+		b.addNotification(fmt.Sprintf("Error opening metrics database: %v", err), "error")
 		return
 	}
+	b.metrics = store
+	b.loadWeatherTrend()
+	if err := store.migrateHistoryJSON(filepath.Join(b.configDir, "system_history.json"), b.intervals.System); err != nil {
+		b.addNotification(fmt.Sprintf("Error importing system_history.json: %v", err), "error")
+	}
 
-	err = json.Unmarshal(data, &b.systemHistory)
+	samples, err := store.Recent(historyLimit)
 	if err != nil {
-		b.addNotification(fmt.Sprintf("Error parsing history.json: %v", err), "error")
-		// Initialize on error
-		b.systemHistory = SystemHistory{
-			CPU:        []float64{},
-			Memory:     []float64{},
-			Timestamps: []string{},
-			NetworkIn:  []uint64{},
-			NetworkOut: []uint64{},
-		}
+		b.addNotification(fmt.Sprintf("Error loading history: %v", err), "error")
+	}
+	for _, s := range samples {
+		b.systemHistory.CPU = append(b.systemHistory.CPU, s.CPU)
+		b.systemHistory.Memory = append(b.systemHistory.Memory, s.Memory)
+		b.systemHistory.Timestamps = append(b.systemHistory.Timestamps, s.Time.Format("15:04:05"))
+		b.systemHistory.NetworkIn = append(b.systemHistory.NetworkIn, s.NetIn)
+		b.systemHistory.NetworkOut = append(b.systemHistory.NetworkOut, s.NetOut)
 	}
 }
 
-func (b *Baseline) saveSystemHistory() {
-	// Called from within locked sections
-	filePath := filepath.Join(b.configDir, "system_history.json")
-
+// saveSystemHistory appends the newest sample to the metrics database and
+// trims the in-memory history. Called from within locked sections.
+func (b *Baseline) saveSystemHistory(sample MetricSample) {
 	// Trim history if needed
 	if len(b.systemHistory.CPU) > historyLimit {
 		b.systemHistory.CPU = b.systemHistory.CPU[len(b.systemHistory.CPU)-historyLimit:]
 		b.systemHistory.Memory = b.systemHistory.Memory[len(b.systemHistory.Memory)-historyLimit:]
 		b.systemHistory.Timestamps = b.systemHistory.Timestamps[len(b.systemHistory.Timestamps)-historyLimit:]
+	}
+	if len(b.systemHistory.NetworkIn) > historyLimit {
 		b.systemHistory.NetworkIn = b.systemHistory.NetworkIn[len(b.systemHistory.NetworkIn)-historyLimit:]
 		b.systemHistory.NetworkOut = b.systemHistory.NetworkOut[len(b.systemHistory.NetworkOut)-historyLimit:]
 	}
 
-	data, err := json.MarshalIndent(b.systemHistory, "", "  ")
-	if err != nil {
-		b.addNotification(fmt.Sprintf("Error marshalling history: %v", err), "error")
+	if b.metrics == nil {
 		return
 	}
-
-	err = os.WriteFile(filePath, data, 0640)
-	if err != nil {
+	if err := b.metrics.Append(sample); err != nil {
 		b.addNotification(fmt.Sprintf("Error saving history: %v", err), "error")
 	}
 }
@@ -314,7 +634,7 @@ func (b *Baseline) setupLayout() {
 	b.systemPanel = tview.NewTextView()
 	b.systemPanel.SetDynamicColors(true).
 		SetScrollable(true).
-		SetBorder(true). // Returns *Box
+		SetBorder(true).            // Returns *Box
 		SetTitle(" System Status ") // Returns *Box
 
 	b.weatherPanel = tview.NewTextView()
@@ -363,45 +683,74 @@ func (b *Baseline) setupLayout() {
 		}
 		// Potentially add history navigation (Up/Down arrows) here later
 	})
+	b.setupCompletion()
 
 	// Layout structure (similar to Python's Rich layout)
-	leftPanel := tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(b.systemPanel, 0, 1, false). // Proportions adjust automatically
-		AddItem(b.weatherPanel, 0, 1, false)
-
-	rightPanel := tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(b.timePanel, 0, 1, false).
-		AddItem(b.todoPanel, 0, 2, false) // Todo gets twice the height ratio
+	b.setupProcTable()
+	mainContent := tview.NewFlex()
+	b.mainContent = mainContent
 
-	mainContent := tview.NewFlex().
-		AddItem(leftPanel, 0, 1, false). // Left takes half width
-		AddItem(rightPanel, 0, 1, false) // Right takes half width
+	// Optional widgets get a column of their own only when at least one is configured
+	b.setupWidgets()
+	b.arrangePanels("grid") // Re-flowed to the terminal width before each draw, see layout.go
 
 	// Main layout with Header, Main Content, Footer
 	b.layout = tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(b.header, 3, 0, false).       // Header fixed height
-		AddItem(mainContent, 0, 1, true).      // Main content takes remaining space, gets focus
-		AddItem(b.footer, 1, 0, false).        // Footer fixed height (for notifications)
-		AddItem(b.cmdInput, 1, 0, false) // Command input, same space as footer, initially hidden
+		AddItem(b.header, 3, 0, false).   // Header fixed height
+		AddItem(mainContent, 0, 1, true). // Main content takes remaining space, gets focus
+		AddItem(b.footer, 1, 0, false).   // Footer fixed height (for notifications)
+		AddItem(b.cmdInput, 1, 0, false)  // Command input, same space as footer, initially hidden
 
 	// Initially hide command input, show footer
 	b.layout.ResizeItem(b.footer, 1, 0)
 	b.layout.ResizeItem(b.cmdInput, 0, 0)
 
+	b.pages = tview.NewPages().AddPage("main", b.layout, true, true)
+
+	b.setupPanelFocus()
+
 	// Apply theme colors
 	b.applyTheme()
 }
 
+// showOverlay displays p centered above the dashboard and gives it focus.
+func (b *Baseline) showOverlay(name string, p tview.Primitive, width, height int) {
+	centered := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p, height, 1, true).
+			AddItem(nil, 0, 1, false), width, 1, true).
+		AddItem(nil, 0, 1, false)
+	b.pages.AddPage(name, centered, true, true)
+	b.overlay = p
+	b.app.SetFocus(p)
+}
+
+// closeOverlay removes an overlay and returns focus to the dashboard.
+func (b *Baseline) closeOverlay(name string) {
+	b.pages.RemovePage(name)
+	b.overlay = nil
+	b.app.SetFocus(b.layout)
+}
+
+// overlayOpen reports whether an overlay is in front of the dashboard.
+func (b *Baseline) overlayOpen() bool {
+	name, _ := b.pages.GetFrontPage()
+	return name != "main"
+}
+
 func (b *Baseline) applyTheme() {
 	// --- Fix Start ---
 	// Removed unused mainColorStr, dimColorStr, brightColorStr declarations
 	// --- Fix End ---
 
 	// Set border colors
-	b.systemPanel.SetBorderColor(b.theme.Main)
-	b.weatherPanel.SetBorderColor(b.theme.Main)
-	b.timePanel.SetBorderColor(b.theme.Main)
-	b.todoPanel.SetBorderColor(b.theme.Main)
+	b.systemPanel.SetBorderColor(b.systemBorder()) // Stays red while an alert rule fires
+	b.weatherPanel.SetBorderColor(b.theme.border())
+	b.timePanel.SetBorderColor(b.theme.border())
+	b.todoPanel.SetBorderColor(b.theme.border())
+	b.procTable.SetBorderColor(b.theme.border())
 	// Footer/CmdInput don't have borders in this setup
 
 	// Set title colors (usually same as border)
@@ -409,6 +758,8 @@ func (b *Baseline) applyTheme() {
 	b.weatherPanel.SetTitleColor(b.theme.Main)
 	b.timePanel.SetTitleColor(b.theme.Main)
 	b.todoPanel.SetTitleColor(b.theme.Main)
+	b.procTable.SetTitleColor(b.theme.Main)
+	b.procTable.SetSelectedStyle(b.theme.selection())
 
 	// Set default text colors (can be overridden with tags)
 	b.header.SetTextColor(b.theme.Main)
@@ -416,11 +767,28 @@ func (b *Baseline) applyTheme() {
 	b.weatherPanel.SetTextColor(b.theme.Main)
 	b.timePanel.SetTextColor(b.theme.Main)
 	b.todoPanel.SetTextColor(b.theme.Main)
-	b.footer.SetTextColor(b.theme.Dim) // Default footer text is dim
+	b.footer.SetTextColor(b.theme.status()) // Default footer text is dim
+
+	// Backgrounds (see themes.go); overlays created later pick up tview.Styles
+	tview.Styles.PrimitiveBackgroundColor = b.theme.background()
+	for _, box := range []*tview.Box{b.header.Box, b.footer.Box, b.cmdInput.Box} {
+		box.SetBackgroundColor(b.theme.background())
+	}
+	for _, box := range []*tview.Box{b.systemPanel.Box, b.weatherPanel.Box, b.timePanel.Box, b.todoPanel.Box, b.procTable.Box} {
+		box.SetBackgroundColor(b.theme.panelBackground())
+	}
+
+	for _, w := range b.widgetPanels {
+		w.view.SetBorderColor(b.theme.border())
+		w.view.SetTitleColor(b.theme.Main)
+		w.view.SetTextColor(b.theme.Main)
+		w.view.SetBackgroundColor(b.theme.panelBackground())
+	}
 
 	// Command input styling
 	b.cmdInput.SetLabelColor(b.theme.Bright)
 	b.cmdInput.SetFieldTextColor(b.theme.Main)
+	b.cmdInput.SetFieldBackgroundColor(b.theme.background())
 
 	// Force redraw with new colors
 	b.updateHeader()
@@ -429,6 +797,9 @@ func (b *Baseline) applyTheme() {
 	b.updateTime()
 	b.updateTodos()
 	b.updateFooter()
+	for _, w := range b.widgetPanels {
+		w.render()
+	}
 }
 
 // --- UI Update Methods ---
@@ -439,6 +810,34 @@ func colorTag(color tcell.Color) string {
 	return fmt.Sprintf("[#%06x]", color.Hex())
 }
 
+// headerBadges lists short status markers shown next to the title.
+func (b *Baseline) headerBadges() []string {
+	var badges []string
+	if until := b.dndStatus(); !until.IsZero() {
+		badges = append(badges, "DND until "+until.Format("15:04"))
+	}
+	if b.rebootReason != "" {
+		badges = append(badges, "REBOOT REQUIRED")
+	}
+	if b.focusActive != nil {
+		badges = append(badges, "Focus since "+b.focusActive.Start.Format("15:04"))
+	}
+	if b.batteryLow && b.battery != nil {
+		badges = append(badges, fmt.Sprintf("BATTERY %.0f%%", b.battery.Percent))
+	}
+	if badge := b.pomodoroBadge(); badge != "" {
+		badges = append(badges, badge)
+	}
+	if b.remote != nil {
+		if b.remote.Err != "" {
+			badges = append(badges, "DISCONNECTED "+b.remote.Addr)
+		} else {
+			badges = append(badges, "ATTACHED "+b.remote.Host)
+		}
+	}
+	return badges
+}
+
 func (b *Baseline) updateHeader() {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
@@ -454,7 +853,11 @@ func (b *Baseline) updateHeader() {
 	mainColor := colorTag(b.theme.Main)
 	dimColor := colorTag(b.theme.Dim)
 
-	headerText := fmt.Sprintf("%s%s%s[-:-:-]\n", mainColor, "[::b]", appName) // Bold main title
+	headerText := fmt.Sprintf("%s%s%s[-:-:-]", mainColor, "[::b]", appName) // Bold main title
+	for _, badge := range b.headerBadges() {
+		headerText += fmt.Sprintf(" %s%s[-:-:-]", colorTag(b.theme.Bright), tview.Escape("["+badge+"]"))
+	}
+	headerText += "\n"
 	subHeaderText := fmt.Sprintf("%s[Session: %s] [Terminal: %s@%s][-:-:-]",
 		dimColor,
 		now.Format("2006-01-02"),
@@ -468,6 +871,10 @@ func (b *Baseline) updateHeader() {
 }
 
 func (b *Baseline) updateSystemInfo() {
+	if b.remote != nil {
+		b.updateRemoteSystemInfo()
+		return
+	}
 	b.mu.Lock() // Lock for writing history
 	defer b.mu.Unlock()
 
@@ -488,6 +895,7 @@ func (b *Baseline) updateSystemInfo() {
 	diskPercent := 0.0
 	if err == nil {
 		diskPercent = diskInfo.UsedPercent
+		b.checkDiskSpace(diskPercent)
 	}
 
 	hostInfo, _ := host.Info()
@@ -498,7 +906,6 @@ func (b *Baseline) updateSystemInfo() {
 		bootTime = time.Unix(int64(hostInfo.BootTime), 0)
 	}
 
-
 	// Network I/O Calculation
 	var rxRate, txRate float64
 	currentNetIO, err := net.IOCounters(false) // Aggregate
@@ -513,33 +920,6 @@ func (b *Baseline) updateSystemInfo() {
 		b.lastNetTime = currentTime
 	}
 
-	// Top Processes
-	procs, err := process.Processes()
-	processInfos := []struct {
-		Name string
-		CPU  float64
-	}{}
-	if err == nil {
-		for _, p := range procs {
-			name, _ := p.Name()
-			// Get CPU % since last call, requires a short sleep or interval
-			// For simplicity here, we might get 0 often if called too rapidly.
-			// A better approach involves storing last CPU times.
-			// Using p.CPUPercent() directly might be sufficient for a snapshot.
-			cpuP, _ := p.CPUPercent()
-			if cpuP > 0.1 { // Only consider processes with some CPU usage
-				processInfos = append(processInfos, struct {
-					Name string
-					CPU  float64
-				}{Name: name, CPU: cpuP / float64(b.cpuCoreCount)}) // Normalize
-			}
-		}
-		// Sort by CPU descending
-		sort.Slice(processInfos, func(i, j int) bool {
-			return processInfos[i].CPU > processInfos[j].CPU
-		})
-	}
-
 	// --- Update History ---
 	nowStr := time.Now().Format("15:04:05")
 	b.systemHistory.CPU = append(b.systemHistory.CPU, cpuPercent)
@@ -549,7 +929,12 @@ func (b *Baseline) updateSystemInfo() {
 		b.systemHistory.NetworkIn = append(b.systemHistory.NetworkIn, currentNetIO[0].BytesRecv)
 		b.systemHistory.NetworkOut = append(b.systemHistory.NetworkOut, currentNetIO[0].BytesSent)
 	}
-	b.saveSystemHistory() // Save (includes trimming)
+	sample := MetricSample{Time: currentTime, CPU: cpuPercent, Memory: memPercent}
+	if len(currentNetIO) > 0 {
+		sample.NetIn, sample.NetOut = currentNetIO[0].BytesRecv, currentNetIO[0].BytesSent
+	}
+	b.saveSystemHistory(sample) // Save (includes trimming)
+	b.writeStatusSummary(cpuPercent, memPercent)
 
 	// --- Format Output ---
 	mainC := colorTag(b.theme.Main)
@@ -567,10 +952,25 @@ func (b *Baseline) updateSystemInfo() {
 		sb.WriteString(fmt.Sprintf("%sHost/OS Info: Unavailable[-:-:-]\n", dimC))
 	}
 
-
 	sb.WriteString(fmt.Sprintf("\n%sCPU: %s %s %.1f%%[-:-:-]\n", mainC, createBar(cpuPercent, 15, b.theme), brightC, cpuPercent))
+	if b.cpuCores {
+		if perCore, err := cpu.Percent(0, true); err == nil {
+			sb.WriteString(renderCoreBars(perCore, b.theme))
+		}
+	}
 	sb.WriteString(fmt.Sprintf("%sMEM: %s %s %.1f%%[-:-:-]\n", mainC, createBar(memPercent, 15, b.theme), brightC, memPercent))
+	if b.memDetail {
+		swapInfo, _ := mem.SwapMemory()
+		sb.WriteString(renderMemDetail(memInfo, swapInfo, b.procs, b.theme))
+	}
 	sb.WriteString(fmt.Sprintf("%sDSK: %s %s %.1f%%[-:-:-]\n", mainC, createBar(diskPercent, 15, b.theme), brightC, diskPercent))
+	sb.WriteString(b.diskIOSection()) // Read/write rates of the busiest disks
+	if b.gpuOn {
+		sb.WriteString(b.gpuSection())
+	}
+	if b.batteryOn {
+		sb.WriteString(b.batterySection())
+	}
 
 	if err == nil && len(currentNetIO) > 0 {
 		sb.WriteString(fmt.Sprintf("%sNET: %s↓ %.1f KB/s ↑ %.1f KB/s[-:-:-]\n", mainC, dimC, rxRate, txRate))
@@ -583,35 +983,34 @@ func (b *Baseline) updateSystemInfo() {
 	if err == nil {
 		sb.WriteString(fmt.Sprintf("%sLOAD: %s%.2f %.2f %.2f[-:-:-]\n", mainC, dimC, loadAvg.Load1, loadAvg.Load5, loadAvg.Load15))
 	}
-
-	sb.WriteString(fmt.Sprintf("\n%sTOP PROCESSES:[-:-:-]\n", mainC))
-	limit := 3
-	if len(processInfos) < limit {
-		limit = len(processInfos)
-	}
-	for i := 0; i < limit; i++ {
-		proc := processInfos[i]
-		// Truncate name if too long
-		name := proc.Name
-		maxLen := 15
-		if len(name) > maxLen {
-			// Use rune count for potentially multi-byte chars
-			nameRunes := []rune(name)
-			if len(nameRunes) > maxLen {
-				name = string(nameRunes[:maxLen-1]) + "…" // Ellipsis
-			}
+	if b.sensorOn {
+		sb.WriteString(b.sensorSection())
+	}
+	b.latestSystem = SystemSnapshot{CPU: cpuPercent, Memory: memPercent, Disk: diskPercent, NetRxBytes: b.lastNetIO.BytesRecv, NetTxBytes: b.lastNetIO.BytesSent} // For the web mirror
+	if hostInfo != nil {
+		b.latestSystem.Host, b.latestSystem.UptimeSeconds = hostInfo.Hostname, hostInfo.Uptime
+		b.latestSystem.OS = strings.TrimSpace(fmt.Sprintf("%s %s %s", hostInfo.OS, hostInfo.Platform, hostInfo.PlatformVersion))
+	}
+	if loadAvg != nil {
+		b.latestSystem.Load = []float64{loadAvg.Load1, loadAvg.Load5, loadAvg.Load15}
+	}
+	if len(b.alertRules) > 0 {
+		metrics := map[string]float64{"cpu": cpuPercent, "mem": memPercent}
+		if diskInfo != nil {
+			metrics["disk"] = diskPercent
 		}
-		sb.WriteString(fmt.Sprintf("%s%-*s %sCPU: %.1f%%[-:-:-]\n", dimC, maxLen, name, mainC, proc.CPU))
+		if loadAvg != nil {
+			metrics["load"] = loadAvg.Load1
+		}
+		b.checkAlertRules(metrics)
 	}
-	if len(processInfos) == 0 {
-		sb.WriteString(fmt.Sprintf("%s(No active processes found)[-:-:-]\n", dimC))
+
+	if b.historyPanel != nil {
+		go b.updateHistoryGraph() // Takes the read lock, b.mu is held here
 	}
 
-	// Update the TextView
-	// Use QueueUpdateDraw to ensure thread safety when updating UI from goroutine
-	b.app.QueueUpdateDraw(func() {
-		b.systemPanel.SetText(sb.String())
-	})
+	// Update the TextView (setPanel is safe from any goroutine)
+	b.setPanel(b.systemPanel, sb.String())
 }
 
 // Helper to create text progress bar
@@ -647,7 +1046,6 @@ func formatDuration(d time.Duration) string {
 	hrs := int(d.Hours()) % 24
 	mins := int(d.Minutes()) % 60
 
-
 	if days > 0 {
 		return fmt.Sprintf("%dd %dh %dm", days, hrs, mins)
 	}
@@ -657,93 +1055,80 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%dm", mins)
 }
 
-func (b *Baseline) fetchWeather() {
-	b.mu.Lock() // Lock for writing weatherInfo
-	// Use a temporary variable to store fetched info
-	var fetchedInfo WeatherInfo
-	location := b.weatherLocation // Read location while locked
-	apiKey := b.weatherAPIKey     // Read API key while locked
-	b.mu.Unlock()                 // Unlock before network call
-
-	fetchedInfo.Location = location // Set location initially
-	fetchedInfo.LastUpdated = time.Now() // Update time regardless of success
-
-	if apiKey == "" {
-		// Use sample data if no API key
-		fetchedInfo.TempC = 22.0
-		fetchedInfo.Condition = "Partly Cloudy (Sample)"
-		fetchedInfo.Humidity = 65
-		fetchedInfo.WindKph = 8.0
-		fetchedInfo.Error = "API Key not set"
-	} else {
-		url := fmt.Sprintf("https://api.weatherapi.com/v1/current.json?key=%s&q=%s", apiKey, location)
-		// Set a timeout for the HTTP client
-		client := http.Client{Timeout: 10 * time.Second}
-		resp, err := client.Get(url)
-
-		if err != nil {
-			fetchedInfo.Error = fmt.Sprintf("HTTP error: %v", err)
-		} else {
-			defer resp.Body.Close()
-
-			if resp.StatusCode != http.StatusOK {
-				// Try to read error message from body
-				var errResp struct {
-					Error struct {
-						Message string `json:"message"`
-					} `json:"error"`
-				}
-				if json.NewDecoder(resp.Body).Decode(&errResp) == nil && errResp.Error.Message != "" {
-					fetchedInfo.Error = fmt.Sprintf("API error: %s (%d)", errResp.Error.Message, resp.StatusCode)
-				} else {
-					fetchedInfo.Error = fmt.Sprintf("API error: Status %d", resp.StatusCode)
-				}
-			} else {
-				var data struct {
-					Location struct {
-						Name string `json:"name"`
-					} `json:"location"`
-					Current struct {
-						TempC     float64 `json:"temp_c"`
-						Condition struct {
-							Text string `json:"text"`
-						} `json:"condition"`
-						Humidity int     `json:"humidity"`
-						WindKph  float64 `json:"wind_kph"`
-					} `json:"current"`
-				}
+// queryWeather fetches the current weather and forecast for location. Errors
+// end up in Error; without an API key the provider returns sample data.
+func queryWeather(provider WeatherProvider, location string) WeatherInfo {
+	info, err := provider.Forecast(location)
+	if err != nil {
+		info = WeatherInfo{Error: err.Error()}
+	}
+	if info.Location == "" {
+		info.Location = location
+	}
+	info.LastUpdated = time.Now() // Update time regardless of success
+	return info
+}
 
-				if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-					fetchedInfo.Error = fmt.Sprintf("JSON parse error: %v", err)
-				} else {
-					// Update weather info successfully
-					fetchedInfo.Location = data.Location.Name // Use name from API response
-					fetchedInfo.TempC = data.Current.TempC
-					fetchedInfo.Condition = data.Current.Condition.Text
-					fetchedInfo.Humidity = data.Current.Humidity
-					fetchedInfo.WindKph = data.Current.WindKph
-					fetchedInfo.Error = "" // Clear previous error
-				}
-			}
+func (b *Baseline) fetchWeather() {
+	b.mu.RLock()
+	locations := append([]string(nil), b.weatherLocs...) // Read locations while locked
+	queries := make([]string, len(locations))
+	for i, location := range locations {
+		queries[i] = b.weatherQuery(location)
+	}
+	provider := b.weatherProvider
+	b.mu.RUnlock() // Unlock before network call
+
+	all := make([]WeatherInfo, len(locations))
+	for i, location := range locations {
+		all[i] = queryWeather(provider, queries[i])
+		if queries[i] != location {
+			all[i].Location = location // The picked place's name, not its coordinates
 		}
 	}
 
 	// Lock again to update the shared state
 	b.mu.Lock()
-	b.weatherInfo = fetchedInfo
+	// Failed locations fall back to their last good report
+	b.cacheWeather(locations, all)
+	fetched := b.recordTemperatures(locations, all)
+	if len(all) == len(b.weatherLocs) { // Unless a location was added or removed meanwhile
+		b.weatherAll = all
+		b.showWeatherLocation(min(b.weatherIndex, len(all)-1))
+	}
+	for _, info := range all {
+		if !info.Cached {
+			b.announceWeatherWarnings(info)
+		}
+	}
 	b.mu.Unlock()
+	b.saveTemperatures(fetched)
 
 	// Trigger UI update
 	b.updateWeather()
 }
 
-
 func (b *Baseline) updateWeather() {
 	b.mu.RLock() // Read lock for weatherInfo
 	// Copy needed data under lock
 	info := b.weatherInfo
-	apiKeySet := b.weatherAPIKey != ""
+	units := b.weatherUnits
+	needsKey := b.weatherNeedsKey
 	location := b.weatherLocation // Use the configured location for display if error
+	trend := b.weatherTrend(location)
+	position := ""
+	if len(b.weatherLocs) > 1 {
+		position = fmt.Sprintf(" (%d/%d)", b.weatherIndex+1, len(b.weatherLocs))
+	}
+	if b.weatherMode == "split" && len(b.weatherAll) > 1 {
+		all := append([]WeatherInfo(nil), b.weatherAll...)
+		b.mu.RUnlock()
+		b.app.QueueUpdate(func() {
+			_, _, width, _ := b.weatherPanel.GetInnerRect()
+			b.setPanel(b.weatherPanel, renderWeatherColumns(all, width, units, b.theme))
+		})
+		return
+	}
 	b.mu.RUnlock()
 
 	mainC := colorTag(b.theme.Main)
@@ -753,44 +1138,58 @@ func (b *Baseline) updateWeather() {
 
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("%sWEATHER REPORT[-:-:-]\n", brightC+"[::b]"))
+	sb.WriteString(renderWeatherWarnings(info.Warnings, b.theme))
 
 	if info.Error != "" {
-		sb.WriteString(fmt.Sprintf("%sLocation: %s[-:-:-]\n", mainC, location)) // Show configured location on error
+		sb.WriteString(fmt.Sprintf("%sLocation: %s%s[-:-:-]\n", mainC, location, position)) // Show configured location on error
 		sb.WriteString(fmt.Sprintf("%sStatus: %s%s[-:-:-]\n", mainC, errorC, info.Error))
-		if !apiKeySet {
-			sb.WriteString(fmt.Sprintf("%s\nSet WEATHER_API_KEY in .env file[-:-:-]\n", dimC))
-			// Sample ASCII art
-			sb.WriteString(fmt.Sprintf("\n%s    \\  /[-:-:-]\n", brightC))
-			sb.WriteString(fmt.Sprintf("%s  _ /\"\".-.    [-:-:-]\n", brightC))
-			sb.WriteString(fmt.Sprintf("%s    \\_(   ).  [-:-:-]\n", brightC))
-			sb.WriteString(fmt.Sprintf("%s    /(___(__)  [-:-:-]\n", brightC))
+		if needsKey != "" {
+			sb.WriteString(fmt.Sprintf("%s\nSet %s in .env file, or use provider = \"open-meteo\"[-:-:-]\n", dimC, needsKey))
+			// Sample art
+			sb.WriteString("\n" + strings.Join(renderWeatherArt(info.Kind, info.IsDay, b.theme), "\n") + "\n")
 		}
 	} else {
-		sb.WriteString(fmt.Sprintf("%sLocation: %s[-:-:-]\n", mainC, info.Location)) // Show location from API
-		sb.WriteString(fmt.Sprintf("%sTemperature: %.1f°C[-:-:-]\n", mainC, info.TempC))
-		sb.WriteString(fmt.Sprintf("%sCondition: %s[-:-:-]\n", mainC, info.Condition))
-		sb.WriteString(fmt.Sprintf("%sHumidity: %d%%[-:-:-]\n", dimC, info.Humidity))
-		sb.WriteString(fmt.Sprintf("%sWind: %.1f km/h[-:-:-]\n", dimC, info.WindKph))
+		sb.WriteString(fmt.Sprintf("%sLocation: %s%s[-:-:-]\n", mainC, info.Location, position)) // Show location from API
+		details := []string{
+			fmt.Sprintf("%sTemperature: %s[-:-:-]", mainC, units.Temp(info.TempC)),
+			fmt.Sprintf("%sCondition: %s[-:-:-]", mainC, info.Condition),
+			fmt.Sprintf("%sHumidity: %d%%[-:-:-]", dimC, info.Humidity),
+			fmt.Sprintf("%sWind: %s[-:-:-]", dimC, units.Wind(info.WindKph)),
+			"",
+		}
+		if info.PrecipMM > 0 {
+			details[4] = fmt.Sprintf("%sPrecipitation: %s[-:-:-]", dimC, units.Precip(info.PrecipMM))
+		}
+		// Picture of the condition on the left, wttr.in style
+		for i, art := range renderWeatherArt(info.Kind, info.IsDay, b.theme) {
+			sb.WriteString(art + details[i] + "\n")
+		}
+		sb.WriteString(renderWeatherTrend(trend, time.Now(), units, b.theme))
 	}
 
-	// Static Forecast Example
-	sb.WriteString(fmt.Sprintf("\n%sFORECAST (Sample):[-:-:-]\n", mainC))
-	hours := []string{"06:00", "12:00", "18:00", "00:00"}
-	temps := []string{"18°C", "22°C", "20°C", "16°C"}
-	for i, hour := range hours {
-		sb.WriteString(fmt.Sprintf("%s%s: %s[-:-:-]\n", dimC, hour, temps[i]))
+	if needsKey == "" || info.Cached {
+		sb.WriteString(renderForecast(info, units, b.theme))
+	} else {
+		// Static Forecast Example
+		sb.WriteString(fmt.Sprintf("\n%sFORECAST (Sample):[-:-:-]\n", mainC))
+		hours := []string{"06:00", "12:00", "18:00", "00:00"}
+		temps := []float64{18, 22, 20, 16}
+		for i, hour := range hours {
+			sb.WriteString(fmt.Sprintf("%s%s: %.0f%s[-:-:-]\n", dimC, hour, units.Degrees(temps[i]), units.TempSymbol()))
+		}
 	}
 
-	sb.WriteString(fmt.Sprintf("\n%sLast updated: %s[-:-:-]", dimC, info.LastUpdated.Format("15:04:05")))
+	if info.Cached {
+		sb.WriteString(fmt.Sprintf("\n%sUpdated %s ago (cached)[-:-:-]", dimC, formatDuration(time.Since(info.LastUpdated))))
+	} else {
+		sb.WriteString(fmt.Sprintf("\n%sLast updated: %s[-:-:-]", dimC, info.LastUpdated.Format("15:04:05")))
+	}
 
 	// Update the TextView
-	b.app.QueueUpdateDraw(func() {
-		b.weatherPanel.SetText(sb.String())
-	})
+	b.setPanel(b.weatherPanel, sb.String())
 }
 
 func (b *Baseline) updateTime() {
-	// No locking needed as time.Now() is safe and we don't access shared state directly here
 	now := time.Now()
 	mainC := colorTag(b.theme.Main)
 	dimC := colorTag(b.theme.Dim)
@@ -800,7 +1199,9 @@ func (b *Baseline) updateTime() {
 
 	// Current Time and Date
 	sb.WriteString(fmt.Sprintf("%s%s%s[-:-:-]\n", brightC, "[::b]", now.Format("15:04:05"))) // Bold time
-	sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n\n", mainC, now.Format("Monday, January 02, 2006")))
+	sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n", mainC, now.Format("Monday, January 02, 2006")))
+	sb.WriteString(renderWorldClocks(now, b.clockZones, b.theme)) // TIMEZONES
+	sb.WriteString("\n")
 
 	// Calendar
 	sb.WriteString(fmt.Sprintf("%s     CALENDAR     [-:-:-]\n", mainC))
@@ -812,7 +1213,7 @@ func (b *Baseline) updateTime() {
 
 	// Calculate weekday of the 1st (Monday=0, Sunday=6)
 	// Go's Weekday() is Sunday=0, Saturday=6. Adjust.
-	startDay := int(firstOfMonth.Weekday()+6)%7 // Monday = 0
+	startDay := int(firstOfMonth.Weekday()+6) % 7 // Monday = 0
 	daysInMonth := lastOfMonth.Day()
 
 	currentDay := 1
@@ -843,40 +1244,82 @@ func (b *Baseline) updateTime() {
 		sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n", weekColor, weekStr.String()))
 	}
 
-	// Static Upcoming Events Example
-	sb.WriteString(fmt.Sprintf("\n%sUPCOMING (Sample):[-:-:-]\n", mainC))
-	events := []struct{ Time, Name string }{
-		{"14:00", "Team Meeting"},
-		{"16:30", "Project Review"},
-		{"Tomorrow", "Deadline: Report"},
-	}
-	for _, event := range events {
-		sb.WriteString(fmt.Sprintf("%s%s: %s%s[-:-:-]\n", dimC, event.Time, mainC, event.Name))
+	// Upcoming events from configured calendar sources
+	b.mu.RLock()
+	hasSources := len(b.calendarSources) > 0
+	calEvents := b.calendarEvents
+	calError := b.calendarError
+	pomodoroText := b.pomodoroSection()
+	timersText := b.timersSection()
+	b.mu.RUnlock()
+
+	sb.WriteString(pomodoroText)
+	sb.WriteString(timersText)
+
+	if hasSources {
+		sb.WriteString(fmt.Sprintf("\n%sUPCOMING:[-:-:-]\n", mainC))
+		shown := 0
+		for _, ev := range calEvents {
+			if shown >= calendarMaxEvents {
+				break
+			}
+			if !ev.End.IsZero() && ev.End.Before(now) {
+				continue // Already over
+			}
+			countdown := ""
+			if c := eventCountdown(ev, now); c != "" {
+				countdown = fmt.Sprintf(" %s(%s)", brightC, c)
+			}
+			sb.WriteString(fmt.Sprintf("%s%s: %s%s%s[-:-:-]\n", dimC, formatEventTime(ev, now), mainC, tview.Escape(ev.Title), countdown))
+			shown++
+		}
+		if shown == 0 {
+			if calError != "" {
+				sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(calError)))
+			} else {
+				sb.WriteString(fmt.Sprintf("%s(No upcoming events)[-:-:-]\n", dimC))
+			}
+		}
+	} else {
+		// Static Upcoming Events Example
+		sb.WriteString(fmt.Sprintf("\n%sUPCOMING (Sample):[-:-:-]\n", mainC))
+		events := []struct{ Time, Name string }{
+			{"14:00", "Team Meeting"},
+			{"16:30", "Project Review"},
+			{"Tomorrow", "Deadline: Report"},
+		}
+		for _, event := range events {
+			sb.WriteString(fmt.Sprintf("%s%s: %s%s[-:-:-]\n", dimC, event.Time, mainC, event.Name))
+		}
 	}
 
 	// Update the TextView
-	b.app.QueueUpdateDraw(func() {
-		b.timePanel.SetText(sb.String())
-	})
+	b.setPanel(b.timePanel, sb.String())
 }
 
 func (b *Baseline) updateTodos() {
 	b.mu.Lock() // Lock for sorting and reading/writing todos
 	defer b.mu.Unlock()
 
-	// Sort todos: High > Medium > Low, then by original order.
-	priorityMap := map[string]int{"high": 0, "medium": 1, "low": 2}
-	sort.SliceStable(b.todoItems, func(i, j int) bool {
-		p1, ok1 := priorityMap[strings.ToLower(b.todoItems[i].Priority)]
-		if !ok1 {
-			p1 = 1 // Default to medium if invalid
-		}
-		p2, ok2 := priorityMap[strings.ToLower(b.todoItems[j].Priority)]
-		if !ok2 {
-			p2 = 1 // Default to medium if invalid
+	// Remember the selected item, sorting may move it
+	var selected *TodoItem
+	if b.todoCursor < len(b.todoItems) {
+		item := b.todoItems[b.todoCursor]
+		selected = &item
+	}
+
+	// Sort todos: High > Medium > Low, then by original order, subtasks under their task.
+	depths := b.sortTodos()
+	children := todoChildren(b.todoItems)
+	if selected != nil {
+		for i, item := range b.todoItems {
+			if sameTodo(item, *selected) {
+				b.todoCursor = i
+				break
+			}
 		}
-		return p1 < p2
-	})
+	}
+	b.clampTodoCursor()
 
 	mainC := colorTag(b.theme.Main)
 	dimC := colorTag(b.theme.Dim)
@@ -886,7 +1329,13 @@ func (b *Baseline) updateTodos() {
 
 	// TODO: Add input mode display if implemented later
 
+	b.todoLines = b.todoLines[:0]
+	b.todoLineItems = b.todoLineItems[:0]
+	cursorLine := 0
 	for i, item := range b.todoItems {
+		if !b.todoShown(item) {
+			continue
+		}
 		var priorityChar string
 		var priorityColor string
 		switch strings.ToLower(item.Priority) {
@@ -913,38 +1362,87 @@ func (b *Baseline) updateTodos() {
 		// Escape brackets in the task text itself to avoid tview tag parsing issues
 		escapedText := strings.ReplaceAll(item.Text, "[", "[[")
 		escapedText = strings.ReplaceAll(escapedText, "]", "]]")
+		escapedText = highlightHashtags(escapedText, brightC, textColor)
+
+		// Due date, red once it has passed
+		due := ""
+		if item.Due != nil && !item.Done {
+			dueColor := dimC
+			if item.Due.Before(time.Now()) {
+				dueColor = "[red]"
+				textColor = "[red]"
+			}
+			due = fmt.Sprintf(" %s(%s)", dueColor, dueLabel(*item.Due, time.Now()))
+		}
+		if item.Repeat != "" {
+			due += fmt.Sprintf(" %s%s", dimC, repeatGlyph)
+		}
 
+		// Finished pomodoros, and a marker on the task being worked on
+		pomodoros := ""
+		if item.Pomodoros > 0 {
+			pomodoros = fmt.Sprintf(" %s×%d", dimC, item.Pomodoros)
+		}
+		if b.pomodoro != nil && b.pomodoro.Task == item.Text && !item.Done {
+			pomodoros += fmt.Sprintf(" %s◷", brightC)
+		}
+
+		// Subtasks indented under their task, which shows how many are done
+		indent := strings.Repeat("  ", depths[i])
+		if done, total := todoProgress(b.todoItems, children, i); total > 0 {
+			pomodoros += fmt.Sprintf(" %s%d/%d", dimC, done, total)
+		}
 
-		sb.WriteString(fmt.Sprintf("%s%2d %s[%s] %s%s %s%s[-:-:-]\n",
-			dimC, i+1, // Index
+		cursor := ""
+		if i == b.todoCursor {
+			cursor = "[::r]" // Selected: reverse video
+			cursorLine = len(b.todoLines)
+		}
+		line := fmt.Sprintf("%s%s%2d %s%s[%s] %s%s %s%s%s%s[-:-:-]",
+			cursor, dimC, i+1, indent, // Index
 			priorityColor, priorityChar, // Priority
 			statusColor, status, // Status
 			textColor, escapedText, // Text (escaped)
-		))
+			due, pomodoros,
+		)
+		b.todoLines = append(b.todoLines, line)
+		b.todoLineItems = append(b.todoLineItems, i)
+		sb.WriteString(line + "\n")
+	}
+	title := " Task List "
+	if b.todoFilter != "" {
+		title = fmt.Sprintf(" Task List #%s (%d/%d) ", b.todoFilter, len(b.todoLines), len(b.todoItems))
 	}
+	title += b.todoSyncLabel()
 
 	// Help text
-	sb.WriteString(fmt.Sprintf("\n%s[N]ew [T]oggle [D]elete [P]riority [Q]uit [:]Cmd [?]Help[-:-:-]", dimC))
+	sb.WriteString(fmt.Sprintf("\n%s↑↓ Select [T]oggle [D]elete [P]riority [N]ew [Q]uit [:]Cmd [?]Help[-:-:-]", dimC))
 
 	// Update the TextView
-	b.app.QueueUpdateDraw(func() {
-		// Reset scroll position when updating content might be good
-		b.todoPanel.ScrollToBeginning()
-		b.todoPanel.SetText(sb.String())
+	lines := append([]string(nil), b.todoLines...)
+	b.renderPanel(b.todoPanel, title+"\n"+sb.String(), func() {
+		b.todoPanel.SetTitle(title)
+		setPanelText(b.todoPanel, sb.String()) // Keep the scroll position
+		b.scrollToTodo(lines, cursorLine)
 	})
 }
 
 func (b *Baseline) updateFooter() {
-	b.mu.RLock() // Read lock for notifications and focus state
-	// Copy needed data under lock
+	b.mu.RLock() // Read lock for focus state
 	currentFocus := b.currentFocus
+	b.mu.RUnlock()
+
+	b.notifMu.Lock()
 	var latest Notification
 	hasNotifications := len(b.notifications) > 0
 	if hasNotifications {
 		latest = b.notifications[len(b.notifications)-1]
+		// Expired notifications stay in the history, the footer goes back to the hint
+		if ttl := b.notifTTL[latest.Type]; ttl > 0 && time.Since(latest.Time) >= ttl {
+			hasNotifications = false
+		}
 	}
-	b.mu.RUnlock()
-
+	b.notifMu.Unlock()
 
 	var content string
 
@@ -952,31 +1450,29 @@ func (b *Baseline) updateFooter() {
 		// Command input is handled by showing/hiding the InputField
 		// Ensure the regular footer TextView is empty or hidden
 		content = ""
+		if !b.footerChanged(content) {
+			return
+		}
 		b.app.QueueUpdateDraw(func() {
 			b.layout.ResizeItem(b.footer, 0, 0)   // Hide notification footer
 			b.layout.ResizeItem(b.cmdInput, 1, 0) // Show command input
-			b.footer.SetText(content) // Clear text just in case
+			b.footer.SetText(content)             // Clear text just in case
 		})
 		return // Don't overwrite with notification logic below
 	}
 
 	// If not in command mode, show notifications
 	if hasNotifications {
-		var color string
-		switch latest.Type {
-		case "error":
-			color = "[red]"
-		case "success":
-			color = "[green]"
-		default: // info
-			color = colorTag(b.theme.Main)
-		}
-		content = fmt.Sprintf("%s[%s] %s%s[-:-:-]", colorTag(b.theme.Dim), latest.Time.Format("15:04:05"), color, latest.Message)
+		color := notificationColor(latest.Type, b.theme)
+		content = fmt.Sprintf("%s[%s] %s%s[-:-:-]", colorTag(b.theme.status()), latest.Time.Format("15:04:05"), color, latest.Message)
 	} else {
-		content = fmt.Sprintf("%sPress ':' to enter command mode, '?' for help[-:-:-]", colorTag(b.theme.Dim))
+		content = fmt.Sprintf("%sPress ':' to enter command mode, '?' for help[-:-:-]", colorTag(b.theme.status()))
 	}
 
-	// Update the TextView and ensure correct visibility
+	// Update the TextView and ensure correct visibility, unless it already shows this
+	if !b.footerChanged(content) {
+		return
+	}
 	b.app.QueueUpdateDraw(func() {
 		b.layout.ResizeItem(b.footer, 1, 0)   // Show notification footer
 		b.layout.ResizeItem(b.cmdInput, 0, 0) // Hide command input
@@ -987,20 +1483,57 @@ func (b *Baseline) updateFooter() {
 // --- Actions & Event Handling ---
 
 func (b *Baseline) addNotification(message, msgType string) {
-	b.mu.Lock()
-	defer b.mu.Unlock()
+	b.notify("", message, msgType)
+}
 
-	b.notifications = append(b.notifications, Notification{
-		Message: message,
-		Type:    msgType,
-		Time:    time.Now(),
-	})
-	// Keep only the last 5 notifications
-	if len(b.notifications) > 5 {
-		b.notifications = b.notifications[len(b.notifications)-5:]
+// notify raises a notification on behalf of a source (widget name, "" for the core app).
+func (b *Baseline) notify(source, message, msgType string) {
+	b.notifyPriority(source, message, msgType, PriorityNormal)
+}
+
+// notifyPriority raises a notification with an explicit default priority;
+// NOTIFY_PRIORITY / NOTIFY_HISTORY_ONLY rules may still override it.
+func (b *Baseline) notifyPriority(source, message, msgType string, priority Priority) {
+	// Uses its own lock: this is called from inside sections that already hold b.mu
+	b.notifMu.Lock()
+	defer b.notifMu.Unlock()
+
+	n := Notification{
+		Message:  message,
+		Type:     msgType,
+		Source:   source,
+		Time:     time.Now(),
+		Priority: b.notifFilter.priority(source, msgType, priority),
+		Route:    b.notifFilter.route(source, msgType),
+	}
+	n.Priority = routed(n)
+	// Do not disturb: only errors and high priority get through, the rest is held in the history
+	held := msgType != "error" && n.Priority < PriorityHigh && b.dndActive()
+	b.logNotification(n, held) // Full history lives on disk
+	if held {
+		b.dndHeld++
+		return
+	}
+	// Low priority only goes to the history
+	if n.Priority == PriorityLow {
+		return
+	}
+	if b.delivery.wants(n) {
+		go b.deliverExternal(n)
+	}
+	b.notifications = append(b.notifications, n)
+	if msgType == "error" {
+		go b.ring()
+	}
+	// Keep only the last notifMax notifications
+	if len(b.notifications) > b.notifMax {
+		b.notifications = b.notifications[len(b.notifications)-b.notifMax:]
 	}
 	// Trigger footer update after adding notification
 	// Need to do this async as we hold the lock here
+	if ttl := b.notifTTL[msgType]; ttl > 0 {
+		time.AfterFunc(ttl, b.updateFooter)
+	}
 	go b.updateFooter()
 }
 
@@ -1008,6 +1541,7 @@ func (b *Baseline) processCommand(command string) {
 	b.mu.Lock() // Lock for modifying state based on command
 	defer b.mu.Unlock()
 
+	rawCommand := strings.TrimSpace(command) // Original case, for paths
 	command = strings.TrimSpace(strings.ToLower(command))
 	if command == "" {
 		return
@@ -1019,120 +1553,22 @@ func (b *Baseline) processCommand(command string) {
 	}
 
 	parts := strings.Fields(command)
-	cmd := parts[0]
-	args := parts[1:]
-
-	needsTodoUpdate := false
-	needsWeatherUpdate := false
-	needsThemeUpdate := false
-
-	switch cmd {
-	case "help", "?":
-		b.addNotification("Cmds: help, todo, weather, clear, exit, theme, shortcut", "info")
-	case "exit", "quit", "q":
-		// Stop is thread-safe
-		b.app.Stop() // Gracefully stop the application
-	case "clear":
-		b.notifications = []Notification{}
-		b.addNotification("Notifications cleared", "success")
-	case "shortcut":
-		b.addNotification("Shortcuts: N(ew), T(oggle), D(elete), P(rio), Q(uit), :(Cmd), ?(Help)", "info")
-	case "theme":
-		if len(args) == 1 {
-			themeName := args[0]
-			if newTheme, ok := themes[themeName]; ok {
-				b.theme = newTheme
-				needsThemeUpdate = true // Flag theme update
-				b.addNotification(fmt.Sprintf("Theme changed to %s", themeName), "success")
-			} else {
-				available := []string{}
-				for k := range themes {
-					available = append(available, k)
-				}
-				b.addNotification(fmt.Sprintf("Unknown theme: %s. Available: %s", themeName, strings.Join(available, ", ")), "error")
-			}
-		} else {
-			b.addNotification("Usage: theme <themename>", "error")
-		}
-	case "todo":
-		if len(args) > 0 {
-			subCmd := args[0]
-			todoArgs := args[1:]
-			switch subCmd {
-			case "add":
-				if len(todoArgs) > 0 {
-					text := strings.Join(todoArgs, " ")
-					b.todoItems = append(b.todoItems, TodoItem{Text: text, Done: false, Priority: "medium"})
-					b.saveTodos()
-					b.addNotification(fmt.Sprintf("Added todo: %s", text), "success")
-					needsTodoUpdate = true
-				} else {
-					b.addNotification("Usage: todo add <task text>", "error")
-				}
-			case "toggle", "done":
-				if len(todoArgs) == 1 {
-					index, err := strconv.Atoi(todoArgs[0])
-					if err == nil && index >= 1 && index <= len(b.todoItems) {
-						b.todoItems[index-1].Done = !b.todoItems[index-1].Done
-						b.saveTodos()
-						b.addNotification(fmt.Sprintf("Toggled todo #%d", index), "success")
-						needsTodoUpdate = true
-					} else {
-						b.addNotification(fmt.Sprintf("Invalid todo index: %s", todoArgs[0]), "error")
-					}
-				} else {
-					b.addNotification("Usage: todo toggle <index>", "error")
-				}
-			case "delete", "rm":
-				if len(todoArgs) == 1 {
-					index, err := strconv.Atoi(todoArgs[0])
-					if err == nil && index >= 1 && index <= len(b.todoItems) {
-						deleted := b.todoItems[index-1]
-						b.todoItems = append(b.todoItems[:index-1], b.todoItems[index:]...) // Slice trick to delete
-						b.saveTodos()
-						b.addNotification(fmt.Sprintf("Deleted todo: %s", deleted.Text), "success")
-						needsTodoUpdate = true
-					} else {
-						b.addNotification(fmt.Sprintf("Invalid todo index: %s", todoArgs[0]), "error")
-					}
-				} else {
-					b.addNotification("Usage: todo delete <index>", "error")
-				}
-			// Add "prio" subcommand later if needed
-			default:
-				b.addNotification(fmt.Sprintf("Unknown todo command: %s", subCmd), "error")
-			}
-		} else {
-			b.addNotification("Todo commands: add, toggle, delete", "info")
-		}
-	case "weather":
-		if len(args) > 0 && args[0] == "set" && len(args) > 1 {
-			location := strings.Join(args[1:], " ")
-			b.weatherLocation = location
-			// TODO: Persist location? Maybe save to a config file?
-			b.addNotification(fmt.Sprintf("Weather location set to: %s. Fetching...", location), "success")
-			needsWeatherUpdate = true // Flag weather fetch
-		} else {
-			b.addNotification("Usage: weather set <location>", "error")
-		}
-	default:
+	raw := strings.Fields(rawCommand)
+	args := CommandArgs{
+		Name: parts[0],
+		Args: parts[1:],
+		Raw:  raw[1:],
+		Text: strings.TrimSpace(rawCommand[len(raw[0]):]),
+	}
+	if c, ok := b.findCommand(args.Name); ok {
+		c.Run(args) // Handlers start slow work and UI updates in goroutines
+	} else {
 		b.addNotification(fmt.Sprintf("Unknown command: %s", command), "error")
 	}
 
 	// Clear input field after processing (do this outside lock if possible, but needs app access)
 	// It's generally safe to call tview methods from the main event loop or via QueueUpdateDraw
 	b.cmdInput.SetText("")
-
-	// Trigger updates outside the main lock if needed
-	if needsTodoUpdate {
-		go b.updateTodos() // Update UI async
-	}
-	if needsThemeUpdate {
-		go b.applyTheme() // Apply theme async
-	}
-	if needsWeatherUpdate {
-		go b.fetchWeather() // Fetch new weather in background async
-	}
 	// Footer update is triggered by addNotification
 }
 
@@ -1144,6 +1580,16 @@ func (b *Baseline) inputHandler(event *tcell.EventKey) *tcell.EventKey {
 		// We could add history navigation (Up/Down) here if needed
 		return event
 	}
+	if b.overlayOpen() {
+		return event // Overlays handle their own keys
+	}
+	if b.app.GetFocus() == b.procTable {
+		return event // Table keys, Esc hands focus back
+	}
+
+	if b.focusKey(event) {
+		return nil // Tab, Shift-Tab, h/j/k/l, Esc: move between panels
+	}
 
 	// Lock only if handling global keys that modify state
 	b.mu.Lock()
@@ -1152,6 +1598,26 @@ func (b *Baseline) inputHandler(event *tcell.EventKey) *tcell.EventKey {
 	needsTodoUpdate := false
 	needsFooterUpdate := true // Most actions add a notification
 
+	// Arrow keys move the todo selection instead of scrolling the task list
+	if b.app.GetFocus() == b.todoPanel && (event.Key() == tcell.KeyUp || event.Key() == tcell.KeyDown) {
+		if event.Key() == tcell.KeyUp {
+			b.moveTodoCursor(-1)
+		} else {
+			b.moveTodoCursor(1)
+		}
+		go b.updateTodos()
+		return nil
+	}
+	// and the headline selection in the news panel
+	if b.newsPanel != nil && b.app.GetFocus() == b.newsPanel && (event.Key() == tcell.KeyUp || event.Key() == tcell.KeyDown) {
+		if event.Key() == tcell.KeyUp {
+			b.moveNewsSelection(-1)
+		} else {
+			b.moveNewsSelection(1)
+		}
+		return nil
+	}
+
 	// Global keybindings when dashboard has focus
 	switch event.Rune() {
 	case ':':
@@ -1161,83 +1627,172 @@ func (b *Baseline) inputHandler(event *tcell.EventKey) *tcell.EventKey {
 		// needsFooterUpdate = true // Already true
 		return nil // Consume the event
 	case 'q':
-		b.app.Stop() // Stop is thread-safe
+		b.quit()                  // Safe from the event loop
 		needsFooterUpdate = false // App is stopping
 		return nil
-	case '?':
-		b.addNotification("Keys: N(ew), T(oggle), D(elete), P(rio), Q(uit), :(Cmd), ?(Help)", "info")
-		// needsFooterUpdate = true // Already true
+	case '?': // Key and command reference
+		b.openHelp()
+		needsFooterUpdate = false
 		return nil
 	case 'n':
 		b.addNotification("Use ':todo add <task>' to add a new task", "info")
 		// needsFooterUpdate = true // Already true
 		return nil
-	case 't': // Toggle first uncompleted todo
-		toggled := false
-		for i := range b.todoItems {
-			if !b.todoItems[i].Done {
-				b.todoItems[i].Done = true
-				b.saveTodos()
-				b.addNotification(fmt.Sprintf("Completed: %s", b.todoItems[i].Text), "success")
-				needsTodoUpdate = true
-				toggled = true
-				break
-			}
+	case 'e', 'E': // Edit notes, in place or in $EDITOR
+		if b.notesFile == "" {
+			needsFooterUpdate = false
+			break
 		}
-		if !toggled {
-			b.addNotification("No pending tasks to toggle.", "info")
+		if event.Rune() == 'e' {
+			b.openNotesEditor()
+		} else {
+			go b.editNotesExternal()
 		}
-		// needsFooterUpdate = true // Already true
 		return nil
-	case 'd': // Delete first completed todo
-		deleted := false
-		for i := range b.todoItems {
-			if b.todoItems[i].Done {
-				deletedText := b.todoItems[i].Text
-				b.todoItems = append(b.todoItems[:i], b.todoItems[i+1:]...)
-				b.saveTodos()
-				b.addNotification(fmt.Sprintf("Deleted: %s", deletedText), "success")
-				needsTodoUpdate = true
-				deleted = true
-				break
-			}
+	case 'c', 'C': // Clipboard history: select next / copy selected
+		if b.clipMax == 0 {
+			needsFooterUpdate = false
+			break
 		}
-		if !deleted {
-			b.addNotification("No completed tasks to delete.", "info")
+		if event.Rune() == 'c' {
+			b.selectNextClip()
+		} else {
+			go b.copyClip(0)
 		}
-		// needsFooterUpdate = true // Already true
 		return nil
-	case 'p': // Cycle priority of first uncompleted todo
-		cycled := false
-		priorities := []string{"low", "medium", "high"}
-		for i := range b.todoItems {
-			if !b.todoItems[i].Done {
-				currentPrio := strings.ToLower(b.todoItems[i].Priority)
-				if currentPrio == "" { currentPrio = "medium" } // Default
-				currentIdx := -1
-				for idx, p := range priorities {
-					if p == currentPrio {
-						currentIdx = idx
-						break
-					}
-				}
-				if currentIdx != -1 {
-					nextIdx := (currentIdx + 1) % len(priorities)
-					b.todoItems[i].Priority = priorities[nextIdx]
-					b.saveTodos()
-					b.addNotification(fmt.Sprintf("Priority set to %s for: %s", priorities[nextIdx], b.todoItems[i].Text), "success")
-					needsTodoUpdate = true
-					cycled = true
-				}
-				break // Only cycle the first one
-			}
+	case 'g', 'G': // Habits: select next / check off selected for today
+		if len(b.habits) == 0 {
+			needsFooterUpdate = false
+			break
 		}
-		if !cycled {
-			b.addNotification("No pending tasks to change priority.", "info")
+		if event.Rune() == 'g' {
+			b.selectNextHabit()
+		} else {
+			b.toggleHabit(0)
+		}
+		return nil
+	case 'N': // Per-interface network view
+		go b.app.QueueUpdateDraw(b.openNetDetail)
+		return nil
+	case 'P': // Move into the process table
+		go b.app.QueueUpdateDraw(b.focusProcTable)
+		return nil
+	case 'K': // Per-core CPU bars in the system panel
+		b.toggleCPUCores()
+		return nil
+	case 'R': // Memory breakdown in the system panel
+		b.toggleMemDetail()
+		return nil
+	case 'F': // Start / stop a focus session
+		if b.focusActive == nil {
+			b.startFocus("")
+		} else {
+			b.stopFocus()
+		}
+		return nil
+	case 'T': // Start / stop a pomodoro on the selected todo
+		if b.pomodoro != nil {
+			b.stopPomodoro()
+		} else if i, ok := b.selectedTodo(); ok {
+			b.startPomodoro(i)
+		}
+		return nil
+	case 'y': // Copy the focused panel as plain text
+		if tv := b.focusedPanel(); tv != nil {
+			go b.copyPanel(tv)
+		} else {
+			b.addNotification("No panel focused (use :copy <panel>)", "info")
+		}
+		return nil
+	case 'Y': // Copy the selected todo's text
+		if i, ok := b.selectedTodo(); ok {
+			b.yankTodo(i)
+		}
+		return nil
+	case '+', '=', '-', 'M': // Volume up / down / mute
+		if b.volume == nil {
+			needsFooterUpdate = false
+			break
+		}
+		switch event.Rune() {
+		case '+', '=':
+			go b.changeVolume(func() error { return b.volume.stepBy(1) })
+		case '-':
+			go b.changeVolume(func() error { return b.volume.stepBy(-1) })
+		default:
+			go b.changeVolume(b.volume.toggleMute)
+		}
+		return nil
+	case '<', '>': // Brightness down / up
+		if b.brightness == nil {
+			needsFooterUpdate = false
+			break
+		}
+		dir := 1
+		if event.Rune() == '<' {
+			dir = -1
+		}
+		go b.changeBrightness(func() error { return b.brightness.stepBy(dir) })
+		return nil
+	case 'f': // File browser
+		b.openFileBrowser("")
+		needsFooterUpdate = false
+		return nil
+	case 'u': // Disk usage of the home directory
+		b.openDiskUsage("")
+		needsFooterUpdate = false
+		return nil
+	case 'z': // Do not disturb
+		go b.toggleDND()
+		needsFooterUpdate = false
+		return nil
+	case 'm': // Notification center
+		b.openNotificationCenter()
+		needsFooterUpdate = false
+		return nil
+	case 'L': // Notification scrollback
+		b.openNotificationScrollback("")
+		needsFooterUpdate = false
+		return nil
+	case 'o': // Open the selected headline (news panel focused) or assigned issues in the browser
+		if b.newsPanel != nil && b.app.GetFocus() == b.newsPanel {
+			go b.openNews(0)
+			return nil
+		}
+		if b.jira == nil {
+			needsFooterUpdate = false
+			break
+		}
+		go b.openJira(0)
+		return nil
+	case '#': // Cycle the todo tag views
+		b.cycleTodoFilter()
+		needsTodoUpdate = true
+		return nil
+	case 't': // Toggle the selected todo
+		if i, ok := b.selectedTodo(); ok {
+			b.toggleTodo(i)
+			needsTodoUpdate = true
+		}
+		return nil
+	case 'd': // Delete the selected todo
+		if i, ok := b.selectedTodo(); ok {
+			b.deleteTodo(i)
+			needsTodoUpdate = true
+		}
+		return nil
+	case 'p': // Cycle priority of the selected todo
+		if i, ok := b.selectedTodo(); ok {
+			b.setTodoPriority(i, "")
+			needsTodoUpdate = true
 		}
-		// needsFooterUpdate = true // Already true
 		return nil
 	default:
+		// User-configured Home Assistant toggle keys
+		if idx := b.haEntityForKey(event.Rune()); idx > 0 {
+			go b.toggleHA(idx)
+			return nil
+		}
 		// If not a recognized global key, don't need lock/updates
 		needsFooterUpdate = false
 	}
@@ -1251,7 +1806,6 @@ func (b *Baseline) inputHandler(event *tcell.EventKey) *tcell.EventKey {
 		go b.updateFooter()
 	}
 
-
 	// Handle other keys like F5 for refresh if needed (outside the rune switch)
 	// switch event.Key() {
 	// case tcell.KeyF5:
@@ -1271,88 +1825,126 @@ func (b *Baseline) inputHandler(event *tcell.EventKey) *tcell.EventKey {
 
 func (b *Baseline) Run() error {
 	// Add more error information
-	log.Println("Setup layout starting...")
+	slog.Debug("Setup layout starting")
 	b.setupLayout()
-	log.Println("Setup layout complete")
+	slog.Debug("Setup layout complete")
 
 	// Initial data fetch and UI update
-	log.Println("Updating initial UI...")
+	slog.Debug("Updating initial UI")
 	b.updateHeader()
-	go b.updateSystemInfo() // Run initial fetch in background
-	go b.fetchWeather()
-	b.updateTime() // Initial time update
-	b.updateTodos() // Initial todo list render
+	b.abortRequestsOnQuit()
+	b.life.Go(b.updateSystemInfo) // Run initial fetch in background
+	b.life.Go(b.fetchWeather)
+	b.updateTime()   // Initial time update
+	b.updateTodos()  // Initial todo list render
 	b.updateFooter() // Initial footer state
+	b.trackRefresh("processes", b.schedule(b.intervals.Processes, b.fetchProcesses))
+	if b.gpuOn {
+		b.trackRefresh("system", b.schedule(b.intervals.System, b.fetchGPU))
+	}
+	if b.batteryOn {
+		b.schedule(batteryRefreshInterval, b.fetchBattery)
+	}
+	b.trackRefresh("calendar", b.schedule(b.intervals.Calendar, b.fetchCalendar))
+	b.schedule(dueCheckInterval, b.checkDueTodos)
+	if b.taskSync != nil {
+		b.schedule(b.taskSync.interval, b.syncTodos)
+	}
+	if len(b.reminders) > 0 {
+		b.schedule(reminderCheckInterval, b.checkReminders)
+	}
+	if !b.journalAt.IsZero() {
+		b.schedule(journalSummaryInterval, b.checkJournalSummary)
+	}
+	if b.rebootCheck {
+		b.schedule(rebootCheckInterval, b.checkReboot)
+	}
+	b.schedule(availabilityHeartbeat, b.heartbeatAvailability)
+	b.schedule(metricsCompactInterval, b.compactMetrics)
+	b.schedule(weatherRotateCheck, b.rotateWeather)
+	b.schedule(pomodoroTick, b.tickPomodoro)
+	b.schedule(timerTick, b.tickTimers)
+	b.startWidgets()
+	if b.config.Web.Listen != "" {
+		b.startWebMirror()
+	}
 	b.addNotification("Welcome to Baseline (Go version)", "info")
-	log.Println("Initial UI updates complete")
+	slog.Debug("Initial UI updates complete")
 
 	// Periodic updates using tickers
-	log.Println("Setting up tickers...")
-	sysTicker := time.NewTicker(refreshInterval)
+	slog.Debug("Setting up tickers")
+	sysTicker := time.NewTicker(b.intervals.System)
 	defer sysTicker.Stop()
-	weatherTicker := time.NewTicker(15 * time.Minute) // Weather less frequent
+	weatherTicker := time.NewTicker(b.intervals.Weather) // Weather less frequent
 	defer weatherTicker.Stop()
-	timeTicker := time.NewTicker(1 * time.Second) // Update time every second
+	timeTicker := time.NewTicker(b.intervals.Clock) // Update time every second by default
 	defer timeTicker.Stop()
-	log.Println("Tickers initialized")
+	b.trackRefresh("system", sysTicker)
+	b.trackRefresh("weather", weatherTicker)
+	b.trackRefresh("clock", timeTicker)
+	b.watchConfig() // Applies config.toml and .env edits to these
+	slog.Debug("Tickers initialized")
 
 	// Goroutine for handling periodic updates
-	go func() {
-		log.Println("Update goroutine started")
-		// Initial weather fetch delay (don't fetch immediately again)
-		time.Sleep(2 * time.Second)
-
+	b.life.Go(func() {
+		slog.Debug("Update goroutine started")
 		for {
 			select {
+			case <-b.life.Done():
+				slog.Debug("Update goroutine stopped")
+				return
 			case <-sysTicker.C:
-				go b.updateSystemInfo() // Fetch in background
+				b.life.Go(b.updateSystemInfo) // Fetch in background
 			case <-weatherTicker.C:
-				go b.fetchWeather() // Fetch in background
+				b.life.Go(b.fetchWeather) // Fetch in background
 			case <-timeTicker.C:
 				// Time update is cheap, can do directly or queue if needed
 				b.updateTime()
+				if b.worldPanel != nil {
+					b.updateWorldMap() // Follows the terminator
+				}
 			}
 		}
-	}()
+	})
 
 	// Set global input capture
 	b.app.SetInputCapture(b.inputHandler)
-	log.Println("Input handler set")
+	if mouseEnabled() {
+		b.setupMouse()
+	}
+	b.app.SetBeforeDrawFunc(b.reflow)
+	firstDraw := make(chan struct{})
+	var drawn sync.Once
+	b.app.SetAfterDrawFunc(func(screen tcell.Screen) {
+		b.screen = screen // Needed for the terminal bell
+		drawn.Do(func() { close(firstDraw) })
+	})
+	slog.Debug("Input handler set")
 
 	// Run the application
 	// Set Root and Focus outside the Run() call
-	log.Println("Setting root and running app...")
-	b.app.SetRoot(b.layout, true).SetFocus(b.layout)
-	
-	// Create a timeout channel to detect if the app hangs
-	timeout := make(chan bool, 1)
-	go func() {
-		time.Sleep(5 * time.Second)
-		timeout <- true
-	}()
-	
+	slog.Debug("Setting root and running app")
+	b.app.SetRoot(b.pages, true).SetFocus(b.layout)
+
 	// Create a done channel to signal application completion
 	done := make(chan error, 1)
 	go func() {
 		done <- b.app.Run()
 	}()
-	
-	// Wait for either app to complete or timeout
+
+	// Wait for the app to complete; if it hasn't drawn within 5 seconds the
+	// terminal is probably not supported
+	var err error
 	select {
-	case err := <-done:
-		if err != nil {
-			log.Printf("Error running application: %v", err)
-			return fmt.Errorf("failed to run application: %w", err)
-		}
-		return nil
-	case <-timeout:
+	case err = <-done:
+	case <-firstDraw:
+		err = <-done
+	case <-time.After(5 * time.Second):
 		// If we reach here, the app might be hanging
-		log.Println("Application appears to be hanging, showing fallback mode")
+		slog.Error("Application appears to be hanging, showing fallback mode")
 		// Stop the tview app (might not work if it's truly hung)
-		go func() {
-			b.app.Stop()
-		}()
-		
+		go b.quit()
+
 		// Fall back to simple text mode
 		clearScreen()
 		fmt.Println("---------------------------------------")
@@ -1369,13 +1961,52 @@ func (b *Baseline) Run() error {
 		fmt.Printf("OS=%s\n", runtime.GOOS)
 		fmt.Println("\nPress Enter to exit...")
 		fmt.Scanln() // Wait for user input
+		b.shutdown()
 		return fmt.Errorf("application timeout - possible terminal compatibility issue")
 	}
+
+	b.shutdown() // Stop collectors, flush the history
+	if err != nil {
+		slog.Error("Application failed", "err", err)
+		return fmt.Errorf("failed to run application: %w", err)
+	}
+	return nil
 }
 
 // --- Entry Point ---
 
 func main() {
+	// Subcommands that run without the TUI
+	if len(os.Args) > 1 && os.Args[1] == "status" {
+		if err := runStatusCommand(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "status: %v\n", err)
+			os.Exit(2)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "snapshot" {
+		if err := runSnapshotCommand(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+			os.Exit(2)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		if err := runServeCommand(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "serve: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	var remote *RemoteClient
+	if len(os.Args) > 1 && os.Args[1] == "attach" {
+		var err error
+		if remote, err = parseAttachArgs(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "attach: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
 	// Clear the screen first for better visibility
 	clearScreen()
 
@@ -1385,34 +2016,25 @@ func main() {
 	// Optional: Set terminal title (might not work everywhere)
 	fmt.Print("\033]0;Baseline (Go)\007")
 
-	// Basic logging setup
-	logFilename := "baseline_debug.log"
-	logFile, err := os.OpenFile(logFilename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err == nil {
-		log.SetOutput(logFile)
-		log.SetFlags(log.LstdFlags | log.Lshortfile)
-		defer logFile.Close()
-		log.Println("--- Application Starting ---")
-	} else {
-		// Fallback to stderr if log file fails
-		log.SetOutput(os.Stderr)
-		log.Printf("Warning: Could not open log file '%s': %v. Logging to stderr.", logFilename, err)
-	}
+	// Log to ~/.baseline/logs, see logging.go
+	logPath, closeLog := setupLogging(defaultConfigDir())
+	defer closeLog()
+	slog.Info("Application starting")
 
 	// Print initialization message
 	fmt.Println("Initializing TUI components...")
-	fmt.Println("If the application appears to hang, check baseline_debug.log")
+	fmt.Printf("If the application appears to hang, check %s\n", logPath)
 	fmt.Println("for troubleshooting information.")
 
-	baselineApp := NewBaseline()
+	baselineApp := NewBaseline(remote)
 	fmt.Println("Starting TUI application. Press 'q' to quit.")
-	
+
 	if err := baselineApp.Run(); err != nil {
 		// Error should already be logged by Run() before returning
 		fmt.Fprintf(os.Stderr, "Application exited with error: %v\n", err)
 		os.Exit(1)
 	}
-	log.Println("--- Application Exited Gracefully ---")
+	slog.Info("Application exited")
 	fmt.Println("Baseline exited.") // Message to user terminal
 }
 
@@ -1427,4 +2049,4 @@ func clearScreen() {
 	}
 	cmd.Stdout = os.Stdout
 	_ = cmd.Run() // Ignore error
-}
\ No newline at end of file
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Battery And Power ---
+//
+// A BAT line in the system panel with charge, charging state, time remaining
+// and the power profile. Linux reads /sys/class/power_supply (all internal
+// batteries combined) and power-profiles-daemon or the ACPI platform profile;
+// macOS reads `pmset`. Below BATTERY_WARN percent (default 15) while
+// discharging, a high priority notification fires once and the header shows
+// a badge. BATTERY=false turns it off; otherwise it is on when a battery is
+// found at startup.
+
+const (
+	batteryRefreshInterval = 15 * time.Second
+	defaultBatteryWarn     = 15.0
+	batteryHysteresis      = 5.0 // Percent above the threshold before warning again
+)
+
+var pmsetBattLine = regexp.MustCompile(`(\d+)%;\s*([^;]+);\s*(\d+:\d+|\(no estimate\))?`)
+
+type BatteryReading struct {
+	Percent   float64
+	State     string        // charging, discharging, full, not charging
+	Remaining time.Duration // Until empty or full, 0 when unknown
+	Profile   string        // Power profile, "" when unknown
+}
+
+// batteryEnabled reports whether battery monitoring should run at all.
+func batteryEnabled() bool {
+	if strings.ToLower(os.Getenv("BATTERY")) == "false" {
+		return false
+	}
+	_, ok := readBattery()
+	return ok
+}
+
+// batteryWarnFromEnv reads BATTERY_WARN, in percent.
+func batteryWarnFromEnv() float64 {
+	if v, err := strconv.ParseFloat(os.Getenv("BATTERY_WARN"), 64); err == nil && v > 0 && v < 100 {
+		return v
+	}
+	return defaultBatteryWarn
+}
+
+func readBattery() (BatteryReading, bool) {
+	var bat BatteryReading
+	var ok bool
+	switch runtime.GOOS {
+	case "linux":
+		bat, ok = readSysfsBattery()
+	case "darwin":
+		bat, ok = readPmsetBattery()
+	}
+	if ok {
+		bat.Profile = readPowerProfile()
+	}
+	return bat, ok
+}
+
+// readSysfsBattery combines every system battery (some laptops have two).
+// Energy files are in µWh and µW, charge files in µAh and µA; the ratios
+// work out the same either way.
+func readSysfsBattery() (BatteryReading, bool) {
+	supplies, _ := filepath.Glob("/sys/class/power_supply/*")
+	var now, full, rate, capacity float64
+	var states []string
+	found := 0
+	for _, dir := range supplies {
+		read := func(name string) string {
+			data, _ := os.ReadFile(filepath.Join(dir, name))
+			return strings.TrimSpace(string(data))
+		}
+		if read("type") != "Battery" || read("scope") == "Device" {
+			continue // Mains adapters, and mice or headsets reporting through the kernel
+		}
+		found++
+		for _, prefix := range []string{"energy", "charge"} {
+			n, err1 := readIntFile(filepath.Join(dir, prefix+"_now"))
+			f, err2 := readIntFile(filepath.Join(dir, prefix+"_full"))
+			if err1 != nil || err2 != nil || f <= 0 {
+				continue
+			}
+			now += float64(n)
+			full += float64(f)
+			power := "power_now"
+			if prefix == "charge" {
+				power = "current_now"
+			}
+			if r, err := readIntFile(filepath.Join(dir, power)); err == nil {
+				rate += float64(max(r, -r)) // Some drivers report discharge as negative
+			}
+			break
+		}
+		if c, err := readIntFile(filepath.Join(dir, "capacity")); err == nil {
+			capacity += float64(c)
+		}
+		states = append(states, strings.ToLower(read("status")))
+	}
+	if found == 0 {
+		return BatteryReading{}, false
+	}
+
+	bat := BatteryReading{Percent: capacity / float64(found), State: "unknown"}
+	if full > 0 {
+		bat.Percent = now / full * 100
+	}
+	for _, s := range []string{"discharging", "charging", "not charging", "full"} {
+		if strings.Contains(strings.Join(states, ","), s) {
+			bat.State = s
+			break
+		}
+	}
+	if rate > 0 {
+		switch bat.State {
+		case "discharging":
+			bat.Remaining = time.Duration(now / rate * float64(time.Hour))
+		case "charging":
+			bat.Remaining = time.Duration((full - now) / rate * float64(time.Hour))
+		}
+	}
+	return bat, true
+}
+
+// readPmsetBattery parses "-InternalBattery-0 (id=...)	85%; discharging; 4:12 remaining".
+func readPmsetBattery() (BatteryReading, bool) {
+	out, err := runOutput("pmset", "-g", "batt")
+	if err != nil || !strings.Contains(out, "InternalBattery") {
+		return BatteryReading{}, false
+	}
+	m := pmsetBattLine.FindStringSubmatch(out)
+	if m == nil {
+		return BatteryReading{}, false
+	}
+	pct, _ := strconv.ParseFloat(m[1], 64)
+	bat := BatteryReading{Percent: pct, State: strings.TrimSpace(m[2])}
+	if bat.State == "charged" || bat.State == "finishing charge" {
+		bat.State = "full"
+	}
+	if h, mins, ok := strings.Cut(m[3], ":"); ok {
+		hours, _ := strconv.Atoi(h)
+		minutes, _ := strconv.Atoi(mins)
+		bat.Remaining = time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+	}
+	return bat, true
+}
+
+// readPowerProfile returns the active power profile ("balanced",
+// "power-saver", "low power", ...), or "".
+func readPowerProfile() string {
+	switch runtime.GOOS {
+	case "linux":
+		if _, err := exec.LookPath("powerprofilesctl"); err == nil {
+			if out, err := runOutput("powerprofilesctl", "get"); err == nil {
+				return out
+			}
+		}
+		if data, err := os.ReadFile("/sys/firmware/acpi/platform_profile"); err == nil {
+			return strings.TrimSpace(string(data))
+		}
+	case "darwin":
+		if out, err := runOutput("pmset", "-g"); err == nil {
+			for _, line := range strings.Split(out, "\n") {
+				if f := strings.Fields(line); len(f) == 2 && f[0] == "lowpowermode" && f[1] == "1" {
+					return "low power"
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (b *Baseline) fetchBattery() {
+	bat, ok := readBattery()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !ok {
+		b.battery = nil
+		return
+	}
+	b.battery = &bat
+	switch {
+	case bat.State == "discharging" && bat.Percent <= b.batteryWarn:
+		if !b.batteryLow {
+			b.batteryLow = true
+			msg := fmt.Sprintf("Battery low: %.0f%%", bat.Percent)
+			if bat.Remaining > 0 {
+				msg += fmt.Sprintf(" (%s left)", formatDuration(bat.Remaining))
+			}
+			b.notifyPriority("battery", msg, "error", PriorityHigh)
+			go b.app.QueueUpdateDraw(b.updateHeader)
+		}
+	case bat.State != "discharging" || bat.Percent > b.batteryWarn+batteryHysteresis:
+		if b.batteryLow {
+			b.batteryLow = false
+			go b.app.QueueUpdateDraw(b.updateHeader)
+		}
+	}
+}
+
+// batterySection renders the BAT line for the system panel. Called from
+// updateSystemInfo with b.mu held.
+func (b *Baseline) batterySection() string {
+	bat := b.battery
+	if bat == nil {
+		return ""
+	}
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	pctC := brightC
+	if b.batteryLow {
+		pctC = "[red::b]"
+	}
+	details := []string{bat.State}
+	if bat.Remaining > 0 {
+		suffix := "left"
+		if bat.State == "charging" {
+			suffix = "to full"
+		}
+		details = append(details, formatDuration(bat.Remaining)+" "+suffix)
+	}
+	if bat.Profile != "" {
+		details = append(details, bat.Profile)
+	}
+	return fmt.Sprintf("%sBAT: %s %s%.0f%% %s%s[-:-:-]\n", mainC, createBar(bat.Percent, 15, b.theme), pctC, bat.Percent, dimC, strings.Join(details, ", "))
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Bluetooth Devices Widget ---
+
+const bluetoothRefreshInterval = 30 * time.Second
+
+var (
+	btDeviceLine  = regexp.MustCompile(`^Device ([0-9A-Fa-f:]{17}) (.*)$`)
+	btBatteryLine = regexp.MustCompile(`Battery Percentage: .*\((\d+)\)`)
+)
+
+type BluetoothDevice struct {
+	Address   string
+	Name      string
+	Connected bool
+	Battery   int // -1 when the platform doesn't report it
+}
+
+// bluetoothEnabled reports whether BLUETOOTH is switched on.
+func bluetoothEnabled() bool {
+	v := strings.ToLower(os.Getenv("BLUETOOTH"))
+	return v == "true" || v == "1" || v == "yes"
+}
+
+// listBluetoothDevices returns paired devices via bluetoothctl (Linux) or system_profiler (macOS).
+func listBluetoothDevices() ([]BluetoothDevice, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return bluetoothctlDevices()
+	case "darwin":
+		return systemProfilerDevices()
+	default:
+		return nil, fmt.Errorf("bluetooth not supported on %s", runtime.GOOS)
+	}
+}
+
+func bluetoothctlDevices() ([]BluetoothDevice, error) {
+	out, err := exec.Command("bluetoothctl", "devices", "Paired").Output()
+	if err != nil || len(out) == 0 {
+		// bluetoothctl < 5.65 only knows paired-devices
+		out, err = exec.Command("bluetoothctl", "paired-devices").Output()
+		if err != nil {
+			return nil, fmt.Errorf("bluetoothctl: %w", err)
+		}
+	}
+
+	var devices []BluetoothDevice
+	for _, line := range strings.Split(string(out), "\n") {
+		m := btDeviceLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		dev := BluetoothDevice{Address: m[1], Name: m[2], Battery: -1}
+		if info, err := exec.Command("bluetoothctl", "info", dev.Address).Output(); err == nil {
+			text := string(info)
+			dev.Connected = strings.Contains(text, "Connected: yes")
+			if bm := btBatteryLine.FindStringSubmatch(text); bm != nil {
+				dev.Battery, _ = strconv.Atoi(bm[1])
+			}
+		}
+		devices = append(devices, dev)
+	}
+	return devices, nil
+}
+
+func systemProfilerDevices() ([]BluetoothDevice, error) {
+	out, err := exec.Command("system_profiler", "SPBluetoothDataType", "-json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("system_profiler: %w", err)
+	}
+
+	type deviceProps struct {
+		Address string `json:"device_address"`
+		Battery string `json:"device_batteryLevelMain"`
+		Left    string `json:"device_batteryLevelLeft"`
+	}
+	var data struct {
+		Items []struct {
+			Connected    []map[string]deviceProps `json:"device_connected"`
+			NotConnected []map[string]deviceProps `json:"device_not_connected"`
+		} `json:"SPBluetoothDataType"`
+	}
+	if err := json.Unmarshal(out, &data); err != nil {
+		return nil, fmt.Errorf("JSON parse error: %w", err)
+	}
+
+	parseLevel := func(s string) int {
+		n, err := strconv.Atoi(strings.TrimSuffix(s, "%"))
+		if err != nil {
+			return -1
+		}
+		return n
+	}
+
+	var devices []BluetoothDevice
+	for _, item := range data.Items {
+		for connected, group := range [][]map[string]deviceProps{item.NotConnected, item.Connected} {
+			for _, entry := range group {
+				for name, props := range entry {
+					battery := parseLevel(props.Battery)
+					if battery < 0 {
+						battery = parseLevel(props.Left) // AirPods report per bud
+					}
+					devices = append(devices, BluetoothDevice{
+						Address:   props.Address,
+						Name:      name,
+						Connected: connected == 1,
+						Battery:   battery,
+					})
+				}
+			}
+		}
+	}
+	// Map iteration order is random; keep indices stable for bt connect/disconnect
+	sort.Slice(devices, func(i, j int) bool { return devices[i].Name < devices[j].Name })
+	return devices, nil
+}
+
+// setBluetoothConnection connects or disconnects a device by address.
+func setBluetoothConnection(address string, connect bool) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		action := "disconnect"
+		if connect {
+			action = "connect"
+		}
+		cmd = exec.Command("bluetoothctl", action, address)
+	case "darwin":
+		// Needs blueutil (brew install blueutil)
+		action := "--disconnect"
+		if connect {
+			action = "--connect"
+		}
+		cmd = exec.Command("blueutil", action, address)
+	default:
+		return fmt.Errorf("bluetooth not supported on %s", runtime.GOOS)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (b *Baseline) fetchBluetooth() {
+	devices, err := listBluetoothDevices()
+
+	b.mu.Lock()
+	prevErr := b.btError
+	b.btDevices = devices
+	b.btError = ""
+	if err != nil {
+		b.btError = err.Error()
+	}
+	b.btLastUpdated = time.Now()
+	b.mu.Unlock()
+
+	if err != nil && err.Error() != prevErr {
+		b.notify("bluetooth", fmt.Sprintf("Bluetooth: %v", err), "error")
+	}
+	b.updateBluetooth()
+}
+
+func (b *Baseline) updateBluetooth() {
+	b.mu.RLock()
+	devices := b.btDevices
+	errMsg := b.btError
+	lastUpdated := b.btLastUpdated
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sBLUETOOTH[-:-:-]\n", brightC+"[::b]"))
+
+	if errMsg != "" {
+		sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(errMsg)))
+	} else if lastUpdated.IsZero() {
+		sb.WriteString(fmt.Sprintf("%sLoading...[-:-:-]\n", dimC))
+	} else if len(devices) == 0 {
+		sb.WriteString(fmt.Sprintf("%s(No paired devices)[-:-:-]\n", dimC))
+	}
+
+	for i, dev := range devices {
+		state, stateC := "off", dimC
+		if dev.Connected {
+			state, stateC = "on ", brightC
+		}
+		battery := ""
+		if dev.Battery >= 0 {
+			batteryC := dimC
+			if dev.Battery <= 20 {
+				batteryC = "[red]"
+			}
+			battery = fmt.Sprintf(" %s%d%%", batteryC, dev.Battery)
+		}
+		sb.WriteString(fmt.Sprintf("%s%2d %s%s %s%s%s[-:-:-]\n",
+			dimC, i+1,
+			stateC, state,
+			mainC, tview.Escape(dev.Name),
+			battery,
+		))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%sLast updated: %s[-:-:-]", dimC, lastUpdated.Format("15:04:05")))
+
+	b.setPanel(b.btPanel, sb.String())
+}
+
+// connectBluetooth connects (or disconnects) the device at the 1-based index.
+func (b *Baseline) connectBluetooth(index int, connect bool) {
+	b.mu.RLock()
+	devices := b.btDevices
+	b.mu.RUnlock()
+
+	if index < 1 || index > len(devices) {
+		b.notify("bluetooth", fmt.Sprintf("Invalid device index: %d", index), "error")
+		return
+	}
+	dev := devices[index-1]
+	verb := "Disconnected"
+	if connect {
+		verb = "Connected"
+	}
+	if err := setBluetoothConnection(dev.Address, connect); err != nil {
+		b.notify("bluetooth", fmt.Sprintf("Bluetooth %s: %v", dev.Name, err), "error")
+		return
+	}
+	b.notify("bluetooth", fmt.Sprintf("%s %s", verb, dev.Name), "success")
+	b.fetchBluetooth()
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Screen Brightness Widget ---
+//
+// Linux reads and writes /sys/class/backlight directly, falling back to
+// brightnessctl when the file isn't writable (it usually needs a udev rule or
+// the video group). macOS uses the `brightness` tool, Windows WMI.
+
+const (
+	brightnessRefreshInterval = 10 * time.Second
+	brightnessDefaultStep     = 10
+	backlightDir              = "/sys/class/backlight"
+)
+
+var macBrightnessLine = regexp.MustCompile(`brightness ([0-9.]+)`)
+
+type BrightnessInfo struct {
+	Device string
+	Level  int // Percent
+	Error  string
+}
+
+type BrightnessControl struct {
+	device string // Backlight under /sys/class/backlight (Linux)
+	step   int
+}
+
+// newBrightnessControlFromEnv returns nil unless BRIGHTNESS is set.
+// BRIGHTNESS_DEVICE picks a backlight when a laptop has several.
+func newBrightnessControlFromEnv() *BrightnessControl {
+	v := strings.ToLower(os.Getenv("BRIGHTNESS"))
+	if v != "true" && v != "1" && v != "yes" && v != "auto" {
+		return nil
+	}
+	c := &BrightnessControl{device: os.Getenv("BRIGHTNESS_DEVICE"), step: brightnessDefaultStep}
+	if c.device == "" && runtime.GOOS == "linux" {
+		if devices, _ := os.ReadDir(backlightDir); len(devices) > 0 {
+			c.device = devices[0].Name()
+		}
+	}
+	if n, err := strconv.Atoi(os.Getenv("BRIGHTNESS_STEP")); err == nil && n > 0 && n <= 100 {
+		c.step = n
+	}
+	return c
+}
+
+func readIntFile(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+func (c *BrightnessControl) status() BrightnessInfo {
+	info := BrightnessInfo{Device: c.device}
+	var err error
+	switch runtime.GOOS {
+	case "linux":
+		if c.device == "" {
+			err = fmt.Errorf("no backlight found in %s", backlightDir)
+			break
+		}
+		var cur, maxLevel int
+		if cur, err = readIntFile(filepath.Join(backlightDir, c.device, "brightness")); err != nil {
+			break
+		}
+		if maxLevel, err = readIntFile(filepath.Join(backlightDir, c.device, "max_brightness")); err == nil && maxLevel > 0 {
+			info.Level = int(math.Round(float64(cur) / float64(maxLevel) * 100))
+		}
+	case "darwin":
+		info.Device = "Built-in display"
+		var out string
+		if out, err = runOutput("brightness", "-l"); err == nil {
+			if m := macBrightnessLine.FindStringSubmatch(out); m != nil {
+				level, _ := strconv.ParseFloat(m[1], 64)
+				info.Level = int(math.Round(level * 100))
+			} else {
+				err = fmt.Errorf("brightness: no built-in display")
+			}
+		}
+	case "windows":
+		info.Device = "Built-in display"
+		var out string
+		if out, err = runOutput("powershell", "-NoProfile", "-Command",
+			"(Get-CimInstance -Namespace root/WMI -ClassName WmiMonitorBrightness).CurrentBrightness"); err == nil {
+			info.Level, err = strconv.Atoi(strings.TrimSpace(strings.Split(out, "\n")[0]))
+		}
+	default:
+		err = fmt.Errorf("brightness not supported on %s", runtime.GOOS)
+	}
+	if err != nil {
+		info.Error = err.Error()
+	}
+	return info
+}
+
+// set changes the brightness to level percent. Never goes fully dark: a
+// black screen is hard to undo from a dashboard.
+func (c *BrightnessControl) set(level int) error {
+	level = min(max(level, 1), 100)
+	switch runtime.GOOS {
+	case "linux":
+		maxLevel, err := readIntFile(filepath.Join(backlightDir, c.device, "max_brightness"))
+		if err != nil {
+			return err
+		}
+		raw := max(int(math.Round(float64(level)/100*float64(maxLevel))), 1)
+		err = os.WriteFile(filepath.Join(backlightDir, c.device, "brightness"), []byte(strconv.Itoa(raw)), 0)
+		if err == nil {
+			return nil
+		}
+		if _, lookErr := exec.LookPath("brightnessctl"); lookErr != nil {
+			return fmt.Errorf("%v (install brightnessctl or add yourself to the video group)", err)
+		}
+		return runControlCommand(exec.Command("brightnessctl", "-q", "-d", c.device, "set", fmt.Sprintf("%d%%", level)))
+	case "darwin":
+		return runControlCommand(exec.Command("brightness", fmt.Sprintf("%.2f", float64(level)/100)))
+	case "windows":
+		return runControlCommand(exec.Command("powershell", "-NoProfile", "-Command",
+			fmt.Sprintf("(Get-CimInstance -Namespace root/WMI -ClassName WmiMonitorBrightnessMethods | Invoke-CimMethod -MethodName WmiSetBrightness -Arguments @{Timeout=1; Brightness=%d})", level)))
+	}
+	return fmt.Errorf("brightness not supported on %s", runtime.GOOS)
+}
+
+// stepBy moves the brightness up (dir 1) or down (dir -1) by one step.
+func (c *BrightnessControl) stepBy(dir int) error {
+	info := c.status()
+	if info.Error != "" {
+		return fmt.Errorf("%s", info.Error)
+	}
+	return c.set(info.Level + dir*c.step)
+}
+
+func (b *Baseline) fetchBrightness() {
+	info := b.brightness.status()
+
+	b.mu.Lock()
+	changed := info != b.brightInfo
+	b.brightInfo = info
+	b.mu.Unlock()
+
+	if changed {
+		b.updateBrightness()
+	}
+}
+
+// changeBrightness runs a change in the background and refreshes the panel.
+func (b *Baseline) changeBrightness(change func() error) {
+	if err := change(); err != nil {
+		b.notify("brightness", fmt.Sprintf("Brightness change failed: %v", err), "error")
+	}
+	b.fetchBrightness()
+}
+
+func (b *Baseline) updateBrightness() {
+	b.mu.RLock()
+	info := b.brightInfo
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sBACKLIGHT[-:-:-]\n", brightC+"[::b]"))
+	if info.Error != "" {
+		sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(info.Error)))
+	} else {
+		sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n\n", mainC, tview.Escape(info.Device)))
+		sb.WriteString(fmt.Sprintf("%s %s%d%%[-:-:-]\n", createBar(float64(info.Level), 15, b.theme), brightC, info.Level))
+	}
+	sb.WriteString(fmt.Sprintf("\n%s</> brightness[-:-:-]", dimC))
+
+	b.setPanel(b.brightPanel, sb.String())
+}
+
+// brightnessCommand handles "bright [up|down|<1-100>]". Called from processCommand with b.mu held.
+func (b *Baseline) brightnessCommand(args []string) {
+	if b.brightness == nil {
+		b.addNotification("Brightness control not configured (set BRIGHTNESS=true)", "error")
+		return
+	}
+	if len(args) == 0 {
+		b.addNotification(fmt.Sprintf("Brightness: %d%%", b.brightInfo.Level), "info")
+		return
+	}
+	switch args[0] {
+	case "up", "+":
+		go b.changeBrightness(func() error { return b.brightness.stepBy(1) })
+	case "down", "-":
+		go b.changeBrightness(func() error { return b.brightness.stepBy(-1) })
+	default:
+		level, err := strconv.Atoi(strings.TrimSuffix(args[0], "%"))
+		if err != nil || level < 1 || level > 100 {
+			b.addNotification("Usage: bright [up|down|<1-100>]", "error")
+			return
+		}
+		go b.changeBrightness(func() error { return b.brightness.set(level) })
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+)
+
+// --- Calendar Sources ---
+
+const (
+	calendarLookahead = 7 * 24 * time.Hour
+	calendarCountdown = 24 * time.Hour // Events closer than this show "in 2h 15m"
+	calendarMaxEvents = 5              // Events shown in the Time & Calendar panel
+)
+
+type CalendarEvent struct {
+	Title    string
+	Start    time.Time
+	End      time.Time
+	Location string
+	AllDay   bool
+	Source   string // Name of the source the event came from
+}
+
+// CalendarSource is anything that can list upcoming events for the
+// Time & Calendar panel (Microsoft Graph, ICS files, ...).
+type CalendarSource interface {
+	Name() string
+	Upcoming(from, to time.Time) ([]CalendarEvent, error)
+}
+
+// loadCalendarSources builds the list of configured sources from the environment.
+func (b *Baseline) loadCalendarSources() {
+	if src := newOutlookSourceFromEnv(); src != nil {
+		b.calendarSources = append(b.calendarSources, src)
+	}
+	for _, src := range icsSourcesFromEnv() {
+		b.calendarSources = append(b.calendarSources, src)
+	}
+}
+
+func (b *Baseline) fetchCalendar() {
+	b.mu.RLock()
+	sources := b.calendarSources
+	b.mu.RUnlock()
+
+	if len(sources) == 0 {
+		return
+	}
+
+	now := time.Now()
+	var events []CalendarEvent
+	var errs []string
+	for _, src := range sources {
+		evs, err := src.Upcoming(now, now.Add(calendarLookahead))
+		if err != nil {
+			slog.Warn("Calendar source failed", "source", src.Name(), "err", err)
+			errs = append(errs, fmt.Sprintf("%s: %v", src.Name(), err))
+			continue
+		}
+		events = append(events, evs...)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+
+	b.mu.Lock()
+	prevErr := b.calendarError
+	b.calendarEvents = events
+	b.calendarError = strings.Join(errs, "; ")
+	changed := b.calendarError != prevErr
+	b.mu.Unlock()
+
+	if len(errs) > 0 && changed {
+		b.notify("calendar", fmt.Sprintf("Calendar error: %s", errs[0]), "error")
+	}
+
+	b.updateTime()
+}
+
+// formatEventTime renders an event start relative to today ("14:00", "Tomorrow 09:30", "Fri 12 10:00").
+func formatEventTime(ev CalendarEvent, now time.Time) string {
+	start := ev.Start.In(now.Location())
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	days := int(start.Sub(today).Hours() / 24)
+	if start.Before(today) {
+		days = 0 // Already running (multi-day or started earlier)
+	}
+
+	clock := start.Format("15:04")
+	if ev.AllDay {
+		clock = "all day"
+	}
+
+	switch days {
+	case 0:
+		if ev.AllDay {
+			return "Today"
+		}
+		return clock
+	case 1:
+		return "Tomorrow " + clock
+	default:
+		return start.Format("Mon 02") + " " + clock
+	}
+}
+
+// eventCountdown is "now" for a running event and "in 1h 5m" for one starting
+// within calendarCountdown; empty otherwise.
+func eventCountdown(ev CalendarEvent, now time.Time) string {
+	until := ev.Start.Sub(now)
+	switch {
+	case until <= 0 && ev.End.After(now):
+		return "now"
+	case until <= 0 || until > calendarCountdown || ev.AllDay:
+		return ""
+	case until < time.Minute:
+		return "in <1m"
+	}
+	return "in " + formatDuration(until)
+}
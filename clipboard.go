@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/rivo/tview"
+)
+
+// --- Clipboard History Widget ---
+//
+// Entries are kept in memory only: the clipboard regularly holds passwords.
+
+const (
+	clipboardRefreshInterval = 1 * time.Second
+	clipboardDefaultSize     = 10
+	clipboardPreviewWidth    = 40
+)
+
+type ClipEntry struct {
+	Text   string
+	Copied time.Time
+}
+
+// clipboardHistorySize returns how many entries to keep, 0 when CLIPBOARD_HISTORY is off.
+// CLIPBOARD_HISTORY=true keeps 10; a number keeps that many.
+func clipboardHistorySize() int {
+	v := strings.ToLower(os.Getenv("CLIPBOARD_HISTORY"))
+	switch v {
+	case "true", "yes":
+		return clipboardDefaultSize
+	}
+	if n, err := strconv.Atoi(v); err == nil && n > 0 {
+		return n
+	}
+	return 0
+}
+
+func (b *Baseline) fetchClipboard() {
+	text, err := clipboard.ReadAll()
+
+	b.mu.Lock()
+	prevErr := b.clipError
+	b.clipError = ""
+	changed := false
+	if err != nil {
+		b.clipError = err.Error()
+		changed = b.clipError != prevErr
+	} else if strings.TrimSpace(text) != "" && (len(b.clips) == 0 || b.clips[0].Text != text) {
+		// Move a repeated entry to the front instead of listing it twice
+		for i, c := range b.clips {
+			if c.Text == text {
+				b.clips = append(b.clips[:i], b.clips[i+1:]...)
+				break
+			}
+		}
+		b.clips = append([]ClipEntry{{Text: text, Copied: time.Now()}}, b.clips...)
+		if len(b.clips) > b.clipMax {
+			b.clips = b.clips[:b.clipMax]
+		}
+		b.clipSel = 0
+		changed = true
+	} else if prevErr != "" {
+		changed = true
+	}
+	b.mu.Unlock()
+
+	if err != nil && changed {
+		b.notify("clipboard", fmt.Sprintf("Clipboard unavailable: %v", err), "error")
+	}
+	if changed {
+		b.updateClipboard()
+	}
+}
+
+// clipPreview flattens an entry onto one line and shortens it for the panel.
+func clipPreview(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if r := []rune(text); len(r) > clipboardPreviewWidth {
+		text = string(r[:clipboardPreviewWidth-1]) + "…"
+	}
+	return text
+}
+
+func (b *Baseline) updateClipboard() {
+	b.mu.RLock()
+	clips := append([]ClipEntry(nil), b.clips...)
+	selected := b.clipSel
+	clipErr := b.clipError
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sCLIPBOARD[-:-:-]\n", brightC+"[::b]"))
+
+	if clipErr != "" {
+		sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(clipErr)))
+	} else if len(clips) == 0 {
+		sb.WriteString(fmt.Sprintf("%s(Nothing copied yet)[-:-:-]\n", dimC))
+	}
+	for i, c := range clips {
+		marker, textC := "  ", mainC
+		if i == selected {
+			marker, textC = brightC+"> ", brightC
+		}
+		sb.WriteString(fmt.Sprintf("%s%s%2d %s%s %s%s[-:-:-]\n",
+			marker, dimC, i+1,
+			textC, tview.Escape(clipPreview(c.Text)),
+			dimC, c.Copied.Format("15:04"),
+		))
+	}
+	if len(clips) > 0 {
+		sb.WriteString(fmt.Sprintf("\n%sc select, C copy[-:-:-]", dimC))
+	}
+
+	b.setPanel(b.clipPanel, sb.String())
+}
+
+// selectNextClip moves the selection down the history, wrapping around.
+// Called from inputHandler with b.mu held.
+func (b *Baseline) selectNextClip() {
+	if len(b.clips) == 0 {
+		return
+	}
+	b.clipSel = (b.clipSel + 1) % len(b.clips)
+	go b.updateClipboard()
+}
+
+// copyClip puts the history entry at the 1-based index back on the clipboard
+// (0 = the selected entry).
+func (b *Baseline) copyClip(index int) {
+	b.mu.RLock()
+	if index == 0 {
+		index = b.clipSel + 1
+	}
+	var text string
+	ok := index >= 1 && index <= len(b.clips)
+	if ok {
+		text = b.clips[index-1].Text
+	}
+	b.mu.RUnlock()
+
+	if !ok {
+		b.notify("clipboard", fmt.Sprintf("Invalid clipboard entry: %d", index), "error")
+		return
+	}
+	if err := clipboard.WriteAll(text); err != nil {
+		b.notify("clipboard", fmt.Sprintf("Copy failed: %v", err), "error")
+		return
+	}
+	b.notify("clipboard", fmt.Sprintf("Copied: %s", clipPreview(text)), "success")
+	b.fetchClipboard() // Moves it to the front right away
+}
@@ -0,0 +1,564 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Command Registry ---
+//
+// Every command-mode command is a Command registered with registerCommand:
+// its names, usage, handler and argument completion. processCommand looks
+// the typed name up here, `help <command>` prints the usage, tab completion
+// and the help overlay read the same list. The built-in commands are
+// registered by registerCoreCommands; other parts of the dashboard add their
+// own (plugins register one per [[plugin]] table, see plugins.go).
+
+// Command is one command-mode command.
+type Command struct {
+	Name    string
+	Aliases []string
+	Args    string // Argument synopsis, "" when it takes none
+	Summary string
+	Words   []string // Fixed first arguments, offered by tab completion
+
+	// Run handles the command. Called with b.mu held, so anything slow goes
+	// to a goroutine.
+	Run func(a CommandArgs)
+	// Complete returns the candidates for the word after args (the complete
+	// words typed after the command name). Without it, or when it returns
+	// nil, the first argument is completed from Words. Called with b.mu
+	// read-locked.
+	Complete func(args []string) []string
+}
+
+// CommandArgs is a parsed command line.
+type CommandArgs struct {
+	Name string   // The name or alias typed, lowercased
+	Args []string // The words after it, lowercased
+	Raw  []string // The same words in their original case, for paths and text
+	Text string   // Everything after the name, original case and spacing
+}
+
+// usage formats the command's synopsis, e.g. "Usage: vol [up|down]".
+func (c *Command) usage() string {
+	usage := "Usage: " + c.Name
+	if c.Args != "" {
+		usage += " " + c.Args
+	}
+	if len(c.Aliases) > 0 {
+		usage += " (also " + strings.Join(c.Aliases, ", ") + ")"
+	}
+	return usage + " - " + c.Summary
+}
+
+// registerCommand adds a command. Names and aliases must not be taken yet.
+func (b *Baseline) registerCommand(c *Command) error {
+	for _, name := range append([]string{c.Name}, c.Aliases...) {
+		if _, taken := b.findCommand(name); taken {
+			return fmt.Errorf("command %q is already registered", name)
+		}
+	}
+	b.commands = append(b.commands, c)
+	return nil
+}
+
+// findCommand looks a command up by name or alias.
+func (b *Baseline) findCommand(name string) (*Command, bool) {
+	for _, c := range b.commands {
+		if c.Name == name || slices.Contains(c.Aliases, name) {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// commandNames returns the command names, sorted.
+func (b *Baseline) commandNames() []string {
+	names := make([]string, 0, len(b.commands))
+	for _, c := range b.commands {
+		names = append(names, c.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// registerCoreCommands registers the built-in commands.
+func (b *Baseline) registerCoreCommands() {
+	core := []*Command{
+		{Name: "help", Aliases: []string{"?", "shortcut"}, Args: "[command]", Summary: "Key and command reference, or one command's usage",
+			Run: func(a CommandArgs) {
+				if len(a.Args) == 0 {
+					go b.app.QueueUpdateDraw(b.openHelp) // After the command input hands focus back
+				} else if c, ok := b.findCommand(a.Args[0]); ok {
+					b.addNotification(c.usage(), "info")
+				} else {
+					b.addNotification(fmt.Sprintf("Unknown command: %s", a.Args[0]), "error")
+				}
+			},
+			Complete: func(args []string) []string {
+				if len(args) == 0 {
+					return b.commandNames()
+				}
+				return nil
+			}},
+		{Name: "exit", Aliases: []string{"quit", "q"}, Summary: "Quit Baseline",
+			Run: func(CommandArgs) { b.quit() }}, // Gracefully stop the application
+		{Name: "clear", Summary: "Clear the notification history",
+			Run: func(CommandArgs) {
+				b.notifMu.Lock()
+				b.notifications = []Notification{}
+				b.notifMu.Unlock()
+				b.addNotification("Notifications cleared", "success")
+			}},
+		{Name: "notifications", Aliases: []string{"history", "alerts"}, Summary: "Notification center with the active alerts",
+			Run: func(CommandArgs) { go b.app.QueueUpdateDraw(b.openNotificationCenter) }},
+		{Name: "scrollback", Args: "[text]", Summary: "Notification scrollback, optionally searched",
+			Run: func(a CommandArgs) {
+				go b.app.QueueUpdateDraw(func() { b.openNotificationScrollback(a.Text) })
+			}},
+		{Name: "ack", Args: "[n]", Summary: "Acknowledge active alert n",
+			Run: func(a CommandArgs) { go b.alertCommand("ack", a.Args) }},
+		{Name: "snooze", Args: "[n] [minutes]", Summary: "Snooze active alert n (default 30 minutes)",
+			Run: func(a CommandArgs) { go b.alertCommand("snooze", a.Args) }},
+		{Name: "dnd", Args: "[off|<duration>]", Summary: "Do not disturb, e.g. dnd 45m", Words: []string{"off", "30m", "1h", "2h"},
+			Run: func(a CommandArgs) {
+				if len(a.Args) == 0 {
+					go b.toggleDND()
+				} else if a.Args[0] == "off" {
+					go b.setDND(0)
+				} else if d, err := time.ParseDuration(a.Args[0]); err == nil && d > 0 {
+					go b.setDND(d)
+				} else {
+					b.addNotification("Usage: dnd [off|<duration>, e.g. 30m, 2h]", "error")
+				}
+			}},
+		{Name: "theme", Args: "list|<name>", Summary: "List or change the color theme",
+			Run: func(a CommandArgs) {
+				if b.themeCommand(a.Args) {
+					go b.applyTheme() // Apply theme async
+				}
+			},
+			Complete: func(args []string) []string {
+				if len(args) == 0 {
+					return append([]string{"list"}, themeNames()...)
+				}
+				return nil
+			}},
+		{Name: "layout", Args: "[auto|stacked|grid|wide] | move <panel> top-left|bottom-left|top-right|bottom-right | size <panel> <1-9> | split <20-80> | hide|show <panel> | reset", Summary: "Show or change the panel arrangement",
+			Run:      func(a CommandArgs) { b.layoutCommand(a.Args) },
+			Complete: completeLayout},
+		{Name: "todo", Args: "add <text> [--due date] [--repeat rule] | sub <n> <text> | toggle|done|delete <n> | due <n> <date>|clear | repeat <n> <rule>|off | prio <n> high|medium|low | tag|untag <n> <tag>... | filter [#tag|off] | sync [status]", Summary: "Manage the task list",
+			Words:    []string{"add", "sub", "toggle", "done", "delete", "due", "repeat", "prio", "tag", "untag", "filter", "sync"},
+			Run:      func(a CommandArgs) { b.todoCommand(a.Args) },
+			Complete: b.completeTodo},
+		{Name: "weather", Args: "set|add|remove <location> | list | next | split | rotate [interval] | units [f|c]", Summary: "Weather locations, display and units",
+			Words: []string{"set", "add", "remove", "list", "next", "split", "rotate", "units"},
+			Run: func(a CommandArgs) {
+				fetch, redraw := b.weatherCommand(a.Raw)
+				if redraw {
+					go b.updateWeather()
+				}
+				if fetch {
+					go b.fetchWeather() // Fetch new weather in background async
+				}
+			},
+			Complete: func(args []string) []string {
+				if len(args) == 0 {
+					return nil // The subcommands, from Words
+				}
+				switch strings.ToLower(args[0]) {
+				case "set", "remove", "rm":
+					return slices.Clone(b.weatherLocs)
+				case "units":
+					if len(args) == 1 {
+						return []string{"c", "f", "metric", "imperial"}
+					}
+				}
+				return nil
+			}},
+		{Name: "jira", Args: "[refresh|open [n]]", Summary: "Refresh the Issues panel or open an issue", Words: []string{"refresh", "open"},
+			Run: func(a CommandArgs) {
+				args := a.Args
+				if b.jira == nil {
+					b.addNotification("Jira is not configured (set JIRA_URL and JIRA_TOKEN)", "error")
+				} else if len(args) == 0 || args[0] == "refresh" {
+					b.addNotification("Refreshing Jira issues...", "info")
+					go b.fetchJira()
+				} else if args[0] == "open" {
+					index := 0
+					if len(args) > 1 {
+						var err error
+						if index, err = strconv.Atoi(args[1]); err != nil || index < 1 {
+							b.addNotification(fmt.Sprintf("Invalid issue index: %s", args[1]), "error")
+							return
+						}
+					}
+					go b.openJira(index)
+				} else {
+					b.addNotification("Usage: jira [refresh|open [index]]", "error")
+				}
+			}},
+		{Name: "news", Args: "[refresh|open [n]]", Summary: "Refresh the News panel or open a headline", Words: []string{"refresh", "open"},
+			Run: func(a CommandArgs) {
+				args := a.Args
+				if b.news == nil {
+					b.addNotification("News is not configured (set NEWS_FEEDS)", "error")
+				} else if len(args) == 0 || args[0] == "refresh" {
+					b.addNotification("Refreshing news...", "info")
+					go b.fetchNews()
+				} else if args[0] == "open" {
+					index := 0
+					if len(args) > 1 {
+						index, _ = strconv.Atoi(args[1])
+					}
+					go b.openNews(index)
+				} else {
+					b.addNotification("Usage: news [refresh|open [index]]", "error")
+				}
+			}},
+		{Name: "markets", Args: "[refresh]", Summary: "Refresh the Markets panel", Words: []string{"refresh"},
+			Run: func(CommandArgs) {
+				if b.markets == nil {
+					b.addNotification("Markets are not configured (set MARKETS_CRYPTO or MARKETS_STOCKS)", "error")
+				} else {
+					b.addNotification("Refreshing prices...", "info")
+					go b.fetchMarkets()
+				}
+			}},
+		{Name: "ha", Args: "[refresh|toggle <n>]", Summary: "Home Assistant states and toggles", Words: []string{"refresh", "toggle"},
+			Run: func(a CommandArgs) {
+				args := a.Args
+				if b.ha == nil {
+					b.addNotification("Home Assistant is not configured (set HA_URL, HA_TOKEN, HA_ENTITIES)", "error")
+				} else if len(args) == 0 || args[0] == "refresh" {
+					go b.fetchHA()
+				} else if args[0] == "toggle" && len(args) == 2 {
+					index, _ := strconv.Atoi(args[1])
+					go b.toggleHA(index)
+				} else {
+					b.addNotification("Usage: ha [refresh|toggle <index>]", "error")
+				}
+			}},
+		{Name: "bt", Aliases: []string{"bluetooth"}, Args: "[refresh|connect <n>|disconnect <n>]", Summary: "Paired Bluetooth devices", Words: []string{"refresh", "connect", "disconnect"},
+			Run: func(a CommandArgs) {
+				args := a.Args
+				if !b.btEnabled {
+					b.addNotification("Bluetooth widget is not enabled (set BLUETOOTH=true)", "error")
+				} else if len(args) == 0 || args[0] == "refresh" {
+					go b.fetchBluetooth()
+				} else if (args[0] == "connect" || args[0] == "disconnect") && len(args) == 2 {
+					index, _ := strconv.Atoi(args[1])
+					go b.connectBluetooth(index, args[0] == "connect")
+				} else {
+					b.addNotification("Usage: bt [refresh|connect <index>|disconnect <index>]", "error")
+				}
+			}},
+		{Name: "ctr", Aliases: []string{"containers"}, Args: "[refresh|stop <n>|restart <n>|logs <n>]", Summary: "Containers", Words: []string{"refresh", "stop", "restart", "logs"},
+			Run: func(a CommandArgs) {
+				args := a.Args
+				index := 0
+				if len(args) == 2 {
+					index, _ = strconv.Atoi(args[1])
+				}
+				if b.containers == nil {
+					b.addNotification("Containers widget is not enabled (set CONTAINERS=docker, podman or auto)", "error")
+				} else if len(args) == 0 || args[0] == "refresh" {
+					go b.fetchContainers()
+				} else if (args[0] == "stop" || args[0] == "restart") && len(args) == 2 {
+					go b.containerAction(index, args[0])
+				} else if args[0] == "logs" && len(args) == 2 {
+					go b.showContainerLogs(index)
+				} else {
+					b.addNotification("Usage: ctr [refresh|stop <index>|restart <index>|logs <index>]", "error")
+				}
+			}},
+		{Name: "service", Aliases: []string{"services"}, Args: "[refresh|restart|start|stop <name|n>]", Summary: "Services from SERVICES", Words: []string{"refresh", "restart", "start", "stop"},
+			Run: func(a CommandArgs) { b.serviceCommand(a.Raw) },
+			Complete: func(args []string) []string {
+				if len(args) != 1 || strings.ToLower(args[0]) == "refresh" {
+					return nil
+				}
+				var names []string
+				for _, s := range b.services {
+					names = append(names, s.Name)
+				}
+				return names
+			}},
+		{Name: "host", Aliases: []string{"hosts"}, Args: "add <name> <user@host[:port]> | remove <name|n> | refresh", Summary: "Remote hosts watched over SSH", Words: []string{"add", "remove", "refresh"},
+			Run: func(a CommandArgs) { b.hostCommand(a.Raw) },
+			Complete: func(args []string) []string {
+				if len(args) != 1 || (strings.ToLower(args[0]) != "remove" && strings.ToLower(args[0]) != "rm") {
+					return nil
+				}
+				var names []string
+				for _, h := range b.hosts {
+					names = append(names, h.Name)
+				}
+				return names
+			}},
+		{Name: "notes", Args: "[edit]", Summary: "Edit the notes, in place or in $EDITOR", Words: []string{"edit"},
+			Run: func(a CommandArgs) {
+				if b.notesFile == "" {
+					b.addNotification("Notes are not enabled (set NOTES=true or NOTES_FILE)", "error")
+				} else if len(a.Args) > 0 && a.Args[0] == "edit" {
+					go b.editNotesExternal()
+				} else {
+					go b.app.QueueUpdateDraw(b.openNotesEditor)
+				}
+			}},
+		{Name: "clip", Aliases: []string{"clipboard"}, Args: "[n|clear]", Summary: "Copy a clipboard history entry back, or clear the history",
+			Run: func(a CommandArgs) {
+				if b.clipMax == 0 {
+					b.addNotification("Clipboard history is not enabled (set CLIPBOARD_HISTORY=true)", "error")
+				} else if len(a.Args) > 0 && a.Args[0] == "clear" {
+					b.clips = nil
+					b.clipSel = 0
+					go b.updateClipboard()
+					b.addNotification("Clipboard history cleared", "success")
+				} else {
+					index := 0
+					if len(a.Args) > 0 {
+						index, _ = strconv.Atoi(a.Args[0])
+					}
+					go b.copyClip(index)
+				}
+			},
+			Complete: func(args []string) []string {
+				if len(args) == 0 {
+					return append([]string{"clear"}, indices(len(b.clips))...)
+				}
+				return nil
+			}},
+		{Name: "vol", Aliases: []string{"volume"}, Args: "[up|down|mute|<0-100>]", Summary: "Output volume", Words: []string{"up", "down", "mute"},
+			Run: func(a CommandArgs) { b.volumeCommand(a.Args) }},
+		{Name: "bright", Aliases: []string{"brightness"}, Args: "[up|down|<1-100>]", Summary: "Screen brightness", Words: []string{"up", "down"},
+			Run: func(a CommandArgs) { b.brightnessCommand(a.Args) }},
+		{Name: "copy", Args: "<panel>", Summary: "Copy a panel's text by (the start of) its title",
+			Run: func(a CommandArgs) { b.copyCommand(a.Args) },
+			Complete: func(args []string) []string {
+				if len(args) > 0 {
+					return nil
+				}
+				var names []string
+				for _, tv := range b.dashboardPanels() {
+					names = append(names, strings.ToLower(strings.Fields(panelName(tv))[0]))
+				}
+				return names
+			}},
+		{Name: "yank", Args: "todo [n] | system | notification", Summary: "Copy a todo, a system summary or the last notification", Words: yankTargets,
+			Run: func(a CommandArgs) { b.yankCommand(a.Args) }},
+		{Name: "screenshot", Args: "<path>", Summary: "Save the dashboard as ANSI text, plain text for .txt or HTML for .html",
+			Run: func(a CommandArgs) {
+				if a.Text == "" {
+					b.addNotification("Usage: screenshot <path> (.html for HTML, .txt for plain text, anything else for ANSI text)", "error")
+				} else {
+					go b.saveScreenshot(a.Text, "")
+				}
+			}},
+		{Name: "export", Args: "history <path> [--format csv|json] [--range 1h] | screen <path> [--format text|ansi|html]", Summary: "Write the stored samples or the screen to a file", Words: []string{"history", "screen"},
+			Run: func(a CommandArgs) { b.exportCommand(a.Raw) },
+			Complete: func(args []string) []string {
+				if len(args) == 2 && strings.ToLower(args[0]) == "history" {
+					return []string{"--format", "--range"}
+				}
+				if len(args) == 2 && strings.ToLower(args[0]) == "screen" {
+					return []string{"--format"}
+				}
+				if len(args) == 3 && strings.ToLower(args[0]) == "screen" && args[2] == "--format" {
+					return []string{"text", "ansi", "html"}
+				}
+				return nil
+			}},
+		{Name: "net", Aliases: []string{"ifaces"}, Summary: "Per-interface network view",
+			Run: func(CommandArgs) { go b.app.QueueUpdateDraw(b.openNetDetail) }},
+		{Name: "ps", Args: "[sort <column>|filter [text]|kill <pid>|term <pid>]", Summary: "Process table", Words: []string{"sort", "filter", "kill", "term"},
+			Run: func(a CommandArgs) { b.psCommand(a.Args) },
+			Complete: func(args []string) []string {
+				if len(args) == 1 && strings.ToLower(args[0]) == "sort" {
+					return []string{"pid", "name", "cpu", "mem", "user", "state"}
+				}
+				return nil
+			}},
+		{Name: "cpu", Args: "[cores|total]", Summary: "Per-core CPU bars", Words: []string{"cores", "total"},
+			Run: func(a CommandArgs) { b.cpuCommand(a.Args) }},
+		{Name: "mem", Aliases: []string{"memory"}, Args: "[detail|total]", Summary: "Memory breakdown", Words: []string{"detail", "total"},
+			Run: func(a CommandArgs) { b.memCommand(a.Args) }},
+		{Name: "uptime", Aliases: []string{"availability"}, Summary: "Host availability history",
+			Run: func(CommandArgs) { go b.app.QueueUpdateDraw(b.openAvailability) }},
+		{Name: "report", Summary: "Health report from the stored history",
+			Run: func(CommandArgs) {
+				if b.metrics == nil {
+					b.addNotification("No metrics database open, nothing to report", "error")
+				} else {
+					go b.app.QueueUpdateDraw(b.openReport)
+				}
+			}},
+		{Name: "timer", Args: "<duration> [label] | list | cancel <n>|all", Summary: "Countdown timer, e.g. timer 10m tea", Words: []string{"list", "cancel", "5m", "10m", "25m", "1h"},
+			Run: func(a CommandArgs) { b.timerCommand(false, a.Raw) }},
+		{Name: "alarm", Args: "<hh:mm> [label] | list | cancel <n>|all", Summary: "Alarm at the next hh:mm, e.g. alarm 07:30 standup", Words: []string{"list", "cancel"},
+			Run: func(a CommandArgs) { b.timerCommand(true, a.Raw) }},
+		{Name: "focus", Args: "[stats|start [label]|stop]", Summary: "Focus sessions", Words: []string{"stats", "start", "stop"},
+			Run: func(a CommandArgs) { b.focusCommand(a.Raw) }},
+		{Name: "pomo", Aliases: []string{"pomodoro"}, Args: "[status|start [n]|stop|skip]", Summary: "Pomodoro timer", Words: []string{"status", "start", "stop", "skip"},
+			Run: func(a CommandArgs) { b.pomodoroCommand(a.Args) }},
+		{Name: "journal", Args: "[add <text>|summary|today|yesterday|YYYY-MM-DD]", Summary: "Daily journal", Words: []string{"add", "summary", "today", "yesterday"},
+			Run: func(a CommandArgs) { b.journalCommand(a.Raw) }},
+		{Name: "log", Args: "open <path> | include [regex] | exclude [regex] | close", Summary: "Tail a file in a panel", Words: []string{"open", "include", "exclude", "close"},
+			Run: func(a CommandArgs) { b.logCommand(a.Raw) }},
+		{Name: "logs", Args: "[debug|info|warn|error]", Summary: "Recent entries of Baseline's own log", Words: []string{"debug", "info", "warn", "error"},
+			Run: func(a CommandArgs) { b.logsCommand(a.Args) }},
+		{Name: "web", Summary: "Address of the web mirror for other devices",
+			Run: func(CommandArgs) { b.webCommand() }},
+		{Name: "files", Aliases: []string{"ls"}, Args: "[path]", Summary: "File browser",
+			Run: func(a CommandArgs) {
+				go b.app.QueueUpdateDraw(func() { b.openFileBrowser(a.Text) })
+			}},
+		{Name: "du", Args: "[path]", Summary: "Disk usage view",
+			Run: func(a CommandArgs) {
+				go b.app.QueueUpdateDraw(func() { b.openDiskUsage(a.Text) })
+			}},
+		{Name: "reminders", Aliases: []string{"remind"}, Args: "[pause|resume|reset]", Summary: "Break reminders", Words: []string{"pause", "resume", "reset"},
+			Run: func(a CommandArgs) { b.remindersCommand(a.Args) }},
+		{Name: "habit", Aliases: []string{"habits"}, Args: "[n]", Summary: "Check off a habit for today",
+			Run: func(a CommandArgs) {
+				if len(b.habits) == 0 {
+					b.addNotification("No habits configured (set HABITS)", "error")
+				} else {
+					b.habitCommand(a.Args)
+				}
+			},
+			Complete: func(args []string) []string {
+				if len(args) == 0 {
+					return indices(len(b.habits))
+				}
+				return nil
+			}},
+	}
+	for _, c := range core {
+		if err := b.registerCommand(c); err != nil {
+			panic(err) // A mistake in the table above
+		}
+	}
+}
+
+// todoCommand handles "todo <subcommand> ...". Called with b.mu held.
+func (b *Baseline) todoCommand(args []string) {
+	if len(args) == 0 {
+		b.addNotification("Todo commands: add, sub, toggle, delete, due, repeat, prio, tag, untag, filter, sync", "info")
+		return
+	}
+	needsTodoUpdate := false
+	subCmd, todoArgs := args[0], args[1:]
+	switch subCmd {
+	case "add":
+		item, err := parseTodoAdd(todoArgs, time.Now())
+		if err != nil {
+			b.addNotification(err.Error(), "error")
+		} else if item.Text != "" {
+			b.todoItems = append(b.todoItems, item)
+			b.saveTodos()
+			b.addNotification(fmt.Sprintf("Added todo: %s", item.Text), "success")
+			needsTodoUpdate = true
+		} else {
+			b.addNotification("Usage: todo add <task text> [--due YYYY-MM-DD [HH:MM]] [--repeat rule]", "error")
+		}
+	case "due":
+		needsTodoUpdate = b.setTodoDue(todoArgs)
+	case "repeat":
+		needsTodoUpdate = b.todoRepeatCommand(todoArgs)
+	case "prio", "priority":
+		needsTodoUpdate = b.todoPrioCommand(todoArgs)
+	case "filter":
+		needsTodoUpdate = b.todoFilterCommand(todoArgs)
+	case "tag", "untag":
+		needsTodoUpdate = b.todoTagCommand(todoArgs, subCmd == "tag")
+	case "sync":
+		b.todoSyncCommand(todoArgs)
+	case "sub":
+		needsTodoUpdate = b.todoSubCommand(todoArgs)
+	case "toggle", "done":
+		if len(todoArgs) != 1 {
+			b.addNotification("Usage: todo toggle <index>", "error")
+			break
+		}
+		index, err := strconv.Atoi(todoArgs[0])
+		if err != nil || index < 1 || index > len(b.todoItems) {
+			b.addNotification(fmt.Sprintf("Invalid todo index: %s", todoArgs[0]), "error")
+			break
+		}
+		msg := fmt.Sprintf("Toggled todo #%d", index)
+		if next := b.setTodoDone(index-1, !b.todoItems[index-1].Done); next != nil { // With its subtasks
+			msg += ", next " + dueLabel(*next.Due, time.Now())
+		}
+		b.saveTodos()
+		b.addNotification(msg, "success")
+		needsTodoUpdate = true
+	case "delete", "rm":
+		if len(todoArgs) != 1 {
+			b.addNotification("Usage: todo delete <index>", "error")
+			break
+		}
+		index, err := strconv.Atoi(todoArgs[0])
+		if err != nil || index < 1 || index > len(b.todoItems) {
+			b.addNotification(fmt.Sprintf("Invalid todo index: %s", todoArgs[0]), "error")
+			break
+		}
+		deleted := b.todoItems[index-1]
+		msg := fmt.Sprintf("Deleted todo: %s", deleted.Text)
+		if n := b.removeTodo(index - 1); n > 0 {
+			msg += fmt.Sprintf(" and %d subtask(s)", n)
+		}
+		b.saveTodos()
+		b.addNotification(msg, "success")
+		needsTodoUpdate = true
+	default:
+		b.addNotification(fmt.Sprintf("Unknown todo command: %s", subCmd), "error")
+	}
+	if needsTodoUpdate {
+		go b.updateTodos() // Update UI async
+	}
+}
+
+// completeTodo offers todo subcommands, task numbers, priorities and tags.
+func (b *Baseline) completeTodo(args []string) []string {
+	if len(args) == 0 {
+		return nil // The subcommands, from Words
+	}
+	switch sub := strings.ToLower(args[0]); sub {
+	case "sub", "toggle", "done", "delete", "rm", "due", "repeat", "prio", "priority", "tag", "untag":
+		if len(args) == 1 {
+			return indices(len(b.todoItems))
+		}
+		if len(args) > 2 {
+			return nil
+		}
+		switch sub {
+		case "due":
+			return []string{"today", "tomorrow", "clear"}
+		case "repeat":
+			return []string{"daily", "weekdays", "weekly", "monthly", "cron", "off"}
+		case "prio", "priority":
+			return []string{"high", "medium", "low"}
+		case "tag", "untag":
+			return b.allTodoTags()
+		}
+	case "filter":
+		if len(args) == 1 {
+			tags := []string{"off"}
+			for _, tag := range b.allTodoTags() {
+				tags = append(tags, "#"+tag)
+			}
+			return tags
+		}
+	case "sync":
+		if len(args) == 1 {
+			return []string{"status"}
+		}
+	}
+	return nil
+}
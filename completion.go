@@ -0,0 +1,150 @@
+package main
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- Command Completion ---
+//
+// Tab in the command input completes the word under the cursor: command
+// names, their subcommands and arguments (theme names, todo indices and
+// tags, weather locations, panels, services), from the command registry in
+// commands.go and the dashboard's state. A single match is filled in;
+// with several, the common prefix is filled in and a second Tab lists them.
+// Arrow keys pick from the list, Tab or Enter accepts, Esc closes it.
+
+// setupCompletion wires Tab completion into the command input.
+func (b *Baseline) setupCompletion() {
+	b.cmdInput.SetAutocompleteFunc(func(text string) []string {
+		if !b.cmdListOpen {
+			return nil // Only list candidates once Tab asked for them
+		}
+		matches := b.completeCommand(text)
+		if len(matches) == 0 {
+			b.cmdListOpen = false
+		}
+		return matches
+	})
+	b.cmdInput.SetAutocompletedFunc(func(text string, index, source int) bool {
+		if source == tview.AutocompletedNavigate {
+			return false
+		}
+		b.cmdInput.SetText(text + " ")
+		b.cmdListOpen = false
+		return true
+	})
+	b.cmdInput.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyTab:
+			if b.cmdListOpen {
+				return event // The list takes Tab as accept
+			}
+			b.completeInput()
+			return nil
+		case tcell.KeyEscape, tcell.KeyEnter:
+			b.cmdListOpen = false
+		}
+		return event
+	})
+}
+
+// completeInput handles Tab with the candidate list closed.
+func (b *Baseline) completeInput() {
+	text := b.cmdInput.GetText()
+	matches := b.completeCommand(text)
+	switch {
+	case len(matches) == 0:
+		return
+	case len(matches) == 1:
+		b.cmdInput.SetText(matches[0] + " ")
+		return
+	}
+	if prefix := commonPrefix(matches); len(prefix) > len(text) {
+		b.cmdInput.SetText(prefix)
+		return
+	}
+	b.cmdListOpen = true
+	b.cmdInput.Autocomplete()
+}
+
+// completeCommand returns the full command lines that complete text's last
+// word, in suggestion order.
+func (b *Baseline) completeCommand(text string) []string {
+	text = strings.TrimLeft(text, " ")
+	words := strings.Fields(text)
+	if len(words) > 0 && !strings.HasSuffix(text, " ") {
+		words = words[:len(words)-1] // The word being completed
+	}
+	if len(words) > 2 && words[0] == "weather" && slices.Contains([]string{"set", "remove", "rm"}, words[1]) {
+		words = words[:2] // Location names may contain spaces
+	}
+	prefix := strings.Join(words, " ")
+	if prefix != "" {
+		prefix += " "
+	}
+
+	b.mu.RLock()
+	candidates := b.nextWords(words)
+	b.mu.RUnlock()
+
+	var matches []string
+	lower := strings.ToLower(text)
+	for _, word := range candidates {
+		line := prefix + word
+		if strings.HasPrefix(strings.ToLower(line), lower) && line != strings.TrimRight(text, " ") {
+			matches = append(matches, line)
+		}
+	}
+	return matches
+}
+
+// nextWords returns the candidates for the word following words. Called
+// with b.mu read-locked.
+func (b *Baseline) nextWords(words []string) []string {
+	if len(words) == 0 {
+		return b.commandNames()
+	}
+	c, ok := b.findCommand(strings.ToLower(words[0]))
+	if !ok {
+		return nil
+	}
+	args := words[1:]
+	if c.Complete != nil {
+		if candidates := c.Complete(args); candidates != nil {
+			return candidates
+		}
+	}
+	if len(args) == 0 {
+		return c.Words
+	}
+	return nil
+}
+
+// indices returns "1".."n".
+func indices(n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = strconv.Itoa(i + 1)
+	}
+	return out
+}
+
+// commonPrefix returns the longest prefix shared by all of lines.
+func commonPrefix(lines []string) string {
+	prefix := lines[0]
+	for _, line := range lines[1:] {
+		for !strings.HasPrefix(line, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	for !utf8.ValidString(prefix) {
+		prefix = prefix[:len(prefix)-1] // Don't split a multi-byte character
+	}
+	return prefix
+}
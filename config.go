@@ -0,0 +1,265 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// --- Config File ---
+//
+// ~/.baseline/config.toml holds settings that don't fit an environment
+// variable well. Everything is optional; missing or invalid values fall back
+// to the defaults with a notification saying why.
+//
+//	log_level = "info" # debug, info, warn or error, see logging.go
+//
+//	[refresh]
+//	system = "2s"     # CPU, memory, disk and network
+//	processes = "5s"  # Top processes list
+//	weather = "15m"
+//	clock = "1s"
+//	calendar = "5m"   # Outlook and ICS calendars
+//
+//	[history]          # Metrics database retention, see metrics.go
+//	raw = "24h"
+//	retention = "30d"
+//
+//	[weather]          # Weather provider, see weatherproviders.go
+//	provider = "open-meteo"
+//
+//	[web]              # Read-only page for other devices, see webmirror.go
+//	listen = "0.0.0.0:7421"
+//
+//	[desktop]          # Desktop notifications, see delivery.go
+//	enabled = true
+//	sources = ["alerts", "todo", "reminders", "weather"]
+//
+//	[[route]]          # Where notifications go, see notifications.go
+//	match = "lan, vpn:success"
+//	to = "log"
+//
+//	[[alert]]          # Any number of these, see alertrules.go
+//	when = "cpu > 90"
+//	for = "30s"
+//
+//	[[plugin]]         # Custom panels, see plugins.go
+//	name = "Backups"
+//	command = "~/bin/backup-status"
+
+// Intervals are the refresh rates of the core panels.
+type Intervals struct {
+	System    time.Duration
+	Processes time.Duration
+	Weather   time.Duration
+	Clock     time.Duration
+	Calendar  time.Duration
+}
+
+var defaultIntervals = Intervals{
+	System:    refreshInterval,
+	Processes: refreshInterval,
+	Weather:   15 * time.Minute,
+	Clock:     1 * time.Second,
+	Calendar:  5 * time.Minute,
+}
+
+// intervalLimits bound each setting: too fast burns CPU (or API quota for
+// the weather), too slow makes the panel look frozen.
+var intervalLimits = map[string][2]time.Duration{
+	"system":    {500 * time.Millisecond, 10 * time.Minute},
+	"processes": {1 * time.Second, 10 * time.Minute},
+	"weather":   {1 * time.Minute, 24 * time.Hour},
+	"clock":     {100 * time.Millisecond, 1 * time.Minute},
+	"calendar":  {1 * time.Minute, 24 * time.Hour},
+}
+
+// Config is everything read from config.toml.
+type Config struct {
+	Intervals Intervals
+	Alerts    []AlertRule
+	Plugins   []PluginConfig
+	Desktop   DesktopConfig
+	History   HistoryRetention
+	Weather   WeatherConfig
+	Web       WebConfig
+	Routes    map[string]string // Notification routes by rule, see notifications.go
+	LogLevel  slog.Level
+}
+
+type configFile struct {
+	LogLevel string            `toml:"log_level"`
+	Refresh  map[string]string `toml:"refresh"`
+	Alerts   []alertRuleFile   `toml:"alert"`
+	Plugins  []pluginFile      `toml:"plugin"`
+	Desktop  DesktopConfig     `toml:"desktop"`
+	History  map[string]string `toml:"history"`
+	Weather  WeatherConfig     `toml:"weather"`
+	Web      WebConfig         `toml:"web"`
+	Routes   []routeFile       `toml:"route"`
+}
+
+// loadConfig reads config.toml from dir. A missing file is not an error; the
+// returned warnings describe anything that was ignored.
+func loadConfig(dir string) (Config, []string) {
+	config, warnings, err := readConfig(dir)
+	if err != nil {
+		return config, []string{fmt.Sprintf("Error parsing config.toml: %v", err)}
+	}
+	return config, warnings
+}
+
+// readConfig is loadConfig, but a file that can't be parsed is an error
+// instead of the defaults.
+func readConfig(dir string) (Config, []string, error) {
+	config := Config{Intervals: defaultIntervals, History: defaultRetention}
+	intervals := &config.Intervals
+	var cfg configFile
+	meta, err := toml.DecodeFile(filepath.Join(dir, "config.toml"), &cfg)
+	if os.IsNotExist(err) {
+		return config, nil, nil
+	}
+	if err != nil {
+		return config, nil, err
+	}
+
+	var warnings []string
+	for _, key := range meta.Undecoded() {
+		if meta.Type(key...) == "Hash" {
+			continue // Its keys are reported instead
+		}
+		warnings = append(warnings, fmt.Sprintf("config.toml: unknown setting %q", key.String()))
+	}
+	targets := map[string]*time.Duration{
+		"system":    &intervals.System,
+		"processes": &intervals.Processes,
+		"weather":   &intervals.Weather,
+		"clock":     &intervals.Clock,
+		"calendar":  &intervals.Calendar,
+	}
+	keys := make([]string, 0, len(cfg.Refresh))
+	for key := range cfg.Refresh {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := cfg.Refresh[key]
+		target, ok := targets[key]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("config.toml: unknown setting \"refresh.%s\"", key))
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("config.toml: refresh.%s: %q is not a duration (e.g. \"5s\")", key, value))
+			continue
+		}
+		limits := intervalLimits[key]
+		if d < limits[0] || d > limits[1] {
+			warnings = append(warnings, fmt.Sprintf("config.toml: refresh.%s must be between %s and %s, using %s", key, limits[0], limits[1], *target))
+			continue
+		}
+		*target = d
+	}
+	config.Desktop = cfg.Desktop
+	config.Weather = cfg.Weather
+	config.Web = cfg.Web
+	if cfg.LogLevel != "" {
+		if err := config.LogLevel.UnmarshalText([]byte(cfg.LogLevel)); err != nil {
+			warnings = append(warnings, fmt.Sprintf("config.toml: log_level: %q is not debug, info, warn or error", cfg.LogLevel))
+		}
+	}
+	warnings = append(warnings, parseRetention(cfg.History, &config.History)...)
+	routes, routeWarnings := parseRoutes(cfg.Routes)
+	config.Routes = routes
+	warnings = append(warnings, routeWarnings...)
+	names := map[string]bool{}
+	for i, f := range cfg.Alerts {
+		rule, err := parseAlertRule(f)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("config.toml: alert #%d: %v", i+1, err))
+			continue
+		}
+		if names[rule.Name] {
+			warnings = append(warnings, fmt.Sprintf("config.toml: alert #%d: duplicate name %q", i+1, rule.Name))
+			continue
+		}
+		names[rule.Name] = true
+		config.Alerts = append(config.Alerts, rule)
+	}
+	for i, f := range cfg.Plugins {
+		plugin, err := parsePlugin(f)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("config.toml: plugin #%d: %v", i+1, err))
+			continue
+		}
+		config.Plugins = append(config.Plugins, plugin)
+	}
+	return config, warnings, nil
+}
+
+// byName maps the [refresh] setting names to their values.
+func (i Intervals) byName() map[string]time.Duration {
+	return map[string]time.Duration{
+		"system":    i.System,
+		"processes": i.Processes,
+		"weather":   i.Weather,
+		"clock":     i.Clock,
+		"calendar":  i.Calendar,
+	}
+}
+
+// parseLongDuration is time.ParseDuration plus whole days ("30d").
+func parseLongDuration(v string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(v, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", v)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(v)
+}
+
+// parseRetention applies the [history] section to r.
+func parseRetention(section map[string]string, r *HistoryRetention) []string {
+	var warnings []string
+	for _, key := range []string{"raw", "retention"} {
+		value, ok := section[key]
+		if !ok {
+			continue
+		}
+		d, err := parseLongDuration(value)
+		if err != nil || d < time.Hour {
+			warnings = append(warnings, fmt.Sprintf("config.toml: history.%s: %q must be a duration of at least 1h (e.g. \"24h\", \"30d\")", key, value))
+			continue
+		}
+		if key == "raw" {
+			r.Raw = d
+		} else {
+			r.Keep = d
+		}
+	}
+	var unknown []string
+	for key := range section {
+		if key != "raw" && key != "retention" {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		warnings = append(warnings, fmt.Sprintf("config.toml: unknown setting \"history.%s\"", key))
+	}
+	if r.Keep < r.Raw {
+		warnings = append(warnings, "config.toml: history.retention is shorter than history.raw, keeping per-minute samples as long as raw ones")
+		r.Keep = r.Raw
+	}
+	return warnings
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/joho/godotenv"
+)
+
+// --- Config Reload ---
+//
+// Saving ~/.baseline/config.toml or .env applies the change without a
+// restart: THEME (custom themes included), the [refresh] rates, the
+// weather provider, units and WEATHER_LOCATION and the notification routes.
+// A notification lists what was applied, along with anything that still
+// needs a restart (alert rules, plugins, desktop notifications, history
+// retention, the web mirror and the other .env settings). A config.toml that
+// doesn't parse is reported and ignored, so a half-finished edit never
+// resets the settings to their defaults.
+
+const configReloadDelay = 500 * time.Millisecond // Editors write in several steps
+
+// envLive are the .env settings a reload applies.
+var envLive = []string{"THEME", "TERMINAL_BACKGROUND", "WEATHER_LOCATION", "WEATHER_PROVIDER", "WEATHER_API_KEY", "WEATHER_UNITS"}
+
+// trackRefresh registers the ticker behind a [refresh] setting so a reload
+// can change its interval.
+func (b *Baseline) trackRefresh(setting string, ticker *time.Ticker) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.refreshTickers == nil {
+		b.refreshTickers = map[string][]*time.Ticker{}
+	}
+	b.refreshTickers[setting] = append(b.refreshTickers[setting], ticker)
+}
+
+// watchConfig reloads the settings whenever config.toml or .env is saved.
+// The directories are watched rather than the files, since many editors
+// save by replacing the file.
+func (b *Baseline) watchConfig() {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		slog.Warn("Config reload disabled", "err", err)
+		return
+	}
+	envPath, _ := filepath.Abs(".env")
+	files := []string{filepath.Join(b.configDir, "config.toml"), envPath}
+	for _, path := range files {
+		if err := watcher.Add(filepath.Dir(path)); err != nil {
+			slog.Warn("Cannot watch for changes", "dir", filepath.Dir(path), "err", err)
+		}
+	}
+
+	b.life.Go(func() {
+		defer watcher.Close()
+		var reload <-chan time.Time
+		for {
+			select {
+			case <-b.life.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if slices.Contains(files, filepath.Clean(event.Name)) && (event.Has(fsnotify.Write) || event.Has(fsnotify.Create)) {
+					reload = time.After(configReloadDelay)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				slog.Warn("Config watcher failed", "err", err)
+			case <-reload:
+				reload = nil
+				b.reloadConfig()
+			}
+		}
+	})
+}
+
+// reloadEnv copies changed .env values into the environment and returns
+// the names that changed.
+func (b *Baseline) reloadEnv() []string {
+	values, err := godotenv.Read()
+	if err != nil {
+		if !os.IsNotExist(err) {
+			b.notify("config", fmt.Sprintf("Error reading .env: %v", err), "error")
+		}
+		return nil
+	}
+	var changed []string
+	for name, value := range values {
+		if old, ok := b.envFile[name]; !ok || old != value {
+			os.Setenv(name, value)
+			changed = append(changed, name)
+		}
+	}
+	for name, old := range b.envFile {
+		if _, ok := values[name]; !ok && os.Getenv(name) == old {
+			os.Unsetenv(name)
+			changed = append(changed, name)
+		}
+	}
+	b.envFile = values
+	sort.Strings(changed)
+	return changed
+}
+
+// reloadConfig applies config.toml and .env as they are now.
+func (b *Baseline) reloadConfig() {
+	cfg, warnings, err := readConfig(b.configDir)
+	if err != nil {
+		b.notify("config", fmt.Sprintf("config.toml not reloaded: %v", err), "error")
+		return
+	}
+	warnings = append(warnings, loadCustomThemes(b.configDir)...)
+
+	b.mu.Lock()
+	envChanged := b.reloadEnv()
+	var applied, restart []string
+	themeChanged, weatherChanged := false, false
+
+	if slices.Contains(envChanged, "THEME") { // Not on every reload, that would undo `theme`
+		themeName := os.Getenv("THEME")
+		if themeName == "" {
+			themeName = "amber"
+		}
+		if t, ok := themes[themeName]; !ok {
+			warnings = append(warnings, fmt.Sprintf("Theme '%s' not found", themeName))
+		} else {
+			b.theme = t
+			themeChanged = true
+			applied = append(applied, "theme "+themeName)
+		}
+	}
+
+	if slices.Contains(envChanged, "TERMINAL_BACKGROUND") {
+		themeChanged = true
+		applied = append(applied, "terminal background")
+	}
+
+	if cfg.Intervals != b.intervals {
+		now := cfg.Intervals.byName()
+		for setting, was := range b.intervals.byName() {
+			if now[setting] == was {
+				continue
+			}
+			for _, ticker := range b.refreshTickers[setting] {
+				ticker.Reset(now[setting])
+			}
+		}
+		b.intervals = cfg.Intervals
+		applied = append(applied, "refresh rates")
+	}
+
+	providerEnv := slices.ContainsFunc(envChanged, func(name string) bool {
+		return name == "WEATHER_PROVIDER" || name == "WEATHER_API_KEY"
+	})
+	if providerEnv || cfg.Weather.Provider != b.config.Weather.Provider || cfg.Weather.APIKey != b.config.Weather.APIKey {
+		provider, needsKey, err := weatherProviderFor(cfg.Weather)
+		if err != nil {
+			warnings = append(warnings, err.Error())
+		}
+		b.weatherProvider, b.weatherNeedsKey = provider, needsKey
+		weatherChanged = true
+		applied = append(applied, "weather provider "+provider.Name())
+	}
+	if units := weatherUnitsFor(cfg.Weather); units != weatherUnitsFor(b.config.Weather) || slices.Contains(envChanged, "WEATHER_UNITS") {
+		b.weatherUnits = units // Until the next `weather units`, which is saved in weather.json
+		b.saveWeatherLocations()
+		weatherChanged = true
+		applied = append(applied, "weather units")
+	}
+	if location := os.Getenv("WEATHER_LOCATION"); location != "" && slices.Contains(envChanged, "WEATHER_LOCATION") {
+		b.weatherLocs[b.weatherIndex] = location // As `weather set` would
+		b.weatherLocation = location
+		b.saveWeatherLocations()
+		weatherChanged = true
+		applied = append(applied, "weather location "+location)
+	}
+
+	if cfg.LogLevel != b.config.LogLevel {
+		logLevel.Set(cfg.LogLevel)
+		applied = append(applied, "log level "+strings.ToLower(cfg.LogLevel.String()))
+	}
+
+	if !reflect.DeepEqual(cfg.Routes, b.config.Routes) {
+		b.notifMu.Lock()
+		b.notifFilter.routes = cfg.Routes
+		b.notifMu.Unlock()
+		applied = append(applied, "notification routes")
+	}
+
+	for _, section := range []struct {
+		name     string
+		was, now any
+	}{
+		{"alert rules", b.config.Alerts, cfg.Alerts},
+		{"plugins", b.config.Plugins, cfg.Plugins},
+		{"[desktop]", b.config.Desktop, cfg.Desktop},
+		{"[history]", b.config.History, cfg.History},
+		{"[web]", b.config.Web, cfg.Web},
+	} {
+		if !reflect.DeepEqual(section.was, section.now) {
+			restart = append(restart, section.name)
+		}
+	}
+	for _, name := range envChanged {
+		if !slices.Contains(envLive, name) {
+			restart = append(restart, name)
+		}
+	}
+	// b.config is what's running: the sections that need a restart keep
+	// their startup values, so later reloads still list them
+	b.config.Intervals, b.config.Weather, b.config.Routes, b.config.LogLevel = cfg.Intervals, cfg.Weather, cfg.Routes, cfg.LogLevel
+	b.mu.Unlock()
+
+	for _, w := range warnings {
+		b.notify("config", w, "error")
+	}
+	switch {
+	case len(applied) > 0:
+		b.notify("config", "Config reloaded: "+strings.Join(applied, ", "), "success")
+	case len(restart) == 0:
+		b.notify("config", "Config reloaded, nothing changed", "info")
+	}
+	if len(restart) > 0 {
+		b.notify("config", "Restart Baseline to apply: "+strings.Join(restart, ", "), "info")
+	}
+
+	if themeChanged {
+		b.applyTheme()
+	}
+	if weatherChanged {
+		b.fetchWeather()
+	}
+}
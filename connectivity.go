@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	stdnet "net"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Connectivity Widget ---
+//
+// CONNECTIVITY=true adds a panel with the public IP and its GeoIP location
+// (from ipinfo.io), the active VPN interface if any, the round trip to the
+// default gateway from a ping and how long resolving CONNECTIVITY_DNS_HOST
+// takes. Whatever fails is shown in red, and losing the gateway or DNS raises
+// a notification, as does coming back.
+
+const (
+	connectivityRefreshInterval = 30 * time.Second
+	publicIPRefreshInterval     = 10 * time.Minute // Also refreshed when the route changes
+	connectivityPingTimeout     = 2 * time.Second
+	connectivityDNSTimeout      = 3 * time.Second
+	defaultDNSCheckHost         = "example.com"
+	publicIPURL                 = "https://ipinfo.io/json"
+)
+
+var pingTime = regexp.MustCompile(`time[=<]\s*([\d.]+)\s*ms`)
+
+type ConnectivityConfig struct {
+	dnsHost string
+	client  http.Client
+}
+
+type ConnectivityInfo struct {
+	PublicIP    string
+	City        string
+	Country     string // ISO code
+	Org         string // Provider, e.g. "AS3320 Deutsche Telekom AG"
+	IPError     string
+	IPChecked   time.Time
+	VPN         string // Active VPN interface, "" without one
+	VPNAddress  string
+	Gateway     string
+	GatewayRTT  time.Duration
+	GatewayErr  string
+	NoPing      bool // ping isn't installed
+	DNSTime     time.Duration
+	DNSErr      string
+	LastUpdated time.Time
+}
+
+// online reports whether the gateway answers and names resolve.
+func (c ConnectivityInfo) online() bool {
+	return c.GatewayErr == "" && c.DNSErr == ""
+}
+
+// newConnectivityFromEnv returns nil unless CONNECTIVITY is switched on.
+func newConnectivityFromEnv() *ConnectivityConfig {
+	v := strings.ToLower(os.Getenv("CONNECTIVITY"))
+	if v != "true" && v != "1" && v != "yes" {
+		return nil
+	}
+	host := os.Getenv("CONNECTIVITY_DNS_HOST")
+	if host == "" {
+		host = defaultDNSCheckHost
+	}
+	return &ConnectivityConfig{dnsHost: host, client: http.Client{Timeout: 10 * time.Second}}
+}
+
+// publicIP asks ipinfo.io for the address the internet sees and where it is.
+func (c *ConnectivityConfig) publicIP(info *ConnectivityInfo) error {
+	resp, err := c.client.Get(publicIPURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ipinfo.io: %s", resp.Status)
+	}
+	var data struct {
+		IP      string `json:"ip"`
+		City    string `json:"city"`
+		Country string `json:"country"`
+		Org     string `json:"org"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return fmt.Errorf("JSON parse error: %w", err)
+	}
+	info.PublicIP, info.City, info.Country, info.Org = data.IP, data.City, data.Country, data.Org
+	return nil
+}
+
+// defaultGateway finds the IPv4 default gateway: /proc/net/route on Linux,
+// the routing table elsewhere.
+func defaultGateway() (string, error) {
+	if runtime.GOOS == "linux" {
+		data, err := os.ReadFile("/proc/net/route")
+		if err != nil {
+			return "", err
+		}
+		for _, line := range strings.Split(string(data), "\n")[1:] {
+			fields := strings.Fields(line)
+			if len(fields) < 4 || fields[1] != "00000000" {
+				continue
+			}
+			flags, _ := strconv.ParseUint(fields[3], 16, 16)
+			raw, err := hex.DecodeString(fields[2])
+			if flags&0x2 == 0 || err != nil || len(raw) != 4 { // RTF_GATEWAY
+				continue
+			}
+			ip := make(stdnet.IP, 4)
+			binary.BigEndian.PutUint32(ip, binary.LittleEndian.Uint32(raw))
+			return ip.String(), nil
+		}
+		return "", fmt.Errorf("no default route")
+	}
+
+	args := []string{"-n", "get", "default"}
+	if runtime.GOOS == "windows" {
+		args = []string{"print", "0.0.0.0"}
+	}
+	out, err := exec.Command("route", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("route: %w", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 2 && fields[0] == "gateway:": // macOS, BSD
+			return fields[1], nil
+		case len(fields) >= 3 && fields[0] == "0.0.0.0" && fields[1] == "0.0.0.0": // Windows
+			return fields[2], nil
+		}
+	}
+	return "", fmt.Errorf("no default route")
+}
+
+// pingHost sends one ping and returns the round trip.
+func pingHost(ctx context.Context, host string) (time.Duration, error) {
+	var args []string
+	switch runtime.GOOS {
+	case "windows":
+		args = []string{"-n", "1", "-w", "1000", host}
+	case "darwin", "freebsd", "openbsd", "netbsd":
+		args = []string{"-c", "1", "-t", "1", host}
+	default:
+		args = []string{"-c", "1", "-W", "1", host}
+	}
+	out, err := exec.CommandContext(ctx, "ping", args...).Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		return 0, err
+	}
+	m := pingTime.FindSubmatch(out)
+	if err != nil || m == nil {
+		return 0, fmt.Errorf("no reply")
+	}
+	ms, _ := strconv.ParseFloat(string(m[1]), 64)
+	return time.Duration(ms * float64(time.Millisecond)), nil
+}
+
+func (b *Baseline) fetchConnectivity() {
+	b.mu.RLock()
+	prev := b.connInfo
+	b.mu.RUnlock()
+
+	info := ConnectivityInfo{LastUpdated: time.Now()}
+	if vpn, _ := interfaceVPNStatus(vpnInterfacePrefixes()); vpn.Up {
+		info.VPN = vpn.Backend
+		if len(vpn.Addresses) > 0 {
+			info.VPNAddress = vpn.Addresses[0]
+		}
+	}
+
+	gw, err := defaultGateway()
+	if err != nil {
+		info.GatewayErr = err.Error()
+	} else {
+		info.Gateway = gw
+		ctx, cancel := context.WithTimeout(b.life.ctx, connectivityPingTimeout)
+		info.GatewayRTT, err = pingHost(ctx, gw)
+		cancel()
+		if errors.Is(err, exec.ErrNotFound) {
+			info.NoPing = true // Reachability unknown rather than offline
+		} else if err != nil {
+			info.GatewayErr = err.Error()
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(b.life.ctx, connectivityDNSTimeout)
+	start := time.Now()
+	_, err = stdnet.DefaultResolver.LookupHost(ctx, b.conn.dnsHost)
+	info.DNSTime = time.Since(start)
+	cancel()
+	if err != nil {
+		info.DNSErr = err.Error()
+	}
+
+	// The public IP only moves with the route, so it's looked up less often
+	info.PublicIP, info.City, info.Country, info.Org, info.IPChecked = prev.PublicIP, prev.City, prev.Country, prev.Org, prev.IPChecked
+	stale := time.Since(prev.IPChecked) >= publicIPRefreshInterval || prev.IPError != ""
+	if stale || info.VPN != prev.VPN || info.Gateway != prev.Gateway || (info.online() && !prev.online()) {
+		if err := b.conn.publicIP(&info); err != nil {
+			info.IPError = err.Error()
+		}
+		info.IPChecked = time.Now()
+	}
+
+	b.mu.Lock()
+	b.connInfo = info
+	b.mu.Unlock()
+
+	// Notify on transitions only, not on every poll
+	if !prev.LastUpdated.IsZero() {
+		switch {
+		case prev.online() && !info.online():
+			problem := "DNS is not resolving"
+			if info.GatewayErr != "" {
+				problem = "the default gateway is unreachable"
+			}
+			b.notify("connectivity", "Offline: "+problem, "error")
+		case !prev.online() && info.online():
+			b.notify("connectivity", "Back online", "success")
+		}
+		if prev.PublicIP != "" && info.PublicIP != "" && info.PublicIP != prev.PublicIP {
+			b.notify("connectivity", fmt.Sprintf("Public IP changed to %s", info.PublicIP), "info")
+		}
+	}
+	b.updateConnectivity()
+}
+
+func (b *Baseline) updateConnectivity() {
+	b.mu.RLock()
+	info := b.connInfo
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sCONNECTIVITY[-:-:-]\n", brightC+"[::b]"))
+
+	if info.LastUpdated.IsZero() {
+		sb.WriteString(fmt.Sprintf("%sChecking...[-:-:-]\n", dimC))
+		b.setPanel(b.connPanel, sb.String())
+		return
+	}
+
+	if info.online() {
+		sb.WriteString(fmt.Sprintf("%sState: %sONLINE[-:-:-]\n", mainC, brightC))
+	} else {
+		sb.WriteString(fmt.Sprintf("%sState: [red::b]OFFLINE[-:-:-]\n", mainC))
+	}
+
+	switch {
+	case info.PublicIP != "":
+		sb.WriteString(fmt.Sprintf("%sPublic IP: %s%s[-:-:-]", mainC, brightC, info.PublicIP))
+		if place := strings.Trim(info.City+", "+info.Country, ", "); place != "" {
+			sb.WriteString(fmt.Sprintf(" %s(%s)[-:-:-]", dimC, tview.Escape(place)))
+		}
+		if info.IPError != "" {
+			sb.WriteString(" [red]●[-:-:-]") // Last lookup failed, this is an older one
+		}
+		sb.WriteString("\n")
+		if info.Org != "" {
+			sb.WriteString(fmt.Sprintf("%s  %s[-:-:-]\n", dimC, tview.Escape(info.Org)))
+		}
+	case info.IPError != "":
+		sb.WriteString(fmt.Sprintf("%sPublic IP: [red]%s[-:-:-]\n", mainC, tview.Escape(info.IPError)))
+	}
+
+	if info.VPN != "" {
+		sb.WriteString(fmt.Sprintf("%sVPN: %s%s[-:-:-] %s%s[-:-:-]\n", mainC, brightC, info.VPN, dimC, info.VPNAddress))
+	} else {
+		sb.WriteString(fmt.Sprintf("%sVPN: %snone[-:-:-]\n", mainC, dimC))
+	}
+
+	switch {
+	case info.Gateway == "":
+		sb.WriteString(fmt.Sprintf("%sGateway: [red]%s[-:-:-]\n", mainC, tview.Escape(info.GatewayErr)))
+	case info.NoPing:
+		sb.WriteString(fmt.Sprintf("%sGateway: %s %s(no ping command)[-:-:-]\n", mainC, info.Gateway, dimC))
+	case info.GatewayErr != "":
+		sb.WriteString(fmt.Sprintf("%sGateway: %s [red]%s[-:-:-]\n", mainC, info.Gateway, tview.Escape(info.GatewayErr)))
+	default:
+		sb.WriteString(fmt.Sprintf("%sGateway: %s %s%.1f ms[-:-:-]\n", mainC, info.Gateway, brightC, float64(info.GatewayRTT)/float64(time.Millisecond)))
+	}
+
+	if info.DNSErr != "" {
+		sb.WriteString(fmt.Sprintf("%sDNS: [red]%s[-:-:-]\n", mainC, tview.Escape(info.DNSErr)))
+	} else {
+		sb.WriteString(fmt.Sprintf("%sDNS: %s%d ms[-:-:-] %s(%s)[-:-:-]\n", mainC, brightC, info.DNSTime.Milliseconds(), dimC, tview.Escape(b.conn.dnsHost)))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%sLast updated: %s[-:-:-]", dimC, info.LastUpdated.Format("15:04:05")))
+
+	b.setPanel(b.connPanel, sb.String())
+}
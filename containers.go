@@ -0,0 +1,463 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- Docker / Podman Containers Widget ---
+//
+// CONTAINERS=docker, podman or auto talks to the engine API on the local
+// socket (DOCKER_HOST / CONTAINER_HOST are honoured, or CONTAINERS can be the
+// socket path itself). Running containers show CPU, memory and network rates;
+// stopped ones are listed dimmed below them so they can be restarted.
+
+const (
+	containerRefreshInterval = 5 * time.Second
+	containerLogLines        = 40
+	containerStopTimeout     = 10 // Seconds the engine waits before killing
+)
+
+type Container struct {
+	ID      string
+	Name    string
+	Image   string
+	State   string // running, exited, paused, ...
+	Status  string // "Up 3 hours", "Exited (0) 2 minutes ago"
+	CPU     float64
+	HasCPU  bool // False until two samples exist
+	MemUsed uint64
+	MemMax  uint64
+	RxRate  float64 // Bytes per second
+	TxRate  float64
+}
+
+type ContainerInfo struct {
+	Containers  []Container
+	Error       string
+	LastUpdated time.Time
+}
+
+// containerSample is the previous stats reading, for CPU and network rates.
+type containerSample struct {
+	at        time.Time
+	cpuTotal  uint64
+	systemCPU uint64
+	rx, tx    uint64
+}
+
+type ContainerClient struct {
+	runtime string // "Docker" or "Podman"
+	socket  string
+	client  http.Client
+
+	mu   sync.Mutex
+	prev map[string]containerSample
+}
+
+// newContainerClientFromEnv returns nil unless CONTAINERS is set.
+func newContainerClientFromEnv() *ContainerClient {
+	mode := strings.TrimSpace(os.Getenv("CONTAINERS"))
+	if mode == "" || strings.EqualFold(mode, "false") {
+		return nil
+	}
+
+	home, _ := os.UserHomeDir()
+	dockerSockets := []string{unixSocket(os.Getenv("DOCKER_HOST")), "/var/run/docker.sock", filepath.Join(home, ".docker/run/docker.sock")}
+	podmanSockets := []string{unixSocket(os.Getenv("CONTAINER_HOST"))}
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		podmanSockets = append(podmanSockets, filepath.Join(dir, "podman/podman.sock"))
+	}
+	podmanSockets = append(podmanSockets, "/run/podman/podman.sock")
+
+	engine, socket := "Docker", ""
+	switch strings.ToLower(mode) {
+	case "docker":
+		socket = firstSocket(dockerSockets)
+	case "podman":
+		engine, socket = "Podman", firstSocket(podmanSockets)
+	case "auto", "true":
+		if socket = firstSocket(dockerSockets); socket == "" {
+			if socket = firstSocket(podmanSockets); socket != "" {
+				engine = "Podman"
+			}
+		}
+	default:
+		socket = strings.TrimPrefix(mode, "unix://")
+		if strings.Contains(socket, "podman") {
+			engine = "Podman"
+		}
+	}
+	if socket == "" {
+		socket = "/var/run/docker.sock" // Reported as an error in the panel
+	}
+
+	return &ContainerClient{
+		runtime: engine,
+		socket:  socket,
+		prev:    map[string]containerSample{},
+		client: http.Client{
+			Timeout: time.Duration(containerStopTimeout+20) * time.Second, // Stop and restart wait for the container
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", socket)
+				},
+			},
+		},
+	}
+}
+
+// unixSocket returns the path of a unix:// address, "" for anything else.
+func unixSocket(addr string) string {
+	path, ok := strings.CutPrefix(addr, "unix://")
+	if !ok {
+		return ""
+	}
+	return path
+}
+
+func firstSocket(paths []string) string {
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		if info, err := os.Stat(p); err == nil && info.Mode()&os.ModeSocket != 0 {
+			return p
+		}
+	}
+	return ""
+}
+
+// do calls the engine API. The host part of the URL is ignored, every
+// request goes to the socket.
+func (c *ContainerClient) do(method, path string, out interface{}) error {
+	req, err := http.NewRequest(method, "http://engine"+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s socket %s: %w", c.runtime, c.socket, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotModified {
+		var errResp struct {
+			Message string `json:"message"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&errResp) == nil && errResp.Message != "" {
+			return fmt.Errorf("API error: %s (%d)", errResp.Message, resp.StatusCode)
+		}
+		return fmt.Errorf("API error: Status %d", resp.StatusCode)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("JSON parse error: %w", err)
+		}
+	}
+	return nil
+}
+
+// list returns all containers, running ones with their resource usage.
+func (c *ContainerClient) list() ([]Container, error) {
+	var data []struct {
+		ID     string   `json:"Id"`
+		Names  []string `json:"Names"`
+		Image  string   `json:"Image"`
+		State  string   `json:"State"`
+		Status string   `json:"Status"`
+	}
+	if err := c.do(http.MethodGet, "/containers/json?all=1", &data); err != nil {
+		return nil, err
+	}
+
+	containers := make([]Container, len(data))
+	var wg sync.WaitGroup
+	for i, d := range data {
+		name := d.ID
+		if len(d.Names) > 0 {
+			name = strings.TrimPrefix(d.Names[0], "/")
+		}
+		containers[i] = Container{ID: d.ID, Name: name, Image: d.Image, State: d.State, Status: d.Status}
+		if d.State != "running" {
+			continue
+		}
+		wg.Add(1)
+		go func(ct *Container) {
+			defer wg.Done()
+			c.stats(ct) // A failed stats call leaves the usage blank
+		}(&containers[i])
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	for id := range c.prev {
+		if !slices.ContainsFunc(containers, func(ct Container) bool { return ct.ID == id }) {
+			delete(c.prev, id) // Removed containers
+		}
+	}
+	c.mu.Unlock()
+
+	sort.SliceStable(containers, func(i, j int) bool {
+		ri, rj := containers[i].State == "running", containers[j].State == "running"
+		if ri != rj {
+			return ri
+		}
+		return containers[i].Name < containers[j].Name
+	})
+	return containers, nil
+}
+
+// stats fills in CPU, memory and network rates from a one-shot stats call.
+func (c *ContainerClient) stats(ct *Container) error {
+	var s struct {
+		CPU struct {
+			Usage struct {
+				Total uint64 `json:"total_usage"`
+			} `json:"cpu_usage"`
+			System uint64 `json:"system_cpu_usage"`
+			Online int    `json:"online_cpus"`
+		} `json:"cpu_stats"`
+		Memory struct {
+			Usage uint64            `json:"usage"`
+			Limit uint64            `json:"limit"`
+			Stats map[string]uint64 `json:"stats"`
+		} `json:"memory_stats"`
+		Networks map[string]struct {
+			Rx uint64 `json:"rx_bytes"`
+			Tx uint64 `json:"tx_bytes"`
+		} `json:"networks"`
+	}
+	if err := c.do(http.MethodGet, "/containers/"+ct.ID+"/stats?stream=false&one-shot=true", &s); err != nil {
+		return err
+	}
+
+	// Page cache is reclaimable; docker stats leaves it out as well
+	ct.MemUsed, ct.MemMax = s.Memory.Usage, s.Memory.Limit
+	for _, key := range []string{"inactive_file", "total_inactive_file", "cache"} {
+		if cache, ok := s.Memory.Stats[key]; ok && cache < ct.MemUsed {
+			ct.MemUsed -= cache
+			break
+		}
+	}
+
+	cur := containerSample{at: time.Now(), cpuTotal: s.CPU.Usage.Total, systemCPU: s.CPU.System}
+	for _, n := range s.Networks {
+		cur.rx += n.Rx
+		cur.tx += n.Tx
+	}
+
+	c.mu.Lock()
+	prev, ok := c.prev[ct.ID]
+	c.prev[ct.ID] = cur
+	c.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	if sysDelta := cur.systemCPU - prev.systemCPU; cur.systemCPU > prev.systemCPU && cur.cpuTotal >= prev.cpuTotal {
+		cpus := s.CPU.Online
+		if cpus == 0 {
+			cpus = 1
+		}
+		ct.CPU = float64(cur.cpuTotal-prev.cpuTotal) / float64(sysDelta) * float64(cpus) * 100
+		ct.HasCPU = true
+	}
+	if elapsed := cur.at.Sub(prev.at).Seconds(); elapsed > 0 && cur.rx >= prev.rx && cur.tx >= prev.tx {
+		ct.RxRate = float64(cur.rx-prev.rx) / elapsed
+		ct.TxRate = float64(cur.tx-prev.tx) / elapsed
+	}
+	return nil
+}
+
+// action runs "stop" or "restart" on a container.
+func (c *ContainerClient) action(id, verb string) error {
+	return c.do(http.MethodPost, fmt.Sprintf("/containers/%s/%s?t=%d", id, verb, containerStopTimeout), nil)
+}
+
+// logs returns the last lines of a container's stdout and stderr.
+func (c *ContainerClient) logs(id string, lines int) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://engine/containers/%s/logs?stdout=1&stderr=1&tail=%d", id, lines), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s socket %s: %w", c.runtime, c.socket, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API error: Status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	return demuxLogs(data), nil
+}
+
+// demuxLogs strips the 8-byte frame headers the engine puts in front of each
+// chunk when the container has no TTY. TTY output is passed through as is.
+func demuxLogs(data []byte) string {
+	var sb strings.Builder
+	for len(data) >= 8 && data[0] <= 2 && data[1] == 0 && data[2] == 0 && data[3] == 0 {
+		size := int(binary.BigEndian.Uint32(data[4:8]))
+		if 8+size > len(data) {
+			break
+		}
+		sb.Write(data[8 : 8+size])
+		data = data[8+size:]
+	}
+	sb.Write(data)
+	return sb.String()
+}
+
+func (b *Baseline) fetchContainers() {
+	var info ContainerInfo
+	info.LastUpdated = time.Now()
+
+	containers, err := b.containers.list()
+	if err != nil {
+		info.Error = err.Error()
+	} else {
+		info.Containers = containers
+	}
+
+	b.mu.Lock()
+	prevErr := b.containerInfo.Error
+	b.containerInfo = info
+	b.mu.Unlock()
+
+	if info.Error != "" && info.Error != prevErr {
+		b.notify("containers", fmt.Sprintf("Containers: %s", info.Error), "error")
+	}
+	b.updateContainers()
+}
+
+func (b *Baseline) updateContainers() {
+	b.mu.RLock()
+	info := b.containerInfo
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s%s CONTAINERS[-:-:-]\n", brightC+"[::b]", strings.ToUpper(b.containers.runtime)))
+
+	if info.Error != "" {
+		sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(info.Error)))
+	} else if info.LastUpdated.IsZero() {
+		sb.WriteString(fmt.Sprintf("%sLoading...[-:-:-]\n", dimC))
+	} else if len(info.Containers) == 0 {
+		sb.WriteString(fmt.Sprintf("%s(No containers)[-:-:-]\n", dimC))
+	}
+
+	for i, ct := range info.Containers {
+		if ct.State != "running" {
+			sb.WriteString(fmt.Sprintf("%s%2d ○ %s  %s[-:-:-]\n", dimC, i+1, tview.Escape(ct.Name), tview.Escape(ct.Status)))
+			continue
+		}
+		cpu := "  -  "
+		if ct.HasCPU {
+			cpu = fmt.Sprintf("%4.1f%%", ct.CPU)
+		}
+		cpuC := mainC
+		if ct.CPU >= 80 {
+			cpuC = "[red]"
+		}
+		mem := formatBytes(int64(ct.MemUsed))
+		if ct.MemMax > 0 {
+			mem += fmt.Sprintf(" %s%.0f%%", dimC, float64(ct.MemUsed)/float64(ct.MemMax)*100)
+		}
+		sb.WriteString(fmt.Sprintf("%s%2d %s● %s%s %s%s %s%s[-:-:-]\n",
+			dimC, i+1,
+			brightC, mainC, tview.Escape(ct.Name),
+			cpuC, cpu,
+			mainC, mem,
+		))
+		sb.WriteString(fmt.Sprintf("%s     %s  ↓%s ↑%s[-:-:-]\n",
+			dimC, tview.Escape(ct.Image), formatRate(ct.RxRate), formatRate(ct.TxRate)))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%sLast updated: %s[-:-:-]", dimC, info.LastUpdated.Format("15:04:05")))
+
+	b.setPanel(b.ctrPanel, sb.String())
+}
+
+// containerAt returns the container at the 1-based index shown in the panel.
+func (b *Baseline) containerAt(index int) (Container, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if index < 1 || index > len(b.containerInfo.Containers) {
+		return Container{}, false
+	}
+	return b.containerInfo.Containers[index-1], true
+}
+
+// containerAction stops or restarts the container at the 1-based index.
+func (b *Baseline) containerAction(index int, verb string) {
+	ct, ok := b.containerAt(index)
+	if !ok {
+		b.notify("containers", fmt.Sprintf("Invalid container index: %d", index), "error")
+		return
+	}
+	if err := b.containers.action(ct.ID, verb); err != nil {
+		b.notify("containers", fmt.Sprintf("%s %s: %v", verb, ct.Name, err), "error")
+		return
+	}
+	past := map[string]string{"stop": "Stopped", "restart": "Restarted"}[verb]
+	b.notify("containers", fmt.Sprintf("%s %s", past, ct.Name), "success")
+	b.fetchContainers()
+}
+
+// showContainerLogs opens the last log lines of a container in an overlay.
+func (b *Baseline) showContainerLogs(index int) {
+	ct, ok := b.containerAt(index)
+	if !ok {
+		b.notify("containers", fmt.Sprintf("Invalid container index: %d", index), "error")
+		return
+	}
+	logs, err := b.containers.logs(ct.ID, containerLogLines)
+	if err != nil {
+		b.notify("containers", fmt.Sprintf("Logs of %s: %v", ct.Name, err), "error")
+		return
+	}
+	if strings.TrimSpace(logs) == "" {
+		logs = "(No output)"
+	}
+
+	b.app.QueueUpdateDraw(func() {
+		view := tview.NewTextView().SetDynamicColors(true).SetScrollable(true)
+		view.SetBorder(true).SetTitle(fmt.Sprintf(" %s logs (last %d lines) ", ct.Name, containerLogLines))
+		view.SetBorderColor(b.theme.Bright)
+		view.SetTitleColor(b.theme.Bright)
+		view.SetTextColor(b.theme.Main)
+		view.SetText(tview.Escape(strings.TrimRight(logs, "\n")))
+		view.ScrollToEnd()
+		view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+			if event.Key() == tcell.KeyEscape || event.Rune() == 'q' {
+				b.closeOverlay("containerlogs")
+				return nil
+			}
+			return event
+		})
+		b.showOverlay("containerlogs", view, 120, 30)
+	})
+}
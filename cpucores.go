@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- Per-Core CPU View ---
+//
+// Replaces the aggregate CPU bar in the system panel with one bar per logical
+// core (k or `cpu cores`), to spot a single thread pinning one core.
+
+const coreBarWidth = 8
+
+// renderCoreBars draws one bar per core, two to a line once there are more
+// than eight so a 16-core machine still fits in the panel.
+func renderCoreBars(percents []float64, theme Theme) string {
+	mainC := colorTag(theme.Main)
+	brightC := colorTag(theme.Bright)
+
+	perLine := 1
+	if len(percents) > 8 {
+		perLine = 2
+	}
+	var sb strings.Builder
+	for i, pct := range percents {
+		sb.WriteString(fmt.Sprintf("%sC%02d %s %s%3.0f%%[-:-:-]", mainC, i, createBar(pct, coreBarWidth, theme), brightC, pct))
+		if (i+1)%perLine == 0 || i == len(percents)-1 {
+			sb.WriteString("\n")
+		} else {
+			sb.WriteString("  ")
+		}
+	}
+	return sb.String()
+}
+
+// toggleCPUCores switches the system panel between the aggregate and per-core
+// view. Called with b.mu held.
+func (b *Baseline) toggleCPUCores() {
+	b.cpuCores = !b.cpuCores
+	if b.cpuCores {
+		b.addNotification(fmt.Sprintf("CPU: per-core view (%d cores)", b.cpuCoreCount), "info")
+	} else {
+		b.addNotification("CPU: total usage view", "info")
+	}
+	go b.updateSystemInfo()
+}
+
+// cpuCommand handles "cpu [cores|total]". Called with b.mu held.
+func (b *Baseline) cpuCommand(args []string) {
+	switch {
+	case len(args) == 0:
+		b.toggleCPUCores()
+	case args[0] == "cores" && !b.cpuCores, args[0] == "total" && b.cpuCores:
+		b.toggleCPUCores()
+	case args[0] == "cores" || args[0] == "total":
+		// Already showing that view
+	default:
+		b.addNotification("Usage: cpu [cores|total]", "error")
+	}
+}
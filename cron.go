@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/robfig/cron/v3"
+)
+
+// --- Cron Jobs Widget ---
+//
+// Lists crontab entries by next run. Last runs come from the cron daemon's
+// log lines ("CRON[123]: (user) CMD (command)") in the journal or syslog.
+
+const (
+	cronRefreshInterval = 1 * time.Minute
+	cronLogTail         = 512 * 1024 // Bytes of a syslog file searched for runs
+	cronMaxJobs         = 15
+)
+
+var (
+	cronEnvLine = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*\s*=`)
+	cronRunLine = regexp.MustCompile(`CRON\[\d+\]: \(([^)]+)\) CMD \((.*)\)\s*$`)
+	cronLogTime = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2})|^([A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2})`)
+)
+
+type CronJob struct {
+	Spec    string // Schedule as written ("*/5 * * * *", "@daily")
+	Command string
+	User    string // Set for system crontabs
+	Source  string // "crontab", "/etc/crontab", "/etc/cron.d/backup"
+	Next    time.Time
+	LastRun time.Time
+}
+
+// cronEnabled reports whether CRON is switched on; CRON_SYSTEM adds the system crontabs.
+func cronEnabled() bool {
+	v := strings.ToLower(os.Getenv("CRON"))
+	return v == "true" || v == "1" || v == "yes" || cronSystemEnabled()
+}
+
+func cronSystemEnabled() bool {
+	v := strings.ToLower(os.Getenv("CRON_SYSTEM"))
+	return v == "true" || v == "1" || v == "yes"
+}
+
+// parseCrontab reads crontab lines. System crontabs have a user field
+// between the schedule and the command.
+func parseCrontab(text, source string, system bool, now time.Time) []CronJob {
+	var jobs []CronJob
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || cronEnvLine.MatchString(line) {
+			continue
+		}
+		fields := strings.Fields(line)
+		specFields := 5
+		if strings.HasPrefix(fields[0], "@") {
+			specFields = 1
+			if fields[0] == "@every" && len(fields) > 1 {
+				specFields = 2
+			}
+		}
+		minFields := specFields + 1
+		if system {
+			minFields++
+		}
+		if len(fields) < minFields {
+			continue
+		}
+		job := CronJob{Spec: strings.Join(fields[:specFields], " "), Source: source}
+		rest := fields[specFields:]
+		if system {
+			job.User, rest = rest[0], rest[1:]
+		}
+		job.Command = strings.Join(rest, " ")
+		if job.Spec != "@reboot" {
+			if sched, err := cron.ParseStandard(job.Spec); err == nil {
+				job.Next = sched.Next(now)
+			}
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// readCronJobs collects jobs from the user crontab and, with CRON_SYSTEM,
+// /etc/crontab and /etc/cron.d.
+func readCronJobs(now time.Time) ([]CronJob, error) {
+	var jobs []CronJob
+	out, err := exec.Command("crontab", "-l").Output()
+	if err != nil {
+		// "no crontab for user" exits 1; only a missing binary is worth reporting
+		if _, ok := err.(*exec.ExitError); !ok {
+			return nil, fmt.Errorf("crontab: %w", err)
+		}
+	}
+	jobs = append(jobs, parseCrontab(string(out), "crontab", false, now)...)
+
+	if cronSystemEnabled() {
+		files, _ := filepath.Glob("/etc/cron.d/*")
+		for _, path := range append([]string{"/etc/crontab"}, files...) {
+			if data, err := os.ReadFile(path); err == nil {
+				jobs = append(jobs, parseCrontab(string(data), path, true, now)...)
+			}
+		}
+	}
+	return jobs, nil
+}
+
+// cronLastRuns maps command -> last start seen in the logs. Uses the journal
+// where available, otherwise the tail of the usual syslog files.
+func cronLastRuns() map[string]time.Time {
+	runs := map[string]time.Time{}
+	scan := func(text string) {
+		now := time.Now()
+		for _, line := range strings.Split(text, "\n") {
+			m := cronRunLine.FindStringSubmatch(line)
+			ts := cronLogTime.FindStringSubmatch(line)
+			if m == nil || ts == nil {
+				continue
+			}
+			var t time.Time
+			var err error
+			if ts[1] != "" {
+				t, err = time.ParseInLocation("2006-01-02T15:04:05", ts[1], time.Local)
+			} else {
+				// Classic syslog omits the year
+				t, err = time.ParseInLocation("Jan _2 15:04:05", ts[2], time.Local)
+				t = t.AddDate(now.Year(), 0, 0)
+				if t.After(now) {
+					t = t.AddDate(-1, 0, 0)
+				}
+			}
+			if err == nil && t.After(runs[m[2]]) {
+				runs[m[2]] = t
+			}
+		}
+	}
+
+	if out, err := exec.Command("journalctl", "-t", "CRON", "-t", "crond", "--since", "-2d", "-o", "short-iso", "--no-pager").Output(); err == nil && len(out) > 0 {
+		scan(string(out))
+		return runs
+	}
+	for _, path := range []string{"/var/log/syslog", "/var/log/cron", "/var/log/cron.log"} {
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		if info, err := f.Stat(); err == nil && info.Size() > cronLogTail {
+			f.Seek(info.Size()-cronLogTail, io.SeekStart)
+		}
+		var sb strings.Builder
+		sc := bufio.NewScanner(f)
+		for sc.Scan() {
+			if strings.Contains(sc.Text(), "CMD (") {
+				sb.WriteString(sc.Text() + "\n")
+			}
+		}
+		f.Close()
+		scan(sb.String())
+	}
+	return runs
+}
+
+func (b *Baseline) fetchCron() {
+	now := time.Now()
+	jobs, err := readCronJobs(now)
+	runs := cronLastRuns()
+	for i := range jobs {
+		jobs[i].LastRun = runs[jobs[i].Command]
+	}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if jobs[i].Next.IsZero() != jobs[j].Next.IsZero() {
+			return !jobs[i].Next.IsZero() // @reboot and unparsable last
+		}
+		return jobs[i].Next.Before(jobs[j].Next)
+	})
+
+	b.mu.Lock()
+	b.cronJobs = jobs
+	b.cronError = ""
+	if err != nil {
+		b.cronError = err.Error()
+	}
+	b.mu.Unlock()
+	b.updateCron()
+}
+
+func (b *Baseline) updateCron() {
+	b.mu.RLock()
+	jobs := b.cronJobs
+	cronErr := b.cronError
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sSCHEDULED JOBS[-:-:-]\n", brightC+"[::b]"))
+	if cronErr != "" {
+		sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(cronErr)))
+	} else if len(jobs) == 0 {
+		sb.WriteString(fmt.Sprintf("%sNo cron jobs[-:-:-]\n", dimC))
+	}
+
+	now := time.Now()
+	for i, job := range jobs {
+		if i == cronMaxJobs {
+			sb.WriteString(fmt.Sprintf("%s... and %d more[-:-:-]\n", dimC, len(jobs)-i))
+			break
+		}
+		next := job.Spec
+		if !job.Next.IsZero() {
+			next = fmt.Sprintf("%s (in %s)", job.Next.Format("Mon 15:04"), formatDuration(job.Next.Sub(now)))
+		}
+		sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n", brightC, next))
+		sb.WriteString(fmt.Sprintf("  %s%s[-:-:-]\n", mainC, tview.Escape(job.Command)))
+
+		var details []string
+		if job.User != "" {
+			details = append(details, job.User)
+		}
+		if job.Source != "crontab" {
+			details = append(details, job.Source)
+		}
+		if !job.LastRun.IsZero() {
+			details = append(details, "last ran "+job.LastRun.Format("01-02 15:04"))
+		}
+		if len(details) > 0 {
+			sb.WriteString(fmt.Sprintf("  %s%s[-:-:-]\n", dimC, tview.Escape(strings.Join(details, " · "))))
+		}
+	}
+
+	b.setPanel(b.cronPanel, sb.String())
+}
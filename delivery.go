@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// --- External Delivery For High Priority Notifications ---
+
+// Delivery forwards notifications outside the terminal: desktop notifications
+// (DESKTOP_NOTIFICATIONS=true) and a JSON webhook (NOTIFY_WEBHOOK_URL) for high
+// priority, phone push (ntfy.sh / Pushover, see push.go) from PUSH_PRIORITY up.
+// The [desktop] section of config.toml also sends everything from the listed
+// sources to the desktop, whatever its priority.
+type Delivery struct {
+	desktop        bool
+	desktopSources []string
+	webhook        string
+	pushers        []Pusher
+	pushMin        Priority
+	client         http.Client
+}
+
+// defaultDesktopSources are used when [desktop] doesn't list any: alerts,
+// todo due dates, reminders, pomodoro phases and weather warnings.
+var defaultDesktopSources = []string{"alerts", "system", "sensors", "todo", "reminders", "pomodoro", "weather"}
+
+// DesktopConfig is the [desktop] section of config.toml.
+type DesktopConfig struct {
+	Enabled bool     `toml:"enabled"`
+	Sources []string `toml:"sources"`
+}
+
+// newDeliveryFromEnv returns nil when no external channel is configured.
+func newDeliveryFromEnv() *Delivery {
+	v := strings.ToLower(os.Getenv("DESKTOP_NOTIFICATIONS"))
+	d := &Delivery{
+		desktop: v == "true" || v == "1" || v == "yes",
+		webhook: os.Getenv("NOTIFY_WEBHOOK_URL"),
+		pushers: pushersFromEnv(),
+		pushMin: PriorityHigh,
+		client:  http.Client{Timeout: 10 * time.Second},
+	}
+	if p, ok := parsePriority(os.Getenv("PUSH_PRIORITY")); ok {
+		d.pushMin = p
+	}
+	if !d.desktop && d.webhook == "" && len(d.pushers) == 0 {
+		return nil
+	}
+	return d
+}
+
+// withDesktop turns desktop notifications on from config.toml, creating the
+// Delivery if no environment variable did.
+func (d *Delivery) withDesktop(cfg DesktopConfig) *Delivery {
+	if !cfg.Enabled {
+		return d
+	}
+	if d == nil {
+		d = &Delivery{pushMin: PriorityHigh, client: http.Client{Timeout: 10 * time.Second}}
+	}
+	d.desktop = true
+	d.desktopSources = defaultDesktopSources
+	if len(cfg.Sources) > 0 {
+		d.desktopSources = nil
+		for _, src := range cfg.Sources {
+			d.desktopSources = append(d.desktopSources, strings.ToLower(src))
+		}
+	}
+	return d
+}
+
+// toDesktop reports whether n is shown as a desktop notification. A
+// [[route]] overrides the priority and sources.
+func (d *Delivery) toDesktop(n Notification) bool {
+	switch n.Route {
+	case "desktop":
+		return d.desktop
+	case "log", "footer":
+		return false
+	}
+	return d.desktop && (n.Priority >= PriorityHigh || slices.Contains(d.desktopSources, n.Source))
+}
+
+// toPush reports whether n goes to the pushers. Routed to the log or the
+// footer, it stays on this machine.
+func (d *Delivery) toPush(n Notification) bool {
+	return len(d.pushers) > 0 && n.Priority >= d.pushMin && n.Route != "log" && n.Route != "footer"
+}
+
+// toWebhook reports whether n is posted to the webhook, with the same
+// exception as toPush.
+func (d *Delivery) toWebhook(n Notification) bool {
+	return d.webhook != "" && n.Priority >= PriorityHigh && n.Route != "log" && n.Route != "footer"
+}
+
+// wants reports whether any channel takes notification n.
+func (d *Delivery) wants(n Notification) bool {
+	if d == nil {
+		return false
+	}
+	return d.toDesktop(n) || d.toWebhook(n) || d.toPush(n)
+}
+
+// deliverExternal sends n to every configured channel. Runs in its own goroutine.
+func (b *Baseline) deliverExternal(n Notification) {
+	d := b.delivery
+	if d == nil {
+		return
+	}
+	title := "Baseline"
+	if n.Source != "" {
+		title += ": " + n.Source
+	}
+	if d.toPush(n) {
+		for _, p := range d.pushers {
+			if err := p.Push(title, n); err != nil {
+				slog.Error("Push failed", "via", p.Name(), "err", err)
+			}
+		}
+	}
+	if d.toDesktop(n) {
+		if err := sendDesktopNotification(title, n.Message, n.Type == "error"); err != nil {
+			slog.Error("Desktop notification failed", "err", err)
+		}
+	}
+	if d.toWebhook(n) {
+		if err := d.postWebhook(n); err != nil {
+			// Logged only: notifying here could loop on a broken webhook
+			slog.Error("Webhook delivery failed", "err", err)
+		}
+	}
+}
+
+func (d *Delivery) postWebhook(n Notification) error {
+	hostName, _ := os.Hostname()
+	body, err := json.Marshal(map[string]string{
+		"time":     n.Time.Format(time.RFC3339),
+		"type":     n.Type,
+		"priority": n.Priority.String(),
+		"source":   n.Source,
+		"message":  n.Message,
+		"host":     hostName,
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := d.client.Post(d.webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("HTTP error: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("API error: Status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sendDesktopNotification shows a native notification: notify-send on Linux/BSD,
+// osascript on macOS, a tray balloon via PowerShell on Windows.
+func sendDesktopNotification(title, message string, urgent bool) error {
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", message, title)
+		return exec.Command("osascript", "-e", script).Run()
+	case "windows":
+		// The texts go in through the environment, never into the script
+		script := "Add-Type -AssemblyName System.Windows.Forms;" +
+			"$n = New-Object System.Windows.Forms.NotifyIcon;" +
+			"$n.Icon = [System.Drawing.SystemIcons]::Information;" +
+			"$n.Visible = $true;" +
+			"$n.ShowBalloonTip(10000, $env:BASELINE_TITLE, $env:BASELINE_MESSAGE, 'None');" +
+			"Start-Sleep -Seconds 10; $n.Dispose()"
+		cmd := exec.Command("powershell", "-NoProfile", "-Command", script)
+		cmd.Env = append(os.Environ(), "BASELINE_TITLE="+title, "BASELINE_MESSAGE="+message)
+		return cmd.Run()
+	default:
+		urgency := "normal"
+		if urgent {
+			urgency = "critical"
+		}
+		return exec.Command("notify-send", "-a", "Baseline", "-u", urgency, title, message).Run()
+	}
+}
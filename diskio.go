@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// --- Disk Throughput ---
+//
+// An IO line under the disk usage bar with the read and write rates of the
+// busiest disks, from the difference between successive disk.IOCounters
+// samples. Partitions are left out when their whole disk is listed, and so
+// are loop and RAM devices, so each disk is counted once.
+
+const diskIOTop = 2 // Disks shown
+
+type diskRate struct {
+	Name        string
+	Read, Write float64 // Bytes per second
+}
+
+// diskIORates returns the rates since the previous sample, busiest first,
+// and stores this sample. Called with b.mu held.
+func (b *Baseline) diskIORates() ([]diskRate, bool) {
+	counters, err := disk.IOCounters()
+	now := time.Now()
+	if err != nil || len(counters) == 0 {
+		return nil, false
+	}
+	prev, elapsed := b.lastDiskIO, now.Sub(b.lastDiskTime).Seconds()
+	b.lastDiskIO, b.lastDiskTime = counters, now
+
+	var rates []diskRate
+	for name, c := range counters {
+		if !wholeDisk(name, counters) {
+			continue
+		}
+		rate := diskRate{Name: name}
+		if p, ok := prev[name]; ok && elapsed > 0 {
+			rate.Read = counterRate(c.ReadBytes, p.ReadBytes, elapsed)
+			rate.Write = counterRate(c.WriteBytes, p.WriteBytes, elapsed)
+		}
+		rates = append(rates, rate)
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		ti, tj := rates[i].Read+rates[i].Write, rates[j].Read+rates[j].Write
+		if ti != tj {
+			return ti > tj
+		}
+		return rates[i].Name < rates[j].Name
+	})
+	return rates, true
+}
+
+// wholeDisk tells disks from partitions ("sda1", "nvme0n1p2") of a listed
+// disk and from loop and RAM devices.
+func wholeDisk(name string, counters map[string]disk.IOCountersStat) bool {
+	if strings.HasPrefix(name, "loop") || strings.HasPrefix(name, "ram") || strings.HasPrefix(name, "zram") {
+		return false
+	}
+	for other := range counters {
+		if other == name || !strings.HasPrefix(name, other) {
+			continue
+		}
+		if rest := strings.TrimPrefix(strings.TrimPrefix(name, other), "p"); rest != "" && strings.Trim(rest, "0123456789") == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// counterRate is the per-second rate between two counter readings, 0 after
+// a reset.
+func counterRate(now, prev uint64, seconds float64) float64 {
+	if now < prev {
+		return 0
+	}
+	return float64(now-prev) / seconds
+}
+
+// diskIOSection renders the IO line. Called from updateSystemInfo with
+// b.mu held.
+func (b *Baseline) diskIOSection() string {
+	rates, ok := b.diskIORates()
+	if !ok {
+		return ""
+	}
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var parts []string
+	for _, r := range rates[:min(diskIOTop, len(rates))] {
+		parts = append(parts, fmt.Sprintf("%s%s %sR %.1f W %.1f", dimC, r.Name, brightC, r.Read/1e6, r.Write/1e6))
+	}
+	return fmt.Sprintf("%sIO:  %s %sMB/s[-:-:-]\n", mainC, strings.Join(parts, "  "), dimC)
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- Disk Usage Analyzer (ncdu-like view) ---
+
+const duProgressInterval = 250 * time.Millisecond
+
+type duNode struct {
+	Name     string
+	Size     int64
+	Dir      bool
+	Children []*duNode // Largest first
+	Parent   *duNode
+	Err      bool // Could not be read (permissions, vanished)
+}
+
+// duScanner walks a tree in the background, counting files as it goes.
+type duScanner struct {
+	files  atomic.Int64
+	cancel atomic.Bool
+}
+
+// scan builds the size tree for path. Symlinks are not followed and other
+// mount points are not skipped, like `du` without -x.
+func (s *duScanner) scan(path string, parent *duNode) *duNode {
+	node := &duNode{Name: filepath.Base(path), Dir: true, Parent: parent}
+	if parent == nil {
+		node.Name = path
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		node.Err = true
+		return node
+	}
+	for _, e := range entries {
+		if s.cancel.Load() {
+			break
+		}
+		if e.IsDir() {
+			child := s.scan(filepath.Join(path, e.Name()), node)
+			node.Size += child.Size
+			node.Children = append(node.Children, child)
+			continue
+		}
+		s.files.Add(1)
+		child := &duNode{Name: e.Name(), Parent: node}
+		if info, err := e.Info(); err == nil {
+			child.Size = info.Size()
+		} else {
+			child.Err = true
+		}
+		node.Size += child.Size
+		node.Children = append(node.Children, child)
+	}
+	sort.Slice(node.Children, func(i, j int) bool {
+		return node.Children[i].Size > node.Children[j].Size
+	})
+	return node
+}
+
+// formatBytes renders a byte count with a binary unit ("1.5 GiB").
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// duPathOf returns the full path of a node.
+func duPathOf(n *duNode) string {
+	var parts []string
+	for ; n.Parent != nil; n = n.Parent {
+		parts = append([]string{n.Name}, parts...)
+	}
+	return filepath.Join(append([]string{n.Name}, parts...)...)
+}
+
+// openDiskUsage scans root asynchronously and shows a full-screen browser of
+// the largest entries. Must run on the UI goroutine.
+func (b *Baseline) openDiskUsage(root string) {
+	if root == "" {
+		root, _ = os.UserHomeDir()
+	}
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		b.addNotification(fmt.Sprintf("du: not a directory: %s", root), "error")
+		return
+	}
+
+	view := newPanel(" Disk Usage ")
+	view.SetBorderColor(b.theme.Bright)
+	view.SetTitleColor(b.theme.Bright)
+	view.SetTextColor(b.theme.Main)
+
+	scanner := &duScanner{}
+	started := time.Now()
+	var current *duNode // Directory being shown, nil while scanning
+	selected := 0
+
+	render := func() {
+		mainC := colorTag(b.theme.Main)
+		dimC := colorTag(b.theme.Dim)
+		brightC := colorTag(b.theme.Bright)
+
+		var sb strings.Builder
+		if current == nil {
+			sb.WriteString(fmt.Sprintf("%sScanning %s[-:-:-]\n", brightC+"[::b]", tview.Escape(root)))
+			sb.WriteString(fmt.Sprintf("%s%d files, %s elapsed (Esc to cancel)[-:-:-]\n", dimC, scanner.files.Load(), time.Since(started).Round(time.Second)))
+			view.SetText(sb.String())
+			return
+		}
+
+		sb.WriteString(fmt.Sprintf("%s%s %s%s[-:-:-]\n", brightC+"[::b]", tview.Escape(duPathOf(current)), dimC, formatBytes(current.Size)))
+		sb.WriteString(fmt.Sprintf("%s↑/↓ select  Enter/→ open  ←/Backspace up  Esc close[-:-:-]\n\n", dimC))
+		if current.Parent != nil {
+			sb.WriteString(fmt.Sprintf("%s  ..[-:-:-]\n", dimC))
+		}
+		if len(current.Children) == 0 {
+			sb.WriteString(fmt.Sprintf("%s(Empty)[-:-:-]\n", dimC))
+		}
+		for i, c := range current.Children {
+			pct := 0.0
+			if current.Size > 0 {
+				pct = float64(c.Size) / float64(current.Size) * 100
+			}
+			marker, nameC := "  ", mainC
+			if i == selected {
+				marker, nameC = brightC+"> ", brightC
+			}
+			name := tview.Escape(c.Name)
+			if c.Dir {
+				name += "/"
+			}
+			if c.Err {
+				name += " [red](unreadable)"
+			}
+			sb.WriteString(fmt.Sprintf("%s%s%10s %s %s%s[-:-:-]\n",
+				marker, mainC, formatBytes(c.Size),
+				createBar(pct, 12, b.theme),
+				nameC, name,
+			))
+		}
+		view.SetText(sb.String())
+		// Keep the selection visible: header lines plus the ".." row
+		offset := 3
+		if current.Parent != nil {
+			offset++
+		}
+		_, _, _, height := view.GetInnerRect()
+		if top := selected + offset - height + 1; top > 0 {
+			view.ScrollTo(top, 0)
+		} else {
+			view.ScrollToBeginning()
+		}
+	}
+	render()
+
+	go func() {
+		ticker := time.NewTicker(duProgressInterval)
+		defer ticker.Stop()
+		done := make(chan *duNode, 1)
+		go func() { done <- scanner.scan(root, nil) }()
+		for {
+			select {
+			case tree := <-done:
+				if scanner.cancel.Load() {
+					return
+				}
+				b.app.QueueUpdateDraw(func() {
+					current = tree
+					render()
+				})
+				return
+			case <-ticker.C:
+				if scanner.cancel.Load() {
+					return
+				}
+				b.app.QueueUpdateDraw(render)
+			}
+		}
+	}()
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' {
+			scanner.cancel.Store(true)
+			b.closeOverlay("du")
+			return nil
+		}
+		if current == nil {
+			return nil // Still scanning
+		}
+		switch {
+		case event.Key() == tcell.KeyUp || event.Rune() == 'k':
+			if selected > 0 {
+				selected--
+			}
+		case event.Key() == tcell.KeyDown || event.Rune() == 'j':
+			if selected < len(current.Children)-1 {
+				selected++
+			}
+		case event.Key() == tcell.KeyEnter || event.Key() == tcell.KeyRight || event.Rune() == 'l':
+			if selected < len(current.Children) && current.Children[selected].Dir {
+				current = current.Children[selected]
+				selected = 0
+			}
+		case event.Key() == tcell.KeyLeft || event.Key() == tcell.KeyBackspace || event.Key() == tcell.KeyBackspace2 || event.Rune() == 'h':
+			if current.Parent != nil {
+				// Re-select the directory we came from
+				child := current
+				current = current.Parent
+				selected = 0
+				for i, c := range current.Children {
+					if c == child {
+						selected = i
+					}
+				}
+			}
+		default:
+			return event
+		}
+		render()
+		return nil
+	})
+
+	b.pages.AddPage("du", view, true, true) // Full screen
+	b.app.SetFocus(view)
+}
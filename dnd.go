@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// --- Do Not Disturb ---
+//
+// While DND is on, non-error notifications skip the footer (and any delivery
+// channel) and are held; they stay in the notification history, marked as held.
+
+const dndFallbackDuration = 1 * time.Hour
+
+// dndDefaultDuration reads DND_DURATION (e.g. "30m"), falling back to an hour.
+func dndDefaultDuration() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("DND_DURATION")); err == nil && d > 0 {
+		return d
+	}
+	return dndFallbackDuration
+}
+
+// dndActive reports whether DND is on. Caller must hold notifMu.
+func (b *Baseline) dndActive() bool {
+	return time.Now().Before(b.dndUntil)
+}
+
+// setDND enables DND for d, or disables it when d is 0.
+func (b *Baseline) setDND(d time.Duration) {
+	if d > 0 {
+		// Announce before enabling, or the message itself would be held
+		b.addNotification(fmt.Sprintf("Do not disturb until %s", time.Now().Add(d).Format("15:04")), "success")
+	}
+
+	b.notifMu.Lock()
+	if b.dndTimer != nil {
+		b.dndTimer.Stop()
+		b.dndTimer = nil
+	}
+	wasActive := b.dndActive()
+	if d > 0 {
+		b.dndUntil = time.Now().Add(d)
+		b.dndTimer = time.AfterFunc(d, func() { b.setDND(0) })
+	} else {
+		b.dndUntil = time.Time{}
+	}
+	held := b.dndHeld
+	if d == 0 {
+		b.dndHeld = 0
+	}
+	b.notifMu.Unlock()
+
+	switch {
+	case d > 0:
+		// Already announced
+	case wasActive && held > 0:
+		b.addNotification(fmt.Sprintf("Do not disturb ended: %d held notification(s), press 'm' to review", held), "info")
+	case wasActive:
+		b.addNotification("Do not disturb ended", "info")
+	}
+	go b.app.QueueUpdateDraw(b.updateHeader)
+}
+
+// toggleDND switches DND on for the default duration, or off.
+func (b *Baseline) toggleDND() {
+	b.notifMu.Lock()
+	active := b.dndActive()
+	b.notifMu.Unlock()
+
+	if active {
+		b.setDND(0)
+	} else {
+		b.setDND(dndDefaultDuration())
+	}
+}
+
+// dndStatus returns the DND end time, or zero when DND is off.
+func (b *Baseline) dndStatus() time.Time {
+	b.notifMu.Lock()
+	defer b.notifMu.Unlock()
+	if b.dndActive() {
+		return b.dndUntil
+	}
+	return time.Time{}
+}
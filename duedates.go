@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Todo Due Dates ---
+//
+// `todo add "task" --due 2024-06-01` (or --due 2024-06-01 14:30, today,
+// tomorrow). A date without a time is due at the end of that day. Open todos
+// raise one notification when they come within TODO_DUE_WINDOW of their due
+// time (default 1h) and turn red once overdue.
+
+const (
+	dueCheckInterval = 1 * time.Minute
+	defaultDueWindow = 1 * time.Hour
+	endOfDayHour     = 23
+	endOfDayMinute   = 59
+)
+
+// dueWindowFromEnv reads TODO_DUE_WINDOW ("30m", "2h", or whole days as "1d").
+func dueWindowFromEnv() time.Duration {
+	v := strings.TrimSpace(os.Getenv("TODO_DUE_WINDOW"))
+	if v == "" {
+		return defaultDueWindow
+	}
+	if days, ok := strings.CutSuffix(v, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return time.Duration(n) * 24 * time.Hour
+		}
+	}
+	if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+		return d
+	}
+	return defaultDueWindow
+}
+
+// parseDue reads a due date from the start of words and returns how many
+// words it used.
+func parseDue(words []string, now time.Time) (time.Time, int, error) {
+	if len(words) == 0 {
+		return time.Time{}, 0, fmt.Errorf("missing due date after --due")
+	}
+	var day time.Time
+	switch strings.ToLower(words[0]) {
+	case "today":
+		day = now
+	case "tomorrow":
+		day = now.AddDate(0, 0, 1)
+	default:
+		if t, err := time.ParseInLocation("2006-01-02T15:04", strings.ToUpper(words[0]), now.Location()); err == nil {
+			return t, 1, nil
+		}
+		t, err := time.ParseInLocation("2006-01-02", words[0], now.Location())
+		if err != nil {
+			return time.Time{}, 0, fmt.Errorf("invalid due date %q (want YYYY-MM-DD [HH:MM], today or tomorrow)", words[0])
+		}
+		day = t
+	}
+	if len(words) > 1 {
+		if clock, err := time.Parse("15:04", words[1]); err == nil {
+			return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location()), 2, nil
+		}
+	}
+	return endOfDay(day), 1, nil
+}
+
+// endOfDay is when a todo due on t's date without a time falls due.
+func endOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), endOfDayHour, endOfDayMinute, 0, 0, t.Location())
+}
+
+// isDateOnly reports whether a due time stands for the whole day.
+func isDateOnly(t time.Time) bool {
+	return t.Hour() == endOfDayHour && t.Minute() == endOfDayMinute
+}
+
+// parseTodoAdd splits "todo add" arguments into the task text, an optional
+// --due and an optional --repeat (see todorepeat.go).
+func parseTodoAdd(args []string, now time.Time) (TodoItem, error) {
+	var words []string
+	item := TodoItem{Priority: "medium"}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--due":
+			t, used, err := parseDue(args[i+1:], now)
+			if err != nil {
+				return TodoItem{}, err
+			}
+			item.Due = &t
+			i += used
+		case "--repeat":
+			rule, used, err := parseRepeat(args[i+1:])
+			if err != nil {
+				return TodoItem{}, err
+			}
+			item.Repeat = rule
+			i += used
+		default:
+			words = append(words, args[i])
+		}
+	}
+	item.Text = strings.Trim(strings.Join(words, " "), `"'`)
+	if item.Repeat != "" && item.Due == nil {
+		due, _ := firstDue(item.Repeat, now) // Valid, parseRepeat checked it
+		item.Due = &due
+	}
+	return item, nil
+}
+
+// dueLabel describes a due time relative to now: "due 14:30", "due Jun 01",
+// "overdue 2h".
+func dueLabel(due, now time.Time) string {
+	if due.Before(now) {
+		return "overdue " + formatDuration(now.Sub(due))
+	}
+	dateOnly := isDateOnly(due)
+	sameDay := due.Year() == now.Year() && due.YearDay() == now.YearDay()
+	switch {
+	case sameDay && dateOnly:
+		return "due today"
+	case sameDay:
+		return "due " + due.Format("15:04")
+	case dateOnly:
+		return "due " + due.Format("Jan 02")
+	}
+	return "due " + due.Format("Jan 02 15:04")
+}
+
+// checkDueTodos notifies once per todo as it comes within the due window.
+func (b *Baseline) checkDueTodos() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	for _, item := range b.todoItems {
+		if item.Done || item.Due == nil {
+			continue
+		}
+		key := item.Text + "|" + item.Due.Format(time.RFC3339)
+		if b.dueNotified[key] || item.Due.Sub(now) > b.dueWindow {
+			continue
+		}
+		b.dueNotified[key] = true
+		if item.Due.Before(now) {
+			b.notify("todo", fmt.Sprintf("Overdue: %s (%s)", item.Text, dueLabel(*item.Due, now)), "error")
+		} else {
+			b.notify("todo", fmt.Sprintf("Due soon: %s (%s)", item.Text, dueLabel(*item.Due, now)), "info")
+		}
+	}
+	go b.updateTodos() // Overdue colors and labels move with the clock
+}
+
+// setTodoDue handles "todo due <index> <date>|clear". Called with b.mu held.
+func (b *Baseline) setTodoDue(args []string) bool {
+	if len(args) < 2 {
+		b.addNotification("Usage: todo due <index> YYYY-MM-DD [HH:MM]|today|tomorrow|clear", "error")
+		return false
+	}
+	index, err := strconv.Atoi(args[0])
+	if err != nil || index < 1 || index > len(b.todoItems) {
+		b.addNotification(fmt.Sprintf("Invalid todo index: %s", args[0]), "error")
+		return false
+	}
+	item := &b.todoItems[index-1]
+	if args[1] == "clear" || args[1] == "none" {
+		item.Due = nil
+		b.saveTodos()
+		b.addNotification(fmt.Sprintf("Cleared due date of: %s", item.Text), "success")
+		return true
+	}
+	due, _, err := parseDue(args[1:], time.Now())
+	if err != nil {
+		b.addNotification(err.Error(), "error")
+		return false
+	}
+	item.Due = &due
+	b.saveTodos()
+	b.addNotification(fmt.Sprintf("%s: %s", item.Text, dueLabel(due, time.Now())), "success")
+	return true
+}
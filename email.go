@@ -0,0 +1,154 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// --- Email Delivery For Critical Alerts ---
+
+const emailRepeatInterval = 1 * time.Hour // Same alert is mailed at most this often
+
+// Mailer sends critical alerts over SMTP. Port 465 uses implicit TLS,
+// anything else goes through smtp.SendMail (STARTTLS when offered).
+type Mailer struct {
+	host     string
+	port     int
+	user     string
+	password string
+	from     string
+	to       []string
+
+	mu   sync.Mutex
+	sent map[string]time.Time // Alert key -> last time it was mailed
+}
+
+// newMailerFromEnv returns nil unless SMTP_HOST and ALERT_EMAIL_TO are set.
+func newMailerFromEnv() *Mailer {
+	host := os.Getenv("SMTP_HOST")
+	var to []string
+	for _, addr := range strings.Split(os.Getenv("ALERT_EMAIL_TO"), ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			to = append(to, addr)
+		}
+	}
+	if host == "" || len(to) == 0 {
+		return nil
+	}
+
+	port := 587
+	if p, err := strconv.Atoi(os.Getenv("SMTP_PORT")); err == nil && p > 0 {
+		port = p
+	}
+	from := os.Getenv("ALERT_EMAIL_FROM")
+	if from == "" {
+		from = os.Getenv("SMTP_USER")
+	}
+
+	return &Mailer{
+		host:     host,
+		port:     port,
+		user:     os.Getenv("SMTP_USER"),
+		password: os.Getenv("SMTP_PASSWORD"),
+		from:     from,
+		to:       to,
+		sent:     map[string]time.Time{},
+	}
+}
+
+// due reports whether an alert with this key may be mailed now, and records it.
+func (m *Mailer) due(key string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if time.Since(m.sent[key]) < emailRepeatInterval {
+		return false
+	}
+	m.sent[key] = time.Now()
+	return true
+}
+
+func (m *Mailer) send(subject, body string) error {
+	hostName, _ := os.Hostname()
+	// A line break in the subject would start headers of its own
+	subject = strings.Join(strings.FieldsFunc(subject, func(r rune) bool { return r == '\r' || r == '\n' }), " ")
+	msg := strings.Join([]string{
+		"From: " + m.from,
+		"To: " + strings.Join(m.to, ", "),
+		"Subject: " + subject,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"X-Mailer: Baseline",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+		"",
+		"-- ",
+		"Baseline on " + hostName,
+	}, "\r\n")
+
+	addr := fmt.Sprintf("%s:%d", m.host, m.port)
+	var auth smtp.Auth
+	if m.user != "" {
+		auth = smtp.PlainAuth("", m.user, m.password, m.host)
+	}
+	if m.port != 465 {
+		return smtp.SendMail(addr, auth, m.from, m.to, []byte(msg))
+	}
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{ServerName: m.host})
+	if err != nil {
+		return err
+	}
+	c, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+	if auth != nil {
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(m.from); err != nil {
+		return err
+	}
+	for _, rcpt := range m.to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte(msg)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// emailAlert mails a critical alert in the background, at most once per
+// emailRepeatInterval for the same key.
+func (b *Baseline) emailAlert(source, key, message string) {
+	if b.mailer == nil || !b.mailer.due(key) {
+		return
+	}
+	go func() {
+		hostName, _ := os.Hostname()
+		subject := fmt.Sprintf("[Baseline] %s: %s", hostName, message)
+		if err := b.mailer.send(subject, fmt.Sprintf("%s\n\nRaised at %s by %s.", message, time.Now().Format("2006-01-02 15:04:05"), source)); err != nil {
+			slog.Error("Email alert failed", "err", err)
+			b.notify("email", fmt.Sprintf("Email alert failed: %v", err), "error")
+		}
+	}()
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- History Export ---
+//
+// `export history <path> [--format csv|json] [--range 1h]` writes the samples
+// in metrics.db to a file. The format follows the extension unless given;
+// without --range everything still kept is exported. Older parts of a long
+// range come from the per-minute averages (see metrics.go).
+
+// exportedSample is one row of the export. Network rates are worked out
+// from consecutive samples, the counters themselves are kept for reference.
+type exportedSample struct {
+	Time       time.Time `json:"time"`
+	CPU        float64   `json:"cpu_percent"`
+	Memory     float64   `json:"mem_percent"`
+	NetInRate  float64   `json:"net_in_bytes_per_sec"`
+	NetOutRate float64   `json:"net_out_bytes_per_sec"`
+	NetIn      uint64    `json:"net_in_bytes"`
+	NetOut     uint64    `json:"net_out_bytes"`
+}
+
+var exportCSVHeader = []string{"time", "cpu_percent", "mem_percent", "net_in_bytes_per_sec", "net_out_bytes_per_sec", "net_in_bytes", "net_out_bytes"}
+
+// exportSamples adds network rates to the stored samples.
+func exportSamples(samples []MetricSample) []exportedSample {
+	rows := make([]exportedSample, len(samples))
+	for i, s := range samples {
+		rows[i] = exportedSample{Time: s.Time, CPU: s.CPU, Memory: s.Memory, NetIn: s.NetIn, NetOut: s.NetOut}
+		if i == 0 {
+			continue
+		}
+		prev := samples[i-1]
+		elapsed := s.Time.Sub(prev.Time).Seconds()
+		if elapsed > 0 && s.NetIn >= prev.NetIn && s.NetOut >= prev.NetOut { // Counters reset on reboot
+			rows[i].NetInRate = float64(s.NetIn-prev.NetIn) / elapsed
+			rows[i].NetOutRate = float64(s.NetOut-prev.NetOut) / elapsed
+		}
+	}
+	return rows
+}
+
+func writeExportCSV(path string, rows []exportedSample) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	w.Write(exportCSVHeader)
+	float := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, r := range rows {
+		w.Write([]string{
+			r.Time.Format(time.RFC3339),
+			float(r.CPU), float(r.Memory),
+			float(r.NetInRate), float(r.NetOutRate),
+			strconv.FormatUint(r.NetIn, 10), strconv.FormatUint(r.NetOut, 10),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeExportJSON(path string, rows []exportedSample) error {
+	data, err := json.MarshalIndent(rows, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// exportCommand handles "export history ..." and "export screen ..." (see
+// screenshot.go). rawArgs keeps the path's case. Called from processCommand
+// with b.mu held.
+func (b *Baseline) exportCommand(rawArgs []string) {
+	const usage = "Usage: export history <path> [--format csv|json] [--range 1h]"
+	if len(rawArgs) > 0 && strings.ToLower(rawArgs[0]) == "screen" {
+		b.exportScreenCommand(rawArgs[1:])
+		return
+	}
+	if len(rawArgs) < 2 || strings.ToLower(rawArgs[0]) != "history" {
+		b.addNotification(usage+" | screen <path> [--format text|ansi|html]", "error")
+		return
+	}
+	var path, format string
+	var window time.Duration
+	for i := 1; i < len(rawArgs); i++ {
+		switch arg := rawArgs[i]; {
+		case (arg == "--format" || arg == "--range") && i+1 == len(rawArgs):
+			b.addNotification(fmt.Sprintf("Missing value after %s. %s", arg, usage), "error")
+			return
+		case arg == "--format":
+			i++
+			format = strings.ToLower(rawArgs[i])
+			if format != "csv" && format != "json" {
+				b.addNotification(fmt.Sprintf("Unknown format %q (csv or json)", rawArgs[i]), "error")
+				return
+			}
+		case arg == "--range":
+			i++
+			d, err := parseLongDuration(rawArgs[i])
+			if err != nil || d <= 0 {
+				b.addNotification(fmt.Sprintf("Invalid range %q (e.g. 30m, 6h, 7d)", rawArgs[i]), "error")
+				return
+			}
+			window = d
+		case path == "":
+			path = arg
+		default:
+			b.addNotification(usage, "error")
+			return
+		}
+	}
+	if path == "" {
+		b.addNotification(usage, "error")
+		return
+	}
+	if strings.HasPrefix(path, "~") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[1:])
+		}
+	}
+	if format == "" {
+		format = "csv"
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			format = "json"
+		}
+	}
+	if b.metrics == nil {
+		b.addNotification("No metrics database open, nothing to export", "error")
+		return
+	}
+	go b.exportHistory(path, format, window)
+}
+
+// exportHistory writes the samples of the last window (all when 0) to path.
+func (b *Baseline) exportHistory(path, format string, window time.Duration) {
+	to := time.Now()
+	from := time.Time{}
+	if window > 0 {
+		from = to.Add(-window)
+	}
+	samples, err := b.metrics.Range(from, to)
+	if err != nil {
+		b.notify("history", fmt.Sprintf("Error reading metrics: %v", err), "error")
+		return
+	}
+	rows := exportSamples(samples)
+	if format == "json" {
+		err = writeExportJSON(path, rows)
+	} else {
+		err = writeExportCSV(path, rows)
+	}
+	if err != nil {
+		b.notify("history", fmt.Sprintf("Export failed: %v", err), "error")
+		return
+	}
+	b.notify("history", fmt.Sprintf("Exported %d samples to %s", len(rows), path), "success")
+}
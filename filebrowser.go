@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- File Browser View ---
+
+type fileEntry struct {
+	Name string
+	Info fs.FileInfo // nil when it could not be stat'ed
+}
+
+// listDir returns the entries of dir, directories first, then by name.
+func listDir(dir string, hidden bool) ([]fileEntry, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []fileEntry
+	for _, e := range entries {
+		if !hidden && strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		info, _ := os.Stat(filepath.Join(dir, e.Name())) // Follows symlinks, so linked dirs can be entered
+		files = append(files, fileEntry{Name: e.Name(), Info: info})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		di, dj := files[i].isDir(), files[j].isDir()
+		if di != dj {
+			return di
+		}
+		return strings.ToLower(files[i].Name) < strings.ToLower(files[j].Name)
+	})
+	return files, nil
+}
+
+func (f fileEntry) isDir() bool { return f.Info != nil && f.Info.IsDir() }
+
+// openFileBrowser shows a full-screen browser starting at dir (default: the
+// working directory). Must run on the UI goroutine.
+func (b *Baseline) openFileBrowser(dir string) {
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+
+	view := newPanel(" Files ")
+	view.SetBorderColor(b.theme.Bright)
+	view.SetTitleColor(b.theme.Bright)
+	view.SetTextColor(b.theme.Main)
+
+	var files []fileEntry
+	var listErr error
+	selected := 0
+	hidden := false
+
+	load := func(selectName string) {
+		files, listErr = listDir(dir, hidden)
+		selected = 0
+		for i, f := range files {
+			if f.Name == selectName {
+				selected = i
+			}
+		}
+	}
+
+	render := func() {
+		mainC := colorTag(b.theme.Main)
+		dimC := colorTag(b.theme.Dim)
+		brightC := colorTag(b.theme.Bright)
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n", brightC+"[::b]", tview.Escape(dir)))
+		sb.WriteString(fmt.Sprintf("%s↑/↓ select  Enter open  e edit  ← up  . hidden  r refresh  Esc close[-:-:-]\n\n", dimC))
+
+		if listErr != nil {
+			sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(listErr.Error())))
+		} else if len(files) == 0 {
+			sb.WriteString(fmt.Sprintf("%s(Empty)[-:-:-]\n", dimC))
+		}
+		for i, f := range files {
+			marker, nameC := "  ", mainC
+			if i == selected {
+				marker, nameC = brightC+"> ", brightC
+			}
+			size, modified := "?", ""
+			name := tview.Escape(f.Name)
+			if f.Info != nil {
+				size = formatBytes(f.Info.Size())
+				modified = f.Info.ModTime().Format("2006-01-02 15:04")
+			}
+			if f.isDir() {
+				size = "-"
+				name += "/"
+			}
+			sb.WriteString(fmt.Sprintf("%s%s%10s  %s%s  %s%s[-:-:-]\n",
+				marker, dimC, size,
+				dimC, modified,
+				nameC, name,
+			))
+		}
+		view.SetText(sb.String())
+
+		_, _, _, height := view.GetInnerRect()
+		if top := selected + 3 - height + 1; top > 0 {
+			view.ScrollTo(top, 0)
+		} else {
+			view.ScrollToBeginning()
+		}
+	}
+	load("")
+	render()
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		var current *fileEntry
+		if selected < len(files) {
+			current = &files[selected]
+		}
+		switch {
+		case event.Key() == tcell.KeyEscape || event.Rune() == 'q':
+			b.closeOverlay("files")
+			return nil
+		case event.Key() == tcell.KeyUp || event.Rune() == 'k':
+			if selected > 0 {
+				selected--
+			}
+		case event.Key() == tcell.KeyDown || event.Rune() == 'j':
+			if selected < len(files)-1 {
+				selected++
+			}
+		case event.Key() == tcell.KeyLeft || event.Key() == tcell.KeyBackspace || event.Key() == tcell.KeyBackspace2 || event.Rune() == 'h':
+			if parent := filepath.Dir(dir); parent != dir {
+				from := filepath.Base(dir)
+				dir = parent
+				load(from)
+			}
+		case event.Key() == tcell.KeyEnter || event.Key() == tcell.KeyRight || event.Rune() == 'l':
+			if current == nil {
+				break
+			}
+			path := filepath.Join(dir, current.Name)
+			if current.isDir() {
+				dir = path
+				load("")
+			} else if err := openBrowser(path); err != nil {
+				go b.notify("files", fmt.Sprintf("Could not open %s: %v", current.Name, err), "error")
+			}
+		case event.Rune() == 'e':
+			if current != nil && !current.isDir() {
+				path := filepath.Join(dir, current.Name)
+				go func() {
+					if err := b.runEditor(path); err != nil {
+						b.notify("files", fmt.Sprintf("Editor failed: %v", err), "error")
+					}
+					b.app.QueueUpdateDraw(func() {
+						load(filepath.Base(path)) // Size and mtime may have changed
+						render()
+					})
+				}()
+			}
+			return nil
+		case event.Rune() == '.':
+			hidden = !hidden
+			name := ""
+			if current != nil {
+				name = current.Name
+			}
+			load(name)
+		case event.Rune() == 'r':
+			name := ""
+			if current != nil {
+				name = current.Name
+			}
+			load(name)
+		default:
+			return event
+		}
+		render()
+		return nil
+	})
+
+	b.pages.AddPage("files", view, true, true) // Full screen
+	b.app.SetFocus(view)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- Focus Session Statistics ---
+//
+// Focus sessions are timed blocks of work, started and stopped by hand (F or
+// `focus start`). Finished sessions are kept in focus.json; the stats view
+// sums them per day and week.
+
+const focusChartDays = 14
+
+type FocusSession struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Label string    `json:"label,omitempty"`
+}
+
+func (s FocusSession) Duration() time.Duration { return s.End.Sub(s.Start) }
+
+// focusFile is the layout of focus.json. Active survives restarts, so a
+// session keeps running if the dashboard is closed in between.
+type focusFile struct {
+	Sessions []FocusSession `json:"sessions"`
+	Active   *FocusSession  `json:"active,omitempty"`
+}
+
+func (b *Baseline) loadFocus() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	data, err := os.ReadFile(filepath.Join(b.configDir, "focus.json"))
+	if err != nil {
+		return
+	}
+	var saved focusFile
+	if err := json.Unmarshal(data, &saved); err != nil {
+		b.notify("focus", fmt.Sprintf("Error parsing focus.json: %v", err), "error")
+		return
+	}
+	b.focusLog = saved.Sessions
+	b.focusActive = saved.Active
+}
+
+func (b *Baseline) saveFocus() {
+	// Called from within locked sections
+	data, err := json.MarshalIndent(focusFile{Sessions: b.focusLog, Active: b.focusActive}, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(b.configDir, "focus.json"), data, 0640); err != nil {
+		b.notify("focus", fmt.Sprintf("Error saving focus sessions: %v", err), "error")
+	}
+}
+
+// startFocus begins a session. Called with b.mu held.
+func (b *Baseline) startFocus(label string) {
+	if b.focusActive != nil {
+		b.notify("focus", fmt.Sprintf("Already focusing since %s", b.focusActive.Start.Format("15:04")), "info")
+		return
+	}
+	b.focusActive = &FocusSession{Start: time.Now(), Label: label}
+	b.saveFocus()
+	msg := "Focus session started"
+	if label != "" {
+		msg += ": " + label
+	}
+	b.notify("focus", msg, "success")
+	go b.app.QueueUpdateDraw(b.updateHeader)
+}
+
+// stopFocus ends the running session and records it. Called with b.mu held.
+func (b *Baseline) stopFocus() {
+	if b.focusActive == nil {
+		b.notify("focus", "No focus session running", "info")
+		return
+	}
+	session := *b.focusActive
+	session.End = time.Now()
+	b.focusActive = nil
+	b.focusLog = append(b.focusLog, session)
+	b.saveFocus()
+	b.notify("focus", fmt.Sprintf("Focused for %s (today: %s)", formatDuration(session.Duration()), formatDuration(b.focusOn(time.Now()))), "success")
+	go b.app.QueueUpdateDraw(b.updateHeader)
+}
+
+// focusOn sums the sessions started on day, including a running one.
+// Called with b.mu held (read).
+func (b *Baseline) focusOn(day time.Time) time.Duration {
+	y, m, d := day.Date()
+	var total time.Duration
+	sessions := b.focusLog
+	if b.focusActive != nil {
+		sessions = append(sessions[:len(sessions):len(sessions)], FocusSession{Start: b.focusActive.Start, End: time.Now()})
+	}
+	for _, s := range sessions {
+		if sy, sm, sd := s.Start.Date(); sy == y && sm == m && sd == d {
+			total += s.Duration()
+		}
+	}
+	return total
+}
+
+// focusWeek sums the week (Monday to Sunday) containing day.
+// Called with b.mu held (read).
+func (b *Baseline) focusWeek(day time.Time) time.Duration {
+	monday := day.AddDate(0, 0, -(int(day.Weekday()+6) % 7))
+	var total time.Duration
+	for i := 0; i < 7; i++ {
+		total += b.focusOn(monday.AddDate(0, 0, i))
+	}
+	return total
+}
+
+// openFocusStats shows daily and weekly totals with a chart of the last two
+// weeks. Must run on the UI goroutine.
+func (b *Baseline) openFocusStats() {
+	view := newPanel(" Focus ")
+	view.SetBorderColor(b.theme.Bright)
+	view.SetTitleColor(b.theme.Bright)
+	view.SetTextColor(b.theme.Main)
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	b.mu.RLock()
+	now := time.Now()
+	days := make([]time.Duration, focusChartDays)
+	var peak time.Duration
+	for i := range days {
+		days[i] = b.focusOn(now.AddDate(0, 0, i-focusChartDays+1))
+		peak = max(peak, days[i])
+	}
+	thisWeek, lastWeek := b.focusWeek(now), b.focusWeek(now.AddDate(0, 0, -7))
+	active := b.focusActive
+	sessions := len(b.focusLog)
+	b.mu.RUnlock()
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sFOCUS TIME[-:-:-]\n\n", brightC+"[::b]"))
+	if active != nil {
+		label := ""
+		if active.Label != "" {
+			label = " - " + tview.Escape(active.Label)
+		}
+		sb.WriteString(fmt.Sprintf("%sRunning: %s%s since %s%s[-:-:-]\n\n", mainC, brightC, formatDuration(now.Sub(active.Start)), active.Start.Format("15:04"), label))
+	}
+	sb.WriteString(fmt.Sprintf("%sToday:     %s%s[-:-:-]\n", mainC, brightC, formatDuration(days[len(days)-1])))
+	sb.WriteString(fmt.Sprintf("%sThis week: %s%s[-:-:-]\n", mainC, brightC, formatDuration(thisWeek)))
+	sb.WriteString(fmt.Sprintf("%sLast week: %s%s[-:-:-]\n", mainC, brightC, formatDuration(lastWeek)))
+	sb.WriteString(fmt.Sprintf("%s%d sessions recorded[-:-:-]\n\n", dimC, sessions))
+
+	for i, d := range days {
+		day := now.AddDate(0, 0, i-focusChartDays+1)
+		pct := 0.0
+		if peak > 0 {
+			pct = float64(d) / float64(peak) * 100
+		}
+		dayC := mainC
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			dayC = dimC
+		}
+		sb.WriteString(fmt.Sprintf("%s%s %s %s%s[-:-:-]\n", dayC, day.Format("Mon 01-02"), createBar(pct, 30, b.theme), mainC, formatDuration(d)))
+	}
+	sb.WriteString(fmt.Sprintf("\n%sEsc close[-:-:-]", dimC))
+	view.SetText(sb.String())
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' {
+			b.closeOverlay("focus")
+			return nil
+		}
+		return event
+	})
+	b.showOverlay("focus", view, 60, focusChartDays+14)
+}
+
+// focusCommand handles "focus [stats]", "focus start [label]" and "focus stop".
+// rawArgs keeps the label's case. Called from processCommand with b.mu held.
+func (b *Baseline) focusCommand(rawArgs []string) {
+	sub := ""
+	if len(rawArgs) > 0 {
+		sub = strings.ToLower(rawArgs[0])
+	}
+	switch sub {
+	case "", "stats":
+		go b.app.QueueUpdateDraw(b.openFocusStats)
+	case "start":
+		b.startFocus(strings.Join(rawArgs[1:], " "))
+	case "stop":
+		b.stopFocus()
+	default:
+		b.addNotification("Usage: focus [stats|start [label]|stop]", "error")
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// --- Weather Forecast ---
+//
+// Providers (see weatherproviders.go) return the current conditions plus
+// forecastDays days of daily and hourly data. The hours are hourly, or
+// 3-hourly for OpenWeatherMap; renderForecast picks one every
+// forecastHourEvery hours either way.
+
+const (
+	forecastDays      = 3
+	forecastHours     = 4 // Hourly entries shown
+	forecastHourEvery = 3 // Hours between them
+)
+
+type ForecastDay struct {
+	Date       time.Time
+	MinC, MaxC float64
+	RainChance int     // Percent
+	PrecipMM   float64 // Total for the day
+	GustKph    float64 // Strongest wind gust, 0 when unknown
+	Condition  string
+	Kind       WeatherKind // Picks the icon
+}
+
+type ForecastHour struct {
+	Time       time.Time
+	TempC      float64
+	RainChance int
+	Condition  string
+	Kind       WeatherKind
+	IsDay      bool
+}
+
+// WeatherWarning is a government weather alert (alerts=yes in forecast.json),
+// or one derived from the forecast, see weatheralerts.go.
+type WeatherWarning struct {
+	Headline string `json:"headline"`
+	Event    string `json:"event"`
+	Severity string `json:"severity"`
+	Expires  string `json:"expires"`
+}
+
+// forecastResponse is the part of weatherapi.com's forecast.json used for the forecast.
+type forecastResponse struct {
+	ForecastDay []struct {
+		Date string `json:"date"`
+		Day  struct {
+			MaxTempC   float64 `json:"maxtemp_c"`
+			MinTempC   float64 `json:"mintemp_c"`
+			RainChance int     `json:"daily_chance_of_rain"`
+			PrecipMM   float64 `json:"totalprecip_mm"`
+			Condition  struct {
+				Text string `json:"text"`
+				Code int    `json:"code"`
+			} `json:"condition"`
+		} `json:"day"`
+		Hour []struct {
+			TimeEpoch  int64   `json:"time_epoch"`
+			TempC      float64 `json:"temp_c"`
+			RainChance int     `json:"chance_of_rain"`
+			IsDay      int     `json:"is_day"`
+			Condition  struct {
+				Text string `json:"text"`
+				Code int    `json:"code"`
+			} `json:"condition"`
+		} `json:"hour"`
+	} `json:"forecastday"`
+}
+
+// forecast converts the response into days and the upcoming hours.
+func (r forecastResponse) forecast(now time.Time) ([]ForecastDay, []ForecastHour) {
+	var days []ForecastDay
+	var hours []ForecastHour
+	for _, fd := range r.ForecastDay {
+		date, _ := time.ParseInLocation("2006-01-02", fd.Date, now.Location())
+		days = append(days, ForecastDay{
+			Date:       date,
+			MinC:       fd.Day.MinTempC,
+			MaxC:       fd.Day.MaxTempC,
+			RainChance: fd.Day.RainChance,
+			PrecipMM:   fd.Day.PrecipMM,
+			Condition:  fd.Day.Condition.Text,
+			Kind:       weatherAPIKind(fd.Day.Condition.Code),
+		})
+		for _, h := range fd.Hour {
+			t := time.Unix(h.TimeEpoch, 0)
+			if t.Before(now.Truncate(time.Hour)) || len(hours) >= forecastHours*forecastHourEvery {
+				continue
+			}
+			hours = append(hours, ForecastHour{
+				Time:       t,
+				TempC:      h.TempC,
+				RainChance: h.RainChance,
+				Condition:  h.Condition.Text,
+				Kind:       weatherAPIKind(h.Condition.Code),
+				IsDay:      h.IsDay == 1,
+			})
+		}
+	}
+	return days, hours
+}
+
+// weatherIcon maps a condition to a single-cell symbol.
+func weatherIcon(kind WeatherKind, isDay bool) string {
+	switch kind {
+	case WeatherClear:
+		if !isDay {
+			return "☾"
+		}
+		return "☀"
+	case WeatherPartlyCloudy:
+		return "◐"
+	case WeatherCloudy:
+		return "☁"
+	case WeatherFog:
+		return "≡"
+	case WeatherThunder:
+		return "ϟ"
+	case WeatherSnow:
+		return "❄"
+	case WeatherSleet:
+		return "✱"
+	case WeatherDrizzle, WeatherRain:
+		return "☂"
+	}
+	return "·"
+}
+
+// renderForecast draws the hourly and daily forecast for the weather panel.
+func renderForecast(info WeatherInfo, units WeatherUnits, theme Theme) string {
+	mainC := colorTag(theme.Main)
+	dimC := colorTag(theme.Dim)
+	brightC := colorTag(theme.Bright)
+
+	var sb strings.Builder
+	if len(info.Hours) > 0 {
+		sb.WriteString(fmt.Sprintf("\n%sNEXT HOURS:[-:-:-]\n", mainC))
+		var next time.Time
+		for _, h := range info.Hours {
+			if h.Time.Before(next) {
+				continue
+			}
+			next = h.Time.Add(forecastHourEvery * time.Hour)
+			sb.WriteString(fmt.Sprintf("%s%s %s%s %s%5.1f%s %s%3d%% rain[-:-:-]\n",
+				dimC, h.Time.Format("15:04"), brightC, weatherIcon(h.Kind, h.IsDay), mainC, units.Degrees(h.TempC), units.TempSymbol(), dimC, h.RainChance))
+		}
+	}
+	if len(info.Days) > 0 {
+		sb.WriteString(fmt.Sprintf("\n%s%d-DAY FORECAST:[-:-:-]\n", mainC, len(info.Days)))
+		for _, d := range info.Days {
+			precip := ""
+			if d.PrecipMM > 0 {
+				precip = " " + units.Precip(d.PrecipMM)
+			}
+			sb.WriteString(fmt.Sprintf("%s%s %s%s %s%.0f°/%.0f%s %s%3d%%%s %s[-:-:-]\n",
+				dimC, d.Date.Format("Mon"), brightC, weatherIcon(d.Kind, true), mainC, units.Degrees(d.MinC), units.Degrees(d.MaxC), units.TempSymbol(), dimC, d.RainChance, precip, d.Condition))
+		}
+	}
+	return sb.String()
+}
+
+// title is the short form of a warning used in the panel and notifications.
+func (w WeatherWarning) title() string {
+	title := w.Event
+	if title == "" {
+		title = w.Headline
+	}
+	if w.Severity != "" {
+		title += " (" + w.Severity + ")"
+	}
+	return title
+}
+
+// announceWeatherWarnings notifies once per warning. Called with b.mu held.
+func (b *Baseline) announceWeatherWarnings(info WeatherInfo) {
+	for _, w := range activeWarnings(info.Warnings, time.Now()) {
+		key := info.Location + "|" + w.Headline + "|" + w.Expires
+		if b.weatherWarned[key] {
+			continue
+		}
+		b.weatherWarned[key] = true
+		b.notifyPriority("weather", fmt.Sprintf("Weather warning for %s: %s", info.Location, w.title()), "error", PriorityHigh)
+	}
+}
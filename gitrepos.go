@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Git Repository Status Widget ---
+
+const gitRefreshInterval = 30 * time.Second
+
+type GitRepoStatus struct {
+	Path   string
+	Name   string
+	Branch string
+	Dirty  int // Changed + untracked files
+	Ahead  int
+	Behind int
+	Error  string
+}
+
+// gitReposFromEnv expands GIT_REPOS (comma separated, ~ allowed) into absolute paths.
+func gitReposFromEnv() []string {
+	var repos []string
+	for _, p := range strings.Split(os.Getenv("GIT_REPOS"), ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if strings.HasPrefix(p, "~") {
+			if home, err := os.UserHomeDir(); err == nil {
+				p = filepath.Join(home, p[1:])
+			}
+		}
+		repos = append(repos, p)
+	}
+	return repos
+}
+
+// gitStatus reads branch, dirty count and ahead/behind from `git status --porcelain=v2 --branch`.
+func gitStatus(path string) GitRepoStatus {
+	st := GitRepoStatus{Path: path, Name: filepath.Base(path)}
+
+	out, err := exec.Command("git", "-C", path, "status", "--porcelain=v2", "--branch").Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			st.Error = strings.TrimSpace(string(exitErr.Stderr))
+		} else {
+			st.Error = err.Error()
+		}
+		return st
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "# branch.head "):
+			st.Branch = strings.TrimPrefix(line, "# branch.head ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			fmt.Sscanf(strings.TrimPrefix(line, "# branch.ab "), "+%d -%d", &st.Ahead, &st.Behind)
+		case strings.HasPrefix(line, "#"):
+			// Other headers (oid, upstream)
+		case line != "":
+			st.Dirty++
+		}
+	}
+	return st
+}
+
+func (b *Baseline) fetchGitRepos() {
+	statuses := make([]GitRepoStatus, 0, len(b.gitRepos))
+	for _, path := range b.gitRepos {
+		statuses = append(statuses, gitStatus(path))
+	}
+
+	b.mu.Lock()
+	b.gitStatuses = statuses
+	b.gitLastUpdated = time.Now()
+	b.mu.Unlock()
+
+	b.updateGitRepos()
+}
+
+func (b *Baseline) updateGitRepos() {
+	b.mu.RLock()
+	statuses := b.gitStatuses
+	lastUpdated := b.gitLastUpdated
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sREPOSITORIES[-:-:-]\n", brightC+"[::b]"))
+
+	if lastUpdated.IsZero() {
+		sb.WriteString(fmt.Sprintf("%sLoading...[-:-:-]\n", dimC))
+	}
+
+	for _, st := range statuses {
+		if st.Error != "" {
+			sb.WriteString(fmt.Sprintf("%s%s: [red]%s[-:-:-]\n", mainC, tview.Escape(st.Name), tview.Escape(st.Error)))
+			continue
+		}
+
+		dirty := fmt.Sprintf("%sclean", dimC)
+		if st.Dirty > 0 {
+			dirty = fmt.Sprintf("%s%d dirty", brightC, st.Dirty)
+		}
+		sync := ""
+		if st.Ahead > 0 {
+			sync += fmt.Sprintf(" ↑%d", st.Ahead)
+		}
+		if st.Behind > 0 {
+			sync += fmt.Sprintf(" ↓%d", st.Behind)
+		}
+		sb.WriteString(fmt.Sprintf("%s%s %s(%s) %s%s%s[-:-:-]\n",
+			mainC, tview.Escape(st.Name),
+			dimC, tview.Escape(st.Branch),
+			dirty,
+			brightC, sync,
+		))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%sLast updated: %s[-:-:-]", dimC, lastUpdated.Format("15:04:05")))
+
+	b.setPanel(b.gitPanel, sb.String())
+}
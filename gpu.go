@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// --- GPU Monitoring ---
+//
+// A GPU section in the system panel with utilization, VRAM, temperature and
+// power for each GPU found: NVIDIA through nvidia-smi, AMD through the amdgpu
+// sysfs files (the counters ROCm reports), Intel through i915 sysfs, which
+// only exposes the clock, so Intel shows frequency instead of utilization.
+// GPU=false turns it off; otherwise it is on when a GPU is found at startup.
+
+type GPUReading struct {
+	Name       string
+	Util       float64 // Percent, -1 when unknown
+	MemUsed    int64   // Bytes, 0 when unknown
+	MemTotal   int64
+	TempC      float64 // 0 when unknown
+	PowerW     float64 // 0 when unknown
+	FreqMHz    int     // Intel only
+	MaxFreqMHz int
+}
+
+// gpuEnabled reports whether GPU monitoring should run at all.
+func gpuEnabled() bool {
+	if strings.ToLower(os.Getenv("GPU")) == "false" {
+		return false
+	}
+	return len(readGPUs()) > 0
+}
+
+// readGPUs collects every GPU from all sources.
+func readGPUs() []GPUReading {
+	gpus := readNvidiaGPUs()
+	return append(gpus, readDRMGPUs()...)
+}
+
+func readNvidiaGPUs() []GPUReading {
+	if _, err := exec.LookPath("nvidia-smi"); err != nil {
+		return nil
+	}
+	out, err := runOutput("nvidia-smi",
+		"--query-gpu=name,utilization.gpu,memory.used,memory.total,temperature.gpu,power.draw",
+		"--format=csv,noheader,nounits")
+	if err != nil {
+		return nil
+	}
+	var gpus []GPUReading
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) != 6 {
+			continue
+		}
+		num := func(i int) float64 {
+			v, err := strconv.ParseFloat(strings.TrimSpace(fields[i]), 64)
+			if err != nil {
+				return 0 // "[N/A]" on cards that don't report it
+			}
+			return v
+		}
+		g := GPUReading{
+			Name:     strings.TrimSpace(fields[0]),
+			Util:     num(1),
+			MemUsed:  int64(num(2)) << 20, // MiB
+			MemTotal: int64(num(3)) << 20,
+			TempC:    num(4),
+			PowerW:   num(5),
+		}
+		if _, err := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64); err != nil {
+			g.Util = -1
+		}
+		gpus = append(gpus, g)
+	}
+	return gpus
+}
+
+// readDRMGPUs reads AMD and Intel GPUs from /sys/class/drm (Linux only).
+// NVIDIA cards are skipped there, nvidia-smi covers them.
+func readDRMGPUs() []GPUReading {
+	cards, _ := filepath.Glob("/sys/class/drm/card[0-9]*")
+	var gpus []GPUReading
+	for _, card := range cards {
+		if strings.Contains(filepath.Base(card), "-") {
+			continue // Connectors such as card0-HDMI-A-1
+		}
+		device := filepath.Join(card, "device")
+		driver, err := os.Readlink(filepath.Join(device, "driver"))
+		if err != nil {
+			continue
+		}
+		g := GPUReading{Util: -1}
+		switch filepath.Base(driver) {
+		case "amdgpu":
+			g.Name = "AMD GPU"
+			if v, err := readIntFile(filepath.Join(device, "gpu_busy_percent")); err == nil {
+				g.Util = float64(v)
+			}
+			if v, err := readIntFile(filepath.Join(device, "mem_info_vram_used")); err == nil {
+				g.MemUsed = int64(v)
+			}
+			if v, err := readIntFile(filepath.Join(device, "mem_info_vram_total")); err == nil {
+				g.MemTotal = int64(v)
+			}
+		case "i915", "xe":
+			g.Name = "Intel GPU"
+			g.FreqMHz, _ = readIntFile(filepath.Join(card, "gt_cur_freq_mhz"))
+			g.MaxFreqMHz, _ = readIntFile(filepath.Join(card, "gt_max_freq_mhz"))
+		default:
+			continue
+		}
+		if hwmons, _ := filepath.Glob(filepath.Join(device, "hwmon", "hwmon*")); len(hwmons) > 0 {
+			if v, err := readIntFile(filepath.Join(hwmons[0], "temp1_input")); err == nil {
+				g.TempC = float64(v) / 1000 // Millidegrees
+			}
+			if v, err := readIntFile(filepath.Join(hwmons[0], "power1_average")); err == nil {
+				g.PowerW = float64(v) / 1e6 // Microwatts
+			} else if v, err := readIntFile(filepath.Join(hwmons[0], "power1_input")); err == nil {
+				g.PowerW = float64(v) / 1e6
+			}
+		}
+		gpus = append(gpus, g)
+	}
+	return gpus
+}
+
+// fetchGPU refreshes the GPU readings shown by gpuSection.
+func (b *Baseline) fetchGPU() {
+	gpus := readGPUs()
+	b.mu.Lock()
+	b.gpus = gpus
+	b.mu.Unlock()
+}
+
+// gpuSection renders the GPU lines for the system panel. Called from
+// updateSystemInfo with b.mu held.
+func (b *Baseline) gpuSection() string {
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	for _, g := range b.gpus {
+		switch {
+		case g.Util >= 0:
+			sb.WriteString(fmt.Sprintf("%sGPU: %s %s %.1f%% %s%s[-:-:-]\n", mainC, createBar(g.Util, 15, b.theme), brightC, g.Util, dimC, g.Name))
+		case g.MaxFreqMHz > 0:
+			load := float64(g.FreqMHz) / float64(g.MaxFreqMHz) * 100
+			sb.WriteString(fmt.Sprintf("%sGPU: %s %s%d/%d MHz %s%s[-:-:-]\n", mainC, createBar(load, 15, b.theme), brightC, g.FreqMHz, g.MaxFreqMHz, dimC, g.Name))
+		default:
+			sb.WriteString(fmt.Sprintf("%sGPU: %s%s[-:-:-]\n", mainC, dimC, g.Name))
+		}
+		var details []string
+		if g.MemTotal > 0 {
+			details = append(details, fmt.Sprintf("VRAM %s/%s", formatBytes(g.MemUsed), formatBytes(g.MemTotal)))
+		}
+		if g.TempC > 0 {
+			details = append(details, fmt.Sprintf("%.0f°C", g.TempC))
+		}
+		if g.PowerW > 0 {
+			details = append(details, fmt.Sprintf("%.0f W", g.PowerW))
+		}
+		if len(details) > 0 {
+			sb.WriteString(fmt.Sprintf("%s     %s[-:-:-]\n", dimC, strings.Join(details, "  ")))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Habit Tracker Widget ---
+
+const (
+	habitsRefreshInterval = 1 * time.Minute // Rolls the grid over at midnight
+	habitDateFormat       = "2006-01-02"
+)
+
+// Habit is a configured habit and the days it was done, persisted in habits.json.
+type Habit struct {
+	Name string   `json:"name"`
+	Done []string `json:"done"` // Sorted "2006-01-02" dates
+}
+
+// habitsFromEnv reads HABITS, a comma separated list of habit names.
+func habitsFromEnv() []Habit {
+	var habits []Habit
+	for _, name := range strings.Split(os.Getenv("HABITS"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			habits = append(habits, Habit{Name: name})
+		}
+	}
+	return habits
+}
+
+func (h *Habit) doneOn(day string) bool {
+	i := sort.SearchStrings(h.Done, day)
+	return i < len(h.Done) && h.Done[i] == day
+}
+
+// toggle marks or unmarks the habit for day and reports the new state.
+func (h *Habit) toggle(day string) bool {
+	i := sort.SearchStrings(h.Done, day)
+	if i < len(h.Done) && h.Done[i] == day {
+		h.Done = append(h.Done[:i], h.Done[i+1:]...)
+		return false
+	}
+	h.Done = append(h.Done, "")
+	copy(h.Done[i+1:], h.Done[i:])
+	h.Done[i] = day
+	return true
+}
+
+// streak counts consecutive days up to today. An unchecked today doesn't
+// break the streak yet: it counts from yesterday instead.
+func (h *Habit) streak(now time.Time) int {
+	day := now
+	if !h.doneOn(day.Format(habitDateFormat)) {
+		day = day.AddDate(0, 0, -1)
+	}
+	n := 0
+	for h.doneOn(day.Format(habitDateFormat)) {
+		n++
+		day = day.AddDate(0, 0, -1)
+	}
+	return n
+}
+
+// loadHabits merges the saved history into the configured habits.
+func (b *Baseline) loadHabits() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	data, err := os.ReadFile(filepath.Join(b.configDir, "habits.json"))
+	if err != nil {
+		return
+	}
+	var saved []Habit
+	if err := json.Unmarshal(data, &saved); err != nil {
+		b.notify("habits", fmt.Sprintf("Error parsing habits.json: %v", err), "error")
+		return
+	}
+	for _, s := range saved {
+		found := false
+		for i := range b.habits {
+			if strings.EqualFold(b.habits[i].Name, s.Name) {
+				b.habits[i].Done = s.Done
+				sort.Strings(b.habits[i].Done)
+				found = true
+			}
+		}
+		if !found {
+			b.habitsRetired = append(b.habitsRetired, s) // No longer configured, keep its history
+		}
+	}
+}
+
+func (b *Baseline) saveHabits() {
+	// Called from within locked sections
+	data, err := json.MarshalIndent(append(append([]Habit(nil), b.habits...), b.habitsRetired...), "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(b.configDir, "habits.json"), data, 0640); err != nil {
+		b.notify("habits", fmt.Sprintf("Error saving habits: %v", err), "error")
+	}
+}
+
+// toggleHabit checks off (or un-checks) the habit at the 1-based index for
+// today; 0 means the selected habit. Called with b.mu held.
+func (b *Baseline) toggleHabit(index int) {
+	if index == 0 {
+		index = b.habitSel + 1
+	}
+	if index < 1 || index > len(b.habits) {
+		b.notify("habits", fmt.Sprintf("Invalid habit index: %d", index), "error")
+		return
+	}
+	h := &b.habits[index-1]
+	if h.toggle(time.Now().Format(habitDateFormat)) {
+		b.notify("habits", fmt.Sprintf("%s done (streak: %d)", h.Name, h.streak(time.Now())), "success")
+	} else {
+		b.notify("habits", fmt.Sprintf("%s unchecked for today", h.Name), "info")
+	}
+	b.saveHabits()
+	go b.updateHabits()
+}
+
+// selectNextHabit moves the selection, wrapping around. Called with b.mu held.
+func (b *Baseline) selectNextHabit() {
+	if len(b.habits) == 0 {
+		return
+	}
+	b.habitSel = (b.habitSel + 1) % len(b.habits)
+	go b.updateHabits()
+}
+
+func (b *Baseline) updateHabits() {
+	b.mu.RLock()
+	habits := make([]Habit, len(b.habits))
+	for i, h := range b.habits {
+		habits[i] = Habit{Name: h.Name, Done: append([]string(nil), h.Done...)}
+	}
+	selected := b.habitSel
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	now := time.Now()
+	today := now.Format(habitDateFormat)
+	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	daysInMonth := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sHABITS - %s[-:-:-]\n", brightC+"[::b]", strings.ToUpper(now.Format("January"))))
+
+	for i, h := range habits {
+		marker, nameC := "  ", mainC
+		if i == selected {
+			marker, nameC = brightC+"> ", brightC
+		}
+		check := dimC + "[ ]"
+		if h.doneOn(today) {
+			check = brightC + "[x]"
+		}
+		sb.WriteString(fmt.Sprintf("%s%s %s%s %sstreak %d[-:-:-]\n",
+			marker, check, nameC, tview.Escape(h.Name), dimC, h.streak(now)))
+
+		// Month grid: one cell per day, today underlined
+		sb.WriteString("    ")
+		for d := 1; d <= daysInMonth; d++ {
+			day := firstOfMonth.AddDate(0, 0, d-1)
+			cell := dimC + "·"
+			switch {
+			case h.doneOn(day.Format(habitDateFormat)):
+				cell = brightC + "■"
+			case day.After(now):
+				cell = dimC + " "
+			}
+			if d == now.Day() {
+				cell = "[::u]" + cell + "[::-]"
+			}
+			sb.WriteString(cell)
+		}
+		sb.WriteString("[-:-:-]\n")
+	}
+	if len(habits) > 0 {
+		sb.WriteString(fmt.Sprintf("\n%sg select, G check off[-:-:-]", dimC))
+	}
+
+	b.setPanel(b.habitsPanel, sb.String())
+}
+
+// habitCommand handles "habit [n]": toggle habit n (default: the selected one) for today.
+// Called from processCommand with b.mu held.
+func (b *Baseline) habitCommand(args []string) {
+	index := 0
+	if len(args) > 0 {
+		var err error
+		if index, err = strconv.Atoi(args[0]); err != nil {
+			b.notify("habits", "Usage: habit [index]", "error")
+			return
+		}
+	}
+	b.toggleHabit(index)
+}
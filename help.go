@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- Help Overlay ---
+//
+// `?` (or the help command) opens a scrollable reference of every key and
+// command. The keys are listed here; the commands come from the registry in
+// commands.go, so registered commands show up without changes here.
+
+type keySpec struct {
+	Keys    string
+	Summary string
+}
+
+// keyGroups lists the dashboard's keys by topic.
+var keyGroups = []struct {
+	Title string
+	Keys  []keySpec
+}{
+	{"GENERAL", []keySpec{
+		{":", "Command mode (Tab completes)"},
+		{"?", "This reference"},
+		{"q", "Quit"},
+		{"Tab / Shift-Tab", "Next / previous panel"},
+		{"h j k l", "Focus the panel left, below, above, right"},
+		{"Esc", "Leave the focused panel"},
+		{"y", "Copy the focused panel"},
+		{"z", "Do not disturb"},
+	}},
+	{"TASKS", []keySpec{
+		{"↑ / ↓", "Select a task (task list focused)"},
+		{"t", "Toggle the selected task"},
+		{"d", "Delete the selected task"},
+		{"p", "Cycle its priority"},
+		{"#", "Cycle the tag views"},
+		{"T", "Start / stop a pomodoro on it"},
+		{"Y", "Copy its text"},
+		{"n", "How to add a task"},
+	}},
+	{"VIEWS", []keySpec{
+		{"P", "Process table"},
+		{"N", "Network interfaces"},
+		{"K", "Per-core CPU bars"},
+		{"R", "Memory breakdown"},
+		{"f", "File browser"},
+		{"u", "Disk usage"},
+		{"m", "Notification center"},
+		{"L", "Notification scrollback"},
+	}},
+	{"WIDGETS", []keySpec{
+		{"F", "Start / stop a focus session"},
+		{"e / E", "Edit notes in place / in $EDITOR"},
+		{"c / C", "Select / copy a clipboard entry"},
+		{"g / G", "Select / check off a habit"},
+		{"+ / - / M", "Volume up, down, mute"},
+		{"< / >", "Brightness down, up"},
+		{"o", "Open assigned Jira issues, or the selected headline (news focused)"},
+		{"↑ / ↓", "Select a headline (news focused)"},
+	}},
+}
+
+// renderHelp draws the reference.
+func renderHelp(theme Theme, commands []*Command) string {
+	mainC := colorTag(theme.Main)
+	dimC := colorTag(theme.Dim)
+	brightC := colorTag(theme.Bright)
+
+	var sb strings.Builder
+	for _, group := range keyGroups {
+		sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n", brightC+"[::b]", group.Title))
+		for _, k := range group.Keys {
+			sb.WriteString(fmt.Sprintf("  %s%-16s %s%s[-:-:-]\n", brightC, tview.Escape(k.Keys), mainC, k.Summary))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("%sCOMMANDS[-:-:-]\n", brightC+"[::b]"))
+	for _, c := range commands {
+		name := c.Name
+		if len(c.Aliases) > 0 {
+			name += dimC + " (" + strings.Join(c.Aliases, ", ") + ")"
+		}
+		sb.WriteString(fmt.Sprintf("  %s%s %s%s[-:-:-]\n", brightC, name, mainC, c.Summary))
+		if c.Args != "" {
+			sb.WriteString(fmt.Sprintf("    %s%s %s[-:-:-]\n", dimC, c.Name, tview.Escape(c.Args)))
+		}
+	}
+	sb.WriteString(fmt.Sprintf("\n%s↑/↓ PgUp/PgDn scroll, Esc close[-:-:-]", dimC))
+	return sb.String()
+}
+
+// openHelp shows the reference. Must run on the UI goroutine.
+func (b *Baseline) openHelp() {
+	view := newPanel(" Help ")
+	view.SetBorderColor(b.theme.Bright)
+	view.SetTitleColor(b.theme.Bright)
+	view.SetTextColor(b.theme.Main)
+	view.SetText(renderHelp(b.theme, b.commands))
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape, event.Rune() == 'q', event.Rune() == '?':
+			b.closeOverlay("help")
+			return nil
+		case event.Rune() == 'j':
+			return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+		case event.Rune() == 'k':
+			return tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+		}
+		return event
+	})
+	b.showOverlay("help", view, 76, 40)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+)
+
+// --- History Graphs Widget ---
+//
+// Sparklines of the SystemHistory samples (the last historyLimit refreshes),
+// drawn with braille so two samples share a character cell.
+
+const historyGraphRows = 2 // Braille rows per graph, 4 levels each
+
+// Braille dot bits from the bottom of a cell up, per column
+var (
+	brailleLeft  = [4]rune{0x40, 0x04, 0x02, 0x01}
+	brailleRight = [4]rune{0x80, 0x20, 0x10, 0x08}
+)
+
+func historyGraphEnabled() bool {
+	v := strings.ToLower(os.Getenv("HISTORY_GRAPH"))
+	return v == "true" || v == "1" || v == "yes"
+}
+
+// brailleSparkline draws values scaled to maxValue as rows lines of braille,
+// top line first.
+func brailleSparkline(values []float64, maxValue float64, rows int) []string {
+	levels := make([]int, len(values))
+	for i, v := range values {
+		if maxValue > 0 {
+			levels[i] = int(math.Round(min(max(v/maxValue, 0), 1) * float64(rows*4)))
+		}
+		if v > 0 && levels[i] == 0 {
+			levels[i] = 1 // Keep activity visible
+		}
+	}
+	lines := make([]string, rows)
+	for r := 0; r < rows; r++ {
+		base := (rows - 1 - r) * 4 // Levels below this row
+		var sb strings.Builder
+		for i := 0; i < len(levels); i += 2 {
+			cell := rune(0x2800)
+			for dot := 0; dot < min(max(levels[i]-base, 0), 4); dot++ {
+				cell |= brailleLeft[dot]
+			}
+			if i+1 < len(levels) {
+				for dot := 0; dot < min(max(levels[i+1]-base, 0), 4); dot++ {
+					cell |= brailleRight[dot]
+				}
+			}
+			sb.WriteRune(cell)
+		}
+		lines[r] = sb.String()
+	}
+	return lines
+}
+
+// networkRates turns the cumulative byte counters into KB/s between samples.
+func networkRates(counters []uint64, timestamps []string, interval time.Duration) []float64 {
+	if len(counters) < 2 {
+		return nil
+	}
+	// Timestamps only line up when every sample had network counters
+	aligned := len(timestamps) == len(counters)
+	rates := make([]float64, 0, len(counters)-1)
+	for i := 1; i < len(counters); i++ {
+		secs := interval.Seconds()
+		if aligned {
+			prev, err1 := time.Parse("15:04:05", timestamps[i-1])
+			cur, err2 := time.Parse("15:04:05", timestamps[i])
+			if d := cur.Sub(prev).Seconds(); err1 == nil && err2 == nil && d > 0 {
+				secs = d
+			}
+		}
+		if counters[i] < counters[i-1] {
+			rates = append(rates, 0) // Counter reset (reboot, interface restart)
+			continue
+		}
+		rates = append(rates, float64(counters[i]-counters[i-1])/secs/1024)
+	}
+	return rates
+}
+
+func maxValue(values []float64) float64 {
+	m := 0.0
+	for _, v := range values {
+		m = max(m, v)
+	}
+	return m
+}
+
+func (b *Baseline) updateHistoryGraph() {
+	b.mu.RLock()
+	cpuHist := append([]float64(nil), b.systemHistory.CPU...)
+	memHist := append([]float64(nil), b.systemHistory.Memory...)
+	rxRates := networkRates(b.systemHistory.NetworkIn, b.systemHistory.Timestamps, b.intervals.System)
+	txRates := networkRates(b.systemHistory.NetworkOut, b.systemHistory.Timestamps, b.intervals.System)
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	graph := func(label string, values []float64, scale float64, current string) {
+		sb.WriteString(fmt.Sprintf("%s%s %s%s[-:-:-]\n", mainC, label, brightC, current))
+		if len(values) == 0 {
+			sb.WriteString(fmt.Sprintf("%sCollecting samples...[-:-:-]\n", dimC))
+			return
+		}
+		for _, line := range brailleSparkline(values, scale, historyGraphRows) {
+			sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n", brightC, line))
+		}
+	}
+	last := func(values []float64) float64 {
+		if len(values) == 0 {
+			return 0
+		}
+		return values[len(values)-1]
+	}
+
+	sb.WriteString(fmt.Sprintf("%sLAST %d SAMPLES[-:-:-]\n", brightC+"[::b]", historyLimit))
+	graph("CPU", cpuHist, 100, fmt.Sprintf("%.1f%%", last(cpuHist)))
+	graph("MEM", memHist, 100, fmt.Sprintf("%.1f%%", last(memHist)))
+	// Both directions share a scale so they compare at a glance
+	netScale := max(maxValue(rxRates), maxValue(txRates))
+	graph("NET ↓", rxRates, netScale, fmt.Sprintf("%.1f KB/s", last(rxRates)))
+	graph("NET ↑", txRates, netScale, fmt.Sprintf("%.1f KB/s", last(txRates)))
+	sb.WriteString(fmt.Sprintf("%sNet peak %.1f KB/s[-:-:-]", dimC, netScale))
+
+	b.setPanel(b.historyPanel, sb.String())
+}
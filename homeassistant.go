@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Home Assistant Entity Widget ---
+
+const haRefreshInterval = 30 * time.Second
+
+type HAEntity struct {
+	ID           string
+	Key          rune // Optional dashboard key that toggles the entity
+	FriendlyName string
+	State        string
+	Unit         string
+}
+
+type HAInfo struct {
+	Entities    []HAEntity
+	Error       string
+	LastUpdated time.Time
+}
+
+type HAClient struct {
+	baseURL  string
+	token    string
+	entities []HAEntity // Configured entities, in display order
+	client   http.Client
+}
+
+// newHAClientFromEnv returns nil unless HA_URL, HA_TOKEN and HA_ENTITIES are set.
+// HA_ENTITIES is a comma separated list; "switch.desk_lamp:l" binds key 'l' to toggle it.
+func newHAClientFromEnv() *HAClient {
+	baseURL := strings.TrimRight(os.Getenv("HA_URL"), "/")
+	token := os.Getenv("HA_TOKEN")
+	if baseURL == "" || token == "" {
+		return nil
+	}
+
+	var entities []HAEntity
+	for _, item := range strings.Split(os.Getenv("HA_ENTITIES"), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		ent := HAEntity{ID: item}
+		if id, key, ok := strings.Cut(item, ":"); ok && len([]rune(key)) == 1 {
+			ent.ID = id
+			ent.Key = []rune(key)[0]
+		}
+		entities = append(entities, ent)
+	}
+	if len(entities) == 0 {
+		return nil
+	}
+
+	return &HAClient{
+		baseURL:  baseURL,
+		token:    token,
+		entities: entities,
+		client:   http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (h *HAClient) do(method, path string, body interface{}, out interface{}) error {
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequest(method, h.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+h.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API error: Status %d", resp.StatusCode)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("JSON parse error: %w", err)
+		}
+	}
+	return nil
+}
+
+func (h *HAClient) states() ([]HAEntity, error) {
+	result := make([]HAEntity, 0, len(h.entities))
+	for _, ent := range h.entities {
+		var data struct {
+			State      string `json:"state"`
+			Attributes struct {
+				FriendlyName string `json:"friendly_name"`
+				Unit         string `json:"unit_of_measurement"`
+			} `json:"attributes"`
+		}
+		if err := h.do(http.MethodGet, "/api/states/"+url.PathEscape(ent.ID), nil, &data); err != nil {
+			return nil, fmt.Errorf("%s: %w", ent.ID, err)
+		}
+		ent.State = data.State
+		ent.FriendlyName = data.Attributes.FriendlyName
+		ent.Unit = data.Attributes.Unit
+		result = append(result, ent)
+	}
+	return result, nil
+}
+
+func (h *HAClient) toggle(entityID string) error {
+	return h.do(http.MethodPost, "/api/services/homeassistant/toggle", map[string]string{"entity_id": entityID}, nil)
+}
+
+// haToggleable reports whether the entity's domain supports homeassistant.toggle.
+func haToggleable(entityID string) bool {
+	domain, _, _ := strings.Cut(entityID, ".")
+	switch domain {
+	case "switch", "light", "fan", "input_boolean", "automation", "cover", "media_player":
+		return true
+	}
+	return false
+}
+
+func (b *Baseline) fetchHA() {
+	var info HAInfo
+	info.LastUpdated = time.Now()
+
+	entities, err := b.ha.states()
+	if err != nil {
+		info.Error = err.Error()
+	} else {
+		info.Entities = entities
+	}
+
+	b.mu.Lock()
+	prevErr := b.haInfo.Error
+	b.haInfo = info
+	b.mu.Unlock()
+
+	if info.Error != "" && info.Error != prevErr {
+		b.notify("ha", fmt.Sprintf("Home Assistant: %s", info.Error), "error")
+	}
+	b.updateHA()
+}
+
+func (b *Baseline) updateHA() {
+	b.mu.RLock()
+	info := b.haInfo
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sHOME ASSISTANT[-:-:-]\n", brightC+"[::b]"))
+
+	if info.Error != "" {
+		sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(info.Error)))
+	} else if info.LastUpdated.IsZero() {
+		sb.WriteString(fmt.Sprintf("%sLoading...[-:-:-]\n", dimC))
+	}
+
+	for i, ent := range info.Entities {
+		name := ent.FriendlyName
+		if name == "" {
+			name = ent.ID
+		}
+		stateC := mainC
+		switch ent.State {
+		case "on", "open", "playing":
+			stateC = brightC
+		case "off", "closed":
+			stateC = dimC
+		case "unavailable", "unknown":
+			stateC = "[red]"
+		}
+		key := ""
+		if ent.Key != 0 {
+			key = fmt.Sprintf(" (%c)", ent.Key)
+		}
+		sb.WriteString(fmt.Sprintf("%s%2d %s%s: %s%s%s%s%s[-:-:-]\n",
+			dimC, i+1,
+			mainC, tview.Escape(name),
+			stateC, tview.Escape(ent.State), tview.Escape(ent.Unit),
+			dimC, key,
+		))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%sLast updated: %s[-:-:-]", dimC, info.LastUpdated.Format("15:04:05")))
+
+	b.setPanel(b.haPanel, sb.String())
+}
+
+// toggleHA toggles the configured entity at the 1-based index and refreshes the panel.
+func (b *Baseline) toggleHA(index int) {
+	if index < 1 || index > len(b.ha.entities) {
+		b.notify("ha", fmt.Sprintf("Invalid entity index: %d", index), "error")
+		return
+	}
+	entityID := b.ha.entities[index-1].ID
+	if !haToggleable(entityID) {
+		b.notify("ha", fmt.Sprintf("%s cannot be toggled", entityID), "error")
+		return
+	}
+	if err := b.ha.toggle(entityID); err != nil {
+		b.notify("ha", fmt.Sprintf("Toggle %s failed: %v", entityID, err), "error")
+		return
+	}
+	b.notify("ha", fmt.Sprintf("Toggled %s", entityID), "success")
+	time.Sleep(500 * time.Millisecond) // Give HA a moment to report the new state
+	b.fetchHA()
+}
+
+// haEntityForKey returns the 1-based index of the entity bound to key, or 0.
+func (b *Baseline) haEntityForKey(key rune) int {
+	if b.ha == nil {
+		return 0
+	}
+	for i, ent := range b.ha.entities {
+		if ent.Key != 0 && ent.Key == key {
+			return i + 1
+		}
+	}
+	return 0
+}
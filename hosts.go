@@ -0,0 +1,376 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Remote Hosts ---
+//
+// `host add web1 user@web1:22` registers a machine to watch over SSH. Every
+// hostRefreshInterval each host runs a short shell script reading /proc
+// (Linux hosts) through the system ssh client, so keys, agents and
+// ~/.ssh/config apply as usual; BatchMode keeps ssh from ever prompting.
+// The Hosts panel shows CPU, memory and root disk use per host and marks
+// unreachable machines in red. Hosts are kept in ~/.baseline/hosts.json.
+
+const (
+	hostsFileName       = "hosts.json"
+	hostRefreshInterval = 30 * time.Second
+	hostTimeout         = 15 * time.Second
+	hostWarnPercent     = 90.0
+)
+
+// hostStatsScript prints two /proc/stat samples a second apart, the memory
+// totals, root filesystem use and the load average.
+const hostStatsScript = `head -n1 /proc/stat; sleep 1; head -n1 /proc/stat; ` +
+	`grep -E '^(MemTotal|MemAvailable):' /proc/meminfo; df -P / | tail -n1; cat /proc/loadavg`
+
+type RemoteHost struct {
+	Name   string `json:"name"`
+	Target string `json:"target"` // user@host[:port]
+}
+
+type HostStats struct {
+	CPU, Memory, Disk float64
+	Load              float64
+	Error             string // Why the host could not be read, "" when reachable
+	Checked           time.Time
+}
+
+func (s HostStats) down() bool {
+	return s.Error != ""
+}
+
+// sshArgs turns user@host[:port] into ssh arguments.
+func (h RemoteHost) sshArgs() []string {
+	args := []string{"-o", "BatchMode=yes", "-o", "ConnectTimeout=5"}
+	target := h.Target
+	if i := strings.LastIndex(target, ":"); i > 0 {
+		if _, err := strconv.Atoi(target[i+1:]); err == nil {
+			args = append(args, "-p", target[i+1:])
+			target = target[:i]
+		}
+	}
+	return append(args, "--", target, hostStatsScript) // A target is never an option
+}
+
+// pollHost runs the stats script on h.
+func pollHost(ctx context.Context, h RemoteHost) HostStats {
+	ctx, cancel := context.WithTimeout(ctx, hostTimeout)
+	defer cancel()
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "ssh", h.sshArgs()...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	stats := HostStats{Checked: time.Now()}
+	if err != nil {
+		stats.Error = sshError(err, stderr.String(), ctx.Err())
+		return stats
+	}
+	if err := stats.parse(string(out)); err != nil {
+		stats.Error = err.Error()
+	}
+	return stats
+}
+
+// sshError picks the most useful reason from a failed ssh run.
+func sshError(err error, stderr string, ctxErr error) string {
+	if errors.Is(ctxErr, context.DeadlineExceeded) {
+		return "timed out"
+	}
+	if errors.Is(err, exec.ErrNotFound) {
+		return "ssh not found"
+	}
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return strings.TrimPrefix(last, "ssh: ")
+	}
+	return err.Error()
+}
+
+// parse reads hostStatsScript's output.
+func (s *HostStats) parse(out string) error {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 6 {
+		return fmt.Errorf("unexpected output (not a Linux host?)")
+	}
+	busy1, total1 := procStatTimes(lines[0])
+	busy2, total2 := procStatTimes(lines[1])
+	if total2 > total1 {
+		s.CPU = (busy2 - busy1) / (total2 - total1) * 100
+	}
+
+	var memTotal, memAvail float64
+	for _, line := range lines[2:4] {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		kb, _ := strconv.ParseFloat(fields[1], 64)
+		switch fields[0] {
+		case "MemTotal:":
+			memTotal = kb
+		case "MemAvailable:":
+			memAvail = kb
+		}
+	}
+	if memTotal > 0 {
+		s.Memory = (memTotal - memAvail) / memTotal * 100
+	}
+
+	// Filesystem 1024-blocks Used Available Capacity Mounted-on
+	if fields := strings.Fields(lines[4]); len(fields) >= 5 {
+		s.Disk, _ = strconv.ParseFloat(strings.TrimSuffix(fields[4], "%"), 64)
+	}
+	if fields := strings.Fields(lines[5]); len(fields) > 0 {
+		s.Load, _ = strconv.ParseFloat(fields[0], 64)
+	}
+	return nil
+}
+
+// procStatTimes sums the jiffies of a /proc/stat "cpu" line into busy and
+// total time.
+func procStatTimes(line string) (busy, total float64) {
+	fields := strings.Fields(line)
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return 0, 0
+	}
+	for i, f := range fields[1:] {
+		v, _ := strconv.ParseFloat(f, 64)
+		total += v
+		if i != 3 && i != 4 { // idle, iowait
+			busy += v
+		}
+	}
+	return busy, total
+}
+
+func (b *Baseline) loadHosts() {
+	data, err := os.ReadFile(filepath.Join(b.configDir, hostsFileName))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			b.addNotification(fmt.Sprintf("Error loading %s: %v", hostsFileName, err), "error")
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &b.hosts); err != nil {
+		b.addNotification(fmt.Sprintf("Error parsing %s: %v", hostsFileName, err), "error")
+	}
+}
+
+// saveHosts writes the host list. Called with b.mu held.
+func (b *Baseline) saveHosts() {
+	data, err := json.MarshalIndent(b.hosts, "", "  ")
+	if err == nil {
+		err = os.WriteFile(filepath.Join(b.configDir, hostsFileName), data, 0640)
+	}
+	if err != nil {
+		b.addNotification(fmt.Sprintf("Error saving %s: %v", hostsFileName, err), "error")
+	}
+}
+
+// fetchHosts polls every host at once.
+func (b *Baseline) fetchHosts() {
+	b.mu.RLock()
+	hosts := append([]RemoteHost(nil), b.hosts...)
+	b.mu.RUnlock()
+	if len(hosts) == 0 {
+		return
+	}
+
+	results := make([]HostStats, len(hosts))
+	var wg sync.WaitGroup
+	for i, h := range hosts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = pollHost(b.life.ctx, h)
+		}()
+	}
+	wg.Wait()
+	if b.life.ctx.Err() != nil {
+		return // Quitting, the ssh runs were killed
+	}
+
+	b.mu.Lock()
+	if b.hostStats == nil {
+		b.hostStats = map[string]HostStats{}
+	}
+	for i, h := range hosts {
+		prev, seen := b.hostStats[h.Name]
+		stats := results[i]
+		if stats.down() && (!seen || !prev.down()) {
+			b.notifyPriority("hosts", fmt.Sprintf("Host unreachable: %s (%s)", h.Name, stats.Error), "error", PriorityHigh)
+		} else if !stats.down() && seen && prev.down() {
+			b.addNotification(fmt.Sprintf("Host reachable again: %s", h.Name), "success")
+		}
+		b.hostStats[h.Name] = stats
+	}
+	b.mu.Unlock()
+
+	b.updateHosts()
+}
+
+func (b *Baseline) updateHosts() {
+	b.mu.RLock()
+	hosts := append([]RemoteHost(nil), b.hosts...)
+	stats := make(map[string]HostStats, len(b.hostStats))
+	for name, s := range b.hostStats {
+		stats[name] = s
+	}
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+	percent := func(v float64) string {
+		c := brightC
+		if v >= hostWarnPercent {
+			c = "[red::b]"
+		}
+		return fmt.Sprintf("%s%3.0f%%", c, v)
+	}
+
+	var sb strings.Builder
+	down := 0
+	var checked time.Time
+	for _, h := range hosts {
+		if s, ok := stats[h.Name]; ok {
+			if s.down() {
+				down++
+			}
+			if s.Checked.After(checked) {
+				checked = s.Checked
+			}
+		}
+	}
+	title := "HOSTS"
+	if down > 0 {
+		title += fmt.Sprintf(" [red](%d down)", down)
+	}
+	sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n", brightC+"[::b]", title))
+	sb.WriteString(fmt.Sprintf("%s   %-10s %4s %4s %4s %5s[-:-:-]\n", dimC, "", "CPU", "MEM", "DISK", "LOAD"))
+
+	for i, h := range hosts {
+		name := tview.Escape(h.Name)
+		s, ok := stats[h.Name]
+		switch {
+		case !ok:
+			sb.WriteString(fmt.Sprintf("%s%2d %s%-10s %sconnecting...[-:-:-]\n", dimC, i+1, mainC, name, dimC))
+		case s.down():
+			sb.WriteString(fmt.Sprintf("%s%2d [red::b]✗ %-8s [red]%s[-:-:-]\n", dimC, i+1, name, tview.Escape(s.Error)))
+		default:
+			sb.WriteString(fmt.Sprintf("%s%2d %s%-10s %s %s %s %s%5.2f[-:-:-]\n", dimC, i+1, mainC, name, percent(s.CPU), percent(s.Memory), percent(s.Disk), mainC, s.Load))
+		}
+	}
+
+	if !checked.IsZero() {
+		sb.WriteString(fmt.Sprintf("\n%sLast updated: %s[-:-:-]", dimC, checked.Format("15:04:05")))
+	}
+
+	b.app.QueueUpdate(func() {
+		if b.hostsPanel != nil { // Gone once the last host is removed
+			b.setPanel(b.hostsPanel, sb.String())
+		}
+	})
+}
+
+// hostCommand handles "host add <name> <user@host[:port]>", "host remove
+// <name|index>" and "host refresh". rawArgs keeps the case of host names.
+// Called with b.mu held.
+func (b *Baseline) hostCommand(rawArgs []string) {
+	const usage = "Usage: host add <name> <user@host[:port]> | remove <name|index> | refresh"
+	if len(rawArgs) == 0 {
+		b.addNotification(usage, "error")
+		return
+	}
+	switch strings.ToLower(rawArgs[0]) {
+	case "add":
+		if len(rawArgs) != 3 {
+			break
+		}
+		name, target := rawArgs[1], rawArgs[2]
+		if b.hostIndex(name) >= 0 {
+			b.addNotification(fmt.Sprintf("Host %s already exists", name), "error")
+			return
+		}
+		if strings.HasPrefix(target, "-") {
+			b.addNotification(fmt.Sprintf("Invalid host %s (want user@host[:port])", target), "error")
+			return
+		}
+		b.hosts = append(b.hosts, RemoteHost{Name: name, Target: target})
+		b.saveHosts()
+		b.addNotification(fmt.Sprintf("Watching %s (%s)", name, target), "success")
+		b.hostsChanged()
+		return
+	case "remove", "rm":
+		if len(rawArgs) != 2 {
+			break
+		}
+		i := b.hostIndex(rawArgs[1])
+		if i < 0 {
+			b.addNotification(fmt.Sprintf("No host %s", rawArgs[1]), "error")
+			return
+		}
+		name := b.hosts[i].Name
+		b.hosts = append(b.hosts[:i], b.hosts[i+1:]...)
+		delete(b.hostStats, name)
+		b.saveHosts()
+		b.addNotification(fmt.Sprintf("Stopped watching %s", name), "success")
+		b.hostsChanged()
+		return
+	case "refresh":
+		go b.fetchHosts()
+		return
+	}
+	b.addNotification(usage, "error")
+}
+
+// hostIndex finds a host by name or 1-based index, -1 if there is none.
+// Called with b.mu held.
+func (b *Baseline) hostIndex(arg string) int {
+	if index, err := strconv.Atoi(arg); err == nil {
+		if index >= 1 && index <= len(b.hosts) {
+			return index - 1
+		}
+		return -1
+	}
+	for i, h := range b.hosts {
+		if strings.EqualFold(h.Name, arg) {
+			return i
+		}
+	}
+	return -1
+}
+
+// hostsChanged shows the Hosts panel when the first host is added, removes it
+// with the last and polls the new list. Called with b.mu held.
+func (b *Baseline) hostsChanged() {
+	empty := len(b.hosts) == 0
+	b.life.Go(func() {
+		b.app.QueueUpdateDraw(func() {
+			switch {
+			case !empty && b.hostsPanel == nil:
+				b.hostsPanel = b.addRuntimePanel(" Hosts ", b.updateHosts)
+			case empty && b.hostsPanel != nil:
+				b.removeRuntimePanel(b.hostsPanel)
+				b.hostsPanel = nil
+			}
+		})
+		b.updateHosts()
+		b.fetchHosts()
+	})
+}
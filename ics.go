@@ -0,0 +1,446 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- ICS Calendars ---
+//
+// CALENDAR_ICS is a comma separated list of .ics files or URLs (http, https
+// or webcal), e.g. the "secret address in iCal format" of a Google calendar
+// or a published Outlook calendar. Each entry is read again on every calendar
+// refresh. Recurring events are expanded for FREQ=DAILY, WEEKLY (with BYDAY),
+// MONTHLY and YEARLY, honouring INTERVAL, COUNT, UNTIL, EXDATE and moved
+// instances (RECURRENCE-ID). Other BY* rules are ignored.
+
+const (
+	icsMaxBytes       = 10 << 20 // Larger feeds are cut off
+	icsMaxOccurrences = 100000   // Guards against runaway rules
+)
+
+// ICSSource reads events from one iCalendar file or URL.
+type ICSSource struct {
+	location string // Path or URL
+	name     string
+	client   http.Client
+}
+
+// icsSourcesFromEnv returns one source per CALENDAR_ICS entry.
+func icsSourcesFromEnv() []*ICSSource {
+	var sources []*ICSSource
+	for _, loc := range strings.Split(os.Getenv("CALENDAR_ICS"), ",") {
+		loc = strings.TrimSpace(loc)
+		if loc == "" {
+			continue
+		}
+		src := &ICSSource{location: loc, client: http.Client{Timeout: 15 * time.Second}}
+		if u, err := url.Parse(loc); err == nil && u.Host != "" {
+			if u.Scheme == "webcal" {
+				u.Scheme = "https"
+				src.location = u.String()
+			}
+			src.name = u.Host
+		} else {
+			if strings.HasPrefix(loc, "~") {
+				if home, err := os.UserHomeDir(); err == nil {
+					src.location = filepath.Join(home, loc[1:])
+				}
+			}
+			src.name = strings.TrimSuffix(filepath.Base(loc), filepath.Ext(loc))
+		}
+		sources = append(sources, src)
+	}
+	return sources
+}
+
+func (s *ICSSource) Name() string { return s.name }
+
+// open returns the calendar data, from disk or over HTTP.
+func (s *ICSSource) open() (io.ReadCloser, error) {
+	if !strings.HasPrefix(s.location, "http://") && !strings.HasPrefix(s.location, "https://") {
+		return os.Open(s.location)
+	}
+	resp, err := s.client.Get(s.location)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("HTTP error: Status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+func (s *ICSSource) Upcoming(from, to time.Time) ([]CalendarEvent, error) {
+	r, err := s.open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	vevents, err := parseICS(io.LimitReader(r, icsMaxBytes))
+	if err != nil {
+		return nil, err
+	}
+	events := expandICSEvents(vevents, from, to)
+	for i := range events {
+		events[i].Source = s.name
+	}
+	return events, nil
+}
+
+// icsProp is one content line: NAME;PARAM=VALUE:value.
+type icsProp struct {
+	Params map[string]string
+	Value  string
+}
+
+// icsEvent is a VEVENT with the properties that matter here.
+type icsEvent struct {
+	UID          string
+	Summary      string
+	Location     string
+	Status       string
+	Start        time.Time
+	End          time.Time
+	Duration     time.Duration
+	HasEnd       bool
+	AllDay       bool
+	RRule        string
+	ExDates      []time.Time
+	RecurrenceID time.Time
+}
+
+// parseICS reads the VEVENTs of a calendar. Nested components (VALARM) and
+// anything outside a VEVENT are skipped.
+func parseICS(r io.Reader) ([]icsEvent, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	// Unfold continuation lines (RFC 5545 3.1) as they are read
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(strings.TrimSpace(lines[0]), "BEGIN:VCALENDAR") {
+		return nil, fmt.Errorf("not an iCalendar file")
+	}
+
+	var events []icsEvent
+	var cur *icsEvent
+	depth := 0 // Components nested inside the current VEVENT
+	for _, line := range lines {
+		name, prop, ok := parseICSLine(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(prop.Value, "VEVENT") && cur == nil:
+			cur = &icsEvent{}
+			continue
+		case name == "BEGIN" && cur != nil:
+			depth++
+			continue
+		case name == "END" && cur != nil && depth > 0:
+			depth--
+			continue
+		case name == "END" && cur != nil:
+			if !cur.Start.IsZero() {
+				events = append(events, *cur)
+			}
+			cur = nil
+			continue
+		}
+		if cur == nil || depth > 0 {
+			continue
+		}
+		switch name {
+		case "UID":
+			cur.UID = prop.Value
+		case "SUMMARY":
+			cur.Summary = icsUnescape(prop.Value)
+		case "LOCATION":
+			cur.Location = icsUnescape(prop.Value)
+		case "STATUS":
+			cur.Status = strings.ToUpper(prop.Value)
+		case "DTSTART":
+			cur.Start, cur.AllDay, _ = parseICSTime(prop)
+		case "DTEND":
+			if t, _, err := parseICSTime(prop); err == nil {
+				cur.End, cur.HasEnd = t, true
+			}
+		case "DURATION":
+			if d, err := parseICSDuration(prop.Value); err == nil {
+				cur.Duration, cur.HasEnd = d, true
+			}
+		case "RRULE":
+			cur.RRule = prop.Value
+		case "EXDATE":
+			for _, v := range strings.Split(prop.Value, ",") {
+				if t, _, err := parseICSTime(icsProp{Params: prop.Params, Value: v}); err == nil {
+					cur.ExDates = append(cur.ExDates, t)
+				}
+			}
+		case "RECURRENCE-ID":
+			cur.RecurrenceID, _, _ = parseICSTime(prop)
+		}
+	}
+	return events, nil
+}
+
+// parseICSLine splits a content line into its upper-cased name, parameters
+// and value. Colons inside quoted parameter values do not end the name.
+func parseICSLine(line string) (string, icsProp, bool) {
+	quoted := false
+	for i, c := range line {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == ':' && !quoted:
+			parts := strings.Split(line[:i], ";")
+			prop := icsProp{Params: map[string]string{}, Value: line[i+1:]}
+			for _, p := range parts[1:] {
+				if k, v, ok := strings.Cut(p, "="); ok {
+					prop.Params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+				}
+			}
+			return strings.ToUpper(parts[0]), prop, true
+		}
+	}
+	return "", icsProp{}, false
+}
+
+func icsUnescape(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
+
+// parseICSTime reads a DATE or DATE-TIME value. UTC values end in Z, TZID
+// names an IANA zone; anything else (floating times, Windows zone names) is
+// taken as local time.
+func parseICSTime(prop icsProp) (time.Time, bool, error) {
+	v := strings.TrimSpace(prop.Value)
+	if prop.Params["VALUE"] == "DATE" || len(v) == 8 {
+		t, err := time.ParseInLocation("20060102", v, time.Local)
+		return t, true, err
+	}
+	if strings.HasSuffix(v, "Z") {
+		t, err := time.Parse("20060102T150405Z", v)
+		return t, false, err
+	}
+	loc := time.Local
+	if tzid := prop.Params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", v, loc)
+	return t, false, err
+}
+
+// parseICSDuration reads durations like "PT1H30M", "P1D" or "-PT15M".
+func parseICSDuration(s string) (time.Duration, error) {
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+	}
+	s = strings.TrimLeft(s, "+-")
+	if !strings.HasPrefix(s, "P") {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	var d time.Duration
+	num := ""
+	for _, c := range s[1:] {
+		if c >= '0' && c <= '9' {
+			num += string(c)
+			continue
+		}
+		if c == 'T' {
+			continue
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		num = ""
+		switch c {
+		case 'W':
+			d += time.Duration(n) * 7 * 24 * time.Hour
+		case 'D':
+			d += time.Duration(n) * 24 * time.Hour
+		case 'H':
+			d += time.Duration(n) * time.Hour
+		case 'M':
+			d += time.Duration(n) * time.Minute
+		case 'S':
+			d += time.Duration(n) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+	}
+	return sign * d, nil
+}
+
+// length is how long each occurrence of the event lasts.
+func (e icsEvent) length() time.Duration {
+	switch {
+	case !e.End.IsZero():
+		return e.End.Sub(e.Start)
+	case e.HasEnd:
+		return e.Duration
+	case e.AllDay:
+		return 24 * time.Hour
+	}
+	return 0
+}
+
+// expandICSEvents turns VEVENTs into the occurrences overlapping [from, to].
+func expandICSEvents(vevents []icsEvent, from, to time.Time) []CalendarEvent {
+	// Instances moved or cancelled through RECURRENCE-ID replace the generated ones
+	moved := map[string]bool{}
+	for _, e := range vevents {
+		if !e.RecurrenceID.IsZero() {
+			moved[e.UID+"|"+e.RecurrenceID.UTC().Format(time.RFC3339)] = true
+		}
+	}
+
+	var events []CalendarEvent
+	for _, e := range vevents {
+		if e.Status == "CANCELLED" {
+			continue
+		}
+		length := e.length()
+		starts := []time.Time{e.Start}
+		if e.RRule != "" && e.RecurrenceID.IsZero() {
+			starts = icsOccurrences(e, from, to)
+		}
+		for _, start := range starts {
+			if e.RecurrenceID.IsZero() && moved[e.UID+"|"+start.UTC().Format(time.RFC3339)] {
+				continue
+			}
+			end := start.Add(length)
+			if !end.After(from) && start.Before(from) || !start.Before(to) {
+				continue
+			}
+			events = append(events, CalendarEvent{
+				Title:    e.Summary,
+				Start:    start,
+				End:      end,
+				Location: e.Location,
+				AllDay:   e.AllDay,
+			})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+	return events
+}
+
+// icsOccurrences lists the start times of a recurring event that are still
+// running at from or start before limit.
+func icsOccurrences(e icsEvent, from, limit time.Time) []time.Time {
+	rule := map[string]string{}
+	for _, part := range strings.Split(e.RRule, ";") {
+		if k, v, ok := strings.Cut(part, "="); ok {
+			rule[strings.ToUpper(k)] = strings.ToUpper(v)
+		}
+	}
+	interval, err := strconv.Atoi(rule["INTERVAL"])
+	if err != nil || interval < 1 {
+		interval = 1
+	}
+	count, _ := strconv.Atoi(rule["COUNT"])
+	if until := rule["UNTIL"]; until != "" {
+		if t, _, err := parseICSTime(icsProp{Value: until, Params: map[string]string{"TZID": e.Start.Location().String()}}); err == nil && t.Before(limit) {
+			if len(until) == 8 {
+				t = t.AddDate(0, 0, 1).Add(-time.Second) // A date includes the whole day
+			}
+			limit = t.Add(time.Second)
+		}
+	}
+	excluded := map[int64]bool{}
+	for _, t := range e.ExDates {
+		excluded[t.Unix()] = true
+	}
+
+	// Weekly rules with BYDAY produce several days per week
+	var weekdays []int // Days after the Monday of the week
+	if rule["FREQ"] == "WEEKLY" {
+		for _, day := range strings.Split(rule["BYDAY"], ",") {
+			if wd, ok := icsWeekdays[strings.TrimLeft(day, "+-0123456789")]; ok {
+				weekdays = append(weekdays, (int(wd)+6)%7)
+			}
+		}
+		sort.Ints(weekdays)
+	}
+
+	var starts []time.Time
+	generated := 0
+	start := e.Start
+	for period := 0; generated < icsMaxOccurrences; period++ {
+		var candidates []time.Time
+		switch rule["FREQ"] {
+		case "DAILY":
+			candidates = []time.Time{start.AddDate(0, 0, period*interval)}
+		case "WEEKLY":
+			if len(weekdays) == 0 {
+				candidates = []time.Time{start.AddDate(0, 0, 7*period*interval)}
+				break
+			}
+			monday := start.AddDate(0, 0, 7*period*interval-(int(start.Weekday())+6)%7)
+			for _, wd := range weekdays {
+				candidates = append(candidates, monday.AddDate(0, 0, wd))
+			}
+		case "MONTHLY":
+			if t := start.AddDate(0, period*interval, 0); t.Day() == start.Day() {
+				candidates = []time.Time{t} // Months without that day are skipped
+			}
+		case "YEARLY":
+			if t := start.AddDate(period*interval, 0, 0); t.Day() == start.Day() {
+				candidates = []time.Time{t}
+			}
+		default:
+			return []time.Time{start} // Unsupported frequency, show the first instance only
+		}
+		if len(candidates) > 0 && !candidates[0].Before(limit) {
+			break
+		}
+		for _, t := range candidates {
+			if t.Before(start) || !t.Before(limit) {
+				continue
+			}
+			if count > 0 && generated >= count {
+				return starts
+			}
+			generated++
+			if !excluded[t.Unix()] && !t.Add(e.length()).Before(from) {
+				starts = append(starts, t)
+			}
+		}
+		if period > icsMaxOccurrences {
+			break // Only skipped periods (e.g. the 31st), nothing more to find
+		}
+	}
+	return starts
+}
+
+var icsWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
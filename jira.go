@@ -0,0 +1,229 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Jira Issues Widget ---
+
+const (
+	jiraRefreshInterval = 5 * time.Minute
+	jiraMaxIssues       = 20
+	jiraDefaultJQL      = "assignee = currentUser() AND resolution = Unresolved ORDER BY updated DESC"
+)
+
+type JiraIssue struct {
+	Key     string
+	Summary string
+	Status  string
+}
+
+type JiraInfo struct {
+	Issues      []JiraIssue
+	Error       string
+	LastUpdated time.Time
+}
+
+// JiraClient holds the connection settings. Jira Cloud authenticates with
+// email + API token (basic auth), Jira Server/Data Center with a personal access token.
+type JiraClient struct {
+	baseURL string
+	email   string
+	token   string
+	jql     string
+	client  http.Client
+}
+
+// newJiraClientFromEnv returns nil when JIRA_URL or JIRA_TOKEN is unset.
+func newJiraClientFromEnv() *JiraClient {
+	baseURL := strings.TrimRight(os.Getenv("JIRA_URL"), "/")
+	token := os.Getenv("JIRA_TOKEN")
+	if baseURL == "" || token == "" {
+		return nil
+	}
+	jql := os.Getenv("JIRA_JQL")
+	if jql == "" {
+		jql = jiraDefaultJQL
+	}
+	return &JiraClient{
+		baseURL: baseURL,
+		email:   os.Getenv("JIRA_EMAIL"),
+		token:   token,
+		jql:     jql,
+		client:  http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// browseURL links to a single issue, or to the configured search when key is empty.
+func (j *JiraClient) browseURL(key string) string {
+	if key == "" {
+		return fmt.Sprintf("%s/issues/?jql=%s", j.baseURL, url.QueryEscape(j.jql))
+	}
+	return fmt.Sprintf("%s/browse/%s", j.baseURL, url.PathEscape(key))
+}
+
+func (j *JiraClient) search() ([]JiraIssue, error) {
+	query := url.Values{}
+	query.Set("jql", j.jql)
+	query.Set("fields", "summary,status")
+	query.Set("maxResults", fmt.Sprint(jiraMaxIssues))
+
+	// Jira Cloud (email set) only serves the newer search endpoint
+	endpoint := "/rest/api/2/search"
+	if j.email != "" {
+		endpoint = "/rest/api/3/search/jql"
+	}
+
+	req, err := http.NewRequest(http.MethodGet, j.baseURL+endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if j.email != "" {
+		req.SetBasicAuth(j.email, j.token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+j.token)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			ErrorMessages []string `json:"errorMessages"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&errResp) == nil && len(errResp.ErrorMessages) > 0 {
+			return nil, fmt.Errorf("API error: %s (%d)", errResp.ErrorMessages[0], resp.StatusCode)
+		}
+		return nil, fmt.Errorf("API error: Status %d", resp.StatusCode)
+	}
+
+	var data struct {
+		Issues []struct {
+			Key    string `json:"key"`
+			Fields struct {
+				Summary string `json:"summary"`
+				Status  struct {
+					Name string `json:"name"`
+				} `json:"status"`
+			} `json:"fields"`
+		} `json:"issues"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("JSON parse error: %w", err)
+	}
+
+	issues := make([]JiraIssue, 0, len(data.Issues))
+	for _, is := range data.Issues {
+		issues = append(issues, JiraIssue{Key: is.Key, Summary: is.Fields.Summary, Status: is.Fields.Status.Name})
+	}
+	return issues, nil
+}
+
+func (b *Baseline) fetchJira() {
+	var info JiraInfo
+	info.LastUpdated = time.Now()
+
+	issues, err := b.jira.search()
+	if err != nil {
+		info.Error = err.Error()
+	} else {
+		info.Issues = issues
+	}
+
+	b.mu.Lock()
+	prevErr := b.jiraInfo.Error
+	b.jiraInfo = info
+	b.mu.Unlock()
+
+	if info.Error != "" && info.Error != prevErr {
+		b.notify("jira", fmt.Sprintf("Jira: %s", info.Error), "error")
+	}
+	b.updateJira()
+}
+
+func (b *Baseline) updateJira() {
+	b.mu.RLock()
+	info := b.jiraInfo
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sASSIGNED ISSUES[-:-:-]\n", brightC+"[::b]"))
+
+	if info.Error != "" {
+		sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(info.Error)))
+	} else if info.LastUpdated.IsZero() {
+		sb.WriteString(fmt.Sprintf("%sLoading...[-:-:-]\n", dimC))
+	} else if len(info.Issues) == 0 {
+		sb.WriteString(fmt.Sprintf("%s(Nothing assigned)[-:-:-]\n", dimC))
+	} else {
+		// Counts per status, most common first
+		counts := map[string]int{}
+		for _, is := range info.Issues {
+			counts[is.Status]++
+		}
+		statuses := make([]string, 0, len(counts))
+		for st := range counts {
+			statuses = append(statuses, st)
+		}
+		sort.Slice(statuses, func(i, j int) bool {
+			if counts[statuses[i]] != counts[statuses[j]] {
+				return counts[statuses[i]] > counts[statuses[j]]
+			}
+			return statuses[i] < statuses[j]
+		})
+		parts := make([]string, 0, len(statuses))
+		for _, st := range statuses {
+			parts = append(parts, fmt.Sprintf("%s %d", st, counts[st]))
+		}
+		sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n\n", dimC, tview.Escape(strings.Join(parts, " · "))))
+
+		for i, is := range info.Issues {
+			sb.WriteString(fmt.Sprintf("%s%2d %s%s %s%s %s(%s)[-:-:-]\n",
+				dimC, i+1,
+				brightC, is.Key,
+				mainC, tview.Escape(is.Summary),
+				dimC, tview.Escape(is.Status),
+			))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%sPress 'o' to open in browser. Last updated: %s[-:-:-]", dimC, info.LastUpdated.Format("15:04:05")))
+
+	b.setPanel(b.jiraPanel, sb.String())
+}
+
+// openJira opens the issue at the 1-based index, or the search view when index is 0.
+func (b *Baseline) openJira(index int) {
+	b.mu.RLock()
+	issues := b.jiraInfo.Issues
+	b.mu.RUnlock()
+
+	key := ""
+	if index > 0 {
+		if index > len(issues) {
+			b.notify("jira", fmt.Sprintf("Invalid issue index: %d", index), "error")
+			return
+		}
+		key = issues[index-1].Key
+	}
+	if err := openBrowser(b.jira.browseURL(key)); err != nil {
+		b.notify("jira", err.Error(), "error")
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- Daily Journal ---
+//
+// One Markdown file per day under ~/.baseline/journal. `journal add` appends a
+// timestamped line; the end-of-day summary lists the day's completed todos.
+
+const (
+	journalDateFormat      = "2006-01-02"
+	journalSummaryHeading  = "## Summary"
+	journalSummaryInterval = 1 * time.Minute
+)
+
+// completionTime returns now for a todo that was just completed, nil otherwise.
+func completionTime(done bool) *time.Time {
+	if !done {
+		return nil
+	}
+	now := time.Now()
+	return &now
+}
+
+func (b *Baseline) journalPath(day time.Time) string {
+	return filepath.Join(b.configDir, "journal", day.Format(journalDateFormat)+".md")
+}
+
+// appendJournal adds text to the day's file, creating it with a heading.
+func (b *Baseline) appendJournal(day time.Time, text string) error {
+	path := b.journalPath(day)
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	_, statErr := os.Stat(path)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if os.IsNotExist(statErr) {
+		text = fmt.Sprintf("# %s\n\n%s", day.Format("Monday, 2 January 2006"), text)
+	}
+	_, err = f.WriteString(text)
+	return err
+}
+
+// journalSummary builds the end-of-day section. Called with b.mu held.
+func (b *Baseline) journalSummary(day time.Time) string {
+	var sb strings.Builder
+	sb.WriteString("\n" + journalSummaryHeading + "\n\n")
+
+	if b.journalTodos {
+		y, m, d := day.Date()
+		var done []string
+		for _, item := range b.todoItems {
+			if item.CompletedAt == nil {
+				continue
+			}
+			if cy, cm, cd := item.CompletedAt.In(day.Location()).Date(); cy == y && cm == m && cd == d {
+				done = append(done, item.Text)
+			}
+		}
+		if len(done) == 0 {
+			sb.WriteString("No todos completed.\n")
+		} else {
+			sb.WriteString("Completed todos:\n\n")
+			for _, text := range done {
+				sb.WriteString("- [x] " + text + "\n")
+			}
+		}
+	}
+
+	open := 0
+	for _, item := range b.todoItems {
+		if !item.Done {
+			open++
+		}
+	}
+	sb.WriteString(fmt.Sprintf("\n%d todos still open.\n", open))
+	return sb.String()
+}
+
+// hasJournalSummary reports whether the day's summary was already written.
+func (b *Baseline) hasJournalSummary(day time.Time) bool {
+	data, err := os.ReadFile(b.journalPath(day))
+	return err == nil && strings.Contains(string(data), journalSummaryHeading)
+}
+
+// writeJournalSummary appends today's summary. Called with b.mu held.
+func (b *Baseline) writeJournalSummary() {
+	now := time.Now()
+	if b.hasJournalSummary(now) {
+		b.notify("journal", "Today's journal summary is already written", "info")
+		return
+	}
+	if err := b.appendJournal(now, b.journalSummary(now)); err != nil {
+		b.notify("journal", fmt.Sprintf("Error writing journal: %v", err), "error")
+		return
+	}
+	b.notify("journal", "End-of-day summary added to the journal", "success")
+}
+
+// checkJournalSummary writes the summary once JOURNAL_SUMMARY_TIME has passed.
+func (b *Baseline) checkJournalSummary() {
+	now := time.Now()
+	at := time.Date(now.Year(), now.Month(), now.Day(), b.journalAt.Hour(), b.journalAt.Minute(), 0, 0, now.Location())
+	if now.Before(at) || b.hasJournalSummary(now) {
+		return
+	}
+	b.mu.Lock()
+	b.writeJournalSummary()
+	b.mu.Unlock()
+}
+
+// journalSummaryTimeFromEnv parses JOURNAL_SUMMARY_TIME ("18:00"); zero when unset.
+func journalSummaryTimeFromEnv() time.Time {
+	t, err := time.Parse("15:04", os.Getenv("JOURNAL_SUMMARY_TIME"))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// openJournal shows the journal for day in an overlay; ←/→ move between days.
+// Must run on the UI goroutine.
+func (b *Baseline) openJournal(day time.Time) {
+	view := newPanel(" Journal ")
+	view.SetBorderColor(b.theme.Bright)
+	view.SetTitleColor(b.theme.Bright)
+	view.SetTextColor(b.theme.Main)
+
+	render := func() {
+		mainC := colorTag(b.theme.Main)
+		dimC := colorTag(b.theme.Dim)
+		brightC := colorTag(b.theme.Bright)
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("%s←/→ previous/next day  Esc close[-:-:-]\n\n", dimC))
+		data, err := os.ReadFile(b.journalPath(day))
+		if err != nil {
+			sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n\n", brightC+"[::b]", day.Format("Monday, 2 January 2006")))
+			sb.WriteString(fmt.Sprintf("%s(No entries)[-:-:-]\n", dimC))
+		} else {
+			for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+				lineC := mainC
+				if strings.HasPrefix(line, "#") {
+					lineC = brightC + "[::b]"
+				}
+				sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n", lineC, tview.Escape(line)))
+			}
+		}
+		view.SetText(sb.String())
+		view.ScrollToBeginning()
+	}
+	render()
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			b.closeOverlay("journal")
+			return nil
+		case tcell.KeyLeft:
+			day = day.AddDate(0, 0, -1)
+			render()
+			return nil
+		case tcell.KeyRight:
+			day = day.AddDate(0, 0, 1)
+			render()
+			return nil
+		}
+		if event.Rune() == 'q' {
+			b.closeOverlay("journal")
+			return nil
+		}
+		return event
+	})
+
+	b.showOverlay("journal", view, 80, 30)
+}
+
+// journalCommand handles "journal add <text>", "journal summary" and
+// "journal [today|yesterday|YYYY-MM-DD]". Called from processCommand with b.mu held.
+func (b *Baseline) journalCommand(rawArgs []string) {
+	sub := ""
+	if len(rawArgs) > 0 {
+		sub = strings.ToLower(rawArgs[0])
+	}
+	now := time.Now()
+	switch sub {
+	case "add":
+		text := strings.Trim(strings.Join(rawArgs[1:], " "), `"`)
+		if text == "" {
+			b.addNotification("Usage: journal add <text>", "error")
+			return
+		}
+		if err := b.appendJournal(now, fmt.Sprintf("- %s %s\n", now.Format("15:04"), text)); err != nil {
+			b.addNotification(fmt.Sprintf("Error writing journal: %v", err), "error")
+			return
+		}
+		b.addNotification("Journal entry added", "success")
+	case "summary":
+		b.writeJournalSummary()
+	case "", "today":
+		go b.app.QueueUpdateDraw(func() { b.openJournal(now) })
+	case "yesterday":
+		go b.app.QueueUpdateDraw(func() { b.openJournal(now.AddDate(0, 0, -1)) })
+	default:
+		day, err := time.ParseInLocation(journalDateFormat, sub, time.Local)
+		if err != nil {
+			b.addNotification("Usage: journal [add <text>|summary|today|yesterday|YYYY-MM-DD]", "error")
+			return
+		}
+		go b.app.QueueUpdateDraw(func() { b.openJournal(day) })
+	}
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	stdnet "net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- LAN Device Discovery Widget ---
+
+const lanRefreshInterval = 1 * time.Minute
+
+// Locations of IEEE OUI listings shipped by common distros (ieee-data, nmap)
+var ouiDatabases = []string{
+	"/usr/share/ieee-data/oui.txt",
+	"/usr/share/misc/oui.txt",
+	"/usr/share/nmap/nmap-mac-prefixes",
+}
+
+// Fallback vendors for when no OUI database is installed
+var builtinOUI = map[string]string{
+	"B827EB": "Raspberry Pi", "DCA632": "Raspberry Pi", "E45F01": "Raspberry Pi", "2CCF67": "Raspberry Pi",
+	"001A11": "Google", "F4F5D8": "Google", "3C5AB4": "Google",
+	"F0189B": "Apple", "A4C361": "Apple", "3C0754": "Apple", "ACBC32": "Apple",
+	"FCFBFB": "Cisco", "00000C": "Cisco",
+	"44650D": "Amazon", "F0272D": "Amazon", "74C246": "Amazon",
+	"000C29": "VMware", "005056": "VMware", "080027": "VirtualBox", "525400": "QEMU",
+	"001132": "Synology", "B0BE76": "TP-Link", "50C7BF": "TP-Link", "ECFABC": "Espressif", "240AC4": "Espressif",
+	"D8EB46": "Google Nest", "18B430": "Nest Labs", "B4E62D": "Sonos",
+}
+
+var arpLinePattern = regexp.MustCompile(`\(([0-9.]+)\) at ([0-9a-fA-F:-]+)`)
+
+type LANDevice struct {
+	IP        string    `json:"ip"`
+	MAC       string    `json:"mac"`
+	Hostname  string    `json:"hostname"`
+	Vendor    string    `json:"vendor"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// lanScanEnabled reports whether LAN_SCAN is switched on.
+func lanScanEnabled() bool {
+	v := strings.ToLower(os.Getenv("LAN_SCAN"))
+	return v == "true" || v == "1" || v == "yes"
+}
+
+// readARPTable returns IP -> MAC pairs known to the OS neighbour cache.
+func readARPTable() (map[string]string, error) {
+	entries := map[string]string{}
+
+	if runtime.GOOS == "linux" {
+		f, err := os.Open("/proc/net/arp")
+		if err == nil {
+			defer f.Close()
+			scanner := bufio.NewScanner(f)
+			scanner.Scan() // Header
+			for scanner.Scan() {
+				fields := strings.Fields(scanner.Text())
+				// IP address, HW type, Flags, HW address, Mask, Device
+				if len(fields) >= 4 && fields[2] != "0x0" && fields[3] != "00:00:00:00:00:00" {
+					entries[fields[0]] = strings.ToUpper(fields[3])
+				}
+			}
+			return entries, nil
+		}
+	}
+
+	// macOS, BSD and Windows all have `arp -a`
+	out, err := exec.Command("arp", "-a").Output()
+	if err != nil {
+		return nil, fmt.Errorf("arp: %w", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if m := arpLinePattern.FindStringSubmatch(line); m != nil {
+			entries[m[1]] = normalizeMAC(m[2])
+			continue
+		}
+		// Windows: "  192.168.1.1          aa-bb-cc-dd-ee-ff     dynamic"
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && stdnet.ParseIP(fields[0]) != nil && strings.Count(fields[1], "-") == 5 {
+			entries[fields[0]] = normalizeMAC(fields[1])
+		}
+	}
+	return entries, nil
+}
+
+// normalizeMAC upper-cases and zero-pads a MAC address ("a:b:c:d:e:f" -> "0A:0B:...").
+func normalizeMAC(mac string) string {
+	parts := strings.FieldsFunc(mac, func(r rune) bool { return r == ':' || r == '-' })
+	for i, p := range parts {
+		if len(p) == 1 {
+			parts[i] = "0" + p
+		}
+	}
+	return strings.ToUpper(strings.Join(parts, ":"))
+}
+
+// loadOUIVendors reads the first available OUI database into prefix -> vendor.
+func loadOUIVendors() map[string]string {
+	for _, path := range ouiDatabases {
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		vendors := map[string]string{}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if idx := strings.Index(line, "(base 16)"); idx > 0 {
+				// ieee-data: "B827EB     (base 16)		Raspberry Pi Foundation"
+				prefix := strings.TrimSpace(line[:idx])
+				vendors[prefix] = strings.TrimSpace(line[idx+len("(base 16)"):])
+			} else if len(line) > 7 && line[6] == ' ' && !strings.HasPrefix(line, "#") {
+				// nmap: "B827EB Raspberry Pi Foundation"
+				vendors[line[:6]] = line[7:]
+			}
+		}
+		f.Close()
+		if len(vendors) > 0 {
+			return vendors
+		}
+	}
+	return builtinOUI
+}
+
+func lookupVendor(vendors map[string]string, mac string) string {
+	prefix := strings.ReplaceAll(mac, ":", "")
+	if len(prefix) < 6 {
+		return ""
+	}
+	return vendors[prefix[:6]]
+}
+
+func (b *Baseline) loadLANDevices() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lanDevices = map[string]*LANDevice{}
+	data, err := os.ReadFile(filepath.Join(b.configDir, "lan_devices.json"))
+	if err != nil {
+		return
+	}
+	var devices []*LANDevice
+	if err := json.Unmarshal(data, &devices); err != nil {
+		b.notify("lan", fmt.Sprintf("Error parsing lan_devices.json: %v", err), "error")
+		return
+	}
+	for _, d := range devices {
+		b.lanDevices[d.MAC] = d
+	}
+}
+
+func (b *Baseline) saveLANDevices() {
+	// Called from within locked sections
+	devices := make([]*LANDevice, 0, len(b.lanDevices))
+	for _, d := range b.lanDevices {
+		devices = append(devices, d)
+	}
+	data, err := json.MarshalIndent(devices, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(b.configDir, "lan_devices.json"), data, 0640); err != nil {
+		b.notify("lan", fmt.Sprintf("Error saving LAN devices: %v", err), "error")
+	}
+}
+
+func (b *Baseline) scanLAN() {
+	table, err := readARPTable()
+	if err != nil {
+		b.notify("lan", fmt.Sprintf("LAN scan failed: %v", err), "error")
+		return
+	}
+	if b.ouiVendors == nil {
+		b.ouiVendors = loadOUIVendors() // Only touched by this goroutine
+	}
+
+	now := time.Now()
+	type seen struct{ ip, mac string }
+	var current []seen
+	for ip, mac := range table {
+		current = append(current, seen{ip, mac})
+	}
+
+	var newDevices []string
+	b.mu.Lock()
+	firstScan := len(b.lanDevices) == 0
+	for _, s := range current {
+		dev, known := b.lanDevices[s.mac]
+		if !known {
+			dev = &LANDevice{MAC: s.mac, FirstSeen: now, Vendor: lookupVendor(b.ouiVendors, s.mac)}
+			b.lanDevices[s.mac] = dev
+			if !firstScan {
+				newDevices = append(newDevices, s.ip)
+			}
+		}
+		dev.IP = s.ip
+		dev.LastSeen = now
+	}
+	b.lanLastScan = now
+	b.saveLANDevices()
+	b.mu.Unlock()
+
+	// Reverse DNS is slow, resolve outside the lock
+	for _, s := range current {
+		if names, err := stdnet.LookupAddr(s.ip); err == nil && len(names) > 0 {
+			b.mu.Lock()
+			b.lanDevices[s.mac].Hostname = strings.TrimSuffix(names[0], ".")
+			b.mu.Unlock()
+		}
+	}
+
+	for _, ip := range newDevices {
+		b.notify("lan", fmt.Sprintf("New device on network: %s", ip), "info")
+	}
+	b.updateLAN()
+}
+
+func (b *Baseline) updateLAN() {
+	b.mu.RLock()
+	lastScan := b.lanLastScan
+	var devices []LANDevice
+	for _, d := range b.lanDevices {
+		if d.LastSeen.Equal(lastScan) {
+			devices = append(devices, *d) // Only devices present in the latest scan
+		}
+	}
+	b.mu.RUnlock()
+
+	sort.Slice(devices, func(i, j int) bool {
+		a, c := stdnet.ParseIP(devices[i].IP).To4(), stdnet.ParseIP(devices[j].IP).To4()
+		if a != nil && c != nil {
+			return string(a) < string(c)
+		}
+		return devices[i].IP < devices[j].IP
+	})
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sLAN DEVICES (%d)[-:-:-]\n", brightC+"[::b]", len(devices)))
+
+	if lastScan.IsZero() {
+		sb.WriteString(fmt.Sprintf("%sScanning...[-:-:-]\n", dimC))
+	}
+	for _, d := range devices {
+		name := d.Hostname
+		if name == "" {
+			name = "?"
+		}
+		newC := mainC
+		if time.Since(d.FirstSeen) < time.Hour {
+			newC = brightC // Recently joined
+		}
+		sb.WriteString(fmt.Sprintf("%s%-15s %s%s %s%s[-:-:-]\n",
+			mainC, d.IP,
+			newC, tview.Escape(name),
+			dimC, tview.Escape(d.Vendor),
+		))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%sLast scan: %s[-:-:-]", dimC, lastScan.Format("15:04:05")))
+
+	b.setPanel(b.lanPanel, sb.String())
+}
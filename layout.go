@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- Responsive Layout ---
+//
+// The core panels are arranged by terminal width: stacked in one column
+// below layoutNarrowWidth, the 2x2 grid in between, and three columns from
+// layoutWideWidth on. Widgets keep their column to the right, or stack
+// below the core panels on narrow terminals. The arrangement is checked
+// before every draw, so resizing the terminal re-flows it. LAYOUT or
+// `layout <mode>` pins one arrangement; panelarrange.go lets panels be
+// moved, resized and hidden.
+
+const (
+	layoutNarrowWidth = 100 // Columns
+	layoutWideWidth   = 200
+)
+
+var layoutModes = []string{"auto", "stacked", "grid", "wide"}
+
+// layoutFromEnv reads LAYOUT, "auto" when unset or unknown.
+func layoutFromEnv() string {
+	mode := strings.ToLower(strings.TrimSpace(os.Getenv("LAYOUT")))
+	for _, m := range layoutModes {
+		if mode == m {
+			return mode
+		}
+	}
+	return "auto"
+}
+
+// layoutFor picks the arrangement for a terminal width columns wide.
+func layoutFor(setting string, width int) string {
+	switch {
+	case setting != "auto":
+		return setting
+	case width < layoutNarrowWidth:
+		return "stacked"
+	case width >= layoutWideWidth:
+		return "wide"
+	}
+	return "grid"
+}
+
+// arrangePanels lays the core panels out in mode, following the custom
+// arrangement (see panelarrange.go), and re-adds the widget column. Must
+// run on the UI goroutine.
+func (b *Baseline) arrangePanels(mode string) {
+	a := b.arrangement
+	column := func(names []string) *tview.Flex {
+		flex := tview.NewFlex().SetDirection(tview.FlexRow)
+		for _, name := range names {
+			flex.AddItem(b.corePanel(name), 0, a.panelHeight(name), false) // The task list gets twice the height
+		}
+		return flex
+	}
+
+	b.mainContent.Clear()
+	switch mode {
+	case "stacked":
+		b.mainContent.SetDirection(tview.FlexRow)
+		for _, name := range a.stackedOrder() {
+			b.mainContent.AddItem(b.corePanel(name), 0, a.panelHeight(name), false)
+		}
+	case "wide":
+		b.mainContent.SetDirection(tview.FlexColumn)
+		for _, names := range a.wideColumns() {
+			b.mainContent.AddItem(column(names), 0, 1, false)
+		}
+	default: // grid
+		b.mainContent.SetDirection(tview.FlexColumn)
+		columns := a.visibleColumns()
+		for i, names := range columns {
+			b.mainContent.AddItem(column(names), 0, a.columnWidth(i, len(columns)), false)
+		}
+	}
+	if len(b.widgetPanels) > 0 {
+		b.mainContent.AddItem(b.widgetColumn, 0, a.widgetColumnWidth(), false)
+	}
+	b.layoutShown = mode
+}
+
+// reflow re-arranges the panels when the width calls for another layout.
+// Runs before every draw.
+func (b *Baseline) reflow(screen tcell.Screen) bool {
+	width, _ := screen.Size()
+	b.screenWidth = width
+	if mode := layoutFor(b.layoutSetting, width); mode != b.layoutShown {
+		b.arrangePanels(mode)
+	}
+	return false
+}
+
+// layoutCommand handles "layout [auto|stacked|grid|wide]" and the
+// arrangement subcommands. processCommand runs on the UI goroutine, so the
+// layout can change right here.
+func (b *Baseline) layoutCommand(args []string) {
+	if len(args) == 0 {
+		b.addNotification(fmt.Sprintf("Layout: %s (%s at %d columns)", b.layoutSetting, b.layoutShown, b.screenWidth), "info")
+		return
+	}
+	for _, m := range layoutModes {
+		if args[0] == m {
+			b.layoutSetting = m
+			b.arrangement.Mode = m
+			b.saveArrangement()
+			b.arrangePanels(layoutFor(m, b.screenWidth))
+			b.addNotification(fmt.Sprintf("Layout set to %s", m), "success")
+			return
+		}
+	}
+	if !b.arrangeCommand(args) {
+		b.addNotification("Usage: layout [auto|stacked|grid|wide|move|size|split|hide|show|reset]", "error")
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// --- Lifecycle ---
+//
+// Every background job (scheduled collectors, the refresh tickers and the
+// fetches they start) runs through the dashboard's lifecycle. Quitting
+// cancels its context: tickers stop, outgoing HTTP requests are aborted and
+// no new fetch starts. Shutdown then waits up to shutdownTimeout for running
+// fetches before the last availability heartbeat and history flush, so
+// nothing writes to the metrics database after it is closed.
+
+const shutdownTimeout = 3 * time.Second
+
+type lifecycle struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	mu      sync.Mutex // Orders Go against Stop
+	stopped bool
+	workers sync.WaitGroup
+}
+
+func newLifecycle() *lifecycle {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &lifecycle{ctx: ctx, cancel: cancel}
+}
+
+// Done is closed once the app quits.
+func (l *lifecycle) Done() <-chan struct{} { return l.ctx.Done() }
+
+// Go runs fn in the background unless the app is quitting.
+func (l *lifecycle) Go(fn func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.stopped {
+		return
+	}
+	l.workers.Add(1)
+	go func() {
+		defer l.workers.Done()
+		fn()
+	}()
+}
+
+// Stop cancels the context and waits up to timeout for running jobs.
+// It reports whether they all finished.
+func (l *lifecycle) Stop(timeout time.Duration) bool {
+	l.mu.Lock()
+	l.stopped = true
+	l.cancel()
+	l.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		l.workers.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// schedule runs fn now and then every interval until the app quits. The
+// ticker is returned so a config reload can change the interval.
+func (b *Baseline) schedule(interval time.Duration, fn func()) *time.Ticker {
+	ticker := time.NewTicker(interval)
+	b.life.Go(func() {
+		defer ticker.Stop()
+		fn()
+		for {
+			select {
+			case <-ticker.C:
+				fn()
+			case <-b.life.Done():
+				return
+			}
+		}
+	})
+	return ticker
+}
+
+// quit stops the dashboard. Safe from any goroutine.
+func (b *Baseline) quit() {
+	b.life.cancel()
+	b.app.Stop()
+}
+
+// shutdown ends the background jobs and flushes the history once the UI has
+// stopped.
+func (b *Baseline) shutdown() {
+	if !b.life.Stop(shutdownTimeout) {
+		// A fetch is stuck (network, a hung command) and may still hold the
+		// state lock; the database is closed when the process exits
+		slog.Warn("Background jobs still running, skipping the history flush", "after", shutdownTimeout)
+		return
+	}
+	b.heartbeatAvailability() // Record the session end
+	if b.metrics != nil {
+		b.compactMetrics()
+		if err := b.metrics.Close(); err != nil {
+			slog.Error("Could not close metrics database", "err", err)
+		}
+	}
+	b.notifMu.Lock()
+	if b.notifLog != nil {
+		b.notifLog.Close()
+		b.notifLog = nil
+	}
+	b.notifMu.Unlock()
+}
+
+// abortRequestsOnQuit routes the default HTTP transport through quitTransport.
+func (b *Baseline) abortRequestsOnQuit() {
+	http.DefaultTransport = quitTransport{base: http.DefaultTransport, quit: b.life.ctx}
+}
+
+// quitTransport aborts outgoing HTTP requests when quit is done. Every
+// widget client without its own transport goes through it.
+type quitTransport struct {
+	base http.RoundTripper
+	quit context.Context
+}
+
+func (t quitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	stop := context.AfterFunc(t.quit, cancel)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		stop()
+		cancel()
+		return nil, err
+	}
+	resp.Body = &quitBody{ReadCloser: resp.Body, release: func() { stop(); cancel() }}
+	return resp, nil
+}
+
+// quitBody releases the request's context once the body is closed.
+type quitBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *quitBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- Logging ---
+//
+// Diagnostics go through log/slog to ~/.baseline/logs/baseline.log, which is
+// rotated once it reaches logMaxSize (baseline.log.1 is the newest of the
+// logKeep old files). log_level in config.toml sets the threshold (debug,
+// info, warn or error; info by default) and applies on reload. `logs
+// [level]` shows the recent entries at or above a level in an overlay.
+
+const (
+	logFileName      = "baseline.log"
+	logMaxSize       = 5 << 20 // Bytes before baseline.log is rotated
+	logKeep          = 3       // Rotated files kept
+	logsViewBytes    = 256 * 1024
+	logsViewMaxLines = 1000 // Entries shown by `logs`
+)
+
+// logLevel is the threshold of the default logger, set from config.toml.
+var logLevel = new(slog.LevelVar)
+
+var logLevelPattern = regexp.MustCompile(`\blevel=(\w+)`)
+
+// logFilePath is where the TUI logs for a config dir.
+func logFilePath(configDir string) string {
+	return filepath.Join(configDir, "logs", logFileName)
+}
+
+// rotatingFile is an append-only log file that moves itself aside to
+// <path>.1 (and older ones to .2, ...) before growing past logMaxSize.
+type rotatingFile struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	size int64
+}
+
+func openRotatingFile(path string) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &rotatingFile{path: path, file: f, size: info.Size()}, nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.size > 0 && r.size+int64(len(p)) > logMaxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate shifts the old files up by one and starts an empty log. Called with
+// r.mu held.
+func (r *rotatingFile) rotate() error {
+	r.file.Close() // Windows can't rename open files
+	for i := logKeep - 1; i > 0; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+	}
+	os.Rename(r.path, r.path+".1")
+	f, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0640)
+	if err != nil {
+		return fmt.Errorf("could not rotate %s: %w", r.path, err)
+	}
+	r.file, r.size = f, 0
+	return nil
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// setupLogging makes slog, and the log package through it, write to the log
+// file of configDir. Falls back to stderr when the file can't be opened.
+// Called from main before the TUI starts.
+func setupLogging(configDir string) (path string, closeLog func()) {
+	path = logFilePath(configDir)
+	var out io.Writer = os.Stderr
+	closeLog = func() {}
+	f, err := openRotatingFile(path)
+	if err == nil {
+		out, closeLog = f, func() { f.Close() }
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(out, &slog.HandlerOptions{
+		AddSource: true,
+		Level:     logLevel,
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if src, ok := a.Value.Any().(*slog.Source); ok && a.Key == slog.SourceKey {
+				if src.File == "" {
+					return slog.Attr{} // Not known for the log package
+				}
+				a.Value = slog.StringValue(fmt.Sprintf("%s:%d", filepath.Base(src.File), src.Line))
+			}
+			return a
+		},
+	})))
+	if err != nil {
+		slog.Warn("Could not open the log file, logging to stderr", "path", path, "err", err)
+		return "stderr", closeLog
+	}
+	return path, closeLog
+}
+
+// readLogTail returns the last complete entries of the log file at or above
+// threshold, oldest first.
+func readLogTail(path string, threshold slog.Level) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	start := max(0, info.Size()-logsViewBytes)
+	data := make([]byte, info.Size()-start)
+	if _, err := f.ReadAt(data, start); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if start > 0 {
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:] // Skip the cut-off entry
+		}
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), logsViewBytes)
+	for scanner.Scan() {
+		if entryLevel(scanner.Text()) >= threshold {
+			lines = append(lines, scanner.Text())
+		}
+	}
+	return lines[max(0, len(lines)-logsViewMaxLines):], scanner.Err()
+}
+
+// entryLevel reads the level of a log line, info for lines slog didn't
+// write (e.g. a panic trace).
+func entryLevel(line string) slog.Level {
+	var level slog.Level
+	if m := logLevelPattern.FindStringSubmatch(line); m != nil {
+		if level.UnmarshalText([]byte(m[1])) == nil {
+			return level
+		}
+	}
+	return slog.LevelInfo
+}
+
+// logsCommand opens the recent log entries, from the given level up
+// (everything that was logged by default).
+func (b *Baseline) logsCommand(args []string) {
+	threshold := slog.LevelDebug
+	if len(args) > 0 {
+		if err := threshold.UnmarshalText([]byte(args[0])); err != nil {
+			b.addNotification("Usage: logs [debug|info|warn|error]", "error")
+			return
+		}
+	}
+	path := logFilePath(b.configDir)
+	lines, err := readLogTail(path, threshold)
+	if err != nil {
+		b.addNotification(fmt.Sprintf("Error reading %s: %v", path, err), "error")
+		return
+	}
+	go b.app.QueueUpdateDraw(func() { b.openLogs(path, threshold, lines) })
+}
+
+// openLogs shows log lines in an overlay, colored by level. Runs on the UI
+// goroutine.
+func (b *Baseline) openLogs(path string, threshold slog.Level, lines []string) {
+	dimC := colorTag(b.theme.Dim)
+	mainC := colorTag(b.theme.Main)
+	var sb strings.Builder
+	if len(lines) == 0 {
+		sb.WriteString(fmt.Sprintf("%s(No %s entries or above)[-:-:-]", dimC, strings.ToLower(threshold.String())))
+	}
+	for _, line := range lines {
+		color := mainC
+		switch level := entryLevel(line); {
+		case level >= slog.LevelError:
+			color = notificationColor("error", b.theme)
+		case level >= slog.LevelWarn:
+			color = "[yellow]"
+		case level < slog.LevelInfo:
+			color = dimC
+		}
+		sb.WriteString(color + tview.Escape(line) + "[-:-:-]\n")
+	}
+
+	view := tview.NewTextView().SetDynamicColors(true).SetScrollable(true).SetWrap(true)
+	view.SetBorder(true).SetTitle(fmt.Sprintf(" %s (%s and above, Esc to close) ", tview.Escape(path), strings.ToLower(threshold.String())))
+	view.SetBorderColor(b.theme.Bright)
+	view.SetTitleColor(b.theme.Bright)
+	view.SetTextColor(b.theme.Main)
+	view.SetText(sb.String())
+	view.ScrollToEnd()
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' {
+			b.closeOverlay("logs")
+			return nil
+		}
+		return event
+	})
+	b.showOverlay("logs", view, 120, 30)
+}
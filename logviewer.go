@@ -0,0 +1,285 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Log File Viewer ---
+//
+// `log open <path>` tails a file in a widget panel. Include/exclude regexes
+// filter what is shown; the buffer keeps everything so filters can change.
+
+const (
+	logTailInterval = 1 * time.Second
+	logTailInitial  = 64 * 1024 // Bytes read from the end when opening
+	logTailMaxLines = 1000
+)
+
+var (
+	logErrorPattern = regexp.MustCompile(`(?i)\b(error|err|fatal|crit(ical)?|panic|fail(ed|ure)?|emerg|alert)\b`)
+	logWarnPattern  = regexp.MustCompile(`(?i)\b(warn(ing)?)\b`)
+	logDebugPattern = regexp.MustCompile(`(?i)\b(debug|trace)\b`)
+)
+
+type LogTail struct {
+	path string
+
+	mu      sync.Mutex
+	lines   []string
+	offset  int64
+	partial string // Incomplete last line
+	include *regexp.Regexp
+	exclude *regexp.Regexp
+	err     string
+
+	stop chan struct{}
+}
+
+func newLogTail(path string) *LogTail {
+	return &LogTail{path: path, stop: make(chan struct{})}
+}
+
+// poll reads whatever was appended since the last call. A file that shrank
+// was truncated or rotated and is read again from the start.
+func (t *LogTail) poll() (changed bool) {
+	f, err := os.Open(t.path)
+	if err != nil {
+		t.mu.Lock()
+		changed = t.err != err.Error()
+		t.err = err.Error()
+		t.mu.Unlock()
+		return changed
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err != "" {
+		t.err = ""
+		changed = true
+	}
+	size := info.Size()
+	if size < t.offset {
+		t.offset, t.partial = 0, ""
+		t.lines = append(t.lines, "--- file truncated ---")
+	}
+	if size == t.offset {
+		return changed
+	}
+	midLine := false
+	if t.offset == 0 && size > logTailInitial {
+		t.offset = size - logTailInitial
+		midLine = true
+	}
+	if _, err := f.Seek(t.offset, io.SeekStart); err != nil {
+		return changed
+	}
+
+	data, _ := io.ReadAll(io.LimitReader(f, size-t.offset))
+	t.offset += int64(len(data))
+
+	parts := strings.Split(t.partial+string(data), "\n")
+	if midLine && len(parts) > 1 {
+		parts = parts[1:] // Started reading mid-line
+	}
+	t.partial = parts[len(parts)-1]
+	for _, line := range parts[:len(parts)-1] {
+		t.lines = append(t.lines, strings.TrimRight(line, "\r"))
+	}
+	if len(t.lines) > logTailMaxLines {
+		t.lines = t.lines[len(t.lines)-logTailMaxLines:]
+	}
+	return true
+}
+
+// visible returns the buffered lines that pass the filters.
+func (t *LogTail) visible() ([]string, string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var out []string
+	for _, line := range t.lines {
+		if t.include != nil && !t.include.MatchString(line) {
+			continue
+		}
+		if t.exclude != nil && t.exclude.MatchString(line) {
+			continue
+		}
+		out = append(out, line)
+	}
+	return out, t.err
+}
+
+func (t *LogTail) setFilter(include bool, expr string) error {
+	var re *regexp.Regexp
+	if expr != "" {
+		var err error
+		if re, err = regexp.Compile(expr); err != nil {
+			return err
+		}
+	}
+	t.mu.Lock()
+	if include {
+		t.include = re
+	} else {
+		t.exclude = re
+	}
+	t.mu.Unlock()
+	return nil
+}
+
+func (t *LogTail) filterSummary() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var parts []string
+	if t.include != nil {
+		parts = append(parts, "+/"+t.include.String()+"/")
+	}
+	if t.exclude != nil {
+		parts = append(parts, "-/"+t.exclude.String()+"/")
+	}
+	return strings.Join(parts, " ")
+}
+
+// logLineColor picks a color tag by the severity words in a line.
+func logLineColor(line string, theme Theme) string {
+	switch {
+	case logErrorPattern.MatchString(line):
+		return "[red]"
+	case logWarnPattern.MatchString(line):
+		return "[yellow]"
+	case logDebugPattern.MatchString(line):
+		return colorTag(theme.Dim)
+	}
+	return colorTag(theme.Main)
+}
+
+// openLog starts tailing path in the log panel, replacing any open log.
+// Called from processCommand with b.mu held.
+func (b *Baseline) openLog(path string) {
+	if b.logTail != nil {
+		close(b.logTail.stop)
+	}
+	tail := newLogTail(path)
+	b.logTail = tail
+	b.addNotification(fmt.Sprintf("Tailing %s", path), "info")
+
+	go b.app.QueueUpdateDraw(func() {
+		if b.logPanel == nil {
+			b.logPanel = b.addRuntimePanel(" Log ", b.updateLog)
+		}
+	})
+	b.life.Go(func() {
+		ticker := time.NewTicker(logTailInterval)
+		defer ticker.Stop()
+		for first := true; ; first = false {
+			if tail.poll() || first {
+				b.updateLog()
+			}
+			select {
+			case <-tail.stop:
+				return
+			case <-b.life.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// closeLog stops tailing and removes the panel. Called with b.mu held.
+func (b *Baseline) closeLog() {
+	if b.logTail == nil {
+		return
+	}
+	close(b.logTail.stop)
+	b.logTail = nil
+	go b.app.QueueUpdateDraw(func() {
+		if b.logPanel != nil {
+			b.removeRuntimePanel(b.logPanel)
+			b.logPanel = nil
+		}
+	})
+}
+
+func (b *Baseline) updateLog() {
+	b.mu.RLock()
+	tail := b.logTail
+	b.mu.RUnlock()
+	if tail == nil {
+		return
+	}
+	lines, tailErr := tail.visible()
+
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	title := tview.Escape(tail.path)
+	if filters := tail.filterSummary(); filters != "" {
+		title += " " + dimC + tview.Escape(filters)
+	}
+	sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n", brightC+"[::b]", title))
+	if tailErr != "" {
+		sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(tailErr)))
+	}
+	for _, line := range lines {
+		sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n", logLineColor(line, b.theme), tview.Escape(line)))
+	}
+
+	b.app.QueueUpdate(func() {
+		tv := b.logPanel
+		if tv == nil {
+			return
+		}
+		b.renderPanel(tv, sb.String(), func() {
+			tv.SetText(sb.String())
+			tv.ScrollToEnd()
+		})
+	})
+}
+
+// logCommand handles "log open <path>", "log include|exclude [regex]" and "log close".
+// rawArgs keeps the original case for paths and patterns. Called with b.mu held.
+func (b *Baseline) logCommand(rawArgs []string) {
+	usage := "Usage: log open <path> | log include [regex] | log exclude [regex] | log close"
+	if len(rawArgs) == 0 {
+		b.addNotification(usage, "error")
+		return
+	}
+	rest := strings.Join(rawArgs[1:], " ")
+	switch strings.ToLower(rawArgs[0]) {
+	case "open":
+		if rest == "" {
+			b.addNotification(usage, "error")
+			return
+		}
+		b.openLog(rest)
+	case "include", "exclude":
+		if b.logTail == nil {
+			b.addNotification("No log open (use: log open <path>)", "error")
+			return
+		}
+		if err := b.logTail.setFilter(strings.ToLower(rawArgs[0]) == "include", rest); err != nil {
+			b.addNotification(fmt.Sprintf("Invalid regex: %v", err), "error")
+			return
+		}
+		go b.updateLog()
+	case "close":
+		b.closeLog()
+	default:
+		b.addNotification(usage, "error")
+	}
+}
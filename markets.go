@@ -0,0 +1,321 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Markets Widget ---
+//
+// MARKETS_CRYPTO (CoinGecko coin ids, e.g. bitcoin,ethereum) and
+// MARKETS_STOCKS (Yahoo Finance symbols, e.g. AAPL,^GSPC,EURUSD=X) add a
+// Markets panel: price, the change over 24 hours (since the previous close
+// for stocks) in the success or error color, and a sparkline of the
+// day. Refreshed every MARKETS_INTERVAL (default 5m, at least 1m: both APIs
+// rate limit). Crypto is priced in MARKETS_CURRENCY (default usd).
+
+const (
+	marketsDefaultInterval = 5 * time.Minute
+	marketsMinInterval     = time.Minute
+	marketsSparkWidth      = 12
+	coinGeckoURL           = "https://api.coingecko.com/api/v3/coins/markets"
+	yahooChartURL          = "https://query1.finance.yahoo.com/v8/finance/chart/"
+)
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+type MarketQuote struct {
+	Symbol   string
+	Price    float64
+	Change   float64 // Percent over 24h, or since the previous close
+	Currency string
+	Trend    []float64 // Prices over the day, oldest first
+	Error    string
+}
+
+type MarketsInfo struct {
+	Quotes      []MarketQuote // In the configured order, crypto first
+	LastUpdated time.Time
+}
+
+// MarketsConfig holds the MARKETS_* settings.
+type MarketsConfig struct {
+	crypto   []string
+	stocks   []string
+	currency string
+	interval time.Duration
+	client   http.Client
+}
+
+// newMarketsFromEnv returns nil when neither MARKETS_CRYPTO nor
+// MARKETS_STOCKS is set.
+func newMarketsFromEnv() *MarketsConfig {
+	split := func(v string) []string {
+		var out []string
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	m := &MarketsConfig{
+		crypto:   split(strings.ToLower(os.Getenv("MARKETS_CRYPTO"))),
+		stocks:   split(strings.ToUpper(os.Getenv("MARKETS_STOCKS"))),
+		currency: strings.ToLower(strings.TrimSpace(os.Getenv("MARKETS_CURRENCY"))),
+		interval: marketsDefaultInterval,
+		client:   http.Client{Timeout: 10 * time.Second},
+	}
+	if len(m.crypto) == 0 && len(m.stocks) == 0 {
+		return nil
+	}
+	if m.currency == "" {
+		m.currency = "usd"
+	}
+	if d, err := time.ParseDuration(os.Getenv("MARKETS_INTERVAL")); err == nil {
+		m.interval = max(d, marketsMinInterval)
+	}
+	return m
+}
+
+// getJSON fetches endpoint into out. Yahoo turns away requests without a
+// browser-like User-Agent.
+func (m *MarketsConfig) getJSON(endpoint string, out any) error {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (baseline dashboard)")
+	req.Header.Set("Accept", "application/json")
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP error: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("rate limited, raise MARKETS_INTERVAL")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API error: Status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("JSON parse error: %w", err)
+	}
+	return nil
+}
+
+// fetchCrypto prices every coin with one CoinGecko request.
+func (m *MarketsConfig) fetchCrypto() []MarketQuote {
+	query := url.Values{}
+	query.Set("vs_currency", m.currency)
+	query.Set("ids", strings.Join(m.crypto, ","))
+	query.Set("sparkline", "true")
+	query.Set("price_change_percentage", "24h")
+	var data []struct {
+		ID        string  `json:"id"`
+		Symbol    string  `json:"symbol"`
+		Price     float64 `json:"current_price"`
+		Change    float64 `json:"price_change_percentage_24h"`
+		Sparkline struct {
+			Price []float64 `json:"price"`
+		} `json:"sparkline_in_7d"`
+	}
+	err := m.getJSON(coinGeckoURL+"?"+query.Encode(), &data)
+
+	quotes := make([]MarketQuote, len(m.crypto))
+	for i, id := range m.crypto {
+		quotes[i] = MarketQuote{Symbol: strings.ToUpper(id), Currency: strings.ToUpper(m.currency), Error: "unknown coin id"}
+		if err != nil {
+			quotes[i].Error = err.Error()
+		}
+		for _, c := range data {
+			if c.ID != id {
+				continue
+			}
+			spark := c.Sparkline.Price
+			quotes[i] = MarketQuote{
+				Symbol:   strings.ToUpper(c.Symbol),
+				Price:    c.Price,
+				Change:   c.Change,
+				Currency: strings.ToUpper(m.currency),
+				Trend:    spark[max(0, len(spark)-24):], // Hourly over 7 days, keep the last day
+			}
+		}
+	}
+	return quotes
+}
+
+// fetchStock reads one Yahoo Finance chart for the current day.
+func (m *MarketsConfig) fetchStock(symbol string) MarketQuote {
+	var data struct {
+		Chart struct {
+			Result []struct {
+				Meta struct {
+					Currency      string  `json:"currency"`
+					Price         float64 `json:"regularMarketPrice"`
+					PreviousClose float64 `json:"chartPreviousClose"`
+				} `json:"meta"`
+				Indicators struct {
+					Quote []struct {
+						Close []*float64 `json:"close"` // null for minutes without trades
+					} `json:"quote"`
+				} `json:"indicators"`
+			} `json:"result"`
+			Error *struct {
+				Description string `json:"description"`
+			} `json:"error"`
+		} `json:"chart"`
+	}
+	quote := MarketQuote{Symbol: symbol}
+	if err := m.getJSON(yahooChartURL+url.PathEscape(symbol)+"?range=1d&interval=15m", &data); err != nil {
+		quote.Error = err.Error()
+		return quote
+	}
+	if data.Chart.Error != nil || len(data.Chart.Result) == 0 {
+		quote.Error = "unknown symbol"
+		if data.Chart.Error != nil && data.Chart.Error.Description != "" {
+			quote.Error = data.Chart.Error.Description
+		}
+		return quote
+	}
+	r := data.Chart.Result[0]
+	quote.Price, quote.Currency = r.Meta.Price, r.Meta.Currency
+	if r.Meta.PreviousClose > 0 {
+		quote.Change = (r.Meta.Price - r.Meta.PreviousClose) / r.Meta.PreviousClose * 100
+	}
+	if len(r.Indicators.Quote) > 0 {
+		for _, c := range r.Indicators.Quote[0].Close {
+			if c != nil {
+				quote.Trend = append(quote.Trend, *c)
+			}
+		}
+	}
+	return quote
+}
+
+func (b *Baseline) fetchMarkets() {
+	m := b.markets
+	quotes := make([]MarketQuote, len(m.crypto)+len(m.stocks))
+	var wg sync.WaitGroup
+	if len(m.crypto) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			copy(quotes, m.fetchCrypto())
+		}()
+	}
+	for i, symbol := range m.stocks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			quotes[len(m.crypto)+i] = m.fetchStock(symbol)
+		}()
+	}
+	wg.Wait()
+
+	b.mu.Lock()
+	prevErrors := marketErrors(b.marketsInfo.Quotes)
+	b.marketsInfo = MarketsInfo{Quotes: quotes, LastUpdated: time.Now()}
+	b.mu.Unlock()
+
+	if errs := marketErrors(quotes); errs != "" && errs != prevErrors {
+		b.notify("markets", "Markets: "+errs, "error")
+	}
+	b.updateMarkets()
+}
+
+// marketErrors joins the failed quotes' errors.
+func marketErrors(quotes []MarketQuote) string {
+	var errs []string
+	for _, q := range quotes {
+		if q.Error != "" {
+			errs = append(errs, q.Symbol+" "+q.Error)
+		}
+	}
+	return strings.Join(errs, "; ")
+}
+
+// blockSparkline draws values as block characters, scaled between their
+// lowest and highest value and squeezed into width cells.
+func blockSparkline(values []float64, width int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	if len(values) > width {
+		sampled := make([]float64, width)
+		for i := range sampled {
+			sampled[i] = values[i*(len(values)-1)/max(1, width-1)]
+		}
+		values = sampled
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	var sb strings.Builder
+	for _, v := range values {
+		level := len(sparkBlocks) / 2 // Flat line
+		if hi > lo {
+			level = int((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1))
+		}
+		sb.WriteRune(sparkBlocks[level])
+	}
+	return sb.String()
+}
+
+// formatPrice keeps four significant digits for small prices.
+func formatPrice(p float64) string {
+	switch {
+	case p >= 1000:
+		return fmt.Sprintf("%.0f", p)
+	case p >= 1:
+		return fmt.Sprintf("%.2f", p)
+	}
+	return fmt.Sprintf("%.4g", p)
+}
+
+func (b *Baseline) updateMarkets() {
+	b.mu.RLock()
+	info := b.marketsInfo
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+	upC := notificationColor("success", b.theme)
+	downC := notificationColor("error", b.theme)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sMARKETS[-:-:-]\n", brightC+"[::b]"))
+	if info.LastUpdated.IsZero() {
+		sb.WriteString(fmt.Sprintf("%sLoading...[-:-:-]\n", dimC))
+	}
+	for _, q := range info.Quotes {
+		if q.Error != "" {
+			sb.WriteString(fmt.Sprintf("%s%-8s [red]%s[-:-:-]\n", brightC, tview.Escape(q.Symbol), tview.Escape(q.Error)))
+			continue
+		}
+		changeC, arrow := upC, "▲"
+		if q.Change < 0 {
+			changeC, arrow = downC, "▼"
+		}
+		sb.WriteString(fmt.Sprintf("%s%-8s %s%10s %s%s%s %+.2f%% %s%s[-:-:-]\n",
+			brightC, tview.Escape(q.Symbol),
+			mainC, formatPrice(q.Price),
+			dimC, tview.Escape(q.Currency),
+			changeC+" "+arrow, q.Change,
+			dimC, blockSparkline(q.Trend, marketsSparkWidth),
+		))
+	}
+	sb.WriteString(fmt.Sprintf("\n%sLast updated: %s[-:-:-]", dimC, info.LastUpdated.Format("15:04:05")))
+
+	b.setPanel(b.marketsPanel, sb.String())
+}
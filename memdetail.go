@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rivo/tview"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// --- Memory Breakdown ---
+//
+// Adds swap, cached/buffers/available and the processes using the most
+// resident memory under the MEM bar in the system panel (R or `mem detail`).
+// Cached and buffers are Linux figures; other systems leave them out.
+
+const memTopProcesses = 5
+
+// renderMemDetail draws the breakdown. procs is the latest process list.
+func renderMemDetail(vm *mem.VirtualMemoryStat, swap *mem.SwapMemoryStat, procs []ProcessInfo, theme Theme) string {
+	mainC := colorTag(theme.Main)
+	dimC := colorTag(theme.Dim)
+	brightC := colorTag(theme.Bright)
+	size := func(n uint64) string { return formatBytes(int64(n)) }
+
+	var sb strings.Builder
+	if vm != nil {
+		sb.WriteString(fmt.Sprintf("%s    used %s%s %sof %s, avail %s%s[-:-:-]\n", dimC, brightC, size(vm.Used), dimC, size(vm.Total), brightC, size(vm.Available)))
+		if vm.Cached > 0 || vm.Buffers > 0 {
+			sb.WriteString(fmt.Sprintf("%s    cached %s, buffers %s[-:-:-]\n", dimC, size(vm.Cached), size(vm.Buffers)))
+		}
+	}
+	if swap != nil && swap.Total > 0 {
+		sb.WriteString(fmt.Sprintf("%sSWP: %s %s %.1f%% %s%s/%s[-:-:-]\n", mainC, createBar(swap.UsedPercent, 15, theme), brightC, swap.UsedPercent, dimC, size(swap.Used), size(swap.Total)))
+	} else {
+		sb.WriteString(fmt.Sprintf("%sSWP: %snone[-:-:-]\n", mainC, dimC))
+	}
+
+	top := append([]ProcessInfo(nil), procs...)
+	sort.Slice(top, func(i, j int) bool { return top[i].RSS > top[j].RSS })
+	if len(top) > memTopProcesses {
+		top = top[:memTopProcesses]
+	}
+	for _, p := range top {
+		if p.RSS == 0 {
+			break
+		}
+		name := p.Name
+		if len([]rune(name)) > 16 {
+			name = string([]rune(name)[:15]) + "…"
+		}
+		sb.WriteString(fmt.Sprintf("%s    %-16s %s%8s %s%4.1f%%[-:-:-]\n", dimC, tview.Escape(name), mainC, size(p.RSS), dimC, p.Mem))
+	}
+	return sb.String()
+}
+
+// toggleMemDetail switches the memory breakdown on or off. Called with b.mu held.
+func (b *Baseline) toggleMemDetail() {
+	b.memDetail = !b.memDetail
+	if b.memDetail {
+		b.addNotification("Memory: detailed view", "info")
+	} else {
+		b.addNotification("Memory: usage bar only", "info")
+	}
+	go b.updateSystemInfo()
+}
+
+// memCommand handles "mem [detail|total]". Called with b.mu held.
+func (b *Baseline) memCommand(args []string) {
+	switch {
+	case len(args) == 0:
+		b.toggleMemDetail()
+	case args[0] == "detail" && !b.memDetail, args[0] == "total" && b.memDetail:
+		b.toggleMemDetail()
+	case args[0] == "detail" || args[0] == "total":
+		// Already showing that view
+	default:
+		b.addNotification("Usage: mem [detail|total]", "error")
+	}
+}
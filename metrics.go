@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// --- Metrics Storage ---
+//
+// Samples are appended to ~/.baseline/metrics.db (bbolt) instead of rewriting
+// system_history.json on every tick. Full-resolution samples are kept for
+// the [history] raw retention, then averaged into one sample per minute that
+// is kept for the retention period:
+//
+//	[history]
+//	raw = "24h"
+//	retention = "30d"
+//
+// Keys are big-endian UnixNano timestamps, so cursors walk them in time order.
+
+const (
+	metricsFileName        = "metrics.db"
+	metricsCompactInterval = 10 * time.Minute
+	metricsOpenTimeout     = 1 * time.Second // Another running instance holds the file lock
+	metricSampleSize       = 32
+)
+
+var (
+	rawBucket    = []byte("raw")
+	minuteBucket = []byte("minute")
+)
+
+// HistoryRetention is the [history] section of config.toml.
+type HistoryRetention struct {
+	Raw  time.Duration
+	Keep time.Duration
+}
+
+var defaultRetention = HistoryRetention{Raw: 24 * time.Hour, Keep: 30 * 24 * time.Hour}
+
+type MetricSample struct {
+	Time   time.Time
+	CPU    float64
+	Memory float64
+	NetIn  uint64 // Cumulative counters, as reported by the OS
+	NetOut uint64
+}
+
+func (s MetricSample) key() []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(s.Time.UnixNano()))
+	return key
+}
+
+func (s MetricSample) encode() []byte {
+	buf := make([]byte, metricSampleSize)
+	binary.BigEndian.PutUint64(buf[0:], math.Float64bits(s.CPU))
+	binary.BigEndian.PutUint64(buf[8:], math.Float64bits(s.Memory))
+	binary.BigEndian.PutUint64(buf[16:], s.NetIn)
+	binary.BigEndian.PutUint64(buf[24:], s.NetOut)
+	return buf
+}
+
+func decodeSample(key, value []byte) (MetricSample, bool) {
+	if len(key) != 8 || len(value) != metricSampleSize {
+		return MetricSample{}, false
+	}
+	return MetricSample{
+		Time:   time.Unix(0, int64(binary.BigEndian.Uint64(key))),
+		CPU:    math.Float64frombits(binary.BigEndian.Uint64(value[0:])),
+		Memory: math.Float64frombits(binary.BigEndian.Uint64(value[8:])),
+		NetIn:  binary.BigEndian.Uint64(value[16:]),
+		NetOut: binary.BigEndian.Uint64(value[24:]),
+	}, true
+}
+
+type MetricsStore struct {
+	db *bolt.DB
+}
+
+func openMetricsStore(path string) (*MetricsStore, error) {
+	db, err := bolt.Open(path, 0640, &bolt.Options{Timeout: metricsOpenTimeout})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("%s is in use by another Baseline", filepath.Base(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{rawBucket, minuteBucket, weatherBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &MetricsStore{db: db}, nil
+}
+
+func (m *MetricsStore) Close() error {
+	return m.db.Close()
+}
+
+// Append stores full-resolution samples.
+func (m *MetricsStore) Append(samples ...MetricSample) error {
+	return m.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(rawBucket)
+		for _, s := range samples {
+			if err := bucket.Put(s.key(), s.encode()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Recent returns the last n full-resolution samples, oldest first.
+func (m *MetricsStore) Recent(n int) ([]MetricSample, error) {
+	var samples []MetricSample
+	err := m.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(rawBucket).Cursor()
+		for k, v := c.Last(); k != nil && len(samples) < n; k, v = c.Prev() {
+			if s, ok := decodeSample(k, v); ok {
+				samples = append(samples, s)
+			}
+		}
+		return nil
+	})
+	for i, j := 0, len(samples)-1; i < j; i, j = i+1, j-1 {
+		samples[i], samples[j] = samples[j], samples[i]
+	}
+	return samples, err
+}
+
+// Range returns the samples between from and to, oldest first: per-minute
+// averages for the part of the range older than the raw retention, full
+// resolution after that.
+func (m *MetricsStore) Range(from, to time.Time) ([]MetricSample, error) {
+	var samples []MetricSample
+	err := m.db.View(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{minuteBucket, rawBucket} {
+			c := tx.Bucket(name).Cursor()
+			start := MetricSample{Time: from}.key()
+			if len(samples) > 0 {
+				start = MetricSample{Time: samples[len(samples)-1].Time.Add(1)}.key()
+			}
+			for k, v := c.Seek(start); k != nil; k, v = c.Next() {
+				s, ok := decodeSample(k, v)
+				if !ok {
+					continue
+				}
+				if s.Time.After(to) {
+					break
+				}
+				samples = append(samples, s)
+			}
+		}
+		return nil
+	})
+	return samples, err
+}
+
+// Compact averages raw samples older than r.Raw into one per minute and
+// drops per-minute samples and temperatures older than r.Keep.
+func (m *MetricsStore) Compact(now time.Time, r HistoryRetention) error {
+	return m.db.Update(func(tx *bolt.Tx) error {
+		raw, minute := tx.Bucket(rawBucket), tx.Bucket(minuteBucket)
+		rawCutoff := MetricSample{Time: now.Add(-r.Raw).Truncate(time.Minute)}.key()
+
+		var group []MetricSample
+		flush := func() error {
+			if len(group) == 0 {
+				return nil
+			}
+			avg := MetricSample{Time: group[0].Time.Truncate(time.Minute)}
+			for _, s := range group {
+				avg.CPU += s.CPU / float64(len(group))
+				avg.Memory += s.Memory / float64(len(group))
+			}
+			last := group[len(group)-1] // Counters are cumulative, keep the latest
+			avg.NetIn, avg.NetOut = last.NetIn, last.NetOut
+			group = group[:0]
+			return minute.Put(avg.key(), avg.encode())
+		}
+
+		var old [][]byte
+		c := raw.Cursor()
+		for k, v := c.First(); k != nil && bytes.Compare(k, rawCutoff) < 0; k, v = c.Next() {
+			old = append(old, append([]byte(nil), k...))
+			s, ok := decodeSample(k, v)
+			if !ok {
+				continue
+			}
+			if len(group) > 0 && !s.Time.Truncate(time.Minute).Equal(group[0].Time.Truncate(time.Minute)) {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			group = append(group, s)
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		for _, k := range old {
+			if err := raw.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		keepCutoff := MetricSample{Time: now.Add(-r.Keep)}.key()
+		old = old[:0]
+		c = minute.Cursor()
+		for k, _ := c.First(); k != nil && bytes.Compare(k, keepCutoff) < 0; k, _ = c.Next() {
+			old = append(old, append([]byte(nil), k...))
+		}
+		for _, k := range old {
+			if err := minute.Delete(k); err != nil {
+				return err
+			}
+		}
+		return compactTemperatures(tx, now.Add(-r.Keep)) // See weathertrend.go
+	})
+}
+
+// migrateHistoryJSON imports an old system_history.json into the store and
+// renames it to .bak. The file only kept clock times, so samples are spaced
+// by interval back from the file's modification time.
+func (m *MetricsStore) migrateHistoryJSON(path string, interval time.Duration) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	var old SystemHistory
+	if err := json.Unmarshal(data, &old); err != nil {
+		return fmt.Errorf("parsing %s: %w", filepath.Base(path), err)
+	}
+	n := min(len(old.CPU), len(old.Memory))
+	samples := make([]MetricSample, 0, n)
+	for i := 0; i < n; i++ {
+		s := MetricSample{
+			Time:   info.ModTime().Add(-time.Duration(n-1-i) * interval),
+			CPU:    old.CPU[i],
+			Memory: old.Memory[i],
+		}
+		if len(old.NetworkIn) == n && len(old.NetworkOut) == n {
+			s.NetIn, s.NetOut = old.NetworkIn[i], old.NetworkOut[i]
+		}
+		samples = append(samples, s)
+	}
+	if err := m.Append(samples...); err != nil {
+		return err
+	}
+	return os.Rename(path, path+".bak")
+}
+
+// compactMetrics runs Compact on the store. Scheduled every metricsCompactInterval.
+func (b *Baseline) compactMetrics() {
+	if b.metrics == nil {
+		return
+	}
+	if err := b.metrics.Compact(time.Now(), b.retention); err != nil {
+		b.notify("history", fmt.Sprintf("Error compacting metrics: %v", err), "error")
+	}
+}
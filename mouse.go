@@ -0,0 +1,94 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- Mouse Support ---
+//
+// Clicking a panel focuses it (see panelfocus.go) and the wheel scrolls it.
+// In the task list a left click toggles the todo under the pointer and a
+// right click deletes it. MOUSE=false keeps the keyboard-only behaviour, e.g.
+// to use the terminal's own text selection.
+
+func mouseEnabled() bool {
+	return strings.ToLower(os.Getenv("MOUSE")) != "false"
+}
+
+// setupMouse turns on mouse reporting. Called from Run after setupLayout.
+func (b *Baseline) setupMouse() {
+	b.app.EnableMouse(true)
+	b.app.SetMouseCapture(b.mouseHandler)
+	b.todoPanel.SetMouseCapture(b.todoMouseHandler)
+}
+
+// mouseHandler keeps clicks from reaching the dashboard behind an overlay or
+// while a command is being typed.
+func (b *Baseline) mouseHandler(event *tcell.EventMouse, action tview.MouseAction) (*tcell.EventMouse, tview.MouseAction) {
+	if action == tview.MouseMove {
+		return event, action
+	}
+	x, y := event.Position()
+	if b.app.GetFocus() == b.cmdInput && !b.cmdInput.InRect(x, y) {
+		return nil, action
+	}
+	if b.overlayOpen() && b.overlay != nil && !inRect(b.overlay, x, y) {
+		return nil, action
+	}
+	return event, action
+}
+
+func inRect(p tview.Primitive, x, y int) bool {
+	px, py, w, h := p.GetRect()
+	return x >= px && x < px+w && y >= py && y < py+h
+}
+
+// todoAt returns the index of the todo drawn at screen row y, or -1. The
+// panel wraps long lines, so each item may take several rows, and a tag
+// filter may hide items.
+func (b *Baseline) todoAt(y int) int {
+	_, top, width, _ := b.todoPanel.GetInnerRect()
+	offset, _ := b.todoPanel.GetScrollOffset()
+	row := y - top + offset
+	if row < 0 || width <= 0 {
+		return -1
+	}
+	for i, line := range b.todoLines {
+		rows := todoRows(line, width)
+		if row < rows {
+			return b.todoLineItems[i]
+		}
+		row -= rows
+	}
+	return -1
+}
+
+// todoMouseHandler toggles (left click) or deletes (right click) a todo.
+func (b *Baseline) todoMouseHandler(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+	if action != tview.MouseLeftClick && action != tview.MouseRightClick {
+		return action, event
+	}
+	_, y := event.Position()
+
+	b.mu.Lock()
+	i := b.todoAt(y)
+	if i < 0 || i >= len(b.todoItems) {
+		b.mu.Unlock()
+		return action, event // Help line or empty space: just focus
+	}
+	b.todoCursor = i
+	if action == tview.MouseLeftClick {
+		b.toggleTodo(i)
+	} else {
+		b.deleteTodo(i)
+	}
+	b.mu.Unlock()
+
+	go b.updateTodos()
+	go b.updateFooter()
+	return action, event
+}
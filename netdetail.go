@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+// --- Per-Interface Network View ---
+//
+// N opens a live table of every interface from net.IOCounters(true): rates
+// since the previous sample, packet totals, errors and drops. Loopback and
+// interfaces that never moved a byte are hidden unless `a` is pressed.
+
+type ifaceRate struct {
+	Stat           net.IOCountersStat
+	RxRate, TxRate float64 // Bytes per second
+}
+
+// interfaceRates pairs the current counters with the previous sample.
+func interfaceRates(prev, cur []net.IOCountersStat, elapsed time.Duration) []ifaceRate {
+	before := map[string]net.IOCountersStat{}
+	for _, s := range prev {
+		before[s.Name] = s
+	}
+	var rates []ifaceRate
+	for _, s := range cur {
+		r := ifaceRate{Stat: s}
+		if p, ok := before[s.Name]; ok && elapsed > 0 && s.BytesRecv >= p.BytesRecv && s.BytesSent >= p.BytesSent {
+			r.RxRate = float64(s.BytesRecv-p.BytesRecv) / elapsed.Seconds()
+			r.TxRate = float64(s.BytesSent-p.BytesSent) / elapsed.Seconds()
+		}
+		rates = append(rates, r)
+	}
+	// Busiest first, then by name so idle interfaces don't jump around
+	sort.SliceStable(rates, func(i, j int) bool {
+		ti, tj := rates[i].RxRate+rates[i].TxRate, rates[j].RxRate+rates[j].TxRate
+		if ti != tj {
+			return ti > tj
+		}
+		return rates[i].Stat.Name < rates[j].Stat.Name
+	})
+	return rates
+}
+
+func isLoopback(name string) bool {
+	return name == "lo" || strings.HasPrefix(name, "lo0") || strings.Contains(strings.ToLower(name), "loopback")
+}
+
+func formatRate(bytesPerSec float64) string {
+	return formatBytes(int64(bytesPerSec)) + "/s"
+}
+
+// openNetDetail shows the per-interface view. Must run on the UI goroutine.
+func (b *Baseline) openNetDetail() {
+	table := tview.NewTable().SetFixed(1, 0)
+	table.SetBorder(true).SetTitle(" Network Interfaces ")
+	table.SetBorderColor(b.theme.Bright)
+	table.SetTitleColor(b.theme.Bright)
+
+	stop := make(chan struct{})
+	showAll := false
+	var rates []ifaceRate
+
+	render := func() {
+		table.Clear()
+		headers := []string{"INTERFACE", "RX/S", "TX/S", "RX TOTAL", "TX TOTAL", "PKTS IN", "PKTS OUT", "ERR IN/OUT", "DROP IN/OUT"}
+		for col, h := range headers {
+			align := tview.AlignRight
+			if col == 0 {
+				align = tview.AlignLeft
+			}
+			table.SetCell(0, col, tview.NewTableCell(h).SetTextColor(b.theme.Bright).SetAttributes(tcell.AttrBold).SetAlign(align))
+		}
+		row := 1
+		hidden := 0
+		for _, r := range rates {
+			s := r.Stat
+			if !showAll && (isLoopback(s.Name) || s.BytesRecv+s.BytesSent == 0) {
+				hidden++
+				continue
+			}
+			color := b.theme.Main
+			if r.RxRate+r.TxRate == 0 {
+				color = b.theme.Dim
+			}
+			problems := b.theme.Dim
+			if s.Errin+s.Errout+s.Dropin+s.Dropout > 0 {
+				problems = tcell.ColorRed
+			}
+			cells := []struct {
+				text  string
+				color tcell.Color
+			}{
+				{s.Name, b.theme.Bright},
+				{formatRate(r.RxRate), color},
+				{formatRate(r.TxRate), color},
+				{formatBytes(int64(s.BytesRecv)), b.theme.Dim},
+				{formatBytes(int64(s.BytesSent)), b.theme.Dim},
+				{fmt.Sprintf("%d", s.PacketsRecv), b.theme.Dim},
+				{fmt.Sprintf("%d", s.PacketsSent), b.theme.Dim},
+				{fmt.Sprintf("%d/%d", s.Errin, s.Errout), problems},
+				{fmt.Sprintf("%d/%d", s.Dropin, s.Dropout), problems},
+			}
+			for col, c := range cells {
+				align := tview.AlignRight
+				if col == 0 {
+					align = tview.AlignLeft
+				}
+				table.SetCell(row, col, tview.NewTableCell(c.text).SetTextColor(c.color).SetAlign(align).SetExpansion(1))
+			}
+			row++
+		}
+		hint := "Esc/N close  a show all"
+		if hidden > 0 {
+			hint = fmt.Sprintf("%d idle/loopback hidden  %s", hidden, hint)
+		}
+		table.SetCell(row+1, 0, tview.NewTableCell(hint).SetTextColor(b.theme.Dim))
+	}
+
+	// Sample in the background until the view closes or the dashboard quits
+	b.mu.RLock()
+	interval := b.intervals.System
+	b.mu.RUnlock()
+	b.life.Go(func() {
+		prev, _ := net.IOCounters(true)
+		prevTime := time.Now()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-b.life.Done():
+				return
+			case <-ticker.C:
+				cur, err := net.IOCounters(true)
+				if err != nil {
+					continue
+				}
+				now := time.Now()
+				sampled := interfaceRates(prev, cur, now.Sub(prevTime))
+				prev, prevTime = cur, now
+				b.app.QueueUpdateDraw(func() {
+					rates = sampled
+					render()
+				})
+			}
+		}
+	})
+	if cur, err := net.IOCounters(true); err == nil {
+		rates = interfaceRates(nil, cur, 0) // Totals right away, rates from the first tick
+	}
+	render()
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape, event.Rune() == 'N', event.Rune() == 'q':
+			close(stop)
+			b.closeOverlay("netdetail")
+			return nil
+		case event.Rune() == 'a':
+			showAll = !showAll
+			render()
+			return nil
+		}
+		return event
+	})
+	b.showOverlay("netdetail", table, 110, 20)
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- News Feed Widget ---
+//
+// NEWS_FEEDS lists RSS or Atom feed URLs; the News panel shows the newest
+// NEWS_COUNT headlines across them with their age. The selection moves to
+// the next headline every NEWS_ROTATE (default 10s, "off" to stop it); with
+// the panel focused ↑/↓ move it and `o` opens the selected link in the
+// browser. `news open <n>` opens any headline.
+
+const (
+	newsRefreshInterval = 15 * time.Minute
+	newsRotateCheck     = time.Second
+	newsDefaultCount    = 8
+	newsDefaultRotate   = 10 * time.Second
+	newsMaxBody         = 4 << 20 // Bytes read from a feed
+)
+
+type NewsItem struct {
+	Title     string
+	Link      string
+	Source    string // Feed title
+	Published time.Time
+}
+
+type NewsInfo struct {
+	Items       []NewsItem
+	Error       string // Every feed failed
+	Failed      []string
+	LastUpdated time.Time
+}
+
+// NewsConfig holds the NEWS_* settings.
+type NewsConfig struct {
+	feeds  []string
+	count  int
+	rotate time.Duration // 0 keeps the selection where it is
+	client http.Client
+}
+
+// newNewsFromEnv returns nil when NEWS_FEEDS is unset.
+func newNewsFromEnv() *NewsConfig {
+	var feeds []string
+	for _, f := range strings.Split(os.Getenv("NEWS_FEEDS"), ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			feeds = append(feeds, f)
+		}
+	}
+	if len(feeds) == 0 {
+		return nil
+	}
+	n := &NewsConfig{feeds: feeds, count: newsDefaultCount, rotate: newsDefaultRotate, client: http.Client{Timeout: 10 * time.Second}}
+	if c, err := strconv.Atoi(os.Getenv("NEWS_COUNT")); err == nil && c > 0 {
+		n.count = c
+	}
+	switch v := strings.ToLower(strings.TrimSpace(os.Getenv("NEWS_ROTATE"))); v {
+	case "":
+	case "off", "false", "0":
+		n.rotate = 0
+	default:
+		if d, err := time.ParseDuration(v); err == nil && d >= time.Second {
+			n.rotate = d
+		}
+	}
+	return n
+}
+
+// feedDoc covers RSS 2.0 (channel/item), RSS 1.0 (item at the top level)
+// and Atom (entry).
+type feedDoc struct {
+	Channel struct {
+		Title string     `xml:"title"`
+		Items []feedItem `xml:"item"`
+	} `xml:"channel"`
+	Title   string     `xml:"title"`
+	Items   []feedItem `xml:"item"`
+	Entries []struct {
+		Title string `xml:"title"`
+		Links []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Published string `xml:"published"`
+		Updated   string `xml:"updated"`
+	} `xml:"entry"`
+}
+
+type feedItem struct {
+	Title   string `xml:"title"`
+	Link    string `xml:"link"`
+	PubDate string `xml:"pubDate"`
+	DCDate  string `xml:"http://purl.org/dc/elements/1.1/ date"`
+}
+
+var feedDateLayouts = []string{time.RFC1123Z, time.RFC1123, time.RFC3339, "Mon, 2 Jan 2006 15:04:05 -0700", "Mon, 2 Jan 2006 15:04:05 MST", "2 Jan 2006 15:04:05 -0700", "2006-01-02T15:04:05Z07:00"}
+
+// parseFeedDate reads the date formats feeds use, zero when none fits.
+func parseFeedDate(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range feedDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// parseFeed reads an RSS or Atom document.
+func parseFeed(r io.Reader, url string) ([]NewsItem, error) {
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	dec.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil } // Read as UTF-8
+	var doc feedDoc
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("not an RSS or Atom feed: %w", err)
+	}
+
+	source := strings.TrimSpace(doc.Channel.Title)
+	if source == "" {
+		source = strings.TrimSpace(doc.Title)
+	}
+	if source == "" {
+		source = url
+	}
+	var items []NewsItem
+	for _, it := range append(doc.Channel.Items, doc.Items...) {
+		date := it.PubDate
+		if date == "" {
+			date = it.DCDate
+		}
+		items = append(items, NewsItem{Title: it.Title, Link: strings.TrimSpace(it.Link), Source: source, Published: parseFeedDate(date)})
+	}
+	for _, e := range doc.Entries {
+		item := NewsItem{Title: e.Title, Source: source, Published: parseFeedDate(e.Published)}
+		if item.Published.IsZero() {
+			item.Published = parseFeedDate(e.Updated)
+		}
+		for _, l := range e.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				item.Link = l.Href
+				break
+			}
+		}
+		items = append(items, item)
+	}
+	for i := range items {
+		items[i].Title = strings.Join(strings.Fields(items[i].Title), " ") // Titles may span lines
+	}
+	return items, nil
+}
+
+// fetchFeed downloads and parses one feed.
+func (n *NewsConfig) fetchFeed(url string) ([]NewsItem, error) {
+	resp, err := n.client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP error: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return parseFeed(io.LimitReader(resp.Body, newsMaxBody), url)
+}
+
+func (b *Baseline) fetchNews() {
+	info := NewsInfo{LastUpdated: time.Now()}
+	var errs []string
+	for _, url := range b.news.feeds {
+		items, err := b.news.fetchFeed(url)
+		if err != nil {
+			info.Failed = append(info.Failed, url)
+			errs = append(errs, fmt.Sprintf("%s: %v", url, err))
+			continue
+		}
+		info.Items = append(info.Items, items...)
+	}
+	if len(info.Items) == 0 && len(errs) > 0 {
+		info.Error = strings.Join(errs, "; ")
+	}
+	sort.SliceStable(info.Items, func(i, j int) bool { return info.Items[i].Published.After(info.Items[j].Published) })
+	info.Items = info.Items[:min(b.news.count, len(info.Items))]
+
+	b.mu.Lock()
+	prevFailed := strings.Join(b.newsInfo.Failed, ",")
+	b.newsInfo = info
+	b.newsSel = min(b.newsSel, max(0, len(info.Items)-1))
+	b.mu.Unlock()
+
+	if len(errs) > 0 && strings.Join(info.Failed, ",") != prevFailed {
+		b.notify("news", "News: "+strings.Join(errs, "; "), "error")
+	}
+	b.updateNews()
+}
+
+// rotateNews moves the selection to the next headline once NEWS_ROTATE has
+// passed. Scheduled every newsRotateCheck.
+func (b *Baseline) rotateNews() {
+	b.mu.Lock()
+	if b.news.rotate == 0 || len(b.newsInfo.Items) < 2 || time.Since(b.newsShown) < b.news.rotate {
+		b.mu.Unlock()
+		return
+	}
+	b.newsSel = (b.newsSel + 1) % len(b.newsInfo.Items)
+	b.newsShown = time.Now()
+	b.mu.Unlock()
+	b.updateNews()
+}
+
+// moveNewsSelection moves the selection by delta, wrapping around, and holds
+// off the rotation. Called from inputHandler with b.mu held.
+func (b *Baseline) moveNewsSelection(delta int) {
+	if n := len(b.newsInfo.Items); n > 0 {
+		b.newsSel = ((b.newsSel+delta)%n + n) % n
+	}
+	b.newsShown = time.Now()
+	go b.updateNews()
+}
+
+// newsAge is a compact age: "5m", "3h", "2d".
+func newsAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", max(0, int(d.Minutes())))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+func (b *Baseline) updateNews() {
+	b.mu.RLock()
+	info := b.newsInfo
+	sel := b.newsSel
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sHEADLINES[-:-:-]\n", brightC+"[::b]"))
+	switch {
+	case info.Error != "":
+		sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(info.Error)))
+	case info.LastUpdated.IsZero():
+		sb.WriteString(fmt.Sprintf("%sLoading...[-:-:-]\n", dimC))
+	case len(info.Items) == 0:
+		sb.WriteString(fmt.Sprintf("%s(No headlines)[-:-:-]\n", dimC))
+	}
+	for i, item := range info.Items {
+		age := "  "
+		if !item.Published.IsZero() {
+			age = newsAge(time.Since(item.Published))
+		}
+		marker, textC := " ", mainC
+		if i == sel {
+			marker, textC = "▸", brightC
+		}
+		sb.WriteString(fmt.Sprintf("%s%s %s%3s %s%s %s(%s)[-:-:-]\n",
+			brightC, marker,
+			dimC, age,
+			textC, tview.Escape(item.Title),
+			dimC, tview.Escape(item.Source),
+		))
+	}
+	if len(info.Failed) > 0 && info.Error == "" {
+		sb.WriteString(fmt.Sprintf("[red]%d feed(s) failed[-:-:-]\n", len(info.Failed)))
+	}
+	sb.WriteString(fmt.Sprintf("\n%sFocus and press 'o' to open. Last updated: %s[-:-:-]", dimC, info.LastUpdated.Format("15:04:05")))
+
+	b.setPanel(b.newsPanel, sb.String())
+}
+
+// openNews opens the headline at the 1-based index, the selected one for 0.
+func (b *Baseline) openNews(index int) {
+	b.mu.RLock()
+	items := b.newsInfo.Items
+	if index == 0 {
+		index = b.newsSel + 1
+	}
+	b.mu.RUnlock()
+
+	if index < 1 || index > len(items) {
+		b.notify("news", fmt.Sprintf("Invalid headline index: %d", index), "error")
+		return
+	}
+	if items[index-1].Link == "" {
+		b.notify("news", "That headline has no link", "error")
+		return
+	}
+	if err := openBrowser(items[index-1].Link); err != nil {
+		b.notify("news", err.Error(), "error")
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- Scratchpad / Notes Widget ---
+
+const notesRefreshInterval = 30 * time.Second // Picks up edits made outside the dashboard
+
+// notesPath returns the notes file when NOTES or NOTES_FILE is set, "" otherwise.
+func notesPath(configDir string) string {
+	if path := os.Getenv("NOTES_FILE"); path != "" {
+		return path
+	}
+	v := strings.ToLower(os.Getenv("NOTES"))
+	if v == "true" || v == "1" || v == "yes" {
+		return filepath.Join(configDir, "notes.md")
+	}
+	return ""
+}
+
+func (b *Baseline) readNotes() (string, time.Time, error) {
+	info, err := os.Stat(b.notesFile)
+	if os.IsNotExist(err) {
+		return "", time.Time{}, nil // Nothing written yet
+	} else if err != nil {
+		return "", time.Time{}, err
+	}
+	data, err := os.ReadFile(b.notesFile)
+	return string(data), info.ModTime(), err
+}
+
+func (b *Baseline) saveNotes(text string) {
+	if err := os.WriteFile(b.notesFile, []byte(text), 0640); err != nil {
+		b.notify("notes", fmt.Sprintf("Error saving notes: %v", err), "error")
+		return
+	}
+	b.updateNotes()
+}
+
+func (b *Baseline) updateNotes() {
+	text, modified, err := b.readNotes()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sNOTES[-:-:-]\n", brightC+"[::b]"))
+
+	if err != nil {
+		sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(err.Error())))
+	} else if strings.TrimSpace(text) == "" {
+		sb.WriteString(fmt.Sprintf("%s(Empty - press 'e' to write)[-:-:-]\n", dimC))
+	} else {
+		sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n", mainC, tview.Escape(strings.TrimRight(text, "\n"))))
+	}
+
+	if !modified.IsZero() {
+		sb.WriteString(fmt.Sprintf("\n%sSaved: %s[-:-:-]", dimC, modified.Format("01-02 15:04")))
+	}
+
+	b.setPanel(b.notesPanel, sb.String())
+}
+
+// openNotesEditor edits the notes in an overlay. Esc saves and closes, Ctrl-X
+// discards the changes. Must run on the UI goroutine.
+func (b *Baseline) openNotesEditor() {
+	text, _, err := b.readNotes()
+	if err != nil {
+		b.notify("notes", fmt.Sprintf("Error reading notes: %v", err), "error")
+		return
+	}
+
+	area := tview.NewTextArea()
+	area.SetText(text, true)
+	area.SetTextStyle(tcell.StyleDefault.Foreground(b.theme.Main))
+	area.SetBorder(true).
+		SetTitle(" Notes (Esc save, Ctrl-X discard) ").
+		SetBorderColor(b.theme.Bright).
+		SetTitleColor(b.theme.Bright)
+
+	area.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			newText := area.GetText()
+			b.closeOverlay("notes")
+			if newText != text {
+				go b.saveNotes(newText)
+			}
+			return nil
+		case tcell.KeyCtrlX:
+			b.closeOverlay("notes")
+			return nil
+		}
+		return event
+	})
+
+	b.showOverlay("notes", area, 80, 25)
+}
+
+// editNotesExternal suspends the dashboard and opens the notes in $EDITOR.
+func (b *Baseline) editNotesExternal() {
+	if err := b.runEditor(b.notesFile); err != nil {
+		b.notify("notes", fmt.Sprintf("Editor failed: %v", err), "error")
+	}
+	b.updateNotes()
+}
+
+// runEditor suspends the dashboard and opens path in $VISUAL / $EDITOR.
+// Don't hold b.mu: background refreshes would block until the editor exits.
+func (b *Baseline) runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	var runErr error
+	b.app.Suspend(func() {
+		// EDITOR may carry flags ("code --wait")
+		args := append(strings.Fields(editor), path)
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		runErr = cmd.Run()
+	})
+	return runErr
+}
@@ -0,0 +1,475 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- Notification History ---
+//
+// Every notification is appended to ~/.baseline/notifications.log (JSON lines),
+// tagged with the session it was raised in. The in-memory list still only keeps
+// the few entries the footer needs.
+
+const (
+	notificationLogFile    = "notifications.log"
+	notificationLogMaxKeep = 10000 // Older entries are dropped at startup
+)
+
+type NotificationRecord struct {
+	Time     time.Time `json:"time"`
+	Type     string    `json:"type"`
+	Message  string    `json:"message"`
+	Source   string    `json:"source,omitempty"`
+	Priority string    `json:"priority,omitempty"`
+	Session  string    `json:"session"`
+	Held     bool      `json:"held,omitempty"` // Suppressed by do-not-disturb
+}
+
+type notificationSession struct {
+	ID      string
+	Records []NotificationRecord
+}
+
+// openNotificationLog trims the history file and opens it for appending.
+func (b *Baseline) openNotificationLog() {
+	path := filepath.Join(b.configDir, notificationLogFile)
+	if records, err := readNotificationLog(path); err == nil && len(records) > notificationLogMaxKeep {
+		var sb strings.Builder
+		for _, r := range records[len(records)-notificationLogMaxKeep:] {
+			line, _ := json.Marshal(r)
+			sb.Write(line)
+			sb.WriteByte('\n')
+		}
+		_ = os.WriteFile(path, []byte(sb.String()), 0640)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		slog.Warn("Could not open notification log", "err", err)
+		return
+	}
+	b.notifLog = f
+}
+
+// logNotification appends one record. Called from addNotification with notifMu held.
+func (b *Baseline) logNotification(n Notification, held bool) {
+	if b.notifLog == nil {
+		return
+	}
+	line, err := json.Marshal(NotificationRecord{
+		Time:     n.Time,
+		Type:     n.Type,
+		Message:  n.Message,
+		Source:   n.Source,
+		Priority: n.Priority.String(),
+		Session:  b.sessionID,
+		Held:     held,
+	})
+	if err != nil {
+		return
+	}
+	if _, err := b.notifLog.Write(append(line, '\n')); err != nil {
+		slog.Warn("Could not write notification log", "err", err)
+	}
+}
+
+func readNotificationLog(path string) ([]NotificationRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []NotificationRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r NotificationRecord
+		if json.Unmarshal(scanner.Bytes(), &r) == nil {
+			records = append(records, r)
+		}
+	}
+	return records, scanner.Err()
+}
+
+// notificationSessions groups the history by session, newest session first.
+func (b *Baseline) notificationSessions() []notificationSession {
+	records, _ := readNotificationLog(filepath.Join(b.configDir, notificationLogFile))
+
+	var sessions []notificationSession
+	index := map[string]int{}
+	for _, r := range records {
+		i, ok := index[r.Session]
+		if !ok {
+			i = len(sessions)
+			index[r.Session] = i
+			sessions = append(sessions, notificationSession{ID: r.Session})
+		}
+		sessions[i].Records = append(sessions[i].Records, r)
+	}
+	if _, ok := index[b.sessionID]; !ok {
+		sessions = append(sessions, notificationSession{ID: b.sessionID}) // Nothing logged yet
+	}
+
+	// Reverse: newest first
+	for i, j := 0, len(sessions)-1; i < j; i, j = i+1, j-1 {
+		sessions[i], sessions[j] = sessions[j], sessions[i]
+	}
+	return sessions
+}
+
+// --- Notification Center Overlay ---
+
+// openNotificationCenter shows the notification history, one session at a time.
+// Must run on the UI goroutine.
+func (b *Baseline) openNotificationCenter() {
+	sessions := b.notificationSessions()
+	current := 0
+	selected := 0 // Index into the active alert list
+
+	view := newPanel(" Notification Center ")
+	view.SetBorderColor(b.theme.Bright)
+	view.SetTitleColor(b.theme.Bright)
+	view.SetTextColor(b.theme.Main)
+
+	render := func() {
+		mainC := colorTag(b.theme.Main)
+		dimC := colorTag(b.theme.Dim)
+		brightC := colorTag(b.theme.Bright)
+
+		s := sessions[current]
+		label := "current session"
+		if s.ID != b.sessionID {
+			label = "past session"
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("%sSession %d/%d: %s (%s)[-:-:-]\n", brightC+"[::b]", current+1, len(sessions), s.ID, label))
+		sb.WriteString(fmt.Sprintf("%s←/→ switch session  ↑/↓ scroll  Esc close[-:-:-]\n\n", dimC))
+
+		if alerts := b.activeAlerts(); len(alerts) > 0 {
+			if selected >= len(alerts) {
+				selected = len(alerts) - 1
+			}
+			sb.WriteString(fmt.Sprintf("%sACTIVE ALERTS[-:-:-] %s(Tab select, a ack, s snooze %s)[-:-:-]\n", brightC+"[::b]", dimC, alertSnoozeDefault))
+			for i, a := range alerts {
+				marker := "  "
+				if i == selected {
+					marker = brightC + "> "
+				}
+				stateC := "[red]"
+				if a.State() != "active" {
+					stateC = dimC
+				}
+				sb.WriteString(fmt.Sprintf("%s%s%d %s%-22s %s%s %ssince %s[-:-:-]\n",
+					marker, dimC, i+1,
+					stateC, a.State(),
+					mainC, tview.Escape(a.Message),
+					dimC, a.Since.Format("01-02 15:04"),
+				))
+			}
+			sb.WriteString("\n")
+		}
+
+		if len(s.Records) == 0 {
+			sb.WriteString(fmt.Sprintf("%s(No notifications)[-:-:-]\n", dimC))
+		}
+		for i := len(s.Records) - 1; i >= 0; i-- {
+			r := s.Records[i]
+			held := ""
+			if r.Held {
+				held = dimC + " (held: DND)"
+			}
+			source := ""
+			if r.Source != "" {
+				source = dimC + r.Source + ": "
+			}
+			sb.WriteString(fmt.Sprintf("%s%s %s%-7s %s%s%s[-:-:-]\n",
+				dimC, r.Time.Format("01-02 15:04:05"),
+				notificationColor(r.Type, b.theme), r.Type,
+				source, mainC+tview.Escape(r.Message), held,
+			))
+		}
+		view.SetText(sb.String())
+		view.ScrollToBeginning()
+	}
+	render()
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			b.closeOverlay("notifications")
+			return nil
+		case tcell.KeyLeft:
+			if current < len(sessions)-1 {
+				current++ // Older
+				render()
+			}
+			return nil
+		case tcell.KeyRight:
+			if current > 0 {
+				current-- // Newer
+				render()
+			}
+			return nil
+		}
+		if event.Key() == tcell.KeyTab {
+			selected++
+			if selected >= len(b.activeAlerts()) {
+				selected = 0
+			}
+			render()
+			return nil
+		}
+		switch event.Rune() {
+		case 'q':
+			b.closeOverlay("notifications")
+			return nil
+		case 'a', 's':
+			alerts := b.activeAlerts()
+			if selected < len(alerts) {
+				if event.Rune() == 'a' {
+					b.ackAlert(alerts[selected].Key)
+				} else {
+					b.snoozeAlert(alerts[selected].Key, alertSnoozeDefault)
+				}
+				render()
+			}
+			return nil
+		}
+		return event
+	})
+
+	b.showOverlay("notifications", view, 90, 30)
+}
+
+// --- Priority Routing ---
+
+// Priority decides where a notification goes: low ones only reach the history,
+// normal ones the footer, high ones also the desktop and webhook (see delivery.go).
+type Priority int
+
+const (
+	PriorityLow Priority = iota - 1
+	PriorityNormal
+	PriorityHigh
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityHigh:
+		return "high"
+	}
+	return "normal"
+}
+
+func parsePriority(s string) (Priority, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "low":
+		return PriorityLow, true
+	case "normal":
+		return PriorityNormal, true
+	case "high":
+		return PriorityHigh, true
+	}
+	return PriorityNormal, false
+}
+
+// notificationFilter assigns priorities and routes from config. Rules are
+// comma separated "type" (any source), "source" (any type) or "source:type"
+// entries: NOTIFY_HISTORY_ONLY lists rules that become low priority, and
+// NOTIFY_PRIORITY lists "rule=level" pairs, e.g.
+// "info=low,vpn=high,jira:error=high". [[route]] tables in config.toml send
+// the notifications matching their rules to one place, see parseRoutes.
+type notificationFilter struct {
+	rules  map[string]Priority // "source:type" keys, "*" as wildcard
+	routes map[string]string   // Same keys, "log", "footer" or "desktop"
+}
+
+var notificationTypes = map[string]bool{"info": true, "error": true, "success": true}
+
+// ruleKey turns a rule into its "source:type" key, "" for an empty rule.
+func ruleKey(rule string) string {
+	rule = strings.ToLower(strings.TrimSpace(rule))
+	switch {
+	case rule == "", strings.Contains(rule, ":"):
+		return rule
+	case notificationTypes[rule]:
+		return "*:" + rule
+	}
+	return rule + ":*"
+}
+
+// matchRule returns the most specific key that has a rule: "source:type",
+// then "*:type", then "source:*". Errors only match a rule naming their
+// source explicitly.
+func matchRule(source, msgType string, has func(key string) bool) (string, bool) {
+	if source == "" {
+		source = "core"
+	}
+	keys := []string{source + ":" + msgType}
+	if msgType != "error" {
+		keys = append(keys, "*:"+msgType, source+":*")
+	}
+	for _, key := range keys {
+		if has(key) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+func parseNotificationFilter(historyOnly, priorities string) notificationFilter {
+	f := notificationFilter{rules: map[string]Priority{}}
+	add := func(rule string, p Priority) {
+		if key := ruleKey(rule); key != "" {
+			f.rules[key] = p
+		}
+	}
+	for _, rule := range strings.Split(historyOnly, ",") {
+		add(rule, PriorityLow)
+	}
+	for _, entry := range strings.Split(priorities, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		rule, level, _ := strings.Cut(entry, "=")
+		p, ok := parsePriority(level)
+		if !ok {
+			slog.Warn("Invalid NOTIFY_PRIORITY entry", "entry", entry)
+			continue
+		}
+		add(rule, p)
+	}
+	return f
+}
+
+// priority returns the configured priority for a notification, or def.
+// Errors are only re-prioritised by a rule naming their source explicitly.
+func (f notificationFilter) priority(source, msgType string, def Priority) Priority {
+	key, ok := matchRule(source, msgType, func(key string) bool {
+		_, ok := f.rules[key]
+		return ok
+	})
+	if !ok {
+		return def
+	}
+	return f.rules[key]
+}
+
+// route returns the [[route]] destination of a notification, "" for none.
+func (f notificationFilter) route(source, msgType string) string {
+	key, _ := matchRule(source, msgType, func(key string) bool { return f.routes[key] != "" })
+	return f.routes[key]
+}
+
+// routeFile is a [[route]] table of config.toml:
+//
+//	[[route]]
+//	match = "lan, vpn:success"  # Rules as in NOTIFY_PRIORITY
+//	to = "log"                  # log, footer or desktop
+type routeFile struct {
+	Match string `toml:"match"`
+	To    string `toml:"to"`
+}
+
+// notificationRoutes are the destinations of a [[route]]: the notification
+// center only (like low priority), the footer but never the desktop, or the
+// desktop as well whatever the priority and [desktop] sources.
+var notificationRoutes = []string{"log", "footer", "desktop"}
+
+// parseRoutes maps the rules of every [[route]] to its destination; a later
+// route wins for the same rule.
+func parseRoutes(files []routeFile) (map[string]string, []string) {
+	routes := map[string]string{}
+	var warnings []string
+	for i, f := range files {
+		to := strings.ToLower(strings.TrimSpace(f.To))
+		if !slices.Contains(notificationRoutes, to) {
+			warnings = append(warnings, fmt.Sprintf("config.toml: route #%d: to must be log, footer or desktop, not %q", i+1, f.To))
+			continue
+		}
+		found := false
+		for _, rule := range strings.Split(f.Match, ",") {
+			if key := ruleKey(rule); key != "" {
+				routes[key], found = to, true
+			}
+		}
+		if !found {
+			warnings = append(warnings, fmt.Sprintf("config.toml: route #%d: match is empty", i+1))
+		}
+	}
+	return routes, warnings
+}
+
+// routed applies the route of n to its priority: "log" is low, "footer" at
+// most normal and "desktop" at least normal.
+func routed(n Notification) Priority {
+	switch n.Route {
+	case "log":
+		return PriorityLow
+	case "footer":
+		return min(n.Priority, PriorityNormal)
+	case "desktop":
+		return max(n.Priority, PriorityNormal)
+	}
+	return n.Priority
+}
+
+// --- Footer Expiry ---
+
+// Default footer display times; errors stay until replaced.
+var defaultNotificationDurations = map[string]time.Duration{
+	"info":    10 * time.Second,
+	"success": 10 * time.Second,
+	"error":   0,
+}
+
+// notificationDurationsFromEnv reads NOTIFY_DURATION_INFO, NOTIFY_DURATION_SUCCESS
+// and NOTIFY_DURATION_ERROR (e.g. "15s", "0" to keep the message until replaced).
+func notificationDurationsFromEnv() map[string]time.Duration {
+	durations := map[string]time.Duration{}
+	for msgType, d := range defaultNotificationDurations {
+		durations[msgType] = d
+		v := os.Getenv("NOTIFY_DURATION_" + strings.ToUpper(msgType))
+		if v == "" {
+			continue
+		}
+		if parsed, err := time.ParseDuration(v); err == nil && parsed >= 0 {
+			durations[msgType] = parsed
+		} else {
+			slog.Warn("Invalid NOTIFY_DURATION_"+strings.ToUpper(msgType), "value", v)
+		}
+	}
+	return durations
+}
+
+// notificationColor maps a notification type to its footer color tag.
+func notificationColor(msgType string, theme Theme) string {
+	switch msgType {
+	case "error":
+		if theme.Error != tcell.ColorDefault {
+			return colorTag(theme.Error)
+		}
+		return "[red]"
+	case "success":
+		if theme.Success != tcell.ColorDefault {
+			return colorTag(theme.Success)
+		}
+		return "[green]"
+	default: // info
+		return colorTag(theme.Main)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- Notification Scrollback ---
+//
+// The footer only shows the latest notification; the in-memory buffer keeps
+// the last NOTIFY_BUFFER of them (default 200) for the scrollback overlay
+// (L or `scrollback [text]`). / searches as you type, c clears the buffer.
+// The notification center (m) covers the on-disk history of past sessions.
+
+const defaultNotificationBuffer = 200
+
+// notificationBufferFromEnv reads NOTIFY_BUFFER, at least 1.
+func notificationBufferFromEnv() int {
+	v := os.Getenv("NOTIFY_BUFFER")
+	if v == "" {
+		return defaultNotificationBuffer
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		slog.Warn("Invalid NOTIFY_BUFFER", "value", v, "using", defaultNotificationBuffer)
+		return defaultNotificationBuffer
+	}
+	return n
+}
+
+// notificationMatches reports whether n contains query (lowercased) in its
+// message, source or type.
+func notificationMatches(n Notification, query string) bool {
+	if query == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(n.Message), query) ||
+		strings.Contains(strings.ToLower(n.Source), query) ||
+		n.Type == query
+}
+
+// openNotificationScrollback shows the buffered notifications, oldest first,
+// filtered by query. Must run on the UI goroutine.
+func (b *Baseline) openNotificationScrollback(query string) {
+	view := newPanel(" Notifications ")
+	view.SetBorderColor(b.theme.Bright)
+	view.SetTitleColor(b.theme.Bright)
+	view.SetTextColor(b.theme.Main)
+
+	search := tview.NewInputField().
+		SetLabel(" / ").
+		SetText(query).
+		SetPlaceholder("search  (c clear, Esc close)").
+		SetPlaceholderTextColor(b.theme.Dim).
+		SetFieldBackgroundColor(tcell.ColorDefault).
+		SetFieldTextColor(b.theme.Bright).
+		SetLabelColor(b.theme.Dim)
+
+	render := func() {
+		mainC := colorTag(b.theme.Main)
+		dimC := colorTag(b.theme.Dim)
+
+		b.notifMu.Lock()
+		all := append([]Notification(nil), b.notifications...)
+		b.notifMu.Unlock()
+
+		q := strings.ToLower(strings.TrimSpace(search.GetText()))
+		var sb strings.Builder
+		shown := 0
+		for _, n := range all {
+			if !notificationMatches(n, q) {
+				continue
+			}
+			shown++
+			source := ""
+			if n.Source != "" {
+				source = dimC + n.Source + ": "
+			}
+			sb.WriteString(fmt.Sprintf("%s%s %s%-7s %s%s%s[-:-:-]\n",
+				dimC, n.Time.Format("01-02 15:04:05"),
+				notificationColor(n.Type, b.theme), n.Type,
+				source, mainC, tview.Escape(n.Message),
+			))
+		}
+		if shown == 0 {
+			sb.WriteString(fmt.Sprintf("%s(No notifications)[-:-:-]\n", dimC))
+		}
+		title := fmt.Sprintf(" Notifications (%d/%d) ", shown, b.notifMax)
+		if q != "" {
+			title = fmt.Sprintf(" Notifications matching %q (%d of %d) ", q, shown, len(all))
+		}
+		view.SetTitle(title)
+		view.SetText(sb.String())
+		view.ScrollToEnd()
+	}
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(view, 0, 1, true).
+		AddItem(search, 1, 0, false)
+
+	search.SetChangedFunc(func(string) { render() })
+	search.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			search.SetText("")
+		}
+		b.app.SetFocus(view)
+	})
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			b.closeOverlay("scrollback")
+			return nil
+		}
+		switch event.Rune() {
+		case 'q':
+			b.closeOverlay("scrollback")
+			return nil
+		case '/':
+			b.app.SetFocus(search)
+			return nil
+		case 'c':
+			b.notifMu.Lock()
+			b.notifications = []Notification{}
+			b.notifMu.Unlock()
+			render()
+			go b.updateFooter()
+			return nil
+		}
+		return event
+	})
+
+	render()
+	b.showOverlay("scrollback", container, 100, 30)
+}
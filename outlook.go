@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// --- Microsoft 365 / Outlook Calendar (Microsoft Graph) ---
+
+const graphBaseURL = "https://graph.microsoft.com/v1.0"
+
+// OutlookSource reads events from a Microsoft 365 calendar through Microsoft Graph.
+// It either uses a pre-issued access token (OUTLOOK_ACCESS_TOKEN) or obtains one
+// with the client credentials flow (OUTLOOK_TENANT_ID/CLIENT_ID/CLIENT_SECRET).
+type OutlookSource struct {
+	tenantID     string
+	clientID     string
+	clientSecret string
+	user         string // UPN or object id; "me" when using a delegated token
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+	client      http.Client
+}
+
+// newOutlookSourceFromEnv returns nil when Outlook is not configured.
+func newOutlookSourceFromEnv() *OutlookSource {
+	src := &OutlookSource{
+		tenantID:     os.Getenv("OUTLOOK_TENANT_ID"),
+		clientID:     os.Getenv("OUTLOOK_CLIENT_ID"),
+		clientSecret: os.Getenv("OUTLOOK_CLIENT_SECRET"),
+		user:         os.Getenv("OUTLOOK_USER"),
+		token:        os.Getenv("OUTLOOK_ACCESS_TOKEN"),
+		client:       http.Client{Timeout: 10 * time.Second},
+	}
+
+	hasCredentials := src.tenantID != "" && src.clientID != "" && src.clientSecret != ""
+	if src.token == "" && !hasCredentials {
+		return nil
+	}
+	if src.token != "" {
+		src.tokenExpiry = time.Now().Add(100 * 365 * 24 * time.Hour) // Static token, never refreshed
+		if src.user == "" {
+			src.user = "me" // Delegated token
+		}
+	} else if src.user == "" {
+		return nil // Application tokens cannot use /me, a user must be named explicitly
+	}
+	return src
+}
+
+func (o *OutlookSource) Name() string { return "Outlook" }
+
+// accessToken returns a valid bearer token, refreshing it via client credentials if needed.
+func (o *OutlookSource) accessToken() (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.token != "" && time.Now().Before(o.tokenExpiry.Add(-time.Minute)) {
+		return o.token, nil
+	}
+
+	form := url.Values{}
+	form.Set("client_id", o.clientID)
+	form.Set("client_secret", o.clientSecret)
+	form.Set("scope", "https://graph.microsoft.com/.default")
+	form.Set("grant_type", "client_credentials")
+
+	tokenURL := fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", url.PathEscape(o.tenantID))
+	resp, err := o.client.PostForm(tokenURL, form)
+	if err != nil {
+		return "", fmt.Errorf("token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+		Error       string `json:"error"`
+		Description string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return "", fmt.Errorf("token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || data.AccessToken == "" {
+		return "", fmt.Errorf("token error: %s (%d)", data.Error, resp.StatusCode)
+	}
+
+	o.token = data.AccessToken
+	o.tokenExpiry = time.Now().Add(time.Duration(data.ExpiresIn) * time.Second)
+	return o.token, nil
+}
+
+func (o *OutlookSource) Upcoming(from, to time.Time) ([]CalendarEvent, error) {
+	token, err := o.accessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	userPath := "me"
+	if o.user != "me" {
+		userPath = "users/" + url.PathEscape(o.user)
+	}
+	query := url.Values{}
+	query.Set("startDateTime", from.UTC().Format(time.RFC3339))
+	query.Set("endDateTime", to.UTC().Format(time.RFC3339))
+	query.Set("$orderby", "start/dateTime")
+	query.Set("$select", "subject,start,end,location,isAllDay,isCancelled")
+	query.Set("$top", "25")
+	reqURL := fmt.Sprintf("%s/%s/calendarView?%s", graphBaseURL, userPath, query.Encode())
+
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Prefer", `outlook.timezone="UTC"`) // Times come back in UTC
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&errResp) == nil && errResp.Error.Message != "" {
+			return nil, fmt.Errorf("API error: %s (%d)", errResp.Error.Message, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("API error: Status %d", resp.StatusCode)
+	}
+
+	type graphTime struct {
+		DateTime string `json:"dateTime"`
+	}
+	var data struct {
+		Value []struct {
+			Subject  string    `json:"subject"`
+			Start    graphTime `json:"start"`
+			End      graphTime `json:"end"`
+			IsAllDay bool      `json:"isAllDay"`
+			Canceled bool      `json:"isCancelled"`
+			Location struct {
+				DisplayName string `json:"displayName"`
+			} `json:"location"`
+		} `json:"value"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("JSON parse error: %w", err)
+	}
+
+	events := make([]CalendarEvent, 0, len(data.Value))
+	for _, v := range data.Value {
+		if v.Canceled {
+			continue
+		}
+		// All-day events are dates, midnight wherever the dashboard runs
+		loc := time.UTC
+		if v.IsAllDay {
+			loc = time.Local
+		}
+		start, err := parseGraphTime(v.Start.DateTime, loc)
+		if err != nil {
+			continue
+		}
+		end, _ := parseGraphTime(v.End.DateTime, loc)
+		events = append(events, CalendarEvent{
+			Title:    strings.TrimSpace(v.Subject),
+			Start:    start,
+			End:      end,
+			Location: v.Location.DisplayName,
+			AllDay:   v.IsAllDay,
+			Source:   o.Name(),
+		})
+	}
+	return events, nil
+}
+
+// parseGraphTime parses Graph's zone-less timestamps ("2024-06-01T14:00:00.0000000")
+// in loc: UTC here, except for the dates of all-day events.
+func parseGraphTime(s string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02T15:04:05.9999999", s, loc)
+}
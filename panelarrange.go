@@ -0,0 +1,323 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// --- Custom Panel Arrangement ---
+//
+// `layout move weather top-right` puts a core panel at the top or bottom of
+// the left or right grid column, `layout size todo 3` changes its relative
+// height, `layout split 60` the width of the left column, and
+// `layout hide|show <panel>` takes it off the screen or brings it back.
+// Once panels are moved, the stacked and wide layouts follow the same order
+// (left column first). Everything, the `layout <mode>` choice included, is
+// saved in ~/.baseline/layout.json; `layout reset` goes back to the
+// defaults.
+
+const layoutFileName = "layout.json"
+
+type panelArrangement struct {
+	Mode    string         `json:"mode,omitempty"`    // Overrides LAYOUT once set with `layout <mode>`
+	Columns [][]string     `json:"columns,omitempty"` // Grid columns, top to bottom; nil keeps the default
+	Heights map[string]int `json:"heights,omitempty"` // Relative heights, see panelHeight
+	Split   int            `json:"split,omitempty"`   // Left column width in percent, 0 for even columns
+	Hidden  []string       `json:"hidden,omitempty"`
+}
+
+// corePanelNames are the panels an arrangement places, in the default
+// grid order.
+var corePanelNames = []string{"system", "processes", "weather", "time", "todo"}
+
+var (
+	defaultColumns   = [][]string{{"system", "processes", "weather"}, {"time", "todo"}}
+	defaultStacked   = []string{"system", "todo", "time", "weather", "processes"}
+	panelNameAliases = map[string]string{"ps": "processes", "procs": "processes", "calendar": "time", "clock": "time", "tasks": "todo", "todos": "todo"}
+	panelPositions   = []string{"top-left", "bottom-left", "top-right", "bottom-right"}
+)
+
+// corePanel returns the primitive behind a core panel name.
+func (b *Baseline) corePanel(name string) tview.Primitive {
+	switch name {
+	case "system":
+		return b.systemPanel
+	case "processes":
+		return b.procTable
+	case "weather":
+		return b.weatherPanel
+	case "time":
+		return b.timePanel
+	case "todo":
+		return b.todoPanel
+	}
+	return nil
+}
+
+// parsePanelName accepts a core panel name or one of its aliases.
+func parsePanelName(s string) (string, bool) {
+	if alias, ok := panelNameAliases[s]; ok {
+		s = alias
+	}
+	return s, slices.Contains(corePanelNames, s)
+}
+
+// panelHeight is a panel's relative height, the task list's 2 by default.
+func (a panelArrangement) panelHeight(name string) int {
+	if h, ok := a.Heights[name]; ok {
+		return h
+	}
+	if name == "todo" {
+		return 2
+	}
+	return 1
+}
+
+// visibleColumns returns the grid columns without hidden panels, dropping
+// columns left empty.
+func (a panelArrangement) visibleColumns() [][]string {
+	columns := a.Columns
+	if columns == nil {
+		columns = defaultColumns
+	}
+	var visible [][]string
+	for _, column := range columns {
+		var names []string
+		for _, name := range column {
+			if !slices.Contains(a.Hidden, name) {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 0 {
+			visible = append(visible, names)
+		}
+	}
+	return visible
+}
+
+// stackedOrder is the one-column order: the classic one until panels are
+// moved, then the grid's, left column first.
+func (a panelArrangement) stackedOrder() []string {
+	if a.Columns == nil {
+		var order []string
+		for _, name := range defaultStacked {
+			if !slices.Contains(a.Hidden, name) {
+				order = append(order, name)
+			}
+		}
+		return order
+	}
+	return slices.Concat(a.visibleColumns()...)
+}
+
+// wideColumns splits the grid order into three columns, two panels each by
+// default.
+func (a panelArrangement) wideColumns() [][]string {
+	flat := slices.Concat(a.visibleColumns()...)
+	per := (len(flat) + 2) / 3
+	var columns [][]string
+	for start := 0; start < len(flat); start += per {
+		columns = append(columns, flat[start:min(start+per, len(flat))])
+	}
+	return columns
+}
+
+// columnWidth is the flex proportion of grid column i; the widget column
+// takes widgetColumnWidth.
+func (a panelArrangement) columnWidth(i, columns int) int {
+	if a.Split == 0 || columns != 2 {
+		return a.widgetColumnWidth()
+	}
+	if i == 0 {
+		return a.Split
+	}
+	return 100 - a.Split
+}
+
+// widgetColumnWidth keeps the widget column as wide as an even core column.
+func (a panelArrangement) widgetColumnWidth() int {
+	if a.Split == 0 {
+		return 1
+	}
+	return 50
+}
+
+// panelHidden reports whether a core panel is off the screen.
+func (b *Baseline) panelHidden(p tview.Primitive) bool {
+	for _, name := range b.arrangement.Hidden {
+		if b.corePanel(name) == p {
+			return true
+		}
+	}
+	return false
+}
+
+// loadArrangement reads layout.json. Called from NewBaseline.
+func (b *Baseline) loadArrangement() {
+	data, err := os.ReadFile(filepath.Join(b.configDir, layoutFileName))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			b.addNotification(fmt.Sprintf("Error loading %s: %v", layoutFileName, err), "error")
+		}
+		return
+	}
+	var a panelArrangement
+	if err := json.Unmarshal(data, &a); err != nil {
+		b.addNotification(fmt.Sprintf("Error parsing %s: %v", layoutFileName, err), "error")
+		return
+	}
+	if a.Columns != nil && !validColumns(a.Columns) {
+		b.addNotification(fmt.Sprintf("%s: two columns must list every panel once (%s), using the default", layoutFileName, strings.Join(corePanelNames, ", ")), "error")
+		a.Columns = nil
+	}
+	if slices.Contains(layoutModes, a.Mode) {
+		b.layoutSetting = a.Mode
+	}
+	b.arrangement = a
+}
+
+// validColumns checks that at most two columns place every core panel
+// exactly once.
+func validColumns(columns [][]string) bool {
+	flat := slices.Concat(columns...)
+	if len(columns) > 2 || len(flat) != len(corePanelNames) {
+		return false
+	}
+	for _, name := range corePanelNames {
+		if !slices.Contains(flat, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// saveArrangement writes layout.json. Called with b.mu held.
+func (b *Baseline) saveArrangement() {
+	data, err := json.MarshalIndent(b.arrangement, "", "  ")
+	if err == nil {
+		err = os.WriteFile(filepath.Join(b.configDir, layoutFileName), data, 0640)
+	}
+	if err != nil {
+		b.addNotification(fmt.Sprintf("Error saving %s: %v", layoutFileName, err), "error")
+	}
+}
+
+// arrangeCommand handles the layout subcommands that change the arrangement
+// and reports whether args was one. Runs on the UI goroutine, with b.mu held.
+func (b *Baseline) arrangeCommand(args []string) bool {
+	a := &b.arrangement
+	switch args[0] {
+	case "move":
+		name, ok := "", false
+		if len(args) == 3 {
+			name, ok = parsePanelName(args[1])
+		}
+		if !ok || !slices.Contains(panelPositions, args[2]) {
+			b.addNotification(fmt.Sprintf("Usage: layout move <%s> <%s>", strings.Join(corePanelNames, "|"), strings.Join(panelPositions, "|")), "error")
+			return true
+		}
+		a.Columns = movePanel(a.Columns, name, args[2])
+		b.addNotification(fmt.Sprintf("Moved %s to the %s", name, args[2]), "success")
+	case "size":
+		name, ok := "", false
+		if len(args) == 3 {
+			name, ok = parsePanelName(args[1])
+		}
+		height, err := 0, error(nil)
+		if ok {
+			height, err = strconv.Atoi(args[2])
+		}
+		if !ok || err != nil || height < 1 || height > 9 {
+			b.addNotification("Usage: layout size <panel> <1-9> (relative height, 1 is the default)", "error")
+			return true
+		}
+		if a.Heights == nil {
+			a.Heights = map[string]int{}
+		}
+		a.Heights[name] = height
+		b.addNotification(fmt.Sprintf("Height of %s set to %d", name, height), "success")
+	case "split":
+		percent, err := 0, error(nil)
+		if len(args) == 2 {
+			percent, err = strconv.Atoi(strings.TrimSuffix(args[1], "%"))
+		}
+		if len(args) != 2 || err != nil || (percent != 0 && (percent < 20 || percent > 80)) {
+			b.addNotification("Usage: layout split <20-80> (left column width in percent, 0 for even)", "error")
+			return true
+		}
+		a.Split = percent
+		b.addNotification(fmt.Sprintf("Left column set to %d%%", percent), "success")
+	case "hide", "show":
+		name, ok := "", false
+		if len(args) == 2 {
+			name, ok = parsePanelName(args[1])
+		}
+		if !ok {
+			b.addNotification(fmt.Sprintf("Usage: layout %s <%s>", args[0], strings.Join(corePanelNames, "|")), "error")
+			return true
+		}
+		a.Hidden = slices.DeleteFunc(a.Hidden, func(h string) bool { return h == name })
+		if args[0] == "hide" {
+			if len(a.Hidden) == len(corePanelNames)-1 {
+				b.addNotification("At least one panel has to stay on screen", "error")
+				return true
+			}
+			a.Hidden = append(a.Hidden, name)
+			b.addNotification(fmt.Sprintf("Hid %s (layout show %s brings it back)", name, name), "success")
+		} else {
+			b.addNotification(fmt.Sprintf("Showing %s", name), "success")
+		}
+	case "reset":
+		b.arrangement = panelArrangement{}
+		b.layoutSetting = layoutFromEnv()
+		b.addNotification("Layout reset to the defaults", "success")
+	default:
+		return false
+	}
+	b.saveArrangement()
+	b.arrangePanels(layoutFor(b.layoutSetting, b.screenWidth))
+	return true
+}
+
+// movePanel returns columns with name at position ("top-left", ...).
+func movePanel(columns [][]string, name, position string) [][]string {
+	if columns == nil {
+		columns = defaultColumns
+	}
+	moved := make([][]string, 2)
+	for i := range moved {
+		if i < len(columns) {
+			moved[i] = slices.DeleteFunc(slices.Clone(columns[i]), func(n string) bool { return n == name })
+		}
+	}
+	target := 0
+	if strings.HasSuffix(position, "right") {
+		target = 1
+	}
+	if strings.HasPrefix(position, "top") {
+		moved[target] = slices.Insert(moved[target], 0, name)
+	} else {
+		moved[target] = append(moved[target], name)
+	}
+	return moved
+}
+
+// completeLayout offers the modes, subcommands, panels and positions.
+func completeLayout(args []string) []string {
+	switch {
+	case len(args) == 0:
+		return append(slices.Clone(layoutModes), "move", "size", "split", "hide", "show", "reset")
+	case len(args) == 1 && slices.Contains([]string{"move", "size", "hide", "show"}, strings.ToLower(args[0])):
+		return corePanelNames
+	case len(args) == 2 && strings.ToLower(args[0]) == "move":
+		return panelPositions
+	}
+	return nil
+}
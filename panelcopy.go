@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/rivo/tview"
+)
+
+// --- Copy Panel Contents ---
+//
+// `y` copies the focused panel as plain text, `copy <panel>` any panel by
+// name, and `yank` single items (see yank.go). Over SSH the platform helper
+// would fill the remote clipboard, so the text goes to the local terminal
+// with OSC 52 instead.
+
+// dashboardPanels lists every panel on screen, core panels first.
+func (b *Baseline) dashboardPanels() []*tview.TextView {
+	var panels []*tview.TextView
+	for _, tv := range []*tview.TextView{b.systemPanel, b.weatherPanel, b.timePanel, b.todoPanel} {
+		if !b.panelHidden(tv) { // `layout hide`
+			panels = append(panels, tv)
+		}
+	}
+	for _, w := range b.widgetPanels {
+		panels = append(panels, w.view)
+	}
+	return panels
+}
+
+// panelName is a panel's title without the padding ("System Status").
+func panelName(tv *tview.TextView) string {
+	return strings.TrimSpace(tv.GetTitle())
+}
+
+// findPanel matches name against the start of each panel title, ignoring case.
+func (b *Baseline) findPanel(name string) *tview.TextView {
+	name = strings.ToLower(name)
+	for _, tv := range b.dashboardPanels() {
+		if strings.HasPrefix(strings.ToLower(panelName(tv)), name) {
+			return tv
+		}
+	}
+	return nil
+}
+
+// focusedPanel returns the dashboard panel with keyboard focus, if any.
+// Must run on the UI goroutine.
+func (b *Baseline) focusedPanel() *tview.TextView {
+	focused, ok := b.app.GetFocus().(*tview.TextView)
+	if !ok {
+		return nil
+	}
+	for _, tv := range b.dashboardPanels() {
+		if tv == focused {
+			return tv
+		}
+	}
+	return nil
+}
+
+// copyPanel puts the panel's text, color tags stripped, on the clipboard.
+func (b *Baseline) copyPanel(tv *tview.TextView) {
+	text := strings.TrimRight(tv.GetText(true), "\n")
+	b.copyText(fmt.Sprintf("%s (%d lines)", panelName(tv), strings.Count(text, "\n")+1), text)
+}
+
+// copyText puts text on the clipboard and notifies with what was copied.
+func (b *Baseline) copyText(what, text string) {
+	copied := func(via string) {
+		b.notify("clipboard", fmt.Sprintf("Copied %s (%s)", what, via), "success")
+	}
+	if os.Getenv("SSH_CONNECTION") == "" && os.Getenv("SSH_TTY") == "" {
+		if err := clipboard.WriteAll(text); err == nil {
+			copied("system clipboard")
+			return
+		}
+	}
+	// No helper (or a remote session): let the terminal set the clipboard
+	b.app.QueueUpdate(func() {
+		if b.screen == nil {
+			go b.notify("clipboard", "Copy failed: no clipboard helper available", "error")
+			return
+		}
+		b.screen.SetClipboard([]byte(text))
+		go copied("OSC 52")
+	})
+}
+
+// copyCommand handles "copy <panel>". Called from processCommand with b.mu held.
+func (b *Baseline) copyCommand(args []string) {
+	if len(args) == 0 {
+		var names []string
+		for _, tv := range b.dashboardPanels() {
+			names = append(names, strings.ToLower(strings.Fields(panelName(tv))[0]))
+		}
+		b.addNotification("Usage: copy <panel> ("+strings.Join(names, ", ")+")", "error")
+		return
+	}
+	tv := b.findPanel(strings.Join(args, " "))
+	if tv == nil {
+		b.addNotification(fmt.Sprintf("No panel named '%s'", strings.Join(args, " ")), "error")
+		return
+	}
+	go b.copyPanel(tv)
+}
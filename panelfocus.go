@@ -0,0 +1,140 @@
+package main
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- Panel Focus ---
+//
+// Tab / Shift-Tab cycle keyboard focus through the dashboard panels and
+// h/j/k/l move it to the nearest panel left, down, up or right. The focused
+// panel's border is drawn in the bright color and the arrow keys, PgUp/PgDn
+// and Home/End scroll it. Esc returns focus to the dashboard.
+
+// setupPanelFocus highlights the border of whichever panel has focus.
+// Called from setupLayout once every panel exists.
+func (b *Baseline) setupPanelFocus() {
+	for _, tv := range b.dashboardPanels() {
+		box := tv.Box
+		box.SetFocusFunc(func() { box.SetBorderColor(b.theme.Bright) })
+		box.SetBlurFunc(func() { box.SetBorderColor(b.panelBorder(box)) })
+	}
+	b.procTable.SetFocusFunc(func() { b.procTable.SetBorderColor(b.theme.Bright) })
+	b.procTable.SetBlurFunc(func() { b.procTable.SetBorderColor(b.theme.border()) })
+}
+
+// panelBorder is the unfocused border color of a panel.
+func (b *Baseline) panelBorder(box *tview.Box) tcell.Color {
+	if box == b.systemPanel.Box {
+		return b.systemBorder()
+	}
+	return b.theme.border()
+}
+
+// cycleFocus moves focus to the next (step 1) or previous (step -1) panel.
+// Must run on the UI goroutine.
+func (b *Baseline) cycleFocus(step int) {
+	panels := b.dashboardPanels()
+	current := -1
+	if tv := b.focusedPanel(); tv != nil {
+		for i, p := range panels {
+			if p == tv {
+				current = i
+			}
+		}
+	}
+	next := 0
+	switch {
+	case current >= 0:
+		next = (current + step + len(panels)) % len(panels)
+	case step < 0:
+		next = len(panels) - 1
+	}
+	b.app.SetFocus(panels[next])
+}
+
+// moveFocus focuses the nearest panel in direction (dx, dy), e.g. (1, 0) for
+// right, judged by the panels' positions on screen. Must run on the UI goroutine.
+func (b *Baseline) moveFocus(dx, dy int) {
+	current := b.focusedPanel()
+	if current == nil {
+		b.cycleFocus(1)
+		return
+	}
+	cx, cy, cw, ch := current.GetRect()
+	fromX, fromY := cx+cw/2, cy+ch/2
+
+	var best *tview.TextView
+	bestDist := 0
+	for _, tv := range b.dashboardPanels() {
+		x, y, w, h := tv.GetRect()
+		if tv == current || w == 0 || h == 0 {
+			continue
+		}
+		// Only panels entirely on that side of the current one
+		if (dx > 0 && x < cx+cw) || (dx < 0 && x+w > cx) || (dy > 0 && y < cy+ch) || (dy < 0 && y+h > cy) {
+			continue
+		}
+		toX, toY := x+w/2, y+h/2
+		along, across := abs(toX-fromX), abs(toY-fromY)
+		if dy != 0 {
+			along, across = across, along
+		}
+		dist := along + 2*across // Prefer panels in line with the current one
+		if best == nil || dist < bestDist {
+			best, bestDist = tv, dist
+		}
+	}
+	if best != nil {
+		b.app.SetFocus(best)
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// focusKey handles the panel focus keys. Returns false for any other key.
+func (b *Baseline) focusKey(event *tcell.EventKey) bool {
+	var move func()
+	switch event.Key() {
+	case tcell.KeyTab:
+		move = func() { b.cycleFocus(1) }
+	case tcell.KeyBacktab:
+		move = func() { b.cycleFocus(-1) }
+	case tcell.KeyEscape:
+		move = func() {
+			if b.focusedPanel() != nil {
+				b.app.SetFocus(b.layout)
+			}
+		}
+	case tcell.KeyRune:
+		switch event.Rune() {
+		case 'h':
+			move = func() { b.moveFocus(-1, 0) }
+		case 'j':
+			move = func() { b.moveFocus(0, 1) }
+		case 'k':
+			move = func() { b.moveFocus(0, -1) }
+		case 'l':
+			move = func() { b.moveFocus(1, 0) }
+		}
+	}
+	if move == nil {
+		return false
+	}
+	go b.app.QueueUpdateDraw(move)
+	return true
+}
+
+// setPanelText replaces a panel's text without losing its scroll position.
+// Must run on the UI goroutine.
+func setPanelText(tv *tview.TextView, text string) {
+	row, col := tv.GetScrollOffset()
+	tv.SetText(text)
+	tv.ScrollTo(row, col)
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Pi-hole Statistics Widget ---
+
+const (
+	piholeRefreshInterval = 1 * time.Minute
+	piholeTopDomains      = 5
+)
+
+type PiholeDomain struct {
+	Domain string
+	Count  int
+}
+
+type PiholeInfo struct {
+	QueriesToday   int
+	BlockedToday   int
+	PercentBlocked float64
+	Status         string // "enabled" / "disabled"
+	TopBlocked     []PiholeDomain
+	Error          string
+	LastUpdated    time.Time
+}
+
+// PiholeClient talks to either the v6 REST API (PIHOLE_PASSWORD, session based)
+// or the legacy v5 api.php (PIHOLE_TOKEN).
+type PiholeClient struct {
+	baseURL  string
+	token    string // v5 API token
+	password string // v6 web/app password
+
+	mu     sync.Mutex
+	sid    string // v6 session id
+	client http.Client
+}
+
+// newPiholeClientFromEnv returns nil when PIHOLE_URL is unset.
+func newPiholeClientFromEnv() *PiholeClient {
+	baseURL := strings.TrimRight(os.Getenv("PIHOLE_URL"), "/")
+	if baseURL == "" {
+		return nil
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/admin")
+	return &PiholeClient{
+		baseURL:  baseURL,
+		token:    os.Getenv("PIHOLE_TOKEN"),
+		password: os.Getenv("PIHOLE_PASSWORD"),
+		client:   http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *PiholeClient) getJSON(reqURL string, header http.Header, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP error: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API error: Status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("JSON parse error: %w", err)
+	}
+	return nil
+}
+
+func (p *PiholeClient) fetch() (PiholeInfo, error) {
+	if p.password != "" {
+		return p.fetchV6()
+	}
+	return p.fetchV5()
+}
+
+func (p *PiholeClient) fetchV5() (PiholeInfo, error) {
+	var info PiholeInfo
+	auth := ""
+	if p.token != "" {
+		auth = "&auth=" + url.QueryEscape(p.token)
+	}
+
+	var summary struct {
+		DNSQueriesToday    int     `json:"dns_queries_today"`
+		AdsBlockedToday    int     `json:"ads_blocked_today"`
+		AdsPercentageToday float64 `json:"ads_percentage_today"`
+		Status             string  `json:"status"`
+	}
+	if err := p.getJSON(p.baseURL+"/admin/api.php?summaryRaw"+auth, nil, &summary); err != nil {
+		return info, err
+	}
+	info.QueriesToday = summary.DNSQueriesToday
+	info.BlockedToday = summary.AdsBlockedToday
+	info.PercentBlocked = summary.AdsPercentageToday
+	info.Status = summary.Status
+
+	// Top items need the token; skip quietly without one
+	if p.token != "" {
+		var top struct {
+			TopAds map[string]int `json:"top_ads"`
+		}
+		if err := p.getJSON(fmt.Sprintf("%s/admin/api.php?topItems=%d%s", p.baseURL, piholeTopDomains, auth), nil, &top); err == nil {
+			for domain, count := range top.TopAds {
+				info.TopBlocked = append(info.TopBlocked, PiholeDomain{Domain: domain, Count: count})
+			}
+			sort.Slice(info.TopBlocked, func(i, j int) bool {
+				return info.TopBlocked[i].Count > info.TopBlocked[j].Count
+			})
+		}
+	}
+	return info, nil
+}
+
+// session returns a v6 session id, logging in when there is none yet.
+func (p *PiholeClient) session(renew bool) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.sid != "" && !renew {
+		return p.sid, nil
+	}
+
+	body, _ := json.Marshal(map[string]string{"password": p.password})
+	resp, err := p.client.Post(p.baseURL+"/api/auth", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("HTTP error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Session struct {
+			Valid   bool   `json:"valid"`
+			SID     string `json:"sid"`
+			Message string `json:"message"`
+		} `json:"session"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return "", fmt.Errorf("JSON parse error: %w", err)
+	}
+	if !data.Session.Valid {
+		return "", fmt.Errorf("login failed: %s", data.Session.Message)
+	}
+	p.sid = data.Session.SID
+	return p.sid, nil
+}
+
+func (p *PiholeClient) fetchV6() (PiholeInfo, error) {
+	var info PiholeInfo
+
+	sid, err := p.session(false)
+	if err != nil {
+		return info, err
+	}
+	header := http.Header{"X-Ftl-Sid": []string{sid}}
+
+	var summary struct {
+		Queries struct {
+			Total          int     `json:"total"`
+			Blocked        int     `json:"blocked"`
+			PercentBlocked float64 `json:"percent_blocked"`
+		} `json:"queries"`
+	}
+	if err := p.getJSON(p.baseURL+"/api/stats/summary", header, &summary); err != nil {
+		// Sessions expire; log in again once before giving up
+		if sid, err = p.session(true); err != nil {
+			return info, err
+		}
+		header.Set("X-Ftl-Sid", sid)
+		if err := p.getJSON(p.baseURL+"/api/stats/summary", header, &summary); err != nil {
+			return info, err
+		}
+	}
+	info.QueriesToday = summary.Queries.Total
+	info.BlockedToday = summary.Queries.Blocked
+	info.PercentBlocked = summary.Queries.PercentBlocked
+
+	var blocking struct {
+		Blocking string `json:"blocking"`
+	}
+	if p.getJSON(p.baseURL+"/api/dns/blocking", header, &blocking) == nil {
+		info.Status = blocking.Blocking
+	}
+
+	var top struct {
+		Domains []struct {
+			Domain string `json:"domain"`
+			Count  int    `json:"count"`
+		} `json:"domains"`
+	}
+	if p.getJSON(fmt.Sprintf("%s/api/stats/top_domains?blocked=true&count=%d", p.baseURL, piholeTopDomains), header, &top) == nil {
+		for _, d := range top.Domains {
+			info.TopBlocked = append(info.TopBlocked, PiholeDomain{Domain: d.Domain, Count: d.Count})
+		}
+	}
+	return info, nil
+}
+
+func (b *Baseline) fetchPihole() {
+	info, err := b.pihole.fetch()
+	info.LastUpdated = time.Now()
+	if err != nil {
+		info.Error = err.Error()
+	}
+
+	b.mu.Lock()
+	prevErr := b.piholeInfo.Error
+	b.piholeInfo = info
+	b.mu.Unlock()
+
+	if info.Error != "" && info.Error != prevErr {
+		b.notify("pihole", fmt.Sprintf("Pi-hole: %s", info.Error), "error")
+	}
+	b.updatePihole()
+}
+
+func (b *Baseline) updatePihole() {
+	b.mu.RLock()
+	info := b.piholeInfo
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sPI-HOLE[-:-:-]\n", brightC+"[::b]"))
+
+	if info.Error != "" {
+		sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(info.Error)))
+	} else if info.LastUpdated.IsZero() {
+		sb.WriteString(fmt.Sprintf("%sLoading...[-:-:-]\n", dimC))
+	} else {
+		if info.Status != "" {
+			statusC := mainC
+			if info.Status != "enabled" {
+				statusC = "[red]"
+			}
+			sb.WriteString(fmt.Sprintf("%sBlocking: %s%s[-:-:-]\n", mainC, statusC, info.Status))
+		}
+		sb.WriteString(fmt.Sprintf("%sQueries today: %s%d[-:-:-]\n", mainC, brightC, info.QueriesToday))
+		sb.WriteString(fmt.Sprintf("%sBlocked: %s%d[-:-:-]\n", mainC, brightC, info.BlockedToday))
+		sb.WriteString(fmt.Sprintf("%sBLK: %s %s %.1f%%[-:-:-]\n", mainC, createBar(info.PercentBlocked, 15, b.theme), brightC, info.PercentBlocked))
+
+		if len(info.TopBlocked) > 0 {
+			sb.WriteString(fmt.Sprintf("\n%sTOP BLOCKED:[-:-:-]\n", mainC))
+			for _, d := range info.TopBlocked {
+				sb.WriteString(fmt.Sprintf("%s%6d %s%s[-:-:-]\n", dimC, d.Count, mainC, tview.Escape(d.Domain)))
+			}
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%sLast updated: %s[-:-:-]", dimC, info.LastUpdated.Format("15:04:05")))
+
+	b.setPanel(b.piholePanel, sb.String())
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Plugin Panels ---
+//
+// A [[plugin]] table in config.toml adds a widget panel filled by an external
+// program, in any language:
+//
+//	[[plugin]]
+//	name = "Backups"                  # Panel title
+//	command = "~/bin/backup-status"   # Run through the shell
+//	interval = "1m"                   # Default 30s
+//
+// On every refresh the command gets a JSON request on stdin and prints either
+// plain text or a JSON response on stdout:
+//
+//	{"title": "Backups (2 late)",
+//	 "blocks": [{"type": "heading", "text": "LAST RUN"},
+//	            {"type": "kv", "label": "home", "value": "3h ago", "style": "success"},
+//	            {"type": "bar", "label": "Disk", "value": 73},
+//	            {"type": "text", "text": "nas unreachable", "style": "error"}]}
+//
+// Baseline does the layout, colors and scheduling; styles are main, dim,
+// bright, error and success. A failing command shows its error in the panel.
+
+const (
+	pluginProtocolVersion  = 1
+	defaultPluginInterval  = 30 * time.Second
+	minPluginInterval      = 1 * time.Second
+	maxPluginTimeout       = 30 * time.Second
+	pluginMaxOutputLines   = 200
+	pluginBarWidth         = 20
+	pluginDefaultLabelSize = 10
+)
+
+type pluginFile struct {
+	Name     string `toml:"name"`
+	Command  string `toml:"command"`
+	Interval string `toml:"interval"`
+}
+
+type PluginConfig struct {
+	Name     string
+	Command  string
+	Interval time.Duration
+}
+
+// parsePlugin validates one [[plugin]] table.
+func parsePlugin(f pluginFile) (PluginConfig, error) {
+	p := PluginConfig{Name: strings.TrimSpace(f.Name), Command: strings.TrimSpace(f.Command), Interval: defaultPluginInterval}
+	if p.Command == "" {
+		return p, fmt.Errorf("command is required")
+	}
+	if p.Name == "" {
+		p.Name = strings.Fields(p.Command)[0]
+	}
+	if f.Interval != "" {
+		d, err := time.ParseDuration(f.Interval)
+		if err != nil {
+			return p, fmt.Errorf("interval %q is not a duration (e.g. \"30s\")", f.Interval)
+		}
+		if d < minPluginInterval {
+			return p, fmt.Errorf("interval must be at least %s", minPluginInterval)
+		}
+		p.Interval = d
+	}
+	return p, nil
+}
+
+// pluginRequest is written to the plugin's stdin.
+type pluginRequest struct {
+	Version int       `json:"version"`
+	Name    string    `json:"name"`
+	Time    time.Time `json:"time"`
+}
+
+type PluginBlock struct {
+	Type  string  `json:"type"` // heading, text, kv, bar or spacer
+	Text  string  `json:"text"`
+	Label string  `json:"label"`
+	Value any     `json:"value"` // A string for kv, a percentage for bar
+	Style string  `json:"style"`
+	Max   float64 `json:"max"` // Bar scale, 100 when unset
+}
+
+// PluginOutput is what a plugin printed, JSON or plain text.
+type PluginOutput struct {
+	Title  string        `json:"title"`
+	Text   string        `json:"text"`
+	Blocks []PluginBlock `json:"blocks"`
+	Error  string        `json:"error"`
+}
+
+type Plugin struct {
+	PluginConfig
+	view        *tview.TextView
+	output      PluginOutput
+	err         string
+	lastUpdated time.Time
+}
+
+// newPlugins wraps the configured plugins; panels are added by setupWidgets.
+func newPlugins(configs []PluginConfig) []*Plugin {
+	plugins := make([]*Plugin, 0, len(configs))
+	for _, cfg := range configs {
+		plugins = append(plugins, &Plugin{PluginConfig: cfg})
+	}
+	return plugins
+}
+
+// registerPluginCommands adds a command per plugin, named after it, that
+// refreshes its panel now.
+func (b *Baseline) registerPluginCommands() {
+	for _, p := range b.plugins {
+		name := strings.ToLower(strings.Join(strings.Fields(p.Name), "-"))
+		err := b.registerCommand(&Command{
+			Name:    name,
+			Summary: fmt.Sprintf("Refresh the %s plugin panel", p.Name),
+			Run:     func(CommandArgs) { go b.runPlugin(p) },
+		})
+		if err != nil {
+			b.addNotification(fmt.Sprintf("Plugin %s: no command added, %v", p.Name, err), "error")
+		}
+	}
+}
+
+// runPlugin executes the plugin once and stores its output.
+func (b *Baseline) runPlugin(p *Plugin) {
+	ctx, cancel := context.WithTimeout(context.Background(), min(p.Interval, maxPluginTimeout))
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", p.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", p.Command)
+	}
+	request, _ := json.Marshal(pluginRequest{Version: pluginProtocolVersion, Name: p.Name, Time: time.Now()})
+	cmd.Stdin = bytes.NewReader(append(request, '\n'))
+	cmd.Env = append(os.Environ(), "BASELINE_PLUGIN="+p.Name, "BASELINE_CONFIG_DIR="+b.configDir)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	output, errMsg := PluginOutput{}, ""
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		errMsg = fmt.Sprintf("timed out after %s", min(p.Interval, maxPluginTimeout))
+	case err != nil:
+		errMsg = err.Error()
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			errMsg += ": " + msg
+		}
+	default:
+		output = parsePluginOutput(out)
+	}
+
+	b.mu.Lock()
+	prevErr := p.err
+	p.output, p.err, p.lastUpdated = output, errMsg, time.Now()
+	b.mu.Unlock()
+
+	if errMsg != "" && errMsg != prevErr {
+		b.notify("plugins", fmt.Sprintf("Plugin %s: %s", p.Name, errMsg), "error")
+	}
+	b.updatePlugin(p)
+}
+
+// parsePluginOutput reads a JSON response, falling back to plain text.
+func parsePluginOutput(out []byte) PluginOutput {
+	var output PluginOutput
+	if trimmed := bytes.TrimSpace(out); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, &output); err == nil {
+			return output
+		}
+	}
+	return PluginOutput{Text: string(out)}
+}
+
+// pluginStyle maps a block style to a color tag.
+func pluginStyle(style string, theme Theme) string {
+	switch style {
+	case "dim":
+		return colorTag(theme.Dim)
+	case "bright":
+		return colorTag(theme.Bright)
+	case "error", "success":
+		return notificationColor(style, theme)
+	}
+	return colorTag(theme.Main)
+}
+
+// renderPluginOutput lays out a plugin's response in the theme's colors.
+func renderPluginOutput(output PluginOutput, theme Theme) string {
+	dimC := colorTag(theme.Dim)
+	brightC := colorTag(theme.Bright)
+
+	var lines []string
+	if output.Error != "" {
+		lines = append(lines, fmt.Sprintf("%s%s[-:-:-]", notificationColor("error", theme), tview.Escape(output.Error)))
+	}
+	if output.Text != "" {
+		for _, line := range strings.Split(strings.TrimRight(output.Text, "\n"), "\n") {
+			lines = append(lines, fmt.Sprintf("%s%s[-:-:-]", colorTag(theme.Main), tview.Escape(line)))
+		}
+	}
+
+	labelWidth := pluginDefaultLabelSize
+	for _, bl := range output.Blocks {
+		if bl.Type == "kv" || bl.Type == "bar" {
+			labelWidth = max(labelWidth, len([]rune(bl.Label)))
+		}
+	}
+	for _, bl := range output.Blocks {
+		styleC := pluginStyle(bl.Style, theme)
+		switch bl.Type {
+		case "heading":
+			lines = append(lines, fmt.Sprintf("%s%s[-:-:-]", brightC+"[::b]", tview.Escape(strings.ToUpper(bl.Text))))
+		case "kv":
+			value := ""
+			if bl.Value != nil {
+				value = fmt.Sprint(bl.Value)
+			}
+			lines = append(lines, fmt.Sprintf("%s%-*s %s%s[-:-:-]", dimC, labelWidth, tview.Escape(bl.Label), styleC, tview.Escape(value)))
+		case "bar":
+			value, _ := bl.Value.(float64) // JSON numbers decode as float64
+			scale := bl.Max
+			if scale <= 0 {
+				scale = 100
+			}
+			lines = append(lines, fmt.Sprintf("%s%-*s %s %s%.0f%%[-:-:-]", dimC, labelWidth, tview.Escape(bl.Label), createBar(value/scale*100, pluginBarWidth, theme), styleC, value/scale*100))
+		case "spacer":
+			lines = append(lines, "")
+		default: // text
+			lines = append(lines, fmt.Sprintf("%s%s[-:-:-]", styleC, tview.Escape(bl.Text)))
+		}
+	}
+	if len(lines) > pluginMaxOutputLines {
+		lines = append(lines[:pluginMaxOutputLines], fmt.Sprintf("%s(%d more lines)[-:-:-]", dimC, len(lines)-pluginMaxOutputLines))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (b *Baseline) updatePlugin(p *Plugin) {
+	b.mu.RLock()
+	output, errMsg, lastUpdated := p.output, p.err, p.lastUpdated
+	b.mu.RUnlock()
+
+	dimC := colorTag(b.theme.Dim)
+	var sb strings.Builder
+	switch {
+	case errMsg != "":
+		sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(errMsg)))
+	case lastUpdated.IsZero():
+		sb.WriteString(fmt.Sprintf("%sLoading...[-:-:-]\n", dimC))
+	default:
+		sb.WriteString(renderPluginOutput(output, b.theme) + "\n")
+	}
+	sb.WriteString(fmt.Sprintf("\n%sLast updated: %s[-:-:-]", dimC, lastUpdated.Format("15:04:05")))
+
+	title := p.Name
+	if output.Title != "" {
+		title = output.Title
+	}
+	title = " " + tview.Escape(title) + " "
+	b.renderPanel(p.view, title+"\n"+sb.String(), func() {
+		p.view.SetTitle(title)
+		setPanelText(p.view, sb.String())
+	})
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Pomodoro Timer ---
+//
+// T (or `pomo start [index]`) runs work/break cycles on the selected todo
+// until stopped: 25 minutes of work, 5 of break, or POMODORO="50/10". The
+// countdown shows in the time panel and the header. Each finished work phase
+// is credited to the todo in todos.json and recorded as a focus session.
+
+const (
+	defaultPomodoroWork  = 25 * time.Minute
+	defaultPomodoroBreak = 5 * time.Minute
+	pomodoroTick         = 1 * time.Second
+)
+
+type Pomodoro struct {
+	Task    string // Text of the todo the pomodoros are credited to
+	Break   bool
+	Started time.Time // Start of the current phase
+	Ends    time.Time
+	Done    int // Work phases finished since start
+}
+
+// pomodoroDurationsFromEnv reads POMODORO as "work/break" in minutes.
+func pomodoroDurationsFromEnv() (time.Duration, time.Duration) {
+	work, brk, ok := strings.Cut(os.Getenv("POMODORO"), "/")
+	w, err1 := strconv.Atoi(strings.TrimSpace(work))
+	r, err2 := strconv.Atoi(strings.TrimSpace(brk))
+	if !ok || err1 != nil || err2 != nil || w <= 0 || r <= 0 {
+		return defaultPomodoroWork, defaultPomodoroBreak
+	}
+	return time.Duration(w) * time.Minute, time.Duration(r) * time.Minute
+}
+
+// startPomodoro begins a work phase on the todo at i. Called with b.mu held.
+func (b *Baseline) startPomodoro(i int) {
+	if b.pomodoro != nil {
+		b.notify("pomodoro", fmt.Sprintf("Pomodoro already running on: %s", b.pomodoro.Task), "info")
+		return
+	}
+	item := b.todoItems[i]
+	if item.Done {
+		b.addNotification("That task is already done", "info")
+		return
+	}
+	now := time.Now()
+	b.pomodoro = &Pomodoro{Task: item.Text, Started: now, Ends: now.Add(b.pomodoroWork)}
+	b.notify("pomodoro", fmt.Sprintf("Pomodoro started: %s (%s)", item.Text, formatDuration(b.pomodoroWork)), "success")
+	go b.app.QueueUpdateDraw(b.updateHeader)
+	go b.updateTodos()
+}
+
+// stopPomodoro ends the cycle. An unfinished work phase is not credited.
+// Called with b.mu held.
+func (b *Baseline) stopPomodoro() {
+	if b.pomodoro == nil {
+		b.notify("pomodoro", "No pomodoro running", "info")
+		return
+	}
+	p := b.pomodoro
+	b.pomodoro = nil
+	b.notify("pomodoro", fmt.Sprintf("Pomodoro stopped after %d finished: %s", p.Done, p.Task), "info")
+	go b.app.QueueUpdateDraw(b.updateHeader)
+	go b.updateTodos()
+}
+
+// advancePomodoro moves to the next phase. A finished work phase credits the
+// todo and records a focus session; a skipped one doesn't. Called with b.mu held.
+func (b *Baseline) advancePomodoro(now time.Time, finished bool) {
+	p := b.pomodoro
+	if p.Break {
+		p.Break, p.Started, p.Ends = false, now, now.Add(b.pomodoroWork)
+		b.notify("pomodoro", fmt.Sprintf("Break over, back to: %s", p.Task), "info")
+		return
+	}
+
+	if !finished {
+		p.Break, p.Started, p.Ends = true, now, now.Add(b.pomodoroBreak)
+		b.notify("pomodoro", fmt.Sprintf("Pomodoro skipped, take a %s break", formatDuration(b.pomodoroBreak)), "info")
+		return
+	}
+	p.Done++
+	total := 0
+	for i := range b.todoItems {
+		if b.todoItems[i].Text == p.Task && !b.todoItems[i].Done {
+			b.todoItems[i].Pomodoros++
+			total = b.todoItems[i].Pomodoros
+			b.saveTodos()
+			break
+		}
+	}
+	b.focusLog = append(b.focusLog, FocusSession{Start: p.Started, End: now, Label: p.Task})
+	b.saveFocus()
+
+	p.Break, p.Started, p.Ends = true, now, now.Add(b.pomodoroBreak)
+	b.notify("pomodoro", fmt.Sprintf("Pomodoro done (%d on %s), take a %s break", total, p.Task, formatDuration(b.pomodoroBreak)), "success")
+	go b.updateTodos()
+}
+
+// tickPomodoro runs every second while the dashboard is open.
+func (b *Baseline) tickPomodoro() {
+	b.mu.Lock()
+	if b.pomodoro == nil {
+		b.mu.Unlock()
+		return
+	}
+	if now := time.Now(); !now.Before(b.pomodoro.Ends) {
+		b.advancePomodoro(now, true)
+	}
+	b.mu.Unlock()
+	b.app.QueueUpdateDraw(b.updateHeader) // The badge counts down
+}
+
+// pomodoroBadge is the header marker, e.g. "Pomodoro 12:34". Called with b.mu held (read).
+func (b *Baseline) pomodoroBadge() string {
+	if b.pomodoro == nil {
+		return ""
+	}
+	phase := "Pomodoro"
+	if b.pomodoro.Break {
+		phase = "Break"
+	}
+	return phase + " " + formatCountdown(time.Until(b.pomodoro.Ends))
+}
+
+// pomodoroSection renders the countdown for the time panel. Called with b.mu held (read).
+func (b *Baseline) pomodoroSection() string {
+	p := b.pomodoro
+	if p == nil {
+		return ""
+	}
+	mainC := colorTag(b.theme.Main)
+	brightC := colorTag(b.theme.Bright)
+
+	phase, length := "WORK", b.pomodoroWork
+	if p.Break {
+		phase, length = "BREAK", b.pomodoroBreak
+	}
+	left := time.Until(p.Ends)
+	pct := 100 - float64(left)/float64(length)*100
+	return fmt.Sprintf("\n%sPOMODORO: %s%s %s[-:-:-] %s\n%s%s[-:-:-]\n",
+		mainC, brightC, phase, formatCountdown(left), createBar(pct, 20, b.theme),
+		mainC, tview.Escape(p.Task))
+}
+
+// formatCountdown renders a remaining time as MM:SS.
+func formatCountdown(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	secs := int(d.Round(time.Second).Seconds())
+	return fmt.Sprintf("%02d:%02d", secs/60, secs%60)
+}
+
+// pomodoroCommand handles "pomo [status|start [index]|stop|skip]". Called from
+// processCommand with b.mu held.
+func (b *Baseline) pomodoroCommand(args []string) {
+	sub := ""
+	if len(args) > 0 {
+		sub = args[0]
+	}
+	switch sub {
+	case "", "status":
+		if badge := b.pomodoroBadge(); badge != "" {
+			b.addNotification(fmt.Sprintf("%s left on: %s", badge, b.pomodoro.Task), "info")
+		} else {
+			b.addNotification("No pomodoro running (select a todo and press T)", "info")
+		}
+	case "start":
+		if len(args) > 1 {
+			index, err := strconv.Atoi(args[1])
+			if err != nil || index < 1 || index > len(b.todoItems) {
+				b.addNotification(fmt.Sprintf("Invalid todo index: %s", args[1]), "error")
+				return
+			}
+			b.startPomodoro(index - 1)
+		} else if i, ok := b.selectedTodo(); ok {
+			b.startPomodoro(i)
+		}
+	case "stop":
+		b.stopPomodoro()
+	case "skip":
+		if b.pomodoro == nil {
+			b.notify("pomodoro", "No pomodoro running", "info")
+			return
+		}
+		b.advancePomodoro(time.Now(), false)
+	default:
+		b.addNotification("Usage: pomo [status|start [index]|stop|skip]", "error")
+	}
+}
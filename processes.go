@@ -0,0 +1,391 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// --- Process Table ---
+//
+// Every process in a tview.Table below the system panel. P (or `ps`) moves
+// focus into the table; from there the arrows select, s/r change the sort,
+// / filters by name, k terminates and K kills after a confirmation.
+
+var procColumns = []string{"pid", "name", "cpu", "mem", "user", "state"}
+
+type ProcessInfo struct {
+	PID   int32
+	Name  string
+	User  string
+	State string  // "running", "sleep", "zombie", ...
+	CPU   float64 // Percent of the whole machine
+	Mem   float64 // Percent of physical memory
+	RSS   uint64  // Resident memory in bytes
+}
+
+// fetchProcesses refreshes the process list on its own interval
+// (refresh.processes), as walking every process is the slowest part of a refresh.
+func (b *Baseline) fetchProcesses() {
+	if b.remote != nil {
+		return // Arrive with the remote state, see updateRemoteSystemInfo
+	}
+	infos, err := b.procSampler.List(b.cpuCoreCount)
+	if err != nil {
+		return
+	}
+
+	b.mu.Lock()
+	b.procs = infos
+	b.mu.Unlock()
+	b.updateProcTable()
+}
+
+// processKey tells a process apart from a later one reusing its PID.
+type processKey struct {
+	pid     int32
+	created int64 // Milliseconds since the epoch
+}
+
+type processCPU struct {
+	seconds float64 // User + system CPU time
+	at      time.Time
+}
+
+// ProcessSampler keeps each process's CPU time from the previous pass, so
+// CPU % is the usage over the interval between passes rather than the
+// average since the process started.
+type ProcessSampler struct {
+	mu   sync.Mutex
+	prev map[processKey]processCPU
+}
+
+func newProcessSampler() *ProcessSampler {
+	return &ProcessSampler{prev: map[processKey]processCPU{}}
+}
+
+// List reads every process; CPU is normalized over cores. Processes seen
+// for the first time report their average since start.
+func (s *ProcessSampler) List(cores int) ([]ProcessInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+	var total uint64 // Read once rather than per process, as MemoryPercent would
+	if vm, err := mem.VirtualMemory(); err == nil {
+		total = vm.Total
+	}
+	now := time.Now()
+	seen := make(map[processKey]processCPU, len(procs)) // Exited processes drop out
+	users := map[int32]string{}                         // Username lookups are slow, most processes share a uid
+	var infos []ProcessInfo
+	for _, p := range procs {
+		info := ProcessInfo{PID: p.Pid}
+		info.Name, _ = p.Name()
+		created, _ := p.CreateTime()
+		if times, err := p.Times(); err == nil {
+			key := processKey{pid: p.Pid, created: created}
+			cur := processCPU{seconds: times.User + times.System, at: now}
+			seen[key] = cur
+			if prev, ok := s.prev[key]; ok && cur.at.After(prev.at) {
+				info.CPU = max(0, cur.seconds-prev.seconds) / cur.at.Sub(prev.at).Seconds() * 100
+			} else {
+				info.CPU, _ = p.CPUPercent()
+			}
+			info.CPU /= float64(cores) // Normalize
+		}
+		if mi, err := p.MemoryInfo(); err == nil && total > 0 {
+			info.RSS = mi.RSS
+			info.Mem = float64(mi.RSS) / float64(total) * 100
+		}
+		if status, err := p.Status(); err == nil && len(status) > 0 {
+			info.State = status[0]
+		}
+		if uids, err := p.Uids(); err == nil && len(uids) > 0 {
+			name, ok := users[uids[0]]
+			if !ok {
+				name, _ = p.Username()
+				users[uids[0]] = name
+			}
+			info.User = name
+		} else {
+			info.User, _ = p.Username() // Windows has no uids
+		}
+		infos = append(infos, info)
+	}
+	s.prev = seen
+	return infos, nil
+}
+
+// sortProcesses orders procs in place by column, busiest/highest first when desc.
+func sortProcesses(procs []ProcessInfo, column string, desc bool) {
+	less := func(i, j int) bool {
+		a, c := procs[i], procs[j]
+		switch column {
+		case "pid":
+			return a.PID < c.PID
+		case "name":
+			return strings.ToLower(a.Name) < strings.ToLower(c.Name)
+		case "mem":
+			return a.Mem < c.Mem
+		case "user":
+			return a.User < c.User
+		case "state":
+			return a.State < c.State
+		}
+		return a.CPU < c.CPU
+	}
+	sort.SliceStable(procs, func(i, j int) bool {
+		if desc {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
+}
+
+func (b *Baseline) updateProcTable() {
+	b.mu.RLock()
+	var procs []ProcessInfo
+	filter := strings.ToLower(b.procFilter)
+	for _, p := range b.procs {
+		if filter == "" || strings.Contains(strings.ToLower(p.Name), filter) {
+			procs = append(procs, p)
+		}
+	}
+	column, desc := b.procSort, b.procDesc
+	b.mu.RUnlock()
+
+	sortProcesses(procs, column, desc)
+
+	title := fmt.Sprintf(" Processes (%d, by %s) ", len(procs), column)
+	if filter != "" {
+		title = fmt.Sprintf(" Processes (%d matching %q, by %s) ", len(procs), filter, column)
+	}
+
+	// What the table shows, to skip refreshes that change nothing
+	var shown strings.Builder
+	fmt.Fprintf(&shown, "%s %v %v %v %v\n", title, desc, b.theme.Main, b.theme.Dim, b.theme.Bright)
+	for _, p := range procs {
+		fmt.Fprintf(&shown, "%d %s %.1f %.1f %s %s\n", p.PID, p.Name, p.CPU, p.Mem, p.User, p.State)
+	}
+
+	b.renderPanel(b.procTable, shown.String(), func() {
+		t := b.procTable
+		// Keep the selection on the same process across refreshes
+		var selectedPID int32
+		if row, _ := t.GetSelection(); row > 0 {
+			if pid, ok := t.GetCell(row, 0).GetReference().(int32); ok {
+				selectedPID = pid
+			}
+		}
+
+		t.Clear()
+		for col, name := range procColumns {
+			label := strings.ToUpper(name)
+			if name == column {
+				label += map[bool]string{true: " ↓", false: " ↑"}[desc]
+			}
+			align := tview.AlignLeft
+			if name == "pid" || name == "cpu" || name == "mem" {
+				align = tview.AlignRight
+			}
+			t.SetCell(0, col, tview.NewTableCell(label).
+				SetTextColor(b.theme.Bright).
+				SetAttributes(tcell.AttrBold).
+				SetAlign(align).
+				SetSelectable(false))
+		}
+		selectRow := 1
+		for i, p := range procs {
+			row := i + 1
+			color := b.theme.Main
+			if p.State == process.Zombie {
+				color = tcell.ColorRed
+			}
+			t.SetCell(row, 0, tview.NewTableCell(strconv.Itoa(int(p.PID))).SetTextColor(b.theme.Dim).SetAlign(tview.AlignRight).SetReference(p.PID))
+			t.SetCell(row, 1, tview.NewTableCell(p.Name).SetTextColor(color).SetExpansion(1).SetMaxWidth(24))
+			t.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%.1f", p.CPU)).SetTextColor(color).SetAlign(tview.AlignRight))
+			t.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%.1f", p.Mem)).SetTextColor(color).SetAlign(tview.AlignRight))
+			t.SetCell(row, 4, tview.NewTableCell(p.User).SetTextColor(b.theme.Dim).SetMaxWidth(12))
+			t.SetCell(row, 5, tview.NewTableCell(p.State).SetTextColor(b.theme.Dim))
+			if p.PID == selectedPID {
+				selectRow = row
+			}
+		}
+		if len(procs) > 0 {
+			t.Select(selectRow, 0)
+		}
+		t.SetTitle(title)
+	})
+}
+
+// selectedProcess returns the highlighted row. Must run on the UI goroutine.
+func (b *Baseline) selectedProcess() (int32, string, bool) {
+	row, _ := b.procTable.GetSelection()
+	pid, ok := b.procTable.GetCell(row, 0).GetReference().(int32)
+	if !ok {
+		return 0, "", false
+	}
+	return pid, b.procTable.GetCell(row, 1).Text, true
+}
+
+// setupProcTable creates the table and its keys. Called from setupLayout.
+func (b *Baseline) setupProcTable() {
+	b.procTable = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	b.procTable.SetBorder(true).SetTitle(" Processes ")
+
+	b.procTable.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape, tcell.KeyTab:
+			b.app.SetFocus(b.layout)
+			b.procTable.SetBorderColor(b.theme.border())
+			return nil
+		}
+		switch event.Rune() {
+		case 's': // Next sort column
+			b.mu.Lock()
+			for i, c := range procColumns {
+				if c == b.procSort {
+					b.procSort = procColumns[(i+1)%len(procColumns)]
+					break
+				}
+			}
+			b.procDesc = b.procSort == "cpu" || b.procSort == "mem"
+			b.mu.Unlock()
+			go b.updateProcTable()
+			return nil
+		case 'r': // Reverse the sort
+			b.mu.Lock()
+			b.procDesc = !b.procDesc
+			b.mu.Unlock()
+			go b.updateProcTable()
+			return nil
+		case '/':
+			b.mu.Lock()
+			b.currentFocus = "command"
+			b.mu.Unlock()
+			b.cmdInput.SetText("ps filter ")
+			b.app.SetFocus(b.cmdInput)
+			go b.updateFooter()
+			return nil
+		case 'k', 'K':
+			if pid, name, ok := b.selectedProcess(); ok {
+				b.confirmSignal(pid, name, event.Rune() == 'K')
+			}
+			return nil
+		}
+		return event
+	})
+}
+
+// confirmSignal asks before terminating (SIGTERM) or killing (SIGKILL) a
+// process. Must run on the UI goroutine.
+func (b *Baseline) confirmSignal(pid int32, name string, kill bool) {
+	if b.remote != nil {
+		b.notify("processes", "Processes of an attached server can't be signalled", "error")
+		return
+	}
+	action := "Terminate"
+	if kill {
+		action = "Kill"
+	}
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("%s %s (PID %d)?", action, name, pid)).
+		AddButtons([]string{action, "Cancel"}).
+		SetTextColor(b.theme.Main).
+		SetButtonTextColor(b.theme.Bright).
+		SetDoneFunc(func(index int, label string) {
+			b.pages.RemovePage("confirm")
+			b.app.SetFocus(b.procTable)
+			if label == action {
+				go b.signalProcess(pid, name, kill)
+			}
+		})
+	modal.SetBorderColor(b.theme.Bright)
+	b.pages.AddPage("confirm", modal, false, true)
+	b.app.SetFocus(modal)
+}
+
+func (b *Baseline) signalProcess(pid int32, name string, kill bool) {
+	p, err := process.NewProcess(pid)
+	if err == nil {
+		if kill {
+			err = p.Kill()
+		} else {
+			err = p.Terminate()
+		}
+	}
+	if err != nil {
+		b.notify("processes", fmt.Sprintf("Could not signal %s (%d): %v", name, pid, err), "error")
+	} else {
+		verb := map[bool]string{true: "Killed", false: "Sent SIGTERM to"}[kill]
+		b.notify("processes", fmt.Sprintf("%s %s (%d)", verb, name, pid), "success")
+	}
+	b.fetchProcesses()
+}
+
+// focusProcTable moves keyboard focus into the table. Must run on the UI goroutine.
+func (b *Baseline) focusProcTable() {
+	b.procTable.SetBorderColor(b.theme.Bright)
+	b.app.SetFocus(b.procTable)
+}
+
+// psCommand handles "ps [sort <column>|filter [text]|kill <pid>]".
+// Called from processCommand with b.mu held.
+func (b *Baseline) psCommand(args []string) {
+	if len(args) == 0 {
+		go b.app.QueueUpdateDraw(b.focusProcTable)
+		return
+	}
+	switch args[0] {
+	case "sort":
+		if len(args) < 2 || !slices.Contains(procColumns, strings.ToLower(args[1])) {
+			b.addNotification("Usage: ps sort pid|name|cpu|mem|user|state", "error")
+			return
+		}
+		if b.procSort == strings.ToLower(args[1]) {
+			b.procDesc = !b.procDesc
+		} else {
+			b.procSort = strings.ToLower(args[1])
+			b.procDesc = b.procSort == "cpu" || b.procSort == "mem"
+		}
+		go b.updateProcTable()
+	case "filter":
+		b.procFilter = strings.Join(args[1:], " ")
+		go b.updateProcTable()
+		go b.app.QueueUpdateDraw(b.focusProcTable)
+	case "kill", "term":
+		var pid int
+		var err error
+		if len(args) > 1 {
+			pid, err = strconv.Atoi(args[1])
+		}
+		if len(args) < 2 || err != nil {
+			b.addNotification("Usage: ps kill|term <pid>", "error")
+			return
+		}
+		name := "process"
+		for _, p := range b.procs {
+			if p.PID == int32(pid) {
+				name = p.Name
+			}
+		}
+		go b.app.QueueUpdateDraw(func() { b.confirmSignal(int32(pid), name, args[0] == "kill") })
+	default:
+		b.addNotification("Usage: ps [sort <column>|filter [text]|kill <pid>|term <pid>]", "error")
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// --- Phone Push Notifications ---
+
+// Pusher sends a notification to a phone push service.
+type Pusher interface {
+	Name() string
+	Push(title string, n Notification) error
+}
+
+// pushersFromEnv returns the configured push services (ntfy.sh and/or Pushover).
+func pushersFromEnv() []Pusher {
+	var pushers []Pusher
+	if topic := os.Getenv("NTFY_TOPIC"); topic != "" {
+		server := strings.TrimRight(os.Getenv("NTFY_URL"), "/")
+		if server == "" {
+			server = "https://ntfy.sh"
+		}
+		pushers = append(pushers, &NtfyPusher{
+			url:    server + "/" + url.PathEscape(topic),
+			token:  os.Getenv("NTFY_TOKEN"),
+			client: http.Client{Timeout: 10 * time.Second},
+		})
+	}
+	if token, user := os.Getenv("PUSHOVER_TOKEN"), os.Getenv("PUSHOVER_USER"); token != "" && user != "" {
+		pushers = append(pushers, &PushoverPusher{
+			token:  token,
+			user:   user,
+			client: http.Client{Timeout: 10 * time.Second},
+		})
+	}
+	return pushers
+}
+
+// --- ntfy ---
+
+// NtfyPusher publishes to an ntfy topic (ntfy.sh or a self-hosted server).
+type NtfyPusher struct {
+	url    string
+	token  string // Optional access token for protected topics
+	client http.Client
+}
+
+func (p *NtfyPusher) Name() string { return "ntfy" }
+
+func (p *NtfyPusher) Push(title string, n Notification) error {
+	req, err := http.NewRequest(http.MethodPost, p.url, strings.NewReader(n.Message))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Title", title)
+	priority, tags := "default", "information_source"
+	switch {
+	case n.Type == "error" && n.Priority >= PriorityHigh:
+		priority, tags = "urgent", "rotating_light"
+	case n.Type == "error":
+		priority, tags = "high", "warning"
+	case n.Type == "success":
+		tags = "white_check_mark"
+	}
+	req.Header.Set("Priority", priority)
+	req.Header.Set("Tags", tags)
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP error: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API error: Status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// --- Pushover ---
+
+type PushoverPusher struct {
+	token  string // Application API token
+	user   string // User or group key
+	client http.Client
+}
+
+func (p *PushoverPusher) Name() string { return "pushover" }
+
+func (p *PushoverPusher) Push(title string, n Notification) error {
+	priority := "0"
+	if n.Type == "error" {
+		priority = "1" // High: bypasses the user's quiet hours
+	}
+	form := url.Values{
+		"token":     {p.token},
+		"user":      {p.user},
+		"title":     {title},
+		"message":   {n.Message},
+		"priority":  {priority},
+		"timestamp": {fmt.Sprint(n.Time.Unix())},
+	}
+
+	resp, err := p.client.PostForm("https://api.pushover.net/1/messages.json", form)
+	if err != nil {
+		return fmt.Errorf("HTTP error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Status int      `json:"status"`
+		Errors []string `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return fmt.Errorf("JSON parse error: %w", err)
+	}
+	if data.Status != 1 {
+		return fmt.Errorf("API error: %s (%d)", strings.Join(data.Errors, "; "), resp.StatusCode)
+	}
+	return nil
+}
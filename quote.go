@@ -0,0 +1,220 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Quote Of The Day Widget ---
+
+const quoteRefreshInterval = 15 * time.Minute // Only refetches once the day changes
+
+//go:embed quotes.txt
+var bundledQuotes string
+
+type Quote struct {
+	Date   string `json:"date"` // Day the quote was picked for, "2006-01-02"
+	Text   string `json:"text"`
+	Author string `json:"author"`
+}
+
+// QuoteSource picks the day's quote from a fortune-style file (entries
+// separated by "%" lines; QUOTE_FILE or the bundled quotes.txt) or from
+// QUOTE_API_URL.
+type QuoteSource struct {
+	file   string
+	apiURL string
+	client http.Client
+}
+
+// newQuoteSourceFromEnv returns nil unless QUOTE is enabled or a file/API is configured.
+func newQuoteSourceFromEnv() *QuoteSource {
+	v := strings.ToLower(os.Getenv("QUOTE"))
+	q := &QuoteSource{
+		file:   os.Getenv("QUOTE_FILE"),
+		apiURL: os.Getenv("QUOTE_API_URL"),
+		client: http.Client{Timeout: 10 * time.Second},
+	}
+	if v != "true" && v != "1" && v != "yes" && q.file == "" && q.apiURL == "" {
+		return nil
+	}
+	return q
+}
+
+// parseFortunes splits a fortune file into entries. Files without "%"
+// separators are read as one quote per line.
+func parseFortunes(data string) []string {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	sep := "\n%\n"
+	if !strings.Contains(data, sep) {
+		sep = "\n"
+	}
+	var entries []string
+	for _, e := range strings.Split(data, sep) {
+		if e = strings.TrimSpace(strings.TrimSuffix(e, "%")); e != "" {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// splitAuthor separates a trailing "— Author" (or "-- Author") attribution.
+func splitAuthor(entry string) (text, author string) {
+	for _, sep := range []string{" — ", " -- ", "\n— ", "\n-- "} {
+		if i := strings.LastIndex(entry, sep); i > 0 {
+			return strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+len(sep):])
+		}
+	}
+	return entry, ""
+}
+
+func (q *QuoteSource) fromFile(date string) (Quote, error) {
+	data := bundledQuotes
+	if q.file != "" {
+		raw, err := os.ReadFile(q.file)
+		if err != nil {
+			return Quote{}, err
+		}
+		data = string(raw)
+	}
+	entries := parseFortunes(data)
+	if len(entries) == 0 {
+		return Quote{}, fmt.Errorf("no quotes in %s", q.file)
+	}
+	// Same quote all day, a different one tomorrow
+	h := fnv.New32a()
+	h.Write([]byte(date))
+	text, author := splitAuthor(entries[h.Sum32()%uint32(len(entries))])
+	return Quote{Date: date, Text: text, Author: author}, nil
+}
+
+// fromAPI understands the common quote API shapes: ZenQuotes ([{"q","a"}]),
+// Quotable ({"content","author"}), {"quote"/"text","author"}, or plain text.
+func (q *QuoteSource) fromAPI(date string) (Quote, error) {
+	resp, err := q.client.Get(q.apiURL)
+	if err != nil {
+		return Quote{}, fmt.Errorf("HTTP error: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Quote{}, fmt.Errorf("API error: Status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return Quote{}, err
+	}
+
+	type apiQuote struct {
+		Q, A    string
+		Content string
+		Quote   string
+		Text    string
+		Author  string
+	}
+	var items []apiQuote
+	var one apiQuote
+	if json.Unmarshal(body, &items) != nil || len(items) == 0 {
+		if json.Unmarshal(body, &one) != nil {
+			text, author := splitAuthor(strings.TrimSpace(string(body)))
+			return Quote{Date: date, Text: text, Author: author}, nil
+		}
+		items = []apiQuote{one}
+	}
+	a := items[0]
+	quote := Quote{Date: date, Author: a.Author}
+	for _, t := range []string{a.Q, a.Content, a.Quote, a.Text} {
+		if t != "" {
+			quote.Text = t
+			break
+		}
+	}
+	if quote.Author == "" {
+		quote.Author = a.A
+	}
+	if quote.Text == "" {
+		return Quote{}, fmt.Errorf("JSON parse error: no quote in response")
+	}
+	return quote, nil
+}
+
+func (b *Baseline) fetchQuote() {
+	today := time.Now().Format("2006-01-02")
+	cachePath := filepath.Join(b.configDir, "quote.json")
+
+	b.mu.RLock()
+	current := b.quoteInfo
+	b.mu.RUnlock()
+	if current.Date == today {
+		return
+	}
+
+	// Cached per day, so restarts don't pick a new quote (or hit the API again)
+	var quote Quote
+	if data, err := os.ReadFile(cachePath); err == nil {
+		_ = json.Unmarshal(data, &quote)
+	}
+	if quote.Date != today {
+		var err error
+		if quote, err = b.quote.fromAPIOrFile(today); err != nil {
+			// Not cached, so the next start tries again; keep whatever fallback we got for today
+			b.notify("quote", fmt.Sprintf("Quote of the day: %v", err), "error")
+			quote.Date = today
+		} else if data, err := json.Marshal(quote); err == nil {
+			_ = os.WriteFile(cachePath, data, 0640)
+		}
+	}
+
+	b.mu.Lock()
+	b.quoteInfo = quote
+	b.mu.Unlock()
+	b.updateQuote()
+}
+
+// fromAPIOrFile uses the API when configured, falling back to the file.
+func (q *QuoteSource) fromAPIOrFile(date string) (Quote, error) {
+	if q.apiURL == "" {
+		return q.fromFile(date)
+	}
+	quote, err := q.fromAPI(date)
+	if err != nil {
+		if fallback, ferr := q.fromFile(date); ferr == nil {
+			return fallback, err
+		}
+	}
+	return quote, err
+}
+
+func (b *Baseline) updateQuote() {
+	b.mu.RLock()
+	quote := b.quoteInfo
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sQUOTE OF THE DAY[-:-:-]\n", brightC+"[::b]"))
+	if quote.Date == "" {
+		sb.WriteString(fmt.Sprintf("%sLoading...[-:-:-]\n", dimC))
+	} else if quote.Text == "" {
+		sb.WriteString(fmt.Sprintf("%s(No quote available)[-:-:-]\n", dimC))
+	} else {
+		sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n", mainC, tview.Escape(quote.Text)))
+		if quote.Author != "" {
+			sb.WriteString(fmt.Sprintf("%s  — %s[-:-:-]\n", dimC, tview.Escape(quote.Author)))
+		}
+	}
+
+	b.setPanel(b.quotePanel, sb.String())
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+// --- Reboot Required Detection ---
+//
+// Checks the usual pending-reboot markers and raises a header badge plus a
+// single notification when one appears. On by default; REBOOT_CHECK=false
+// turns it off.
+
+const rebootCheckInterval = 30 * time.Minute
+
+func rebootCheckEnabled() bool {
+	return strings.ToLower(os.Getenv("REBOOT_CHECK")) != "false"
+}
+
+// rebootRequired returns why a reboot is pending, or "" when none is.
+func rebootRequired() string {
+	switch runtime.GOOS {
+	case "linux":
+		return linuxRebootRequired()
+	case "windows":
+		// Set by Windows Update and Component Based Servicing until the next boot
+		for _, key := range []string{
+			`HKLM\SOFTWARE\Microsoft\Windows\CurrentVersion\WindowsUpdate\Auto Update\RebootRequired`,
+			`HKLM\SOFTWARE\Microsoft\Windows\CurrentVersion\Component Based Servicing\RebootPending`,
+		} {
+			if exec.Command("reg", "query", key).Run() == nil {
+				return "Windows updates are waiting for a restart"
+			}
+		}
+	}
+	return ""
+}
+
+func linuxRebootRequired() string {
+	// Debian/Ubuntu: written by update-notifier, lists the packages responsible
+	if _, err := os.Stat("/var/run/reboot-required"); err == nil {
+		if data, err := os.ReadFile("/var/run/reboot-required.pkgs"); err == nil {
+			if pkgs := strings.Fields(string(data)); len(pkgs) > 0 {
+				return "Updated packages need a reboot: " + strings.Join(uniqueStrings(pkgs), ", ")
+			}
+		}
+		return "System updates need a reboot"
+	}
+
+	running, err := host.KernelVersion()
+	if err != nil || running == "" {
+		return ""
+	}
+	installed, _ := filepath.Glob("/lib/modules/*")
+	if len(installed) == 0 {
+		return "" // Containers and some minimal systems have no modules at all
+	}
+	// Arch and others remove the running kernel's modules on upgrade
+	if _, err := os.Stat(filepath.Join("/lib/modules", running)); os.IsNotExist(err) {
+		return fmt.Sprintf("Running kernel %s is no longer installed", running)
+	}
+	newest := running
+	for _, path := range installed {
+		if v := filepath.Base(path); kernelFlavor(v) == kernelFlavor(running) && compareVersions(v, newest) > 0 {
+			newest = v
+		}
+	}
+	if newest != running {
+		return fmt.Sprintf("Kernel %s is installed, %s is running", newest, running)
+	}
+	return ""
+}
+
+// kernelFlavor returns the non-numeric suffix ("generic", "lts"), so a newer
+// kernel of another flavor installed alongside doesn't count.
+func kernelFlavor(v string) string {
+	return strings.TrimLeftFunc(v, func(r rune) bool {
+		return unicode.IsDigit(r) || r == '.' || r == '-' || r == '_'
+	})
+}
+
+// compareVersions compares the numeric parts of two version strings.
+func compareVersions(a, b string) int {
+	split := func(s string) []int {
+		var nums []int
+		for _, f := range strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsDigit(r) }) {
+			n, _ := strconv.Atoi(f)
+			nums = append(nums, n)
+		}
+		return nums
+	}
+	na, nb := split(a), split(b)
+	for i := 0; i < len(na) && i < len(nb); i++ {
+		if na[i] != nb[i] {
+			if na[i] < nb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(na) - len(nb)
+}
+
+func uniqueStrings(list []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, s := range list {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// checkReboot refreshes the pending-reboot state, notifying when it appears.
+func (b *Baseline) checkReboot() {
+	reason := rebootRequired()
+
+	b.mu.Lock()
+	prev := b.rebootReason
+	b.rebootReason = reason
+	b.mu.Unlock()
+
+	if reason == prev {
+		return
+	}
+	if reason != "" {
+		b.notify("system", "Reboot required: "+reason, "info")
+	}
+	b.app.QueueUpdateDraw(b.updateHeader)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// --- Break / Hydration Reminders ---
+
+const reminderCheckInterval = 30 * time.Second
+
+// Reminder is a recurring wellness nudge ("Stand up" every 50 minutes).
+type Reminder struct {
+	Text  string
+	Every time.Duration
+	Next  time.Time
+}
+
+// remindersFromEnv parses REMINDERS, e.g. "Stand up and stretch:50m,Drink water:1h".
+func remindersFromEnv() []*Reminder {
+	var reminders []*Reminder
+	now := time.Now()
+	for _, item := range strings.Split(os.Getenv("REMINDERS"), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		i := strings.LastIndex(item, ":")
+		if i <= 0 {
+			slog.Warn("Invalid reminder, want text:interval", "reminder", item)
+			continue
+		}
+		every, err := time.ParseDuration(strings.TrimSpace(item[i+1:]))
+		if err != nil || every < time.Minute {
+			slog.Warn("Invalid reminder interval", "reminder", item)
+			continue
+		}
+		reminders = append(reminders, &Reminder{
+			Text:  strings.TrimSpace(item[:i]),
+			Every: every,
+			Next:  now.Add(every),
+		})
+	}
+	return reminders
+}
+
+// pauseRemindersInMeetings reports whether REMINDERS_PAUSE_IN_MEETINGS is on.
+func pauseRemindersInMeetings() bool {
+	v := strings.ToLower(os.Getenv("REMINDERS_PAUSE_IN_MEETINGS"))
+	return v == "true" || v == "1" || v == "yes"
+}
+
+// inMeeting returns the calendar event running right now, if any. All-day
+// events don't count. Caller holds b.mu.
+func (b *Baseline) inMeeting(now time.Time) (CalendarEvent, bool) {
+	for _, ev := range b.calendarEvents {
+		if !ev.AllDay && !now.Before(ev.Start) && now.Before(ev.End) {
+			return ev, true
+		}
+	}
+	return CalendarEvent{}, false
+}
+
+// checkReminders fires due reminders. While paused (by command, or during a
+// meeting) they are held back and fire once the pause ends.
+func (b *Baseline) checkReminders() {
+	now := time.Now()
+
+	b.mu.Lock()
+	var due []string
+	_, meeting := b.inMeeting(now)
+	if !b.remindPaused && !(meeting && b.remindMeeting) {
+		for _, r := range b.reminders {
+			if !now.Before(r.Next) {
+				due = append(due, r.Text)
+				r.Next = now.Add(r.Every)
+			}
+		}
+	}
+	b.mu.Unlock()
+
+	for _, text := range due {
+		b.notify("reminders", text, "info")
+	}
+}
+
+// remindersCommand handles "reminders [pause|resume|reset]". Called with b.mu held.
+func (b *Baseline) remindersCommand(args []string) {
+	if len(b.reminders) == 0 {
+		b.addNotification("No reminders configured (set REMINDERS)", "error")
+		return
+	}
+	action := ""
+	if len(args) > 0 {
+		action = args[0]
+	}
+	now := time.Now()
+	switch action {
+	case "":
+		var parts []string
+		for _, r := range b.reminders {
+			parts = append(parts, fmt.Sprintf("%s in %s", r.Text, r.Next.Sub(now).Round(time.Minute)))
+		}
+		status := strings.Join(parts, ", ")
+		if b.remindPaused {
+			status = "Paused. " + status
+		}
+		b.addNotification(status, "info")
+	case "pause":
+		b.remindPaused = true
+		b.addNotification("Reminders paused", "success")
+	case "resume":
+		b.remindPaused = false
+		b.addNotification("Reminders resumed", "success")
+	case "reset":
+		// E.g. after coming back from a break: restart every interval from now
+		for _, r := range b.reminders {
+			r.Next = now.Add(r.Every)
+		}
+		b.addNotification("Reminder timers restarted", "success")
+	default:
+		b.addNotification("Usage: reminders [pause|resume|reset]", "error")
+	}
+}
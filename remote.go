@@ -0,0 +1,469 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/rivo/tview"
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+// --- Server Mode ---
+//
+// `baseline serve` runs only the collector: system samples go into
+// metrics.db whether or not a dashboard is open, and the latest state and
+// history are served as JSON over a Unix socket (default
+// ~/.baseline/baseline.sock) or TCP:
+//
+//	baseline serve --listen tcp:0.0.0.0:7420
+//	baseline attach myserver:7420 --token ...
+//
+// `baseline attach` starts the dashboard with the system, process and
+// history panels showing the server; everything else stays local. Every
+// request needs the token from --token, BASELINE_TOKEN or
+// ~/.baseline/server.token, which serve creates on first start.
+
+const (
+	serverSocketName   = "baseline.sock"
+	serverTokenName    = "server.token"
+	remoteTimeout      = 5 * time.Second
+	remoteProcessLimit = 100 // Busiest processes sent to clients
+)
+
+// RemoteState is what /v1/state returns.
+type RemoteState struct {
+	Time      time.Time      `json:"time"`
+	System    SystemSnapshot `json:"system"`
+	Processes []ProcessInfo  `json:"processes"`
+}
+
+// parseListenAddr splits "unix:/path", "tcp:host:port", a bare path or a
+// bare host:port into a network and address.
+func parseListenAddr(addr, configDir string) (network, address string) {
+	switch {
+	case addr == "":
+		return "unix", filepath.Join(configDir, serverSocketName)
+	case strings.HasPrefix(addr, "unix:"):
+		return "unix", expandHome(strings.TrimPrefix(addr, "unix:"))
+	case strings.HasPrefix(addr, "tcp:"):
+		return "tcp", strings.TrimPrefix(addr, "tcp:")
+	case strings.ContainsAny(addr, `/\`) || strings.HasPrefix(addr, "~"):
+		return "unix", expandHome(addr)
+	}
+	return "tcp", addr
+}
+
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
+// serverToken returns the explicit token, BASELINE_TOKEN, or the one in
+// server.token. With create, a missing token file is generated.
+func serverToken(configDir, explicit string, create bool) (string, error) {
+	if explicit != "" {
+		return explicit, nil
+	}
+	if token := os.Getenv("BASELINE_TOKEN"); token != "" {
+		return token, nil
+	}
+	path := filepath.Join(configDir, serverTokenName)
+	if data, err := os.ReadFile(path); err == nil && strings.TrimSpace(string(data)) != "" {
+		return strings.TrimSpace(string(data)), nil
+	}
+	if !create {
+		return "", fmt.Errorf("no token: use --token, BASELINE_TOKEN or %s", path)
+	}
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(raw)
+	if err := os.WriteFile(path, []byte(token+"\n"), 0600); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// collector is the state of a running `baseline serve`.
+type collector struct {
+	mu      sync.RWMutex
+	state   RemoteState
+	metrics *MetricsStore
+	procs   *ProcessSampler
+	cores   int
+}
+
+// collect takes one system sample and stores it.
+func (c *collector) collect() {
+	now := time.Now()
+	sys := collectSystem(0)
+	c.mu.Lock()
+	c.state.Time, c.state.System = now, sys
+	c.mu.Unlock()
+
+	sample := MetricSample{Time: now, CPU: sys.CPU, Memory: sys.Memory, NetIn: sys.NetRxBytes, NetOut: sys.NetTxBytes}
+	if err := c.metrics.Append(sample); err != nil {
+		slog.Error("Could not save sample", "err", err)
+	}
+}
+
+func (c *collector) collectProcesses() {
+	procs, err := c.procs.List(c.cores)
+	if err != nil {
+		return
+	}
+	sortProcesses(procs, "cpu", true)
+	if len(procs) > remoteProcessLimit {
+		procs = procs[:remoteProcessLimit]
+	}
+	c.mu.Lock()
+	c.state.Processes = procs
+	c.mu.Unlock()
+}
+
+// handler serves the API, checking the bearer token on every request.
+func (c *collector) handler(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/state", func(w http.ResponseWriter, r *http.Request) {
+		c.mu.RLock()
+		state := c.state
+		c.mu.RUnlock()
+		writeJSON(w, state)
+	})
+	mux.HandleFunc("/v1/history", func(w http.ResponseWriter, r *http.Request) {
+		n := historyLimit // All the history graph shows
+		if v := r.URL.Query().Get("n"); v != "" {
+			var err error
+			if n, err = strconv.Atoi(v); err != nil || n <= 0 {
+				http.Error(w, "n must be a positive number", http.StatusBadRequest)
+				return
+			}
+			n = min(n, historyLimit)
+		}
+		samples, err := c.metrics.Recent(n)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, samples)
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// runServeCommand implements `baseline serve [--listen addr] [--token token]`.
+func runServeCommand(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	listen := fs.String("listen", "", "unix:/path or tcp:host:port (default unix socket in ~/.baseline)")
+	tokenFlag := fs.String("token", "", "token clients must send (default BASELINE_TOKEN or ~/.baseline/server.token)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	_ = godotenv.Load()
+	configDir := defaultConfigDir()
+	_ = os.MkdirAll(configDir, 0750)
+	cfg, warnings := loadConfig(configDir)
+	for _, w := range warnings {
+		slog.Warn(w)
+	}
+
+	token, err := serverToken(configDir, *tokenFlag, true)
+	if err != nil {
+		return err
+	}
+	store, err := openMetricsStore(filepath.Join(configDir, metricsFileName))
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	cores, err := cpu.Counts(true)
+	if err != nil || cores == 0 {
+		cores = 1
+	}
+	c := &collector{metrics: store, procs: newProcessSampler(), cores: cores}
+
+	network, address := parseListenAddr(*listen, configDir)
+	if network == "unix" {
+		if conn, err := net.DialTimeout("unix", address, remoteTimeout); err == nil {
+			conn.Close()
+			return fmt.Errorf("another server is listening on %s", address)
+		} else if errors.Is(err, syscall.ECONNREFUSED) {
+			os.Remove(address) // A socket left behind by a crash
+		}
+	}
+	ln, err := net.Listen(network, address)
+	if err != nil {
+		return err
+	}
+	if network == "unix" {
+		os.Chmod(address, 0600)
+		defer os.Remove(address)
+	}
+	slog.Info("Serving", "network", network, "address", address)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	every := func(interval time.Duration, fn func()) {
+		go func() {
+			fn()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					fn()
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	every(cfg.Intervals.System, c.collect)
+	every(cfg.Intervals.Processes, c.collectProcesses)
+	every(metricsCompactInterval, func() {
+		if err := store.Compact(time.Now(), cfg.History); err != nil {
+			slog.Error("Could not compact metrics", "err", err)
+		}
+	})
+
+	srv := &http.Server{Handler: c.handler(token), ReadHeaderTimeout: remoteTimeout}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// --- Attached Client ---
+
+// RemoteClient talks to a `baseline serve`.
+type RemoteClient struct {
+	Addr   string // As given to attach, for display
+	token  string
+	base   string
+	client *http.Client
+
+	Host string // Server's host name, from the last state
+	Err  string // Last error, "" while connected
+}
+
+func newRemoteClient(addr, token, configDir string) *RemoteClient {
+	network, address := parseListenAddr(addr, configDir)
+	r := &RemoteClient{Addr: addr, token: token, base: "http://" + address, client: &http.Client{Timeout: remoteTimeout}}
+	if addr == "" {
+		r.Addr = "local"
+	}
+	if network == "unix" {
+		r.base = "http://baseline" // Host is ignored, every request goes to the socket
+		r.client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", address)
+			},
+		}
+	}
+	return r
+}
+
+func (r *RemoteClient) get(path string, out interface{}) error {
+	req, err := http.NewRequest("GET", r.base+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+r.token)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server returned %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (r *RemoteClient) State() (RemoteState, error) {
+	var state RemoteState
+	err := r.get("/v1/state", &state)
+	return state, err
+}
+
+func (r *RemoteClient) History(n int) ([]MetricSample, error) {
+	var samples []MetricSample
+	err := r.get("/v1/history?n="+strconv.Itoa(n), &samples)
+	return samples, err
+}
+
+// parseAttachArgs reads `baseline attach [addr] [--token token]`.
+func parseAttachArgs(args []string) (*RemoteClient, error) {
+	fs := flag.NewFlagSet("attach", flag.ContinueOnError)
+	tokenFlag := fs.String("token", "", "server token (default BASELINE_TOKEN or ~/.baseline/server.token)")
+	addr := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") { // Flags may follow the address
+		addr, args = args[0], args[1:]
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if addr == "" && fs.NArg() > 0 {
+		addr = fs.Arg(0)
+	}
+	_ = godotenv.Load()
+	configDir := defaultConfigDir()
+	token, err := serverToken(configDir, *tokenFlag, false)
+	if err != nil {
+		return nil, err
+	}
+	r := newRemoteClient(addr, token, configDir)
+	state, err := r.State()
+	if err != nil {
+		return nil, fmt.Errorf("cannot reach %s: %v", r.Addr, err)
+	}
+	r.Host = state.System.Host
+	return r, nil
+}
+
+// updateRemoteSystemInfo is updateSystemInfo for an attached dashboard: the
+// system panel and process list come from the server.
+func (b *Baseline) updateRemoteSystemInfo() {
+	state, err := b.remote.State()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	if err != nil {
+		if b.remote.Err == "" {
+			b.notifyPriority("system", fmt.Sprintf("Lost connection to %s: %v", b.remote.Addr, err), "error", PriorityHigh)
+			go b.app.QueueUpdateDraw(b.updateHeader)
+		}
+		b.remote.Err = err.Error()
+		text := fmt.Sprintf("%sSYSTEM STATUS[-:-:-]\n%sServer: %s[-:-:-]\n[red]%s[-:-:-]\n", brightC+"[::b]", mainC, b.remote.Addr, tview.Escape(err.Error()))
+		b.setPanel(b.systemPanel, text)
+		return
+	}
+	if b.remote.Err != "" {
+		b.remote.Err = ""
+		b.notify("system", fmt.Sprintf("Reconnected to %s", b.remote.Addr), "success")
+		go b.app.QueueUpdateDraw(b.updateHeader)
+	}
+	b.remote.Host = state.System.Host
+	sys := state.System
+	b.latestSystem = sys
+
+	// Rates from the server's counters, between the states seen here
+	var rxRate, txRate float64
+	if elapsed := state.Time.Sub(b.lastNetTime).Seconds(); elapsed > 0 && !b.lastNetTime.IsZero() &&
+		sys.NetRxBytes >= b.lastNetIO.BytesRecv && sys.NetTxBytes >= b.lastNetIO.BytesSent {
+		rxRate = float64(sys.NetRxBytes-b.lastNetIO.BytesRecv) / elapsed / 1024 // KB/s
+		txRate = float64(sys.NetTxBytes-b.lastNetIO.BytesSent) / elapsed / 1024
+	}
+	isNew := state.Time.After(b.lastNetTime)
+	b.lastNetIO.BytesRecv, b.lastNetIO.BytesSent, b.lastNetTime = sys.NetRxBytes, sys.NetTxBytes, state.Time
+
+	if isNew { // Unless the server hasn't sampled since the last poll
+		b.systemHistory.CPU = append(b.systemHistory.CPU, sys.CPU)
+		b.systemHistory.Memory = append(b.systemHistory.Memory, sys.Memory)
+		b.systemHistory.Timestamps = append(b.systemHistory.Timestamps, state.Time.Local().Format("15:04:05"))
+		b.systemHistory.NetworkIn = append(b.systemHistory.NetworkIn, sys.NetRxBytes)
+		b.systemHistory.NetworkOut = append(b.systemHistory.NetworkOut, sys.NetTxBytes)
+		b.saveSystemHistory(MetricSample{}) // Only trims, b.metrics is nil when attached
+	}
+	b.procs = state.Processes
+	go b.updateProcTable()
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sSYSTEM STATUS[-:-:-]\n", brightC+"[::b]"))
+	sb.WriteString(fmt.Sprintf("%sHost: %s %s(via %s)[-:-:-]\n", mainC, sys.Host, dimC, b.remote.Addr))
+	sb.WriteString(fmt.Sprintf("%sOS: %s[-:-:-]\n", mainC, sys.OS))
+	sb.WriteString(fmt.Sprintf("%sUptime: %s[-:-:-]\n", mainC, formatDuration(time.Duration(sys.UptimeSeconds)*time.Second)))
+	sb.WriteString(fmt.Sprintf("%sSampled: %s[-:-:-]\n", dimC, state.Time.Local().Format("15:04:05")))
+
+	sb.WriteString(fmt.Sprintf("\n%sCPU: %s %s %.1f%%[-:-:-]\n", mainC, createBar(sys.CPU, 15, b.theme), brightC, sys.CPU))
+	sb.WriteString(fmt.Sprintf("%sMEM: %s %s %.1f%%[-:-:-]\n", mainC, createBar(sys.Memory, 15, b.theme), brightC, sys.Memory))
+	sb.WriteString(fmt.Sprintf("%sDSK: %s %s %.1f%%[-:-:-]\n", mainC, createBar(sys.Disk, 15, b.theme), brightC, sys.Disk))
+	sb.WriteString(fmt.Sprintf("%sNET: %s↓ %.1f KB/s ↑ %.1f KB/s[-:-:-]\n", mainC, dimC, rxRate, txRate))
+	if len(sys.Load) == 3 {
+		sb.WriteString(fmt.Sprintf("%sLOAD: %s%.2f %.2f %.2f[-:-:-]\n", mainC, dimC, sys.Load[0], sys.Load[1], sys.Load[2]))
+	}
+	if len(sys.Temperatures) > 0 {
+		var temps []string
+		for _, cat := range sensorCategories {
+			if t, ok := sys.Temperatures[cat]; ok {
+				temps = append(temps, fmt.Sprintf("%s %.0f°C", cat, t))
+			}
+		}
+		sb.WriteString(fmt.Sprintf("%sTEMP: %s%s[-:-:-]\n", mainC, dimC, strings.Join(temps, ", ")))
+	}
+
+	if len(b.alertRules) > 0 {
+		metrics := map[string]float64{"cpu": sys.CPU, "mem": sys.Memory, "disk": sys.Disk}
+		if len(sys.Load) == 3 {
+			metrics["load"] = sys.Load[0]
+		}
+		b.checkAlertRules(metrics)
+	}
+	if b.historyPanel != nil {
+		go b.updateHistoryGraph()
+	}
+	text := sb.String()
+	b.setPanel(b.systemPanel, text)
+}
+
+// loadRemoteHistory fills the history graph from the server. Called from
+// loadSystemHistory with b.mu held.
+func (b *Baseline) loadRemoteHistory() {
+	samples, err := b.remote.History(historyLimit)
+	if err != nil {
+		b.addNotification(fmt.Sprintf("Error loading history from %s: %v", b.remote.Addr, err), "error")
+		return
+	}
+	for _, s := range samples {
+		b.systemHistory.CPU = append(b.systemHistory.CPU, s.CPU)
+		b.systemHistory.Memory = append(b.systemHistory.Memory, s.Memory)
+		b.systemHistory.Timestamps = append(b.systemHistory.Timestamps, s.Time.Local().Format("15:04:05")) // As live points are
+		b.systemHistory.NetworkIn = append(b.systemHistory.NetworkIn, s.NetIn)
+		b.systemHistory.NetworkOut = append(b.systemHistory.NetworkOut, s.NetOut)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Panel Rendering ---
+//
+// Most panels are re-rendered by a ticker or a fetch on their own goroutine,
+// and usually come out the same as last time: the clock only changes once a
+// second, a widget only when its data does. setPanel remembers the text each
+// panel was given and drops unchanged updates, so an idle dashboard doesn't
+// redraw at all. Changed panels are collected for renderBatchDelay and
+// applied in one QueueUpdateDraw, one redraw for everything a tick refreshed
+// instead of one per panel. Panels that are more than their text (a table, a
+// title, a scroll position) go through renderPanel with a description of
+// what they show.
+
+const renderBatchDelay = 15 * time.Millisecond
+
+type panelRenderer struct {
+	mu        sync.Mutex
+	shown     map[tview.Primitive]string // Last content given to each panel
+	pending   map[tview.Primitive]func() // Applies what changed since the last redraw
+	scheduled bool                       // A flush is waiting for renderBatchDelay
+	footer    string                     // What the footer shows, "" in command mode
+}
+
+// setPanel sets the text of a panel unless it is already showing it. Safe
+// from any goroutine, including the UI one; the scroll position is kept.
+func (b *Baseline) setPanel(tv *tview.TextView, text string) {
+	b.renderPanel(tv, text, func() { setPanelText(tv, text) })
+}
+
+// renderPanel queues apply, which updates panel p on the UI goroutine,
+// unless content (everything apply shows, as text) is what p shows already.
+// Safe from any goroutine.
+func (b *Baseline) renderPanel(p tview.Primitive, content string, apply func()) {
+	r := &b.panels
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.shown[p]; ok && old == content {
+		return
+	}
+	if r.shown == nil {
+		r.shown = make(map[tview.Primitive]string)
+		r.pending = make(map[tview.Primitive]func())
+	}
+	r.shown[p] = content
+	r.pending[p] = apply
+	if !r.scheduled {
+		r.scheduled = true
+		time.AfterFunc(renderBatchDelay, b.flushPanels)
+	}
+}
+
+// flushPanels applies the pending panel updates in a single redraw.
+func (b *Baseline) flushPanels() {
+	r := &b.panels
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[tview.Primitive]func())
+	r.scheduled = false
+	r.mu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+	b.app.QueueUpdateDraw(func() {
+		for _, apply := range pending {
+			apply()
+		}
+	})
+}
+
+// forgetPanel drops what setPanel remembers about a panel taken off the
+// screen.
+func (b *Baseline) forgetPanel(p tview.Primitive) {
+	r := &b.panels
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.shown, p)
+	delete(r.pending, p)
+}
+
+// footerChanged records what the footer is about to show and reports whether
+// that differs from what it shows now.
+func (b *Baseline) footerChanged(content string) bool {
+	r := &b.panels
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.footer == content {
+		return false
+	}
+	r.footer = content
+	return true
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// --- Health Report ---
+//
+// `report` summarises the stored history (metrics.go): min/avg/max CPU and
+// memory and the network traffic over the last hour and day, and the periods
+// in the last day where a cpu or mem [[alert]] rule's threshold was crossed
+// for at least its `for` duration. Without such rules, 90% is used for both.
+
+const reportBreachLimit = 10 // Most recent breaches listed
+
+type metricSummary struct {
+	Min, Avg, Max float64
+}
+
+func summarize(samples []MetricSample, value func(MetricSample) float64) metricSummary {
+	var s metricSummary
+	for i, sample := range samples {
+		v := value(sample)
+		if i == 0 || v < s.Min {
+			s.Min = v
+		}
+		s.Max = max(s.Max, v)
+		s.Avg += v
+	}
+	if len(samples) > 0 {
+		s.Avg /= float64(len(samples))
+	}
+	return s
+}
+
+// networkTotals adds up the traffic between consecutive samples, skipping
+// counter resets.
+func networkTotals(samples []MetricSample) (in, out uint64) {
+	for i := 1; i < len(samples); i++ {
+		prev, s := samples[i-1], samples[i]
+		if s.NetIn >= prev.NetIn && s.NetOut >= prev.NetOut { // Counters reset on reboot
+			in += s.NetIn - prev.NetIn
+			out += s.NetOut - prev.NetOut
+		}
+	}
+	return in, out
+}
+
+type breachPeriod struct {
+	Rule       AlertRule
+	Start, End time.Time
+	Peak       float64 // Furthest value past the threshold
+}
+
+// breachPeriods finds the runs of samples where r holds for at least r.For.
+func breachPeriods(samples []MetricSample, r AlertRule) []breachPeriod {
+	value := func(s MetricSample) float64 { return s.CPU }
+	if r.Metric == "mem" {
+		value = func(s MetricSample) float64 { return s.Memory }
+	}
+	var periods []breachPeriod
+	var current *breachPeriod
+	closeRun := func() {
+		if current != nil && current.End.Sub(current.Start) >= r.For {
+			periods = append(periods, *current)
+		}
+		current = nil
+	}
+	for _, s := range samples {
+		v := value(s)
+		if !r.holds(v) {
+			closeRun()
+			continue
+		}
+		if current == nil {
+			current = &breachPeriod{Rule: r, Start: s.Time, Peak: v}
+		}
+		current.End = s.Time
+		if (r.Op[0] == '>' && v > current.Peak) || (r.Op[0] == '<' && v < current.Peak) {
+			current.Peak = v
+		}
+	}
+	closeRun()
+	return periods
+}
+
+// reportRules returns the cpu and mem alert rules, or 90% defaults when none
+// are configured.
+func (b *Baseline) reportRules() []AlertRule {
+	var rules []AlertRule
+	for _, r := range b.alertRules {
+		if r.Metric == "cpu" || r.Metric == "mem" {
+			rules = append(rules, r)
+		}
+	}
+	if len(rules) == 0 {
+		rules = []AlertRule{
+			{Name: "cpu-90", Metric: "cpu", Op: ">", Threshold: 90},
+			{Name: "mem-90", Metric: "mem", Op: ">", Threshold: 90},
+		}
+	}
+	return rules
+}
+
+// openReport shows the health report. Must run on the UI goroutine, with
+// the metrics database open.
+func (b *Baseline) openReport() {
+	now := time.Now()
+	day, err := b.metrics.Range(now.Add(-24*time.Hour), now)
+	var hour []MetricSample
+	for i, s := range day {
+		if !s.Time.Before(now.Add(-time.Hour)) {
+			hour = day[i:]
+			break
+		}
+	}
+
+	view := newPanel(" Health Report ")
+	view.SetBorderColor(b.theme.Bright)
+	view.SetTitleColor(b.theme.Bright)
+	view.SetTextColor(b.theme.Main)
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	if err != nil {
+		sb.WriteString(fmt.Sprintf("%sCould not read history: %v[-:-:-]\n\n", "[red]", err))
+	}
+	for _, period := range []struct {
+		title   string
+		samples []MetricSample
+	}{{"LAST HOUR", hour}, {"LAST DAY", day}} {
+		sb.WriteString(fmt.Sprintf("%s%s[-:-:-] %s(%d samples)[-:-:-]\n", brightC+"[::b]", period.title, dimC, len(period.samples)))
+		if len(period.samples) == 0 {
+			sb.WriteString(fmt.Sprintf("%sNo history yet[-:-:-]\n\n", dimC))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s         %8s %8s %8s[-:-:-]\n", dimC, "min", "avg", "max"))
+		cpu := summarize(period.samples, func(s MetricSample) float64 { return s.CPU })
+		mem := summarize(period.samples, func(s MetricSample) float64 { return s.Memory })
+		for _, row := range []struct {
+			label string
+			s     metricSummary
+		}{{"CPU", cpu}, {"Memory", mem}} {
+			sb.WriteString(fmt.Sprintf("%s%-8s %s%7.1f%% %7.1f%% %7.1f%%[-:-:-]\n", mainC, row.label, brightC, row.s.Min, row.s.Avg, row.s.Max))
+		}
+		in, out := networkTotals(period.samples)
+		sb.WriteString(fmt.Sprintf("%s%-8s %s↓ %s  ↑ %s[-:-:-]\n\n", mainC, "Network", brightC, formatBytes(int64(in)), formatBytes(int64(out))))
+	}
+
+	sb.WriteString(fmt.Sprintf("%sTHRESHOLD BREACHES[-:-:-] %s(last day)[-:-:-]\n", brightC+"[::b]", dimC))
+	var breaches []breachPeriod
+	for _, r := range b.reportRules() {
+		breaches = append(breaches, breachPeriods(day, r)...)
+	}
+	if len(breaches) == 0 {
+		sb.WriteString(fmt.Sprintf("%sNone[-:-:-]\n", dimC))
+	}
+	sort.Slice(breaches, func(i, j int) bool { return breaches[i].Start.After(breaches[j].Start) }) // Newest first
+	for i, p := range breaches {
+		if i == reportBreachLimit {
+			sb.WriteString(fmt.Sprintf("%s... and %d earlier[-:-:-]\n", dimC, len(breaches)-i))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("%s%s - %s %s%-10s %s%s, peak %.1f%%[-:-:-]\n",
+			mainC, p.Start.Format("15:04"), p.End.Format("15:04"),
+			brightC, fmt.Sprintf("%s %s %g", p.Rule.Metric, p.Rule.Op, p.Rule.Threshold),
+			dimC, formatDuration(p.End.Sub(p.Start)), p.Peak))
+	}
+	sb.WriteString(fmt.Sprintf("\n%sEsc close[-:-:-]", dimC))
+	view.SetText(sb.String())
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' {
+			b.closeOverlay("report")
+			return nil
+		}
+		return event
+	})
+	b.showOverlay("report", view, 64, 30)
+}
@@ -11,7 +11,7 @@ if %ERRORLEVEL% NEQ 0 (
 echo Build successful!
 echo.
 echo Running Baseline...
-echo If the TUI doesn't appear properly, check baseline_debug.log for details.
+echo If the TUI doesn't appear properly, check %USERPROFILE%\.baseline\logs\baseline.log for details.
 echo.
 echo Press CTRL+C to force quit if necessary.
 echo.
@@ -20,7 +20,7 @@ baseline.exe
 if %ERRORLEVEL% NEQ 0 (
     echo.
     echo Application exited with error code %ERRORLEVEL%
-    echo Check baseline_debug.log for details.
+    echo Check %USERPROFILE%\.baseline\logs\baseline.log for details.
 )
 
 pause
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// --- Dashboard Snapshot Export ---
+//
+// `screenshot <path>` (or `export screen <path> [--format text|ansi|html]`)
+// writes what is on screen: HTML for .html/.htm paths, plain text for .txt,
+// ANSI escape sequences (for `cat` or `less -R`) otherwise. HTML and ANSI
+// keep the colors.
+
+const (
+	snapshotBackground = "#000000" // Terminal default colors, for HTML
+	snapshotForeground = "#c0c0c0"
+)
+
+// snapshotCell is one screen cell with its style broken up.
+type snapshotCell struct {
+	text   string
+	fg, bg tcell.Color
+	attr   tcell.AttrMask
+}
+
+// captureScreen copies the screen contents row by row. Wide characters take
+// one entry. Must run on the UI goroutine.
+func captureScreen(screen tcell.Screen) [][]snapshotCell {
+	width, height := screen.Size()
+	rows := make([][]snapshotCell, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; {
+			primary, combining, style, w := screen.GetContent(x, y)
+			if primary == 0 {
+				primary = ' '
+			}
+			fg, bg, attr := style.Decompose()
+			rows[y] = append(rows[y], snapshotCell{text: string(append([]rune{primary}, combining...)), fg: fg, bg: bg, attr: attr})
+			x += max(w, 1)
+		}
+	}
+	return rows
+}
+
+// ansiStyle returns the SGR sequence selecting a cell's style from a reset state.
+func ansiStyle(c snapshotCell) string {
+	codes := []string{"0"}
+	if c.attr&tcell.AttrBold != 0 {
+		codes = append(codes, "1")
+	}
+	if c.attr&tcell.AttrDim != 0 {
+		codes = append(codes, "2")
+	}
+	if c.attr&tcell.AttrItalic != 0 {
+		codes = append(codes, "3")
+	}
+	if c.attr&tcell.AttrUnderline != 0 {
+		codes = append(codes, "4")
+	}
+	if c.attr&tcell.AttrReverse != 0 {
+		codes = append(codes, "7")
+	}
+	if r, g, b := c.fg.RGB(); r >= 0 {
+		codes = append(codes, fmt.Sprintf("38;2;%d;%d;%d", r, g, b))
+	}
+	if r, g, b := c.bg.RGB(); r >= 0 {
+		codes = append(codes, fmt.Sprintf("48;2;%d;%d;%d", r, g, b))
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+func renderANSI(rows [][]snapshotCell) string {
+	var sb strings.Builder
+	for _, row := range rows {
+		current := ""
+		for _, c := range row {
+			if style := ansiStyle(c); style != current {
+				sb.WriteString(style)
+				current = style
+			}
+			sb.WriteString(c.text)
+		}
+		sb.WriteString("\x1b[0m\n")
+	}
+	return sb.String()
+}
+
+// renderText drops the styles, and the padding at the end of each row.
+func renderText(rows [][]snapshotCell) string {
+	var sb strings.Builder
+	for _, row := range rows {
+		var line strings.Builder
+		for _, c := range row {
+			line.WriteString(c.text)
+		}
+		sb.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+	return sb.String()
+}
+
+// cssColor returns a color as #rrggbb, or def for the terminal default.
+func cssColor(c tcell.Color, def string) string {
+	r, g, b := c.RGB()
+	if r < 0 {
+		return def
+	}
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// cssStyle returns the inline style for a cell.
+func cssStyle(c snapshotCell) string {
+	fg, bg := cssColor(c.fg, snapshotForeground), cssColor(c.bg, snapshotBackground)
+	if c.attr&tcell.AttrReverse != 0 {
+		fg, bg = bg, fg
+	}
+	style := fmt.Sprintf("color:%s;background:%s", fg, bg)
+	if c.attr&tcell.AttrBold != 0 {
+		style += ";font-weight:bold"
+	}
+	if c.attr&tcell.AttrDim != 0 {
+		style += ";opacity:0.6"
+	}
+	if c.attr&tcell.AttrItalic != 0 {
+		style += ";font-style:italic"
+	}
+	if c.attr&tcell.AttrUnderline != 0 {
+		style += ";text-decoration:underline"
+	}
+	return style
+}
+
+func renderHTML(rows [][]snapshotCell, title string) string {
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	sb.WriteString(fmt.Sprintf("<title>%s</title>\n</head>\n", html.EscapeString(title)))
+	sb.WriteString(fmt.Sprintf("<body style=\"background:%s\">\n", snapshotBackground))
+	sb.WriteString(fmt.Sprintf("<pre style=\"font-family:monospace;line-height:1.2;color:%s\">", snapshotForeground))
+	for _, row := range rows {
+		current := ""
+		for _, c := range row {
+			if style := cssStyle(c); style != current {
+				if current != "" {
+					sb.WriteString("</span>")
+				}
+				sb.WriteString(fmt.Sprintf("<span style=\"%s\">", style))
+				current = style
+			}
+			sb.WriteString(html.EscapeString(c.text))
+		}
+		if current != "" {
+			sb.WriteString("</span>")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("</pre>\n</body>\n</html>\n")
+	return sb.String()
+}
+
+// screenFormat picks the format for a path from its extension.
+func screenFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		return "html"
+	case ".txt", ".text":
+		return "text"
+	}
+	return "ansi"
+}
+
+// exportScreenCommand handles "export screen <path> [--format ...]". rawArgs
+// keeps the path's case. Called with b.mu held.
+func (b *Baseline) exportScreenCommand(rawArgs []string) {
+	const usage = "Usage: export screen <path> [--format text|ansi|html]"
+	var path, format string
+	for i := 0; i < len(rawArgs); i++ {
+		switch arg := rawArgs[i]; {
+		case arg == "--format" && i+1 == len(rawArgs):
+			b.addNotification(fmt.Sprintf("Missing value after --format. %s", usage), "error")
+			return
+		case arg == "--format":
+			i++
+			format = strings.ToLower(rawArgs[i])
+			if format != "text" && format != "ansi" && format != "html" {
+				b.addNotification(fmt.Sprintf("Unknown format %q (text, ansi or html)", rawArgs[i]), "error")
+				return
+			}
+		case path == "":
+			path = arg
+		default:
+			b.addNotification(usage, "error")
+			return
+		}
+	}
+	if path == "" {
+		b.addNotification(usage, "error")
+		return
+	}
+	go b.saveScreenshot(path, format)
+}
+
+// saveScreenshot writes the dashboard to path in format ("" to go by the
+// extension) once the command line has closed, so the snapshot shows the
+// footer rather than the typed command.
+func (b *Baseline) saveScreenshot(path, format string) {
+	if strings.HasPrefix(path, "~") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[1:])
+		}
+	}
+	if format == "" {
+		format = screenFormat(path)
+	}
+	b.app.QueueUpdateDraw(func() {}) // Let the dashboard redraw first
+	b.app.QueueUpdate(func() {
+		if b.screen == nil {
+			go b.notify("screenshot", "Screen not ready yet", "error")
+			return
+		}
+		rows := captureScreen(b.screen)
+		var out string
+		switch format {
+		case "html":
+			out = renderHTML(rows, fmt.Sprintf("%s - %s", appName, time.Now().Format("2006-01-02 15:04:05")))
+		case "text":
+			out = renderText(rows)
+		default:
+			out = renderANSI(rows)
+		}
+		go func() {
+			if err := os.WriteFile(path, []byte(out), 0640); err != nil {
+				b.notify("screenshot", fmt.Sprintf("Error saving screenshot: %v", err), "error")
+				return
+			}
+			b.notify("screenshot", fmt.Sprintf("Saved screenshot to %s", path), "success")
+		}()
+	})
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+// --- Temperature And Fan Sensors ---
+//
+// A SENSORS section in the system panel with the hottest CPU, GPU and NVMe
+// reading and any fans. Readings at or above the category's threshold turn
+// red and raise one notification until they cool down again.
+// SENSORS=false hides the section.
+
+const sensorHysteresis = 5.0 // °C below the threshold before a sensor counts as cooled down
+
+var sensorCategories = []string{"CPU", "GPU", "NVMe"}
+
+var defaultSensorWarn = map[string]float64{"CPU": 85, "GPU": 85, "NVMe": 70}
+
+type FanReading struct {
+	Label string
+	RPM   int
+}
+
+func sensorsEnabled() bool {
+	return strings.ToLower(os.Getenv("SENSORS")) != "false"
+}
+
+// sensorThresholdsFromEnv reads SENSOR_WARN (all categories) and
+// SENSOR_WARN_CPU / _GPU / _NVME, in °C.
+func sensorThresholdsFromEnv() map[string]float64 {
+	warn := map[string]float64{}
+	for cat, def := range defaultSensorWarn {
+		warn[cat] = def
+		if v, err := strconv.ParseFloat(os.Getenv("SENSOR_WARN"), 64); err == nil && v > 0 {
+			warn[cat] = v
+		}
+		if v, err := strconv.ParseFloat(os.Getenv("SENSOR_WARN_"+strings.ToUpper(cat)), 64); err == nil && v > 0 {
+			warn[cat] = v
+		}
+	}
+	return warn
+}
+
+// sensorCategory sorts a gopsutil sensor key ("coretemp_package_id_0",
+// "amdgpu_edge", "nvme_composite", "TC0P") into CPU, GPU, NVMe or "".
+func sensorCategory(key string) string {
+	k := strings.ToLower(key)
+	switch {
+	case strings.Contains(k, "nvme"):
+		return "NVMe"
+	case strings.Contains(k, "amdgpu"), strings.Contains(k, "radeon"), strings.Contains(k, "nouveau"),
+		strings.Contains(k, "nvidia"), strings.Contains(k, "gpu"), strings.HasPrefix(k, "tg"):
+		return "GPU"
+	case strings.Contains(k, "coretemp"), strings.Contains(k, "k10temp"), strings.Contains(k, "zenpower"),
+		strings.Contains(k, "cpu"), strings.Contains(k, "package"), strings.Contains(k, "tctl"), strings.HasPrefix(k, "tc"):
+		return "CPU"
+	}
+	return ""
+}
+
+// readTemperatures returns the hottest reading per category.
+func readTemperatures() map[string]float64 {
+	temps := map[string]float64{}
+	stats, _ := host.SensorsTemperatures() // Partial results come with a warning error
+	for _, s := range stats {
+		cat := sensorCategory(s.SensorKey)
+		if cat == "" || s.Temperature <= 0 || s.Temperature > 150 {
+			continue // Unused channels report 0 or garbage
+		}
+		temps[cat] = max(temps[cat], s.Temperature)
+	}
+	return temps
+}
+
+// readFans reads fan speeds from hwmon (Linux only; gopsutil has no fan API).
+func readFans() []FanReading {
+	inputs, _ := filepath.Glob("/sys/class/hwmon/hwmon*/fan*_input")
+	var fans []FanReading
+	for _, path := range inputs {
+		rpm, err := readIntFile(path)
+		if err != nil || rpm <= 0 {
+			continue // Header present but no fan plugged in
+		}
+		label := strings.TrimSuffix(filepath.Base(path), "_input")
+		if data, err := os.ReadFile(strings.TrimSuffix(path, "_input") + "_label"); err == nil {
+			label = strings.TrimSpace(string(data))
+		} else if chip, err := os.ReadFile(filepath.Join(filepath.Dir(path), "name")); err == nil {
+			label = strings.TrimSpace(string(chip)) + " " + label
+		}
+		fans = append(fans, FanReading{Label: label, RPM: rpm})
+	}
+	sort.Slice(fans, func(i, j int) bool { return fans[i].Label < fans[j].Label })
+	return fans
+}
+
+// sensorSection renders the SENSORS lines and raises threshold notifications.
+// Called from updateSystemInfo with b.mu held.
+func (b *Baseline) sensorSection() string {
+	temps := readTemperatures()
+	fans := readFans()
+	if len(temps) == 0 && len(fans) == 0 {
+		return ""
+	}
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var parts []string
+	for _, cat := range sensorCategories {
+		t, ok := temps[cat]
+		if !ok {
+			continue
+		}
+		warn := b.sensorWarn[cat]
+		color := brightC
+		switch {
+		case t >= warn:
+			color = "[red::b]"
+			if !b.sensorHot[cat] {
+				b.sensorHot[cat] = true
+				b.notify("sensors", fmt.Sprintf("%s temperature %.0f°C (warning at %.0f°C)", cat, t, warn), "error")
+			}
+		case t < warn-sensorHysteresis:
+			b.sensorHot[cat] = false
+		}
+		parts = append(parts, fmt.Sprintf("%s%s %s%.0f°C", mainC, cat, color, t))
+	}
+
+	var sb strings.Builder
+	if len(parts) > 0 {
+		sb.WriteString(fmt.Sprintf("%sTEMP: %s[-:-:-]\n", mainC, strings.Join(parts, "[-:-:-]  ")))
+	}
+	for _, f := range fans {
+		sb.WriteString(fmt.Sprintf("%sFAN: %s%s %s%d rpm[-:-:-]\n", mainC, dimC, f.Label, brightC, f.RPM))
+	}
+	return sb.String()
+}
@@ -0,0 +1,381 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Services Widget ---
+//
+// SERVICES lists the services to watch: systemd units on Linux (a "user:"
+// prefix for `systemctl --user` units), launchd labels on macOS and service
+// names on Windows:
+//
+//	SERVICES=nginx,postgresql,user:syncthing
+//
+// Failed services are shown in red with a notification when one fails.
+// `service restart|start|stop <name|index>` never prompts for a password:
+// systemctl runs with --no-ask-password, falling back to `sudo -n`, and
+// when neither is allowed the notification says what to run instead.
+
+const serviceRefreshInterval = 15 * time.Second
+
+type ServiceStatus struct {
+	Name        string // As configured, without the user: prefix
+	User        bool   // systemd user unit
+	Description string
+	State       string // active, inactive, failed, activating, ... ("running"/"stopped" outside systemd)
+	Sub         string // systemd sub-state or launchd exit status
+	Enabled     string // systemd unit file state
+}
+
+func (s ServiceStatus) failed() bool {
+	return s.State == "failed"
+}
+
+type ServiceInfo struct {
+	Services    []ServiceStatus
+	Error       string
+	LastUpdated time.Time
+}
+
+// serviceSpec is one SERVICES entry.
+type serviceSpec struct {
+	Name string
+	User bool
+}
+
+// servicesFromEnv parses SERVICES, nil when unset.
+func servicesFromEnv() []serviceSpec {
+	var specs []serviceSpec
+	for _, entry := range strings.Split(os.Getenv("SERVICES"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		user := strings.HasPrefix(entry, "user:")
+		specs = append(specs, serviceSpec{Name: strings.TrimPrefix(entry, "user:"), User: user})
+	}
+	return specs
+}
+
+// systemdUnit adds .service to bare unit names.
+func systemdUnit(name string) string {
+	if strings.Contains(name, ".") {
+		return name
+	}
+	return name + ".service"
+}
+
+func queryServices(specs []serviceSpec) ([]ServiceStatus, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return querySystemd(specs)
+	case "darwin":
+		return queryLaunchd(specs)
+	case "windows":
+		return queryWindowsServices(specs)
+	}
+	return nil, fmt.Errorf("services are not supported on %s", runtime.GOOS)
+}
+
+// querySystemd reads all units in one `systemctl show` per scope. Its output
+// is one block of Key=Value lines per unit, in the order asked.
+func querySystemd(specs []serviceSpec) ([]ServiceStatus, error) {
+	statuses := make([]ServiceStatus, len(specs))
+	for _, user := range []bool{false, true} {
+		args := []string{"show", "--property=LoadState,Description,ActiveState,SubState,UnitFileState"}
+		if user {
+			args = append([]string{"--user"}, args...)
+		}
+		var indices []int
+		for i, s := range specs {
+			if s.User == user {
+				args = append(args, systemdUnit(s.Name))
+				indices = append(indices, i)
+			}
+		}
+		if len(indices) == 0 {
+			continue
+		}
+		out, err := runOutput("systemctl", args...)
+		if err != nil {
+			return nil, err
+		}
+		blocks := strings.Split(out, "\n\n")
+		for j, i := range indices {
+			status := ServiceStatus{Name: specs[i].Name, User: user, State: "unknown"}
+			if j < len(blocks) {
+				for _, line := range strings.Split(blocks[j], "\n") {
+					key, value, _ := strings.Cut(line, "=")
+					switch key {
+					case "LoadState":
+						if value == "not-found" {
+							status.State = "not found"
+						}
+					case "Description":
+						status.Description = value
+					case "ActiveState":
+						if status.State != "not found" {
+							status.State = value
+						}
+					case "SubState":
+						status.Sub = value
+					case "UnitFileState":
+						status.Enabled = value
+					}
+				}
+			}
+			statuses[i] = status
+		}
+	}
+	return statuses, nil
+}
+
+// queryLaunchd reads `launchctl list`: PID (or -), last exit status and label.
+func queryLaunchd(specs []serviceSpec) ([]ServiceStatus, error) {
+	out, err := runOutput("launchctl", "list")
+	if err != nil {
+		return nil, err
+	}
+	jobs := map[string][2]string{}
+	for _, line := range strings.Split(out, "\n")[1:] {
+		if f := strings.Fields(line); len(f) == 3 {
+			jobs[f[2]] = [2]string{f[0], f[1]}
+		}
+	}
+	var statuses []ServiceStatus
+	for _, s := range specs {
+		status := ServiceStatus{Name: s.Name, State: "not found"}
+		if job, ok := jobs[s.Name]; ok {
+			switch {
+			case job[0] != "-":
+				status.State, status.Sub = "running", "pid "+job[0]
+			case job[1] != "0":
+				status.State, status.Sub = "failed", "exit "+job[1]
+			default:
+				status.State = "stopped"
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// queryWindowsServices reads the STATE line of `sc query`.
+func queryWindowsServices(specs []serviceSpec) ([]ServiceStatus, error) {
+	var statuses []ServiceStatus
+	for _, s := range specs {
+		status := ServiceStatus{Name: s.Name, State: "not found"}
+		out, _ := exec.Command("sc", "query", s.Name).Output() // Fails for unknown services
+		for _, line := range strings.Split(string(out), "\n") {
+			if key, value, ok := strings.Cut(line, ":"); ok && strings.TrimSpace(key) == "STATE" {
+				if f := strings.Fields(value); len(f) >= 2 {
+					status.State = strings.ToLower(f[1])
+				}
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// serviceAction runs verb (restart, start or stop) without ever prompting.
+func serviceAction(s ServiceStatus, verb string) error {
+	switch runtime.GOOS {
+	case "linux":
+		if s.User {
+			return runPrivileged(false, "systemctl", "--user", verb, systemdUnit(s.Name))
+		}
+		return runPrivileged(true, "systemctl", "--no-ask-password", verb, systemdUnit(s.Name))
+	case "darwin":
+		domain := fmt.Sprintf("gui/%d/", os.Getuid())
+		if _, err := os.Stat("/Library/LaunchDaemons/" + s.Name + ".plist"); err == nil {
+			domain = "system/"
+		}
+		switch verb {
+		case "restart":
+			return runPrivileged(domain == "system/", "launchctl", "kickstart", "-k", domain+s.Name)
+		case "start":
+			return runPrivileged(domain == "system/", "launchctl", "kickstart", domain+s.Name)
+		default:
+			return runPrivileged(domain == "system/", "launchctl", "kill", "SIGTERM", domain+s.Name)
+		}
+	case "windows":
+		cmdlet := map[string]string{"restart": "Restart-Service", "start": "Start-Service", "stop": "Stop-Service"}[verb]
+		return runPrivileged(false, "powershell", "-NoProfile", "-Command", cmdlet+" -Name '"+strings.ReplaceAll(s.Name, "'", "''")+"'")
+	}
+	return fmt.Errorf("services are not supported on %s", runtime.GOOS)
+}
+
+// runPrivileged runs the command, retrying through `sudo -n` when it was
+// refused for lack of privileges and sudo is an option. A password prompt
+// would corrupt the dashboard, so those are never allowed.
+func runPrivileged(trySudo bool, name string, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err == nil {
+		return nil
+	}
+	msg := strings.TrimSpace(stderr.String())
+	denied := strings.Contains(msg, "authentication required") || strings.Contains(msg, "Access denied") ||
+		strings.Contains(msg, "Operation not permitted") || strings.Contains(msg, "Permission denied")
+	if !denied || !trySudo || os.Geteuid() == 0 {
+		if msg == "" {
+			msg = err.Error()
+		}
+		return fmt.Errorf("%s", msg)
+	}
+	if _, err := exec.LookPath("sudo"); err == nil {
+		sudo := exec.Command("sudo", append([]string{"-n", name}, args...)...)
+		if sudo.Run() == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("needs root: run `sudo %s %s` or allow it for your user (sudoers NOPASSWD or a polkit rule)", name, strings.Join(args, " "))
+}
+
+func (b *Baseline) fetchServices() {
+	statuses, err := queryServices(b.services)
+
+	b.mu.Lock()
+	prev := map[string]bool{}
+	for _, s := range b.serviceInfo.Services {
+		prev[s.Name] = s.failed()
+	}
+	info := ServiceInfo{Services: statuses, LastUpdated: time.Now()}
+	if err != nil {
+		info.Error = err.Error()
+		info.Services = b.serviceInfo.Services // Keep the last known states
+	}
+	for _, s := range statuses {
+		if s.failed() && !prev[s.Name] {
+			b.notifyPriority("services", fmt.Sprintf("Service failed: %s", s.Name), "error", PriorityHigh)
+		}
+	}
+	b.serviceInfo = info
+	b.mu.Unlock()
+
+	b.updateServices()
+}
+
+func (b *Baseline) updateServices() {
+	b.mu.RLock()
+	info := b.serviceInfo
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	failed := 0
+	for _, s := range info.Services {
+		if s.failed() {
+			failed++
+		}
+	}
+	title := "SERVICES"
+	if failed > 0 {
+		title += fmt.Sprintf(" [red](%d failed)", failed)
+	}
+	sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n", brightC+"[::b]", title))
+
+	if info.Error != "" {
+		sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(info.Error)))
+	} else if info.LastUpdated.IsZero() {
+		sb.WriteString(fmt.Sprintf("%sLoading...[-:-:-]\n", dimC))
+	}
+	for i, s := range info.Services {
+		marker, stateC := "●", brightC
+		switch s.State {
+		case "active", "running":
+		case "failed":
+			marker, stateC = "✗", "[red::b]"
+		case "activating", "deactivating", "reloading", "start_pending", "stop_pending":
+			marker, stateC = "◐", mainC
+		default:
+			marker, stateC = "○", dimC
+		}
+		state := s.State
+		if s.Sub != "" && s.Sub != s.State {
+			state += " (" + s.Sub + ")"
+		}
+		name := s.Name
+		if s.User {
+			name += " (user)"
+		}
+		sb.WriteString(fmt.Sprintf("%s%2d %s%s %s%s %s%s[-:-:-]\n", dimC, i+1, stateC, marker, mainC, tview.Escape(name), stateC, tview.Escape(state)))
+		if s.Description != "" && s.Description != s.Name {
+			sb.WriteString(fmt.Sprintf("%s     %s[-:-:-]\n", dimC, tview.Escape(s.Description)))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%sLast updated: %s[-:-:-]", dimC, info.LastUpdated.Format("15:04:05")))
+
+	b.setPanel(b.svcPanel, sb.String())
+}
+
+// serviceByArg finds a watched service by 1-based index or name.
+func (b *Baseline) serviceByArg(arg string) (ServiceStatus, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if index, err := strconv.Atoi(arg); err == nil {
+		if index >= 1 && index <= len(b.serviceInfo.Services) {
+			return b.serviceInfo.Services[index-1], true
+		}
+		return ServiceStatus{}, false
+	}
+	for _, s := range b.serviceInfo.Services {
+		if strings.EqualFold(s.Name, arg) || strings.EqualFold(systemdUnit(s.Name), arg) {
+			return s, true
+		}
+	}
+	return ServiceStatus{}, false
+}
+
+// runServiceAction restarts, starts or stops a watched service.
+func (b *Baseline) runServiceAction(arg, verb string) {
+	s, ok := b.serviceByArg(arg)
+	if !ok {
+		b.notify("services", fmt.Sprintf("Not a watched service: %s (see SERVICES)", arg), "error")
+		return
+	}
+	b.notify("services", fmt.Sprintf("%s %s...", strings.ToUpper(verb[:1])+verb[1:], s.Name), "info")
+	if err := serviceAction(s, verb); err != nil {
+		b.notify("services", fmt.Sprintf("%s %s: %v", verb, s.Name, err), "error")
+		return
+	}
+	past := map[string]string{"restart": "Restarted", "start": "Started", "stop": "Stopped"}[verb]
+	b.notify("services", fmt.Sprintf("%s %s", past, s.Name), "success")
+	b.fetchServices()
+}
+
+// serviceCommand handles "service [refresh|restart|start|stop <name|index>]".
+// rawArgs keeps the case of unit names. Called with b.mu held.
+func (b *Baseline) serviceCommand(rawArgs []string) {
+	if len(b.services) == 0 {
+		b.addNotification("Services widget is not enabled (set SERVICES=nginx,postgresql,...)", "error")
+		return
+	}
+	if len(rawArgs) == 0 || strings.ToLower(rawArgs[0]) == "refresh" {
+		go b.fetchServices()
+		return
+	}
+	verb := strings.ToLower(rawArgs[0])
+	if (verb == "restart" || verb == "start" || verb == "stop") && len(rawArgs) == 2 {
+		go b.runServiceAction(rawArgs[1], verb)
+		return
+	}
+	b.addNotification("Usage: service [refresh|restart|start|stop <name|index>]", "error")
+}
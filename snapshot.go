@@ -0,0 +1,238 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+// --- Headless Snapshot ---
+//
+// `baseline snapshot` gathers the system, weather and todo data once and
+// prints it without starting the TUI, for scripts and cron jobs:
+//
+//	baseline snapshot | jq .system.cpu
+//	baseline snapshot --format text --no-weather
+
+type Snapshot struct {
+	Time         time.Time        `json:"time"`
+	System       SystemSnapshot   `json:"system"`
+	Weather      *WeatherSnapshot `json:"weather,omitempty"`
+	WeatherError string           `json:"weather_error,omitempty"`
+	Todos        []TodoItem       `json:"todos"`
+}
+
+type SystemSnapshot struct {
+	Host          string             `json:"host"`
+	OS            string             `json:"os"`
+	UptimeSeconds uint64             `json:"uptime_seconds"`
+	CPU           float64            `json:"cpu"`
+	Memory        float64            `json:"memory"`
+	Disk          float64            `json:"disk"` // Root filesystem
+	Load          []float64          `json:"load,omitempty"`
+	NetRxBytes    uint64             `json:"net_rx_bytes"`
+	NetTxBytes    uint64             `json:"net_tx_bytes"`
+	Temperatures  map[string]float64 `json:"temperatures_c,omitempty"`
+}
+
+type WeatherSnapshot struct {
+	Location  string                `json:"location"`
+	TempC     float64               `json:"temp_c"`
+	Condition string                `json:"condition"`
+	Humidity  int                   `json:"humidity"`
+	WindKph   float64               `json:"wind_kph"`
+	Warnings  []string              `json:"warnings,omitempty"`
+	Forecast  []ForecastDaySnapshot `json:"forecast,omitempty"`
+}
+
+type ForecastDaySnapshot struct {
+	Date       string  `json:"date"`
+	MinC       float64 `json:"min_c"`
+	MaxC       float64 `json:"max_c"`
+	RainChance int     `json:"rain_chance"`
+	Condition  string  `json:"condition"`
+}
+
+// takeSnapshot collects everything; weather is skipped when withWeather is false.
+func takeSnapshot(configDir string, withWeather bool) (Snapshot, error) {
+	snap := Snapshot{Time: time.Now(), System: collectSystem(statusSampleTime)}
+
+	if withWeather {
+		location := os.Getenv("WEATHER_LOCATION")
+		if location == "" {
+			location = "Lahore" // Same default as the dashboard
+		}
+		cfg, _ := loadConfig(configDir)
+		provider, _, _ := weatherProviderFor(cfg.Weather)
+		info := queryWeather(provider, location)
+		if info.Error != "" {
+			snap.WeatherError = info.Error
+		} else {
+			snap.Weather = newWeatherSnapshot(info)
+		}
+	}
+
+	snap.Todos = []TodoItem{}
+	data, err := os.ReadFile(filepath.Join(configDir, "todos.json"))
+	if err != nil && !os.IsNotExist(err) {
+		return snap, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &snap.Todos); err != nil {
+			return snap, fmt.Errorf("parsing todos.json: %w", err)
+		}
+	}
+	return snap, nil
+}
+
+// newWeatherSnapshot keeps the parts of a weather report a snapshot shows.
+func newWeatherSnapshot(info WeatherInfo) *WeatherSnapshot {
+	w := &WeatherSnapshot{
+		Location:  info.Location,
+		TempC:     info.TempC,
+		Condition: info.Condition,
+		Humidity:  info.Humidity,
+		WindKph:   info.WindKph,
+	}
+	for _, warning := range activeWarnings(info.Warnings, time.Now()) {
+		w.Warnings = append(w.Warnings, warning.title())
+	}
+	for _, d := range info.Days {
+		w.Forecast = append(w.Forecast, ForecastDaySnapshot{
+			Date:       d.Date.Format("2006-01-02"),
+			MinC:       d.MinC,
+			MaxC:       d.MaxC,
+			RainChance: d.RainChance,
+			Condition:  d.Condition,
+		})
+	}
+	return w
+}
+
+// collectSystem reads the system figures. The CPU is measured over
+// cpuSample; 0 compares with the previous call instead of blocking.
+func collectSystem(cpuSample time.Duration) SystemSnapshot {
+	var sys SystemSnapshot
+	if info, err := host.Info(); err == nil {
+		sys.Host = info.Hostname
+		sys.OS = strings.TrimSpace(fmt.Sprintf("%s %s %s", info.OS, info.Platform, info.PlatformVersion))
+		sys.UptimeSeconds = info.Uptime
+	}
+	if percents, err := cpu.Percent(cpuSample, false); err == nil && len(percents) > 0 {
+		sys.CPU = percents[0]
+	}
+	if vm, err := mem.VirtualMemory(); err == nil {
+		sys.Memory = vm.UsedPercent
+	}
+	if usage, err := disk.Usage("/"); err == nil {
+		sys.Disk = usage.UsedPercent
+	}
+	if avg, err := load.Avg(); err == nil {
+		sys.Load = []float64{avg.Load1, avg.Load5, avg.Load15}
+	}
+	if counters, err := net.IOCounters(false); err == nil && len(counters) > 0 {
+		sys.NetRxBytes, sys.NetTxBytes = counters[0].BytesRecv, counters[0].BytesSent
+	}
+	if temps := readTemperatures(); len(temps) > 0 {
+		sys.Temperatures = temps
+	}
+	return sys
+}
+
+// writeText prints the snapshot in the same terms as the dashboard panels.
+func (s Snapshot) writeText(w io.Writer) {
+	sys := s.System
+	fmt.Fprintf(w, "Host:    %s (%s)\n", sys.Host, sys.OS)
+	fmt.Fprintf(w, "Uptime:  %s\n", formatDuration(time.Duration(sys.UptimeSeconds)*time.Second))
+	fmt.Fprintf(w, "CPU:     %.1f%%\n", sys.CPU)
+	fmt.Fprintf(w, "Memory:  %.1f%%\n", sys.Memory)
+	fmt.Fprintf(w, "Disk:    %.1f%%\n", sys.Disk)
+	if len(sys.Load) == 3 {
+		fmt.Fprintf(w, "Load:    %.2f %.2f %.2f\n", sys.Load[0], sys.Load[1], sys.Load[2])
+	}
+	fmt.Fprintf(w, "Network: %s in, %s out\n", formatBytes(int64(sys.NetRxBytes)), formatBytes(int64(sys.NetTxBytes)))
+	var temps []string
+	for _, cat := range sensorCategories {
+		if t, ok := sys.Temperatures[cat]; ok {
+			temps = append(temps, fmt.Sprintf("%s %.0f°C", cat, t))
+		}
+	}
+	if len(temps) > 0 {
+		fmt.Fprintf(w, "Temp:    %s\n", strings.Join(temps, ", "))
+	}
+
+	switch {
+	case s.Weather != nil:
+		wx := s.Weather
+		fmt.Fprintf(w, "\nWeather: %s %.1f°C %s, humidity %d%%, wind %.1f km/h\n", wx.Location, wx.TempC, wx.Condition, wx.Humidity, wx.WindKph)
+		for _, warning := range wx.Warnings {
+			fmt.Fprintf(w, "  WARNING: %s\n", warning)
+		}
+		for _, d := range wx.Forecast {
+			fmt.Fprintf(w, "  %s %.0f°/%.0f°C %3d%% %s\n", d.Date, d.MinC, d.MaxC, d.RainChance, d.Condition)
+		}
+	case s.WeatherError != "":
+		fmt.Fprintf(w, "\nWeather: unavailable (%s)\n", s.WeatherError)
+	}
+
+	open := 0
+	for _, item := range s.Todos {
+		if !item.Done {
+			open++
+		}
+	}
+	fmt.Fprintf(w, "\nTodos (%d open, %d done):\n", open, len(s.Todos)-open)
+	for i, item := range s.Todos {
+		status := "[ ]"
+		if item.Done {
+			status = "[X]"
+		}
+		due := ""
+		if item.Due != nil && !item.Done {
+			due = " (" + dueLabel(*item.Due, s.Time) + ")"
+		}
+		fmt.Fprintf(w, "%3d %s %s%s\n", i+1, status, item.Text, due)
+	}
+}
+
+// runSnapshotCommand implements `baseline snapshot [--format json|text] [--no-weather]`.
+func runSnapshotCommand(args []string) error {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	format := fs.String("format", "json", "output format: json or text")
+	noWeather := fs.Bool("no-weather", false, "skip the weather API call")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "json" && *format != "text" {
+		return fmt.Errorf("unknown format %q (json or text)", *format)
+	}
+	_ = godotenv.Load()
+
+	snap, err := takeSnapshot(defaultConfigDir(), !*noWeather)
+	if err != nil {
+		return err
+	}
+	if *format == "text" {
+		snap.writeText(os.Stdout)
+		return nil
+	}
+	out, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
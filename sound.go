@@ -0,0 +1,95 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// --- Audible Alerts ---
+
+const soundMinGap = 5 * time.Second // Don't ring more often than this
+
+// AlertSound rings the terminal bell or plays a sound file on errors/alerts.
+// Configured with ALERT_SOUND: "bell", or a path to a sound file.
+type AlertSound struct {
+	mode string // "bell" or "file"
+	file string
+
+	mu       sync.Mutex
+	lastRing time.Time
+}
+
+// newAlertSoundFromEnv returns nil when ALERT_SOUND is unset or "off".
+func newAlertSoundFromEnv() *AlertSound {
+	return parseAlertSound(os.Getenv("ALERT_SOUND"))
+}
+
+// parseAlertSound reads "bell", a sound file path or "off" (nil).
+func parseAlertSound(v string) *AlertSound {
+	v = strings.TrimSpace(v)
+	switch strings.ToLower(v) {
+	case "", "off", "false", "none":
+		return nil
+	case "bell", "true", "on":
+		return &AlertSound{mode: "bell"}
+	}
+	return &AlertSound{mode: "file", file: v}
+}
+
+// ring plays the configured sound, rate limited so a burst of errors is one beep.
+func (b *Baseline) ring() {
+	b.playSound(b.alertSound)
+}
+
+// playSound rings the bell or plays the file of s, at most once per
+// soundMinGap. A nil s is silent.
+func (b *Baseline) playSound(s *AlertSound) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if time.Since(s.lastRing) < soundMinGap {
+		s.mu.Unlock()
+		return
+	}
+	s.lastRing = time.Now()
+	s.mu.Unlock()
+
+	if s.mode == "bell" {
+		b.app.QueueUpdate(func() {
+			if b.screen != nil {
+				_ = b.screen.Beep()
+			}
+		})
+		return
+	}
+	if err := playSoundFile(s.file); err != nil {
+		slog.Warn("Could not play sound", "file", s.file, "err", err)
+	}
+}
+
+// playSoundFile plays a sound with the platform's command line player.
+func playSoundFile(path string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("afplay", path)
+	case "windows":
+		// -Command doesn't hand arguments to the script, the path goes through the environment
+		cmd = exec.Command("powershell", "-NoProfile", "-Command",
+			"(New-Object Media.SoundPlayer $env:BASELINE_SOUND).PlaySync()")
+		cmd.Env = append(os.Environ(), "BASELINE_SOUND="+path)
+	default:
+		player := "paplay" // PulseAudio/PipeWire
+		if _, err := exec.LookPath(player); err != nil {
+			player = "aplay" // Plain ALSA
+		}
+		cmd = exec.Command(player, path)
+	}
+	return cmd.Run()
+}
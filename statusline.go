@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// --- Status Line Export (tmux / shell prompt) ---
+
+const (
+	statusFileName   = "status.json"
+	statusMaxAge     = 30 * time.Second // Older cached status is re-sampled by `baseline status`
+	statusAlertTTL   = 15 * time.Minute // Error notifications younger than this count as alerts
+	statusSampleTime = 500 * time.Millisecond
+)
+
+// StatusSummary is the compact state shared with status bars.
+type StatusSummary struct {
+	CPU     float64   `json:"cpu"`
+	Memory  float64   `json:"memory"`
+	Alerts  int       `json:"alerts"`
+	TempC   *float64  `json:"temp_c,omitempty"` // nil when no weather is available
+	Updated time.Time `json:"updated"`
+}
+
+// OneLine renders the summary for a tmux status segment, e.g. "CPU 12% MEM 43% !2 22°C".
+func (s StatusSummary) OneLine() string {
+	parts := []string{
+		fmt.Sprintf("CPU %.0f%%", s.CPU),
+		fmt.Sprintf("MEM %.0f%%", s.Memory),
+	}
+	if s.Alerts > 0 {
+		parts = append(parts, fmt.Sprintf("!%d", s.Alerts))
+	}
+	if s.TempC != nil {
+		parts = append(parts, fmt.Sprintf("%.0f°C", *s.TempC))
+	}
+	return strings.Join(parts, " ")
+}
+
+// writeStatusSummary stores the latest summary in the config dir and, when
+// STATUS_LINE_FILE is set, writes the one-line form there as well.
+// Called from updateSystemInfo with b.mu held.
+func (b *Baseline) writeStatusSummary(cpuPercent, memPercent float64) {
+	summary := StatusSummary{
+		CPU:     cpuPercent,
+		Memory:  memPercent,
+		Alerts:  b.recentAlertCount(),
+		Updated: time.Now(),
+	}
+	if b.weatherInfo.Error == "" && !b.weatherInfo.LastUpdated.IsZero() {
+		temp := b.weatherInfo.TempC
+		summary.TempC = &temp
+	}
+
+	data, err := json.Marshal(summary)
+	if err == nil {
+		_ = os.WriteFile(filepath.Join(b.configDir, statusFileName), data, 0640)
+	}
+	if path := os.Getenv("STATUS_LINE_FILE"); path != "" {
+		_ = os.WriteFile(path, []byte(summary.OneLine()+"\n"), 0640)
+	}
+}
+
+// recentAlertCount counts recent error notifications.
+func (b *Baseline) recentAlertCount() int {
+	b.notifMu.Lock()
+	defer b.notifMu.Unlock()
+
+	count := 0
+	for _, n := range b.notifications {
+		if n.Type == "error" && time.Since(n.Time) < statusAlertTTL {
+			count++
+		}
+	}
+	return count
+}
+
+// runStatusCommand implements `baseline status [--oneline|--json]`. It prefers the
+// summary written by a running dashboard and samples CPU/MEM itself otherwise.
+func runStatusCommand(args []string) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	oneline := fs.Bool("oneline", false, "print a compact single line (for tmux/prompt)")
+	asJSON := fs.Bool("json", false, "print the summary as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var summary StatusSummary
+	data, err := os.ReadFile(filepath.Join(defaultConfigDir(), statusFileName))
+	if err != nil || json.Unmarshal(data, &summary) != nil || time.Since(summary.Updated) > statusMaxAge {
+		// No fresh dashboard data, sample directly (weather/alerts unknown)
+		summary = StatusSummary{Updated: time.Now()}
+		if percents, err := cpu.Percent(statusSampleTime, false); err == nil && len(percents) > 0 {
+			summary.CPU = percents[0]
+		}
+		if vm, err := mem.VirtualMemory(); err == nil {
+			summary.Memory = vm.UsedPercent
+		}
+	}
+
+	switch {
+	case *asJSON:
+		out, _ := json.MarshalIndent(summary, "", "  ")
+		fmt.Println(string(out))
+	case *oneline:
+		fmt.Println(summary.OneLine())
+	default:
+		fmt.Printf("CPU:    %.1f%%\nMemory: %.1f%%\nAlerts: %d\n", summary.CPU, summary.Memory, summary.Alerts)
+		if summary.TempC != nil {
+			fmt.Printf("Temp:   %.1f°C\n", *summary.TempC)
+		}
+		fmt.Printf("As of:  %s\n", summary.Updated.Format("15:04:05"))
+	}
+	return nil
+}
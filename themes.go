@@ -0,0 +1,220 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- Custom Themes ---
+//
+// Each ~/.baseline/themes/<name>.json adds (or overrides) a theme:
+//
+//	{"main": "#FF79C6", "dim": "#BD93F9", "bright": "#F8F8F2",
+//	 "background": "#282A36", "panel": "#21222C", "border": "#6272A4",
+//	 "selection": "#44475A", "status": "#6272A4",
+//	 "error": "#FF5555", "success": "#50FA7B"}
+//
+// main, dim and bright are required; colors are #RRGGBB or W3C color names.
+// background is behind the header, footer and overlays, panel inside the
+// panels; either may be "terminal" to keep the terminal's own background,
+// as TERMINAL_BACKGROUND=true does for every theme (e.g. for a translucent
+// terminal). The directory is read again on every `theme` command, so new
+// and edited files apply without a restart.
+
+var builtinThemes = []string{"amber", "green", "blue", "light"}
+
+// defaultBackground is tview's, used by themes without a background.
+var defaultBackground = tview.Styles.PrimitiveBackgroundColor
+
+type themeFile struct {
+	Main       string `json:"main"`
+	Dim        string `json:"dim"`
+	Bright     string `json:"bright"`
+	Background string `json:"background"`
+	Border     string `json:"border"`
+	Error      string `json:"error"`
+	Success    string `json:"success"`
+	Panel      string `json:"panel"`
+	Selection  string `json:"selection"`
+	Status     string `json:"status"`
+}
+
+func parseThemeColor(field, value string, required bool) (tcell.Color, error) {
+	if value == "" {
+		if required {
+			return tcell.ColorDefault, fmt.Errorf("%s is required", field)
+		}
+		return tcell.ColorDefault, nil
+	}
+	c := tcell.GetColor(strings.ToLower(value))
+	if c == tcell.ColorDefault {
+		return c, fmt.Errorf("%s: unknown color %q", field, value)
+	}
+	return c, nil
+}
+
+func loadThemeFile(path string) (Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, err
+	}
+	var f themeFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return Theme{}, err
+	}
+	var t Theme
+	for _, c := range []struct {
+		field    string
+		value    string
+		required bool
+		dst      *tcell.Color
+	}{
+		{"main", f.Main, true, &t.Main},
+		{"dim", f.Dim, true, &t.Dim},
+		{"bright", f.Bright, true, &t.Bright},
+		{"background", f.Background, false, &t.Background},
+		{"border", f.Border, false, &t.Border},
+		{"error", f.Error, false, &t.Error},
+		{"success", f.Success, false, &t.Success},
+		{"panel", f.Panel, false, &t.Panel},
+		{"selection", f.Selection, false, &t.Selection},
+		{"status", f.Status, false, &t.Status},
+	} {
+		if strings.EqualFold(c.value, "terminal") && (c.dst == &t.Background || c.dst == &t.Panel) {
+			*c.dst = tcell.ColorReset
+			continue
+		}
+		if *c.dst, err = parseThemeColor(c.field, c.value, c.required); err != nil {
+			return Theme{}, err
+		}
+	}
+	return t, nil
+}
+
+// loadCustomThemes adds the themes in dir/themes to the themes map and
+// returns a warning for each file that could not be used.
+func loadCustomThemes(dir string) []string {
+	files, _ := filepath.Glob(filepath.Join(dir, "themes", "*.json"))
+	var warnings []string
+	for _, path := range files {
+		name := strings.ToLower(strings.TrimSuffix(filepath.Base(path), ".json"))
+		t, err := loadThemeFile(path)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Theme %s: %v", filepath.Base(path), err))
+			continue
+		}
+		themes[name] = t
+	}
+	return warnings
+}
+
+// themeNames lists the built-in themes first, then custom ones by name.
+func themeNames() []string {
+	names := append([]string(nil), builtinThemes...)
+	var custom []string
+	for name := range themes {
+		if !slices.Contains(builtinThemes, name) {
+			custom = append(custom, name)
+		}
+	}
+	sort.Strings(custom)
+	return append(names, custom...)
+}
+
+// border is the panel border color, Main unless the theme sets one.
+func (t Theme) border() tcell.Color {
+	if t.Border != tcell.ColorDefault {
+		return t.Border
+	}
+	return t.Main
+}
+
+// terminalBackgroundEnabled reports whether TERMINAL_BACKGROUND asks to keep
+// the terminal's background whatever the theme.
+func terminalBackgroundEnabled() bool {
+	v := strings.ToLower(os.Getenv("TERMINAL_BACKGROUND"))
+	return v == "true" || v == "1" || v == "yes"
+}
+
+// background is the screen background. It falls back to tview's default, so
+// switching from a custom theme back to a built-in one restores it.
+func (t Theme) background() tcell.Color {
+	switch {
+	case terminalBackgroundEnabled():
+		return tcell.ColorReset
+	case t.Background != tcell.ColorDefault:
+		return t.Background
+	}
+	return defaultBackground
+}
+
+// panelBackground is the background inside the panels.
+func (t Theme) panelBackground() tcell.Color {
+	if t.Panel != tcell.ColorDefault && !terminalBackgroundEnabled() {
+		return t.Panel
+	}
+	return t.background()
+}
+
+// selection is the style of selected table rows, with black or white text
+// for contrast.
+func (t Theme) selection() tcell.Style {
+	bg := t.Selection
+	if bg == tcell.ColorDefault {
+		bg = t.Bright
+	}
+	fg := tcell.ColorWhite
+	if r, g, b := bg.RGB(); 0.299*float64(r)+0.587*float64(g)+0.114*float64(b) > 140 {
+		fg = tcell.ColorBlack
+	}
+	return tcell.StyleDefault.Foreground(fg).Background(bg)
+}
+
+// status is the footer text color.
+func (t Theme) status() tcell.Color {
+	if t.Status != tcell.ColorDefault {
+		return t.Status
+	}
+	return t.Dim
+}
+
+// themeCommand handles "theme list" and "theme <name>". Called from
+// processCommand with b.mu held; returns true when the theme changed.
+func (b *Baseline) themeCommand(args []string) bool {
+	if len(args) != 1 {
+		b.addNotification("Usage: theme list | theme <name>", "error")
+		return false
+	}
+	for _, w := range loadCustomThemes(b.configDir) {
+		b.addNotification(w, "error")
+	}
+	if args[0] == "list" {
+		var names []string
+		for _, name := range themeNames() {
+			if themes[name] == b.theme {
+				name += " (current)"
+			} else if !slices.Contains(builtinThemes, name) {
+				name += " (custom)"
+			}
+			names = append(names, name)
+		}
+		b.addNotification("Themes: "+strings.Join(names, ", "), "info")
+		return false
+	}
+	newTheme, ok := themes[args[0]]
+	if !ok {
+		b.addNotification(fmt.Sprintf("Unknown theme: %s. Available: %s", args[0], strings.Join(themeNames(), ", ")), "error")
+		return false
+	}
+	b.theme = newTheme
+	b.addNotification(fmt.Sprintf("Theme changed to %s", args[0]), "success")
+	return true
+}
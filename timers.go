@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Timers and Alarms ---
+//
+// `timer 10m tea` counts down, `alarm 07:30 standup` goes off at the next
+// 07:30. Both show in the time panel and, when done, send a high priority
+// notification, flash the time panel and ring TIMER_SOUND ("bell" by
+// default, a sound file, or "off"). They are kept in ~/.baseline/timers.json,
+// so they survive a restart; one that ran out while Baseline was closed is
+// reported at the next start.
+
+const (
+	timersFileName = "timers.json"
+	timerTick      = 1 * time.Second
+	timerMaxLength = 7 * 24 * time.Hour
+)
+
+type Timer struct {
+	Label   string    `json:"label,omitempty"`
+	Started time.Time `json:"started"`
+	Ends    time.Time `json:"ends"`
+	Alarm   bool      `json:"alarm,omitempty"` // Set for a time of day rather than a length
+}
+
+// newTimerSoundFromEnv reads TIMER_SOUND, the bell when unset.
+func newTimerSoundFromEnv() *AlertSound {
+	v := os.Getenv("TIMER_SOUND")
+	if strings.TrimSpace(v) == "" {
+		v = "bell"
+	}
+	return parseAlertSound(v)
+}
+
+// name is the label, or what the timer was set for.
+func (t Timer) name() string {
+	switch {
+	case t.Label != "":
+		return t.Label
+	case t.Alarm:
+		return "Alarm " + t.Ends.Format("15:04")
+	}
+	return "Timer " + formatDuration(t.Ends.Sub(t.Started))
+}
+
+func (b *Baseline) loadTimers() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	data, err := os.ReadFile(filepath.Join(b.configDir, timersFileName))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			b.addNotification(fmt.Sprintf("Error loading %s: %v", timersFileName, err), "error")
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &b.timers); err != nil {
+		b.addNotification(fmt.Sprintf("Error parsing %s: %v", timersFileName, err), "error")
+	}
+}
+
+// saveTimers writes timers.json. Called with b.mu held.
+func (b *Baseline) saveTimers() {
+	data, err := json.MarshalIndent(b.timers, "", "  ")
+	if err == nil {
+		err = os.WriteFile(filepath.Join(b.configDir, timersFileName), data, 0640)
+	}
+	if err != nil {
+		b.addNotification(fmt.Sprintf("Error saving %s: %v", timersFileName, err), "error")
+	}
+}
+
+// addTimer adds t, keeping the list soonest first. Called with b.mu held.
+func (b *Baseline) addTimer(t Timer) {
+	b.timers = append(b.timers, t)
+	sort.SliceStable(b.timers, func(i, j int) bool { return b.timers[i].Ends.Before(b.timers[j].Ends) })
+	b.saveTimers()
+}
+
+// tickTimers fires the timers that are done. Runs every timerTick.
+func (b *Baseline) tickTimers() {
+	now := time.Now()
+	b.mu.Lock()
+	var done []Timer
+	for len(b.timers) > 0 && !b.timers[0].Ends.After(now) {
+		done = append(done, b.timers[0])
+		b.timers = b.timers[1:]
+	}
+	if len(done) > 0 {
+		b.saveTimers()
+	}
+	b.mu.Unlock()
+
+	for _, t := range done {
+		msg := fmt.Sprintf("⏰ %s: time's up", t.name())
+		if now.Sub(t.Ends) > time.Minute {
+			msg = fmt.Sprintf("⏰ %s ran out at %s while Baseline was closed", t.name(), t.Ends.Format("Mon 15:04"))
+		}
+		b.notifyPriority("timers", msg, "success", PriorityHigh)
+	}
+	if len(done) > 0 {
+		b.flashTimePanel()
+		b.playSound(b.timerSound)
+	}
+}
+
+// flashTimePanel blinks the time panel border like a firing alert rule.
+func (b *Baseline) flashTimePanel() {
+	panel := b.timePanel.Box
+	b.mu.RLock()
+	flash, normal := b.theme.Bright, b.theme.border()
+	b.mu.RUnlock()
+	go func() {
+		for i := 0; i < alertFlashCount; i++ {
+			color := flash
+			if i%2 == 1 {
+				color = normal
+			}
+			b.app.QueueUpdateDraw(func() { panel.SetBorderColor(color) })
+			time.Sleep(alertFlashInterval)
+		}
+		b.mu.RLock()
+		settle := b.theme.border()
+		b.mu.RUnlock()
+		b.app.QueueUpdateDraw(func() { panel.SetBorderColor(settle) })
+	}()
+}
+
+// formatTimerLeft is MM:SS under an hour, then hours and minutes.
+func formatTimerLeft(d time.Duration) string {
+	if d < time.Hour {
+		return formatCountdown(d)
+	}
+	return formatDuration(d)
+}
+
+// timersSection renders the running timers for the time panel. Called with
+// b.mu held (read).
+func (b *Baseline) timersSection() string {
+	if len(b.timers) == 0 {
+		return ""
+	}
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n%sTIMERS:[-:-:-]\n", mainC))
+	for _, t := range b.timers {
+		left := time.Until(t.Ends)
+		if t.Alarm {
+			sb.WriteString(fmt.Sprintf("%s%s %s%s %sin %s[-:-:-]\n", brightC, t.Ends.Format("15:04"), mainC, tview.Escape(t.name()), dimC, formatDuration(left)))
+			continue
+		}
+		length := t.Ends.Sub(t.Started)
+		pct := 100 - float64(left)/float64(max(length, time.Second))*100
+		sb.WriteString(fmt.Sprintf("%s%s %s%s[-:-:-] %s\n", brightC, formatTimerLeft(left), mainC, tview.Escape(t.name()), createBar(pct, 10, b.theme)))
+	}
+	return sb.String()
+}
+
+// parseTimerLength reads "10m", "1h30m" or plain minutes ("25").
+func parseTimerLength(s string) (time.Duration, bool) {
+	if n, err := strconv.Atoi(s); err == nil {
+		s = strconv.Itoa(n) + "m"
+	}
+	d, err := time.ParseDuration(s)
+	return d, err == nil && d >= time.Second && d <= timerMaxLength
+}
+
+// nextAlarm returns the next time the clock shows hh:mm ("7:30", "19:05").
+func nextAlarm(s string, now time.Time) (time.Time, bool) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	at := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	if !at.After(now) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at, true
+}
+
+// timerCommand handles "timer <duration> [label]" and "alarm <hh:mm>
+// [label]", and list / cancel for both. Called from processCommand with b.mu
+// held.
+func (b *Baseline) timerCommand(alarm bool, rawArgs []string) {
+	command, usage := "timer", "Usage: timer <duration> [label] | list | cancel <n>|all"
+	if alarm {
+		command, usage = "alarm", "Usage: alarm <hh:mm> [label] | list | cancel <n>|all"
+	}
+	if len(rawArgs) == 0 {
+		b.addNotification(usage, "error")
+		return
+	}
+	label := strings.Join(rawArgs[1:], " ")
+	now := time.Now()
+	switch strings.ToLower(rawArgs[0]) {
+	case "list":
+		if len(b.timers) == 0 {
+			b.addNotification("No timers or alarms", "info")
+			return
+		}
+		var items []string
+		for i, t := range b.timers {
+			when := formatTimerLeft(time.Until(t.Ends)) + " left"
+			if t.Alarm {
+				when = "at " + t.Ends.Format("15:04")
+			}
+			items = append(items, fmt.Sprintf("%d. %s (%s)", i+1, t.name(), when))
+		}
+		b.addNotification("Timers: "+strings.Join(items, ", "), "info")
+	case "cancel", "stop", "rm":
+		if label == "all" {
+			b.timers = nil
+			b.saveTimers()
+			b.addNotification("Cancelled every timer and alarm", "success")
+			break
+		}
+		n, err := strconv.Atoi(label)
+		if err != nil || n < 1 || n > len(b.timers) {
+			b.addNotification(fmt.Sprintf("Invalid timer index: %s (see %s list)", label, command), "error")
+			return
+		}
+		t := b.timers[n-1]
+		b.timers = append(b.timers[:n-1], b.timers[n:]...)
+		b.saveTimers()
+		b.addNotification(fmt.Sprintf("Cancelled %s", t.name()), "success")
+	default:
+		if alarm {
+			at, ok := nextAlarm(rawArgs[0], now)
+			if !ok {
+				b.addNotification(usage, "error")
+				return
+			}
+			b.addTimer(Timer{Label: label, Started: now, Ends: at, Alarm: true})
+			b.notify("timers", fmt.Sprintf("Alarm set for %s (in %s)", at.Format("Mon 15:04"), formatDuration(at.Sub(now))), "success")
+		} else {
+			length, ok := parseTimerLength(rawArgs[0])
+			if !ok {
+				b.addNotification(usage+" (1s to 7d, e.g. 10m or 1h30m)", "error")
+				return
+			}
+			t := Timer{Label: label, Started: now, Ends: now.Add(length)}
+			b.addTimer(t)
+			b.notify("timers", fmt.Sprintf("Timer set: %s, done at %s", t.name(), t.Ends.Format("15:04:05")), "success")
+		}
+	}
+	go b.updateTime()
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Todo Selection ---
+//
+// The task list has a selection cursor (drawn in reverse video). With the
+// panel focused ↑/↓ move it; t, d and p toggle, delete and re-prioritize the
+// selected todo. The cursor follows its todo when sorting moves it.
+
+var todoPriorities = []string{"low", "medium", "high"}
+
+// clampTodoCursor keeps the cursor on an existing item, the nearest shown
+// one while a tag filter is on. Called with b.mu held.
+func (b *Baseline) clampTodoCursor() {
+	b.todoCursor = max(0, min(b.todoCursor, len(b.todoItems)-1))
+	if len(b.todoItems) == 0 || b.todoShown(b.todoItems[b.todoCursor]) {
+		return
+	}
+	for d := 1; d < len(b.todoItems); d++ {
+		for _, i := range []int{b.todoCursor + d, b.todoCursor - d} {
+			if i >= 0 && i < len(b.todoItems) && b.todoShown(b.todoItems[i]) {
+				b.todoCursor = i
+				return
+			}
+		}
+	}
+}
+
+// moveTodoCursor moves the selection by delta shown items. Called with b.mu held.
+func (b *Baseline) moveTodoCursor(delta int) {
+	step := 1
+	if delta < 0 {
+		step, delta = -1, -delta
+	}
+	for i := b.todoCursor + step; delta > 0 && i >= 0 && i < len(b.todoItems); i += step {
+		if b.todoShown(b.todoItems[i]) {
+			b.todoCursor = i
+			delta--
+		}
+	}
+	b.clampTodoCursor()
+}
+
+// selectedTodo returns the selected item's index, or false when the list is
+// empty. Called with b.mu held.
+func (b *Baseline) selectedTodo() (int, bool) {
+	if len(b.todoItems) == 0 {
+		b.addNotification("No tasks", "info")
+		return 0, false
+	}
+	b.clampTodoCursor()
+	if !b.todoShown(b.todoItems[b.todoCursor]) {
+		b.addNotification(fmt.Sprintf("No tasks tagged #%s", b.todoFilter), "info")
+		return 0, false
+	}
+	return b.todoCursor, true
+}
+
+func sameTodo(a, b TodoItem) bool {
+	if a.Text != b.Text || a.Done != b.Done || a.Priority != b.Priority {
+		return false
+	}
+	if (a.Due == nil) != (b.Due == nil) {
+		return false
+	}
+	return a.Due == nil || a.Due.Equal(*b.Due)
+}
+
+// toggleTodo flips item i, and its subtasks, between done and open. Called
+// with b.mu held.
+func (b *Baseline) toggleTodo(i int) {
+	next := b.setTodoDone(i, !b.todoItems[i].Done)
+	b.saveTodos()
+	item := &b.todoItems[i]
+	if next != nil {
+		b.addNotification(fmt.Sprintf("Completed: %s, next %s", item.Text, dueLabel(*next.Due, time.Now())), "success")
+	} else if item.Done {
+		b.addNotification(fmt.Sprintf("Completed: %s", item.Text), "success")
+	} else {
+		b.addNotification(fmt.Sprintf("Reopened: %s", item.Text), "info")
+	}
+}
+
+// deleteTodo removes item i with its subtasks. Called with b.mu held.
+func (b *Baseline) deleteTodo(i int) {
+	text := b.todoItems[i].Text
+	if n := b.removeTodo(i); n > 0 {
+		text += fmt.Sprintf(" and %d subtask(s)", n)
+	}
+	b.clampTodoCursor()
+	b.saveTodos()
+	b.addNotification(fmt.Sprintf("Deleted: %s", text), "success")
+}
+
+// setTodoPriority sets item i to level, or the next level when level is "".
+// Called with b.mu held.
+func (b *Baseline) setTodoPriority(i int, level string) {
+	item := &b.todoItems[i]
+	if level == "" {
+		current := strings.ToLower(item.Priority)
+		if current == "" {
+			current = "medium"
+		}
+		next := 0
+		for idx, p := range todoPriorities {
+			if p == current {
+				next = (idx + 1) % len(todoPriorities)
+			}
+		}
+		level = todoPriorities[next]
+	}
+	item.Priority = level
+	b.saveTodos()
+	b.addNotification(fmt.Sprintf("Priority set to %s for: %s", level, item.Text), "success")
+}
+
+// parsePriority accepts high/medium/low and their first letters.
+func parseTodoPriority(s string) (string, bool) {
+	for _, p := range todoPriorities {
+		if s == p || s == p[:1] {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// todoPrioCommand handles "todo prio <index> <level>". Called with b.mu held.
+func (b *Baseline) todoPrioCommand(args []string) bool {
+	if len(args) != 2 {
+		b.addNotification("Usage: todo prio <index> high|medium|low", "error")
+		return false
+	}
+	index, err := strconv.Atoi(args[0])
+	if err != nil || index < 1 || index > len(b.todoItems) {
+		b.addNotification(fmt.Sprintf("Invalid todo index: %s", args[0]), "error")
+		return false
+	}
+	level, ok := parseTodoPriority(args[1])
+	if !ok {
+		b.addNotification(fmt.Sprintf("Invalid priority: %s (high, medium or low)", args[1]), "error")
+		return false
+	}
+	b.todoCursor = index - 1
+	b.setTodoPriority(index-1, level)
+	return true
+}
+
+// todoRows is how many screen rows a rendered todo line takes in a panel
+// width cells wide; long lines wrap.
+func todoRows(line string, width int) int {
+	return max(1, (tview.TaggedStringWidth(line)+width-1)/width)
+}
+
+// scrollToTodo scrolls the task list just enough to show item i.
+// Must run on the UI goroutine.
+func (b *Baseline) scrollToTodo(lines []string, i int) {
+	_, _, width, height := b.todoPanel.GetInnerRect()
+	if width <= 0 || height <= 0 || i >= len(lines) {
+		return
+	}
+	start := 0
+	for _, line := range lines[:i] {
+		start += todoRows(line, width)
+	}
+	end := start + todoRows(lines[i], width)
+	offset, _ := b.todoPanel.GetScrollOffset()
+	switch {
+	case start < offset:
+		b.todoPanel.ScrollTo(start, 0)
+	case end > offset+height:
+		b.todoPanel.ScrollTo(end-height, 0)
+	}
+}
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// --- Task Sync Providers ---
+//
+// Todoist (TODOIST_TOKEN, the API token from Settings > Integrations) and
+// TickTick (TICKTICK_TOKEN, an OAuth access token for its Open API). Each
+// mirrors one project, TODOIST_PROJECT / TICKTICK_PROJECT, given by name
+// or id.
+
+// errTaskNotFound is returned for a task deleted on the provider's side.
+var errTaskNotFound = errors.New("task not found")
+
+// RemoteTask is a task as a provider sees it, in Baseline's terms.
+type RemoteTask struct {
+	ID       string
+	Text     string
+	Done     bool
+	Priority string // "high", "medium", "low"
+	Due      *time.Time
+	Updated  time.Time // Zero when the provider doesn't report it
+}
+
+// TaskProvider lists and edits the tasks of the mirrored project.
+type TaskProvider interface {
+	Name() string
+	List() ([]RemoteTask, error) // Open tasks only
+	Create(t RemoteTask) (string, error)
+	Update(t RemoteTask) error // Text, priority and due date
+	SetDone(id string, done bool) error
+	Delete(id string) error
+}
+
+// newTaskProviderFromEnv returns nil when TODO_SYNC is unset.
+func newTaskProviderFromEnv() (TaskProvider, error) {
+	client := http.Client{Timeout: 15 * time.Second}
+	switch name := strings.ToLower(strings.TrimSpace(os.Getenv("TODO_SYNC"))); name {
+	case "":
+		return nil, nil
+	case "todoist":
+		token := os.Getenv("TODOIST_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("TODO_SYNC=todoist needs TODOIST_TOKEN")
+		}
+		return &todoistProvider{token: token, project: os.Getenv("TODOIST_PROJECT"), client: client}, nil
+	case "ticktick":
+		token, project := os.Getenv("TICKTICK_TOKEN"), os.Getenv("TICKTICK_PROJECT")
+		if token == "" || project == "" {
+			return nil, fmt.Errorf("TODO_SYNC=ticktick needs TICKTICK_TOKEN and TICKTICK_PROJECT")
+		}
+		return &tickTickProvider{token: token, project: project, client: client}, nil
+	default:
+		return nil, fmt.Errorf("unknown TODO_SYNC provider %q (todoist or ticktick)", name)
+	}
+}
+
+// taskAPIRequest sends body as JSON with a bearer token and decodes the
+// response into out (either may be nil).
+func taskAPIRequest(client http.Client, token, method, endpoint string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errTaskNotFound
+	}
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		if text := strings.TrimSpace(string(msg)); text != "" {
+			return fmt.Errorf("API error: %s (%d)", text, resp.StatusCode)
+		}
+		return fmt.Errorf("API error: Status %d", resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("JSON parse error: %w", err)
+	}
+	return nil
+}
+
+// --- Todoist ---
+
+const todoistAPI = "https://api.todoist.com/api/v1"
+
+type todoistProvider struct {
+	token     string
+	project   string // Name or id, "" for the Inbox
+	projectID string // Resolved on first use
+	client    http.Client
+}
+
+func (t *todoistProvider) Name() string { return "Todoist" }
+
+type todoistTask struct {
+	ID       string `json:"id"`
+	Content  string `json:"content"`
+	Priority int    `json:"priority"` // 4 is the most urgent (p1)
+	Checked  bool   `json:"checked"`
+	Updated  string `json:"updated_at"`
+	Due      *struct {
+		Date string `json:"date"` // "2024-06-01", or with a time, floating or UTC
+	} `json:"due"`
+}
+
+func (t *todoistProvider) call(method, path string, body, out interface{}) error {
+	return taskAPIRequest(t.client, t.token, method, todoistAPI+path, body, out)
+}
+
+// resolveProject finds the project id, by name if needed.
+func (t *todoistProvider) resolveProject() (string, error) {
+	if t.projectID != "" || t.project == "" {
+		return t.projectID, nil
+	}
+	var page struct {
+		Results []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"results"`
+	}
+	if err := t.call(http.MethodGet, "/projects?limit=200", nil, &page); err != nil {
+		return "", err
+	}
+	for _, p := range page.Results {
+		if p.ID == t.project || strings.EqualFold(p.Name, t.project) {
+			t.projectID = p.ID
+			return p.ID, nil
+		}
+	}
+	return "", fmt.Errorf("Todoist project %q not found", t.project)
+}
+
+func (t *todoistProvider) List() ([]RemoteTask, error) {
+	project, err := t.resolveProject()
+	if err != nil {
+		return nil, err
+	}
+	var tasks []RemoteTask
+	cursor := ""
+	for {
+		query := url.Values{}
+		query.Set("limit", "200")
+		if project != "" {
+			query.Set("project_id", project)
+		}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+		var page struct {
+			Results    []todoistTask `json:"results"`
+			NextCursor *string       `json:"next_cursor"`
+		}
+		if err := t.call(http.MethodGet, "/tasks?"+query.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+		for _, task := range page.Results {
+			r := RemoteTask{
+				ID:       task.ID,
+				Text:     task.Content,
+				Done:     task.Checked,
+				Priority: map[int]string{4: "high", 3: "high", 2: "medium"}[task.Priority],
+			}
+			if r.Priority == "" {
+				r.Priority = "low" // p4, no priority
+			}
+			if task.Due != nil {
+				r.Due = parseTaskDue(task.Due.Date)
+			}
+			r.Updated, _ = time.Parse(time.RFC3339Nano, task.Updated)
+			tasks = append(tasks, r)
+		}
+		if page.NextCursor == nil || *page.NextCursor == "" {
+			return tasks, nil
+		}
+		cursor = *page.NextCursor
+	}
+}
+
+// fields is the editable part of a task in Todoist's terms.
+func (t *todoistProvider) fields(task RemoteTask) map[string]interface{} {
+	priority := map[string]int{"high": 4, "medium": 2}[task.Priority]
+	if priority == 0 {
+		priority = 1 // Low: p4, no priority
+	}
+	body := map[string]interface{}{"content": task.Text, "priority": priority}
+	switch {
+	case task.Due == nil:
+		body["due_string"] = "no date"
+	case isDateOnly(*task.Due):
+		body["due_date"] = task.Due.Format("2006-01-02")
+	default:
+		body["due_datetime"] = task.Due.UTC().Format(time.RFC3339)
+	}
+	return body
+}
+
+func (t *todoistProvider) Create(task RemoteTask) (string, error) {
+	project, err := t.resolveProject()
+	if err != nil {
+		return "", err
+	}
+	body := t.fields(task)
+	if task.Due == nil {
+		delete(body, "due_string")
+	}
+	if project != "" {
+		body["project_id"] = project
+	}
+	var created todoistTask
+	if err := t.call(http.MethodPost, "/tasks", body, &created); err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+func (t *todoistProvider) Update(task RemoteTask) error {
+	return t.call(http.MethodPost, "/tasks/"+url.PathEscape(task.ID), t.fields(task), nil)
+}
+
+func (t *todoistProvider) SetDone(id string, done bool) error {
+	action := "/reopen"
+	if done {
+		action = "/close"
+	}
+	return t.call(http.MethodPost, "/tasks/"+url.PathEscape(id)+action, nil, nil)
+}
+
+func (t *todoistProvider) Delete(id string) error {
+	return t.call(http.MethodDelete, "/tasks/"+url.PathEscape(id), nil, nil)
+}
+
+// --- TickTick ---
+
+const tickTickAPI = "https://api.ticktick.com/open/v1"
+
+type tickTickProvider struct {
+	token     string
+	project   string // Name or id
+	projectID string // Resolved on first use
+	client    http.Client
+}
+
+func (t *tickTickProvider) Name() string { return "TickTick" }
+
+type tickTickTask struct {
+	ID        string `json:"id,omitempty"`
+	ProjectID string `json:"projectId"`
+	Title     string `json:"title"`
+	Priority  int    `json:"priority"` // 0 none, 1 low, 3 medium, 5 high
+	Status    int    `json:"status"`   // 0 open, 2 completed
+	DueDate   string `json:"dueDate,omitempty"`
+	IsAllDay  bool   `json:"isAllDay"`
+}
+
+func (t *tickTickProvider) call(method, path string, body, out interface{}) error {
+	return taskAPIRequest(t.client, t.token, method, tickTickAPI+path, body, out)
+}
+
+func (t *tickTickProvider) resolveProject() (string, error) {
+	if t.projectID != "" {
+		return t.projectID, nil
+	}
+	var projects []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := t.call(http.MethodGet, "/project", nil, &projects); err != nil {
+		return "", err
+	}
+	for _, p := range projects {
+		if p.ID == t.project || strings.EqualFold(p.Name, t.project) {
+			t.projectID = p.ID
+			return p.ID, nil
+		}
+	}
+	return "", fmt.Errorf("TickTick project %q not found", t.project)
+}
+
+func (t *tickTickProvider) List() ([]RemoteTask, error) {
+	project, err := t.resolveProject()
+	if err != nil {
+		return nil, err
+	}
+	var data struct {
+		Tasks []tickTickTask `json:"tasks"`
+	}
+	if err := t.call(http.MethodGet, "/project/"+url.PathEscape(project)+"/data", nil, &data); err != nil {
+		return nil, err
+	}
+	tasks := make([]RemoteTask, 0, len(data.Tasks))
+	for _, task := range data.Tasks {
+		r := RemoteTask{
+			ID:       task.ID,
+			Text:     task.Title,
+			Done:     task.Status == 2,
+			Priority: map[int]string{5: "high", 3: "medium"}[task.Priority],
+		}
+		if r.Priority == "" {
+			r.Priority = "low"
+		}
+		if task.DueDate != "" {
+			for _, layout := range []string{"2006-01-02T15:04:05.000-0700", "2006-01-02T15:04:05-0700"} {
+				if due, err := time.Parse(layout, task.DueDate); err == nil {
+					due = due.Local()
+					if task.IsAllDay {
+						due = endOfDay(due)
+					}
+					r.Due = &due
+					break
+				}
+			}
+		}
+		tasks = append(tasks, r)
+	}
+	return tasks, nil
+}
+
+func (t *tickTickProvider) task(r RemoteTask) tickTickTask {
+	task := tickTickTask{
+		ID:        r.ID,
+		ProjectID: t.projectID,
+		Title:     r.Text,
+		Priority:  map[string]int{"high": 5, "medium": 3, "low": 1}[r.Priority],
+	}
+	if r.Due != nil {
+		due := *r.Due
+		if task.IsAllDay = isDateOnly(due); task.IsAllDay {
+			due = time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, due.Location())
+		}
+		task.DueDate = due.Format("2006-01-02T15:04:05.000-0700")
+	}
+	return task
+}
+
+func (t *tickTickProvider) Create(r RemoteTask) (string, error) {
+	if _, err := t.resolveProject(); err != nil {
+		return "", err
+	}
+	var created tickTickTask
+	if err := t.call(http.MethodPost, "/task", t.task(r), &created); err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+func (t *tickTickProvider) Update(r RemoteTask) error {
+	return t.call(http.MethodPost, "/task/"+url.PathEscape(r.ID), t.task(r), nil)
+}
+
+func (t *tickTickProvider) SetDone(id string, done bool) error {
+	if done {
+		return t.call(http.MethodPost, "/project/"+url.PathEscape(t.projectID)+"/task/"+url.PathEscape(id)+"/complete", nil, nil)
+	}
+	return t.call(http.MethodPost, "/task/"+url.PathEscape(id), map[string]interface{}{"id": id, "projectId": t.projectID, "status": 0}, nil)
+}
+
+func (t *tickTickProvider) Delete(id string) error {
+	return t.call(http.MethodDelete, "/project/"+url.PathEscape(t.projectID)+"/task/"+url.PathEscape(id), nil, nil)
+}
+
+// parseTaskDue reads a Todoist due date: a plain date is due at the end of
+// that day like `--due 2024-06-01`, a floating time is local.
+func parseTaskDue(s string) *time.Time {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		t = t.Local()
+		return &t
+	}
+	if t, err := time.ParseInLocation("2006-01-02T15:04:05", s, time.Local); err == nil {
+		return &t
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		t = endOfDay(t)
+		return &t
+	}
+	return nil
+}
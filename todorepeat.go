@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// --- Recurring Todos ---
+//
+// `todo add "water plants" --repeat weekly:mon` (or `todo repeat <index>
+// <rule>` later). Rules are daily, weekdays, weekly[:mon,thu],
+// monthly[:15] or `cron <min> <hour> <dom> <month> <dow>`. Completing a
+// recurring todo adds its next occurrence as a new open todo, due at the same
+// time of day, and the completed one stays behind as done for the journal.
+// Subtasks aren't carried over. A recurring todo added without --due is due
+// at its first occurrence. Recurring todos are marked with ↻ in the list.
+
+const repeatGlyph = "↻"
+
+var repeatDays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"} // Cron numbering
+
+// parseRepeat reads a rule from the start of words and returns it in the
+// form kept in todos.json, with how many words it used.
+func parseRepeat(words []string) (string, int, error) {
+	if len(words) == 0 {
+		return "", 0, fmt.Errorf("missing rule after --repeat (daily, weekdays, weekly[:mon], monthly[:15] or cron <spec>)")
+	}
+	kind, arg, _ := strings.Cut(strings.ToLower(words[0]), ":")
+	switch kind {
+	case "daily", "weekdays":
+		if arg == "" {
+			return kind, 1, nil
+		}
+	case "weekly":
+		if arg == "" {
+			return kind, 1, nil
+		}
+		var days []string
+		for _, d := range strings.Split(arg, ",") {
+			if len(d) < 3 || !slices.Contains(repeatDays, d[:3]) {
+				return "", 0, fmt.Errorf("invalid weekday %q in %s (want mon, tue, ...)", d, words[0])
+			}
+			if !slices.Contains(days, d[:3]) {
+				days = append(days, d[:3])
+			}
+		}
+		return kind + ":" + strings.Join(days, ","), 1, nil
+	case "monthly":
+		if arg == "" {
+			return kind, 1, nil
+		}
+		if day, err := strconv.Atoi(arg); err == nil && day >= 1 && day <= 31 {
+			return kind + ":" + arg, 1, nil
+		}
+		return "", 0, fmt.Errorf("invalid day of the month in %s (want 1-31)", words[0])
+	case "cron":
+		fields := 5
+		if len(words) > 1 && strings.HasPrefix(words[1], "@") {
+			fields = 1
+		}
+		if len(words) < fields+1 {
+			return "", 0, fmt.Errorf("incomplete cron rule (want cron <min> <hour> <dom> <month> <dow>)")
+		}
+		spec := strings.Join(words[1:fields+1], " ")
+		if _, err := cron.ParseStandard(spec); err != nil {
+			return "", 0, fmt.Errorf("invalid cron rule %q: %v", spec, err)
+		}
+		return "cron:" + spec, fields + 1, nil
+	}
+	return "", 0, fmt.Errorf("invalid repeat rule %q (want daily, weekdays, weekly[:mon], monthly[:15] or cron <spec>)", words[0])
+}
+
+// repeatSchedule turns a rule into a cron schedule. The simple rules fire at
+// the time of day of ref, and weekly and monthly ones without a day on ref's
+// weekday or day of the month.
+func repeatSchedule(rule string, ref time.Time) (cron.Schedule, error) {
+	kind, arg, _ := strings.Cut(rule, ":")
+	clock := fmt.Sprintf("%d %d", ref.Minute(), ref.Hour())
+	var spec string
+	switch kind {
+	case "daily":
+		spec = clock + " * * *"
+	case "weekdays":
+		spec = clock + " * * 1-5"
+	case "weekly":
+		days := []string{strconv.Itoa(int(ref.Weekday()))}
+		if arg != "" {
+			days = days[:0]
+			for _, d := range strings.Split(arg, ",") {
+				days = append(days, strconv.Itoa(slices.Index(repeatDays, d)))
+			}
+		}
+		spec = clock + " * * " + strings.Join(days, ",")
+	case "monthly":
+		if arg == "" {
+			arg = strconv.Itoa(ref.Day())
+		}
+		spec = clock + " " + arg + " * *"
+	case "cron":
+		spec = arg
+	default:
+		return nil, fmt.Errorf("invalid repeat rule %q", rule)
+	}
+	return cron.ParseStandard(spec)
+}
+
+// nextDue is when the occurrence after one due at due (nil if it had no due
+// date) falls due. Occurrences missed while the todo stayed open are skipped.
+func nextDue(rule string, due *time.Time, now time.Time) (time.Time, error) {
+	ref, from := endOfDay(now), now
+	if due != nil {
+		ref = *due
+		if due.After(now) {
+			from = *due
+		}
+	}
+	sched, err := repeatSchedule(rule, ref)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return sched.Next(from), nil
+}
+
+// firstDue is when a recurring todo added without a due date is first due.
+func firstDue(rule string, now time.Time) (time.Time, error) {
+	sched, err := repeatSchedule(rule, endOfDay(now))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return sched.Next(now), nil
+}
+
+// repeatTodo adds the next occurrence of completed item i, which hands its
+// rule on to it, and returns the new todo; nil when i doesn't recur. Called
+// with b.mu held.
+func (b *Baseline) repeatTodo(i int) *TodoItem {
+	item := &b.todoItems[i]
+	if item.Repeat == "" {
+		return nil
+	}
+	due, err := nextDue(item.Repeat, item.Due, time.Now())
+	if err != nil {
+		b.addNotification(fmt.Sprintf("%s: %v", item.Text, err), "error")
+		return nil
+	}
+	next := TodoItem{
+		Text:     item.Text,
+		Priority: item.Priority,
+		Due:      &due,
+		Tags:     slices.Clone(item.Tags),
+		Parent:   item.Parent,
+		Repeat:   item.Repeat,
+	}
+	item.Repeat = "" // Reopening the done one doesn't repeat it twice
+	b.todoItems = append(b.todoItems, next)
+	return &next
+}
+
+// todoRepeatCommand handles "todo repeat <index> <rule>|off". Called with
+// b.mu held.
+func (b *Baseline) todoRepeatCommand(args []string) bool {
+	if len(args) < 2 {
+		b.addNotification("Usage: todo repeat <index> daily|weekdays|weekly[:mon]|monthly[:15]|cron <spec>|off", "error")
+		return false
+	}
+	index, err := strconv.Atoi(args[0])
+	if err != nil || index < 1 || index > len(b.todoItems) {
+		b.addNotification(fmt.Sprintf("Invalid todo index: %s", args[0]), "error")
+		return false
+	}
+	item := &b.todoItems[index-1]
+	if args[1] == "off" || args[1] == "clear" || args[1] == "none" {
+		item.Repeat = ""
+		b.saveTodos()
+		b.addNotification(fmt.Sprintf("%s no longer repeats", item.Text), "success")
+		return true
+	}
+	rule, _, err := parseRepeat(args[1:])
+	if err != nil {
+		b.addNotification(err.Error(), "error")
+		return false
+	}
+	item.Repeat = rule
+	msg := fmt.Sprintf("%s repeats %s", item.Text, rule)
+	if item.Due == nil {
+		due, _ := firstDue(rule, time.Now()) // Valid, parseRepeat checked it
+		item.Due = &due
+		msg += ", " + dueLabel(due, time.Now())
+	}
+	b.saveTodos()
+	b.addNotification(msg, "success")
+	return true
+}
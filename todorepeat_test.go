@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// Finishing the last subtask of a recurring task completes it, which must
+// schedule the next occurrence just like toggling the task itself.
+func TestDeriveTodoDoneRepeatsParent(t *testing.T) {
+	due := time.Now().Add(-time.Hour)
+	b := &Baseline{
+		app: tview.NewApplication(),
+		todoItems: []TodoItem{
+			{ID: 1, Text: "weekly review", Due: &due, Repeat: "daily"},
+			{Text: "inbox zero", Parent: 1, Done: true},
+			{Text: "plan the week", Parent: 1, Done: true},
+		},
+	}
+
+	b.deriveTodoDone()
+
+	if len(b.todoItems) != 4 {
+		t.Fatalf("got %d todos, want the next occurrence added", len(b.todoItems))
+	}
+	parent, next := b.todoItems[0], b.todoItems[3]
+	if !parent.Done || parent.Repeat != "" {
+		t.Errorf("completed parent: done %v, repeat %q; want done, no longer repeating", parent.Done, parent.Repeat)
+	}
+	if next.Text != parent.Text || next.Done || next.Repeat != "daily" {
+		t.Errorf("next occurrence = %+v, want an open %q repeating daily", next, parent.Text)
+	}
+	if next.Due == nil || !next.Due.After(time.Now()) {
+		t.Errorf("next occurrence due %v, want a time after now", next.Due)
+	}
+
+	// Saving again doesn't add another one
+	b.deriveTodoDone()
+	if len(b.todoItems) != 4 {
+		t.Errorf("got %d todos after deriving again, want 4", len(b.todoItems))
+	}
+}
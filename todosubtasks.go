@@ -0,0 +1,231 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Todo Subtasks ---
+//
+// `todo sub 3 write changelog` adds a subtask under task 3; subtasks can have
+// their own. They stay in the flat todos.json list, linked by ids: a task
+// with subtasks gets an "id", each subtask the "parent" id, so lists written
+// before subtasks load unchanged. The list is kept in tree order, every task
+// followed by its subtasks, and drawn indented. A task with subtasks is done
+// when all of them are; toggling or deleting it does the same to them.
+
+// todoID returns item i's id, assigning the next free one. Called with b.mu held.
+func (b *Baseline) todoID(i int) int {
+	if b.todoItems[i].ID == 0 {
+		next := 0
+		for _, item := range b.todoItems {
+			next = max(next, item.ID)
+		}
+		b.todoItems[i].ID = next + 1
+	}
+	return b.todoItems[i].ID
+}
+
+// todoChildren maps each id to the indices of its subtasks, 0 to the top
+// level tasks. A subtask whose parent is gone, or that is its own ancestor
+// in a hand-edited file, counts as top level.
+func todoChildren(items []TodoItem) map[int][]int {
+	parents := map[int]int{}
+	for _, item := range items {
+		if item.ID != 0 {
+			parents[item.ID] = item.Parent
+		}
+	}
+	children := map[int][]int{}
+	for i, item := range items {
+		parent := item.Parent
+		for p, steps := parent, 0; p != 0; steps++ {
+			up, ok := parents[p]
+			if !ok || steps > len(items) {
+				parent = 0
+				break
+			}
+			p = up
+		}
+		children[parent] = append(children[parent], i)
+	}
+	return children
+}
+
+// todoSubtree returns the indices of item i and all its subtasks.
+func todoSubtree(items []TodoItem, i int) []int {
+	children := todoChildren(items)
+	subtree := []int{i}
+	for k := 0; k < len(subtree); k++ {
+		if id := items[subtree[k]].ID; id != 0 {
+			subtree = append(subtree, children[id]...)
+		}
+	}
+	return subtree
+}
+
+// sortTodos orders the list by priority, high first, keeping every task's
+// subtasks right after it, and returns each item's nesting depth. Called
+// with b.mu held.
+func (b *Baseline) sortTodos() []int {
+	priorityMap := map[string]int{"high": 0, "medium": 1, "low": 2}
+	rank := func(item TodoItem) int {
+		if p, ok := priorityMap[strings.ToLower(item.Priority)]; ok {
+			return p
+		}
+		return 1 // Default to medium if invalid
+	}
+	children := todoChildren(b.todoItems)
+	for _, siblings := range children {
+		sort.SliceStable(siblings, func(i, j int) bool {
+			return rank(b.todoItems[siblings[i]]) < rank(b.todoItems[siblings[j]])
+		})
+	}
+
+	sorted := make([]TodoItem, 0, len(b.todoItems))
+	depths := make([]int, 0, len(b.todoItems))
+	seen := make([]bool, len(b.todoItems))
+	var visit func(i, depth int)
+	visit = func(i, depth int) {
+		if seen[i] {
+			return // Listed under another task with the same id
+		}
+		seen[i] = true
+		sorted = append(sorted, b.todoItems[i])
+		depths = append(depths, depth)
+		if id := b.todoItems[i].ID; id != 0 {
+			for _, child := range children[id] {
+				visit(child, depth+1)
+			}
+		}
+	}
+	for _, i := range children[0] {
+		visit(i, 0)
+	}
+	b.todoItems = sorted
+	return depths
+}
+
+// deriveTodoDone marks tasks with subtasks done exactly when all their
+// subtasks are, deepest first. A recurring task completed this way gets its
+// next occurrence, as if it had been toggled. Called with b.mu held, from
+// saveTodos.
+func (b *Baseline) deriveTodoDone() {
+	children := todoChildren(b.todoItems)
+	var completed []int
+	var done func(i int) bool
+	done = func(i int) bool {
+		item := &b.todoItems[i]
+		kids := children[item.ID]
+		if item.ID == 0 || len(kids) == 0 {
+			return item.Done
+		}
+		all := true
+		for _, child := range kids {
+			all = done(child) && all // Visit every child, nested parents too
+		}
+		if item.Done != all {
+			item.Done = all
+			item.CompletedAt = completionTime(all)
+			if all && item.Repeat != "" {
+				completed = append(completed, i)
+			}
+		}
+		return all
+	}
+	for _, i := range children[0] {
+		done(i)
+	}
+
+	// Appended after the walk, which indexes b.todoItems
+	for _, i := range completed {
+		if next := b.repeatTodo(i); next != nil {
+			b.addNotification(fmt.Sprintf("Completed: %s, next %s", next.Text, dueLabel(*next.Due, time.Now())), "success")
+		}
+	}
+	if len(completed) > 0 {
+		b.deriveTodoDone() // A new occurrence reopens the task it belongs to
+	}
+}
+
+// todoProgress returns how many of item i's direct subtasks are done, and
+// how many it has.
+func todoProgress(items []TodoItem, children map[int][]int, i int) (done, total int) {
+	if items[i].ID == 0 {
+		return 0, 0
+	}
+	for _, child := range children[items[i].ID] {
+		total++
+		if items[child].Done {
+			done++
+		}
+	}
+	return done, total
+}
+
+// setTodoDone sets item i and its subtasks to done or open. Completing a
+// recurring item adds its next occurrence, which is returned (see
+// todorepeat.go). Called with b.mu held.
+func (b *Baseline) setTodoDone(i int, done bool) *TodoItem {
+	completed := done && !b.todoItems[i].Done
+	for _, k := range todoSubtree(b.todoItems, i) {
+		if b.todoItems[k].Done != done {
+			b.todoItems[k].Done = done
+			b.todoItems[k].CompletedAt = completionTime(done)
+		}
+	}
+	if completed {
+		return b.repeatTodo(i)
+	}
+	return nil
+}
+
+// removeTodo deletes item i with its subtasks and returns how many subtasks
+// went with it. Called with b.mu held.
+func (b *Baseline) removeTodo(i int) int {
+	gone := map[int]bool{}
+	subtree := todoSubtree(b.todoItems, i)
+	for _, k := range subtree {
+		gone[k] = true
+	}
+	kept := b.todoItems[:0]
+	for k, item := range b.todoItems {
+		if !gone[k] {
+			kept = append(kept, item)
+		}
+	}
+	b.todoItems = kept
+	return len(subtree) - 1
+}
+
+// todoSubCommand handles "todo sub <index> <text> [--due date]". Called
+// with b.mu held.
+func (b *Baseline) todoSubCommand(args []string) bool {
+	if len(args) < 2 {
+		b.addNotification("Usage: todo sub <index> <task text> [--due YYYY-MM-DD [HH:MM]]", "error")
+		return false
+	}
+	index, err := strconv.Atoi(args[0])
+	if err != nil || index < 1 || index > len(b.todoItems) {
+		b.addNotification(fmt.Sprintf("Invalid todo index: %s", args[0]), "error")
+		return false
+	}
+	item, err := parseTodoAdd(args[1:], time.Now())
+	if err != nil {
+		b.addNotification(err.Error(), "error")
+		return false
+	}
+	if item.Text == "" {
+		b.addNotification("Usage: todo sub <index> <task text> [--due YYYY-MM-DD [HH:MM]]", "error")
+		return false
+	}
+	parent := b.todoID(index - 1)
+	item.Parent = parent
+	b.todoItems = append(b.todoItems, item)
+	b.saveTodos() // Reopens the parent if it was done
+	b.addNotification(fmt.Sprintf("Added subtask to %s: %s", b.todoItems[index-1].Text, item.Text), "success")
+	return true
+}
@@ -0,0 +1,434 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// --- Task Sync ---
+//
+// With TODO_SYNC set the task list mirrors a Todoist or TickTick project
+// (see todoproviders.go). A sync pulls the project's open tasks, compares
+// both sides with the state of the last sync (todosync.json) and sends
+// local additions, edits, completions and deletions back. When a task was
+// changed on both sides the newer change wins. Syncs run on start, every
+// TODO_SYNC_INTERVAL (default 5m), shortly after a local change and on
+// `todo sync`; the task list title shows how the last one went.
+
+const (
+	todoSyncFile     = "todosync.json"
+	todoSyncDefault  = 5 * time.Minute
+	todoSyncDebounce = 3 * time.Second // Local edits are pushed once they settle
+)
+
+// syncedTask is what both sides agreed on at the last sync.
+type syncedTask struct {
+	Text     string     `json:"text"`
+	Done     bool       `json:"done"`
+	Priority string     `json:"priority"`
+	Due      *time.Time `json:"due,omitempty"`
+}
+
+func syncedFromTodo(item TodoItem) syncedTask {
+	priority := strings.ToLower(item.Priority)
+	if priority == "" {
+		priority = "medium"
+	}
+	return syncedTask{Text: item.Text, Done: item.Done, Priority: priority, Due: item.Due}
+}
+
+func syncedFromRemote(r RemoteTask) syncedTask {
+	return syncedTask{Text: r.Text, Done: r.Done, Priority: r.Priority, Due: r.Due}
+}
+
+func (s syncedTask) equal(o syncedTask) bool {
+	if s.Text != o.Text || s.Done != o.Done || s.Priority != o.Priority || (s.Due == nil) != (o.Due == nil) {
+		return false
+	}
+	return s.Due == nil || s.Due.Truncate(time.Minute).Equal(o.Due.Truncate(time.Minute))
+}
+
+type TaskSync struct {
+	provider TaskProvider
+	interval time.Duration
+	path     string
+	running  sync.Mutex // One sync at a time
+	timer    *time.Timer
+
+	// Guarded by b.mu
+	base      map[string]syncedTask // By task id
+	lastSync  time.Time
+	lastErr   string
+	conflicts int  // Resolved in the last sync
+	syncing   bool // A sync is saving its own changes
+}
+
+// newTaskSyncFromEnv returns nil when TODO_SYNC is unset or misconfigured.
+func newTaskSyncFromEnv(configDir string) *TaskSync {
+	provider, err := newTaskProviderFromEnv()
+	if err != nil {
+		slog.Warn(err.Error())
+		return nil
+	}
+	if provider == nil {
+		return nil
+	}
+	s := &TaskSync{
+		provider: provider,
+		interval: todoSyncDefault,
+		path:     filepath.Join(configDir, todoSyncFile),
+		base:     map[string]syncedTask{},
+	}
+	if v := os.Getenv("TODO_SYNC_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= time.Minute {
+			s.interval = d
+		} else {
+			slog.Warn("Invalid TODO_SYNC_INTERVAL (at least 1m)", "value", v, "using", todoSyncDefault)
+		}
+	}
+
+	var state struct {
+		LastSync time.Time             `json:"last_sync"`
+		Base     map[string]syncedTask `json:"tasks"`
+	}
+	if data, err := os.ReadFile(s.path); err == nil && json.Unmarshal(data, &state) == nil && state.Base != nil {
+		s.base, s.lastSync = state.Base, state.LastSync
+	}
+	return s
+}
+
+// save writes the last synced state. Called with b.mu held.
+func (s *TaskSync) save() error {
+	data, err := json.MarshalIndent(map[string]interface{}{"last_sync": s.lastSync, "tasks": s.base}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0640)
+}
+
+// todosChanged stamps locally edited synced todos and schedules a push.
+// After a failed sync the periodic one retries instead. Called from saveTodos
+// with b.mu held.
+func (b *Baseline) todosChanged() {
+	s := b.taskSync
+	if s == nil {
+		return
+	}
+	pending := false
+	mirrored := 0
+	now := time.Now()
+	for i := range b.todoItems {
+		item := &b.todoItems[i]
+		if item.SyncID == "" {
+			pending = pending || !item.Done
+			continue
+		}
+		base, ok := s.base[item.SyncID]
+		if ok {
+			mirrored++
+		}
+		if !ok || !base.equal(syncedFromTodo(*item)) {
+			if item.Modified == nil || item.Modified.Before(s.lastSync) {
+				item.Modified = &now
+			}
+			pending = true
+		}
+	}
+	if mirrored < len(s.base) {
+		pending = true // Deleted here
+	}
+	if !pending || s.syncing || s.lastErr != "" {
+		return
+	}
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.timer = time.AfterFunc(todoSyncDebounce, func() { b.life.Go(b.syncTodos) })
+}
+
+// syncOp is one change to send to the provider. apply records the result
+// and runs with b.mu held.
+type syncOp struct {
+	run   func() error
+	apply func()
+}
+
+// syncTodos runs one sync.
+func (b *Baseline) syncTodos() {
+	s := b.taskSync
+	if s == nil {
+		return
+	}
+	s.running.Lock()
+	defer s.running.Unlock()
+
+	remote, err := s.provider.List()
+	if err != nil {
+		b.mu.Lock()
+		first := s.lastErr == ""
+		s.lastErr = err.Error()
+		b.mu.Unlock()
+		if first {
+			b.notify("todo", fmt.Sprintf("%s sync failed: %v", s.provider.Name(), err), "error")
+		}
+		go b.updateTodos()
+		return
+	}
+
+	b.mu.Lock()
+	ops, pulled, conflicts := b.planTodoSync(remote)
+	if pulled {
+		b.saveSyncedTodos()
+	}
+	b.mu.Unlock()
+
+	var failed []error
+	applied := 0
+	for _, op := range ops {
+		if err := op.run(); err != nil {
+			failed = append(failed, err)
+			continue
+		}
+		b.mu.Lock()
+		op.apply()
+		b.mu.Unlock()
+		applied++
+	}
+
+	b.mu.Lock()
+	s.lastSync = time.Now()
+	s.conflicts = conflicts
+	s.lastErr = ""
+	if len(failed) > 0 {
+		s.lastErr = fmt.Sprintf("%d change(s) not sent: %v", len(failed), failed[0])
+	}
+	if err := s.save(); err != nil {
+		b.addNotification(fmt.Sprintf("Error saving %s: %v", todoSyncFile, err), "error")
+	}
+	if applied > 0 {
+		b.saveSyncedTodos() // New ids
+	}
+	b.mu.Unlock()
+
+	switch {
+	case len(failed) > 0:
+		b.notify("todo", fmt.Sprintf("%s sync: %s", s.provider.Name(), s.lastErr), "error")
+	case conflicts > 0:
+		b.notify("todo", fmt.Sprintf("%s sync: %d task(s) changed on both sides, kept the newer change", s.provider.Name(), conflicts), "info")
+	}
+	go b.updateTodos()
+}
+
+// saveSyncedTodos saves the todos a sync changed without scheduling another
+// sync. Called with b.mu held.
+func (b *Baseline) saveSyncedTodos() {
+	b.taskSync.syncing = true
+	b.saveTodos()
+	b.taskSync.syncing = false
+}
+
+// planTodoSync merges the remote tasks into the todo list and returns the
+// changes to send back. Called with b.mu held.
+func (b *Baseline) planTodoSync(remote []RemoteTask) (ops []syncOp, pulled bool, conflicts int) {
+	s := b.taskSync
+	byID := map[string]RemoteTask{}
+	for _, r := range remote {
+		byID[r.ID] = r
+	}
+	local := map[string]bool{}
+
+	for i := range b.todoItems {
+		item := &b.todoItems[i]
+		if item.SyncID == "" {
+			if !item.Done { // Finished before syncing: nothing to mirror
+				ops = append(ops, b.createOp(syncedFromTodo(*item)))
+			}
+			continue
+		}
+		id := item.SyncID
+		local[id] = true
+		base, known := s.base[id]
+		r, open := byID[id]
+		theirs := base
+		theirs.Done = true // Gone from the open tasks: completed (or deleted) there
+		if open {
+			theirs = syncedFromRemote(r)
+		}
+		mine := syncedFromTodo(*item)
+		localChanged := !known || !base.equal(mine)
+		remoteChanged := known && !base.equal(theirs)
+
+		switch {
+		case !localChanged && !remoteChanged:
+		case remoteChanged && (!localChanged || item.Modified == nil || r.Updated.After(*item.Modified)):
+			if localChanged {
+				conflicts++
+			}
+			if item.Done != theirs.Done {
+				item.CompletedAt = completionTime(theirs.Done)
+			}
+			item.Text, item.Done, item.Priority, item.Due = theirs.Text, theirs.Done, theirs.Priority, theirs.Due
+			item.Modified = nil
+			s.base[id] = theirs
+			pulled = true
+		default:
+			if remoteChanged {
+				conflicts++
+			}
+			ops = append(ops, b.pushOp(id, mine, theirs, open))
+		}
+	}
+
+	// Deleted here: delete there too, unless it was edited there since
+	for id, base := range s.base {
+		if local[id] {
+			continue
+		}
+		r, open := byID[id]
+		switch {
+		case !open:
+			delete(s.base, id) // Gone on both sides
+		case !base.equal(syncedFromRemote(r)) && r.Updated.After(s.lastSync):
+			conflicts++
+			b.todoItems = append(b.todoItems, b.todoFromRemote(r))
+			pulled = true
+		default:
+			ops = append(ops, syncOp{
+				run: func() error {
+					if err := b.taskSync.provider.Delete(id); err != nil && !errors.Is(err, errTaskNotFound) {
+						return err
+					}
+					return nil
+				},
+				apply: func() { delete(s.base, id) },
+			})
+		}
+	}
+
+	// New there
+	for _, r := range remote {
+		if _, known := s.base[r.ID]; !known && !local[r.ID] {
+			b.todoItems = append(b.todoItems, b.todoFromRemote(r))
+			pulled = true
+		}
+	}
+	return ops, pulled, conflicts
+}
+
+// todoFromRemote adds a remote task to the synced state. Called with b.mu held.
+func (b *Baseline) todoFromRemote(r RemoteTask) TodoItem {
+	b.taskSync.base[r.ID] = syncedFromRemote(r)
+	return TodoItem{Text: r.Text, Done: r.Done, Priority: r.Priority, Due: r.Due, SyncID: r.ID}
+}
+
+// createOp sends a new local todo. The todo is found again by its text,
+// since the list may be re-sorted or edited in the meantime.
+func (b *Baseline) createOp(task syncedTask) syncOp {
+	var id string
+	return syncOp{
+		run: func() (err error) {
+			id, err = b.taskSync.provider.Create(RemoteTask{Text: task.Text, Priority: task.Priority, Due: task.Due})
+			return err
+		},
+		apply: func() {
+			for i := range b.todoItems {
+				if item := &b.todoItems[i]; item.SyncID == "" && item.Text == task.Text {
+					item.SyncID = id
+					break
+				}
+			}
+			b.taskSync.base[id] = task
+		},
+	}
+}
+
+// pushOp sends a local edit of task id; theirs is its remote state. A todo
+// reopened here after its task was deleted there is created again.
+func (b *Baseline) pushOp(id string, mine, theirs syncedTask, open bool) syncOp {
+	provider := b.taskSync.provider
+	newID := ""
+	return syncOp{
+		run: func() (err error) {
+			if !open && !mine.Done {
+				err = provider.SetDone(id, false)
+				if errors.Is(err, errTaskNotFound) {
+					newID, err = provider.Create(RemoteTask{Text: mine.Text, Priority: mine.Priority, Due: mine.Due})
+					return err
+				}
+				if err != nil {
+					return err
+				}
+				theirs.Done = false
+			}
+			if mine.Text != theirs.Text || mine.Priority != theirs.Priority || !(syncedTask{Due: mine.Due}).equal(syncedTask{Due: theirs.Due}) {
+				if err := provider.Update(RemoteTask{ID: id, Text: mine.Text, Priority: mine.Priority, Due: mine.Due}); err != nil {
+					return err
+				}
+			}
+			if mine.Done && open {
+				return provider.SetDone(id, true)
+			}
+			return nil
+		},
+		apply: func() {
+			if newID != "" {
+				for i := range b.todoItems {
+					if b.todoItems[i].SyncID == id {
+						b.todoItems[i].SyncID = newID
+					}
+				}
+				delete(b.taskSync.base, id)
+				id = newID
+			}
+			for i := range b.todoItems {
+				if b.todoItems[i].SyncID == id {
+					b.todoItems[i].Modified = nil
+				}
+			}
+			b.taskSync.base[id] = mine
+		},
+	}
+}
+
+// todoSyncLabel is the sync status for the task list title. Called with b.mu held.
+func (b *Baseline) todoSyncLabel() string {
+	s := b.taskSync
+	switch {
+	case s == nil:
+		return ""
+	case s.lastErr != "":
+		return fmt.Sprintf("· %s ⚠ ", s.provider.Name())
+	case s.lastSync.IsZero():
+		return fmt.Sprintf("· %s … ", s.provider.Name())
+	}
+	return fmt.Sprintf("· %s ⟳ %s ", s.provider.Name(), s.lastSync.Format("15:04"))
+}
+
+// todoSyncCommand handles "todo sync [status]". Called with b.mu held.
+func (b *Baseline) todoSyncCommand(args []string) {
+	s := b.taskSync
+	if s == nil {
+		b.addNotification("Task sync is not configured (set TODO_SYNC=todoist or ticktick)", "error")
+		return
+	}
+	if len(args) > 0 && args[0] == "status" {
+		status := "never synced"
+		if !s.lastSync.IsZero() {
+			status = fmt.Sprintf("last sync %s, %d task(s) mirrored", s.lastSync.Format("15:04:05"), len(s.base))
+		}
+		if s.lastErr != "" {
+			status += ", error: " + s.lastErr
+		}
+		b.addNotification(fmt.Sprintf("%s: %s", s.provider.Name(), status), "info")
+		return
+	}
+	b.addNotification(fmt.Sprintf("Syncing tasks with %s...", s.provider.Name()), "info")
+	b.life.Go(b.syncTodos)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// --- Todo Tags ---
+//
+// A todo's tags are the #hashtags in its text (`todo add fix build #work`)
+// plus any in its tags field, set with `todo tag <index> <tag>`. `todo filter
+// #work` (or # cycling through the tags) shows only the todos with that tag;
+// indices stay those of the full list, so commands work the same filtered.
+
+var todoHashtag = regexp.MustCompile(`(?:^|\s)#([\p{L}\p{N}_-]+)`)
+
+// todoTags returns the item's tags, lowercased, without the #.
+func todoTags(item TodoItem) []string {
+	var tags []string
+	add := func(tag string) {
+		tag = strings.ToLower(strings.TrimPrefix(tag, "#"))
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	for _, m := range todoHashtag.FindAllStringSubmatch(item.Text, -1) {
+		add(m[1])
+	}
+	for _, tag := range item.Tags {
+		add(tag)
+	}
+	return tags
+}
+
+// todoShown reports whether item passes the tag filter. Called with b.mu held.
+func (b *Baseline) todoShown(item TodoItem) bool {
+	return b.todoFilter == "" || slices.Contains(todoTags(item), b.todoFilter)
+}
+
+// allTodoTags returns every tag in use, sorted. Called with b.mu held.
+func (b *Baseline) allTodoTags() []string {
+	var all []string
+	for _, item := range b.todoItems {
+		for _, tag := range todoTags(item) {
+			if !slices.Contains(all, tag) {
+				all = append(all, tag)
+			}
+		}
+	}
+	sort.Strings(all)
+	return all
+}
+
+// setTodoFilter shows only todos tagged tag, or all for "". Called with b.mu held.
+func (b *Baseline) setTodoFilter(tag string) {
+	b.todoFilter = tag
+	b.clampTodoCursor()
+	if tag == "" {
+		b.addNotification("Showing all tasks", "info")
+		return
+	}
+	count := 0
+	for _, item := range b.todoItems {
+		if b.todoShown(item) {
+			count++
+		}
+	}
+	b.addNotification(fmt.Sprintf("Showing %d tasks tagged #%s", count, tag), "info")
+}
+
+// cycleTodoFilter moves to the next tag view, back to all after the last.
+// Called with b.mu held.
+func (b *Baseline) cycleTodoFilter() {
+	tags := b.allTodoTags()
+	if len(tags) == 0 {
+		b.addNotification("No tagged tasks (add #tags to a task)", "info")
+		return
+	}
+	next := tags[0]
+	if i := slices.Index(tags, b.todoFilter); i >= 0 {
+		next = ""
+		if i+1 < len(tags) {
+			next = tags[i+1]
+		}
+	}
+	b.setTodoFilter(next)
+}
+
+// todoFilterCommand handles "todo filter [#tag|off]". Called with b.mu held.
+func (b *Baseline) todoFilterCommand(args []string) bool {
+	if len(args) == 0 || args[0] == "off" || args[0] == "all" {
+		b.setTodoFilter("")
+		return true
+	}
+	tag := strings.TrimPrefix(args[0], "#")
+	if !slices.Contains(b.allTodoTags(), tag) {
+		b.addNotification(fmt.Sprintf("No tasks tagged #%s (tags: %s)", tag, strings.Join(b.allTodoTags(), ", ")), "error")
+		return false
+	}
+	b.setTodoFilter(tag)
+	return true
+}
+
+// todoTagCommand handles "todo tag|untag <index> <tag>...". Hashtags in the
+// text can only be removed by editing it. Called with b.mu held.
+func (b *Baseline) todoTagCommand(args []string, add bool) bool {
+	verb := map[bool]string{true: "tag", false: "untag"}[add]
+	if len(args) < 2 {
+		b.addNotification(fmt.Sprintf("Usage: todo %s <index> <tag>...", verb), "error")
+		return false
+	}
+	index, err := strconv.Atoi(args[0])
+	if err != nil || index < 1 || index > len(b.todoItems) {
+		b.addNotification(fmt.Sprintf("Invalid todo index: %s", args[0]), "error")
+		return false
+	}
+	item := &b.todoItems[index-1]
+	for _, arg := range args[1:] {
+		tag := strings.ToLower(strings.TrimPrefix(arg, "#"))
+		if add && !slices.Contains(todoTags(*item), tag) {
+			item.Tags = append(item.Tags, tag)
+		} else if !add {
+			item.Tags = slices.DeleteFunc(item.Tags, func(t string) bool { return strings.EqualFold(t, tag) })
+		}
+	}
+	b.saveTodos()
+	tags := todoTags(*item)
+	if len(tags) == 0 {
+		b.addNotification(fmt.Sprintf("No tags left on: %s", item.Text), "success")
+	} else {
+		b.addNotification(fmt.Sprintf("Tags of %s: #%s", item.Text, strings.Join(tags, " #")), "success")
+	}
+	if b.todoFilter != "" {
+		b.clampTodoCursor() // The selection may have left the view
+	}
+	return true
+}
+
+// highlightHashtags brightens #tags in already escaped todo text.
+func highlightHashtags(text, tagColor, textColor string) string {
+	return todoHashtag.ReplaceAllStringFunc(text, func(m string) string {
+		i := strings.Index(m, "#")
+		return m[:i] + tagColor + m[i:] + textColor
+	})
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Audio Volume Widget ---
+
+const (
+	volumeRefreshInterval = 5 * time.Second
+	volumeDefaultStep     = 5
+)
+
+var (
+	pactlVolumeLine = regexp.MustCompile(`(\d+)%`)
+	amixerLevelLine = regexp.MustCompile(`\[(\d+)%\](?: \[[^\]]*dB\])? \[(on|off)\]`)
+)
+
+type VolumeInfo struct {
+	Device string
+	Level  int // Percent
+	Muted  bool
+	Error  string
+}
+
+type VolumeControl struct {
+	backend string // "pactl", "amixer" or "osascript"
+	step    int    // Percent per key press
+}
+
+// newVolumeControlFromEnv returns nil unless VOLUME is set ("pactl", "amixer",
+// "osascript" or "auto"/"true" to pick the first one available).
+func newVolumeControlFromEnv() *VolumeControl {
+	backend := strings.ToLower(os.Getenv("VOLUME"))
+	if backend == "" || backend == "off" || backend == "false" {
+		return nil
+	}
+	if backend == "auto" || backend == "true" {
+		backend = ""
+		if runtime.GOOS == "darwin" {
+			backend = "osascript"
+		} else {
+			for _, tool := range []string{"pactl", "amixer"} {
+				if _, err := exec.LookPath(tool); err == nil {
+					backend = tool
+					break
+				}
+			}
+		}
+		if backend == "" {
+			backend = "pactl" // Reported as an error in the panel
+		}
+	}
+
+	step := volumeDefaultStep
+	if n, err := strconv.Atoi(os.Getenv("VOLUME_STEP")); err == nil && n > 0 && n <= 100 {
+		step = n
+	}
+	return &VolumeControl{backend: backend, step: step}
+}
+
+func runOutput(name string, args ...string) (string, error) {
+	out, err := exec.Command(name, args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (c *VolumeControl) status() VolumeInfo {
+	var info VolumeInfo
+	var err error
+	switch c.backend {
+	case "pactl":
+		info, err = pactlStatus()
+	case "amixer":
+		info, err = amixerStatus()
+	case "osascript":
+		info, err = osascriptVolumeStatus()
+	default:
+		err = fmt.Errorf("unknown VOLUME backend %q", c.backend)
+	}
+	if err != nil {
+		info.Error = err.Error()
+	}
+	return info
+}
+
+func pactlStatus() (VolumeInfo, error) {
+	info := VolumeInfo{}
+	sink, err := runOutput("pactl", "get-default-sink")
+	if err != nil {
+		return info, err
+	}
+	info.Device = sink
+	vol, err := runOutput("pactl", "get-sink-volume", "@DEFAULT_SINK@")
+	if err != nil {
+		return info, err
+	}
+	if m := pactlVolumeLine.FindStringSubmatch(vol); m != nil {
+		info.Level, _ = strconv.Atoi(m[1]) // First channel
+	}
+	mute, err := runOutput("pactl", "get-sink-mute", "@DEFAULT_SINK@")
+	if err != nil {
+		return info, err
+	}
+	info.Muted = strings.HasSuffix(mute, "yes")
+
+	// Prefer the human readable description over the sink name
+	if sinks, err := runOutput("pactl", "list", "sinks"); err == nil {
+		current := false
+		for _, line := range strings.Split(sinks, "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(line, "Name: ") {
+				current = strings.TrimPrefix(line, "Name: ") == sink
+			} else if current && strings.HasPrefix(line, "Description: ") {
+				info.Device = strings.TrimPrefix(line, "Description: ")
+				break
+			}
+		}
+	}
+	return info, nil
+}
+
+func amixerStatus() (VolumeInfo, error) {
+	info := VolumeInfo{Device: "Master"}
+	out, err := runOutput("amixer", "get", "Master")
+	if err != nil {
+		return info, err
+	}
+	m := amixerLevelLine.FindStringSubmatch(out)
+	if m == nil {
+		return info, fmt.Errorf("amixer: unexpected output")
+	}
+	info.Level, _ = strconv.Atoi(m[1])
+	info.Muted = m[2] == "off"
+	return info, nil
+}
+
+func osascriptVolumeStatus() (VolumeInfo, error) {
+	info := VolumeInfo{Device: "Default output"}
+	out, err := runOutput("osascript", "-e", "set s to get volume settings",
+		"-e", "return (output volume of s as text) & \",\" & (output muted of s as text)")
+	if err != nil {
+		return info, err
+	}
+	level, muted, _ := strings.Cut(out, ",")
+	info.Level, _ = strconv.Atoi(level)
+	info.Muted = muted == "true"
+	return info, nil
+}
+
+// adjust changes the volume by delta percent.
+func (c *VolumeControl) adjust(delta int) error {
+	var cmd *exec.Cmd
+	switch c.backend {
+	case "pactl":
+		cmd = exec.Command("pactl", "set-sink-volume", "@DEFAULT_SINK@", fmt.Sprintf("%+d%%", delta))
+	case "amixer":
+		if delta >= 0 {
+			cmd = exec.Command("amixer", "-q", "set", "Master", fmt.Sprintf("%d%%+", delta))
+		} else {
+			cmd = exec.Command("amixer", "-q", "set", "Master", fmt.Sprintf("%d%%-", -delta))
+		}
+	case "osascript":
+		cmd = exec.Command("osascript", "-e", fmt.Sprintf("set volume output volume ((output volume of (get volume settings)) + %d)", delta))
+	default:
+		return fmt.Errorf("unknown VOLUME backend %q", c.backend)
+	}
+	return runControlCommand(cmd)
+}
+
+// set changes the volume to level percent, clamped to 0-100 (pactl would
+// happily go past 100).
+func (c *VolumeControl) set(level int) error {
+	info := c.status()
+	if info.Error != "" {
+		return fmt.Errorf("%s", info.Error)
+	}
+	return c.adjust(min(max(level, 0), 100) - info.Level)
+}
+
+// stepBy moves the volume up (dir 1) or down (dir -1) by one step.
+func (c *VolumeControl) stepBy(dir int) error {
+	info := c.status()
+	if info.Error != "" {
+		return fmt.Errorf("%s", info.Error)
+	}
+	return c.adjust(min(max(info.Level+dir*c.step, 0), 100) - info.Level)
+}
+
+func (c *VolumeControl) toggleMute() error {
+	var cmd *exec.Cmd
+	switch c.backend {
+	case "pactl":
+		cmd = exec.Command("pactl", "set-sink-mute", "@DEFAULT_SINK@", "toggle")
+	case "amixer":
+		cmd = exec.Command("amixer", "-q", "set", "Master", "toggle")
+	case "osascript":
+		cmd = exec.Command("osascript", "-e", "set volume output muted not (output muted of (get volume settings))")
+	default:
+		return fmt.Errorf("unknown VOLUME backend %q", c.backend)
+	}
+	return runControlCommand(cmd)
+}
+
+// runControlCommand runs a command that changes a setting, turning its output into the error.
+func runControlCommand(cmd *exec.Cmd) error {
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s: %s", cmd.Args[0], msg)
+		}
+		return fmt.Errorf("%s: %w", cmd.Args[0], err)
+	}
+	return nil
+}
+
+func (b *Baseline) fetchVolume() {
+	info := b.volume.status()
+
+	b.mu.Lock()
+	changed := info != b.volumeInfo
+	b.volumeInfo = info
+	b.mu.Unlock()
+
+	if changed {
+		b.updateVolume()
+	}
+}
+
+// changeVolume runs a volume change in the background and refreshes the panel.
+func (b *Baseline) changeVolume(change func() error) {
+	if err := change(); err != nil {
+		b.notify("volume", fmt.Sprintf("Volume change failed: %v", err), "error")
+	}
+	b.fetchVolume()
+}
+
+func (b *Baseline) updateVolume() {
+	b.mu.RLock()
+	info := b.volumeInfo
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sOUTPUT[-:-:-]\n", brightC+"[::b]"))
+	if info.Error != "" {
+		sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(info.Error)))
+	} else {
+		sb.WriteString(fmt.Sprintf("%s%s[-:-:-]\n\n", mainC, tview.Escape(info.Device)))
+		state := fmt.Sprintf("%s%d%%", brightC, info.Level)
+		if info.Muted {
+			state = "[red::b]MUTED"
+		}
+		sb.WriteString(fmt.Sprintf("%s %s[-:-:-]\n", createBar(float64(info.Level), 15, b.theme), state))
+	}
+	sb.WriteString(fmt.Sprintf("\n%s+/- volume  M mute[-:-:-]", dimC))
+
+	b.setPanel(b.volumePanel, sb.String())
+}
+
+// volumeCommand handles "vol [up|down|mute|<0-100>]". Called from processCommand with b.mu held.
+func (b *Baseline) volumeCommand(args []string) {
+	if b.volume == nil {
+		b.addNotification("Volume control not configured (set VOLUME)", "error")
+		return
+	}
+	if len(args) == 0 {
+		b.addNotification(fmt.Sprintf("Volume: %d%% on %s", b.volumeInfo.Level, b.volumeInfo.Device), "info")
+		return
+	}
+	switch args[0] {
+	case "up", "+":
+		go b.changeVolume(func() error { return b.volume.stepBy(1) })
+	case "down", "-":
+		go b.changeVolume(func() error { return b.volume.stepBy(-1) })
+	case "mute":
+		go b.changeVolume(b.volume.toggleMute)
+	default:
+		level, err := strconv.Atoi(strings.TrimSuffix(args[0], "%"))
+		if err != nil || level < 0 || level > 100 {
+			b.addNotification("Usage: vol [up|down|mute|<0-100>]", "error")
+			return
+		}
+		go b.changeVolume(func() error { return b.volume.set(level) })
+	}
+}
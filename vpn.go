@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	stdnet "net"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- VPN / Tailscale Status Widget ---
+
+const vpnRefreshInterval = 30 * time.Second
+
+// Interface name prefixes that usually belong to a VPN
+var defaultVPNPrefixes = []string{"tun", "tap", "wg", "utun", "ppp", "tailscale", "nordlynx", "proton"}
+
+type VPNInfo struct {
+	Up          bool
+	Backend     string // "tailscale" or the interface name
+	Addresses   []string
+	ExitNode    string
+	Peers       int
+	PeersOnline int
+	Error       string
+	LastUpdated time.Time
+}
+
+type VPNConfig struct {
+	mode     string   // "tailscale" or "interface"
+	prefixes []string // Interface prefixes for the "interface" mode
+}
+
+// newVPNConfigFromEnv returns nil unless VPN_STATUS is set ("tailscale", "interface" or "auto").
+func newVPNConfigFromEnv() *VPNConfig {
+	mode := strings.ToLower(os.Getenv("VPN_STATUS"))
+	if mode == "" || mode == "off" || mode == "false" {
+		return nil
+	}
+	if mode == "auto" || mode == "true" {
+		mode = "interface"
+		if _, err := exec.LookPath("tailscale"); err == nil {
+			mode = "tailscale"
+		}
+	}
+
+	return &VPNConfig{mode: mode, prefixes: vpnInterfacePrefixes()}
+}
+
+// vpnInterfacePrefixes returns VPN_INTERFACES, or the usual VPN interface names.
+func vpnInterfacePrefixes() []string {
+	if list := os.Getenv("VPN_INTERFACES"); list != "" {
+		return strings.Split(list, ",")
+	}
+	return defaultVPNPrefixes
+}
+
+func (c *VPNConfig) status() (VPNInfo, error) {
+	if c.mode == "tailscale" {
+		return tailscaleStatus()
+	}
+	return interfaceVPNStatus(c.prefixes)
+}
+
+func tailscaleStatus() (VPNInfo, error) {
+	info := VPNInfo{Backend: "tailscale"}
+
+	out, err := exec.Command("tailscale", "status", "--json").Output()
+	if err != nil {
+		return info, fmt.Errorf("tailscale status: %w", err)
+	}
+
+	type peer struct {
+		HostName     string   `json:"HostName"`
+		DNSName      string   `json:"DNSName"`
+		TailscaleIPs []string `json:"TailscaleIPs"`
+		Online       bool     `json:"Online"`
+		ExitNode     bool     `json:"ExitNode"`
+	}
+	var data struct {
+		BackendState string          `json:"BackendState"`
+		Self         peer            `json:"Self"`
+		Peer         map[string]peer `json:"Peer"`
+	}
+	if err := json.Unmarshal(out, &data); err != nil {
+		return info, fmt.Errorf("JSON parse error: %w", err)
+	}
+
+	info.Up = data.BackendState == "Running"
+	info.Addresses = data.Self.TailscaleIPs
+	for _, p := range data.Peer {
+		info.Peers++
+		if p.Online {
+			info.PeersOnline++
+		}
+		if p.ExitNode {
+			info.ExitNode = p.HostName
+		}
+	}
+	if !info.Up {
+		info.Error = data.BackendState
+	}
+	return info, nil
+}
+
+// interfaceVPNStatus treats any up interface matching one of the prefixes as an active VPN.
+func interfaceVPNStatus(prefixes []string) (VPNInfo, error) {
+	var info VPNInfo
+
+	ifaces, err := stdnet.Interfaces()
+	if err != nil {
+		return info, err
+	}
+	for _, iface := range ifaces {
+		if iface.Flags&stdnet.FlagUp == 0 || !hasAnyPrefix(iface.Name, prefixes) {
+			continue
+		}
+		addrs, _ := iface.Addrs()
+		if len(addrs) == 0 {
+			continue // Up but unconfigured (e.g. idle utun devices on macOS)
+		}
+		info.Up = true
+		info.Backend = iface.Name
+		for _, a := range addrs {
+			info.Addresses = append(info.Addresses, a.String())
+		}
+		break
+	}
+	return info, nil
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if p = strings.TrimSpace(p); p != "" && strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Baseline) fetchVPN() {
+	info, err := b.vpn.status()
+	info.LastUpdated = time.Now()
+	if err != nil {
+		info.Error = err.Error()
+	}
+
+	b.mu.Lock()
+	prev := b.vpnInfo
+	b.vpnInfo = info
+	b.mu.Unlock()
+
+	// Notify on transitions only, not on every poll
+	if !prev.LastUpdated.IsZero() {
+		if prev.Up && !info.Up {
+			b.notify("vpn", "VPN connection dropped", "error")
+		} else if !prev.Up && info.Up {
+			b.notify("vpn", "VPN connected", "success")
+		}
+	}
+	b.updateVPN()
+}
+
+func (b *Baseline) updateVPN() {
+	b.mu.RLock()
+	info := b.vpnInfo
+	b.mu.RUnlock()
+
+	mainC := colorTag(b.theme.Main)
+	dimC := colorTag(b.theme.Dim)
+	brightC := colorTag(b.theme.Bright)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sVPN STATUS[-:-:-]\n", brightC+"[::b]"))
+
+	if info.LastUpdated.IsZero() {
+		sb.WriteString(fmt.Sprintf("%sLoading...[-:-:-]\n", dimC))
+	} else if info.Up {
+		sb.WriteString(fmt.Sprintf("%sState: %sUP[-:-:-] %s(%s)[-:-:-]\n", mainC, brightC, dimC, info.Backend))
+		for _, addr := range info.Addresses {
+			sb.WriteString(fmt.Sprintf("%sAddress: %s[-:-:-]\n", mainC, addr))
+		}
+		if b.vpn.mode == "tailscale" {
+			exitNode := info.ExitNode
+			if exitNode == "" {
+				exitNode = "none"
+			}
+			sb.WriteString(fmt.Sprintf("%sExit node: %s[-:-:-]\n", mainC, tview.Escape(exitNode)))
+			sb.WriteString(fmt.Sprintf("%sPeers: %d online / %d[-:-:-]\n", dimC, info.PeersOnline, info.Peers))
+		}
+	} else {
+		sb.WriteString(fmt.Sprintf("%sState: [red]DOWN[-:-:-]\n", mainC))
+		if info.Error != "" {
+			sb.WriteString(fmt.Sprintf("[red]%s[-:-:-]\n", tview.Escape(info.Error)))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%sLast updated: %s[-:-:-]", dimC, info.LastUpdated.Format("15:04:05")))
+
+	b.setPanel(b.vpnPanel, sb.String())
+}
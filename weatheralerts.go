@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Weather Warnings ---
+//
+// WeatherAPI.com returns the official warnings for a location (alerts=yes).
+// Open-Meteo and OpenWeatherMap's free plan have none, so for them
+// forecastWarnings raises its own from the daily forecast of today and
+// tomorrow: thunderstorms, extreme heat, heavy rain that may flood and
+// damaging wind gusts. Active warnings head the weather panel, and each new
+// one is a high priority notification naming the location.
+
+const (
+	warnHeatC         = 35.0 // Daily maximum
+	warnHeatSevereC   = 40.0
+	warnRainMM        = 50.0 // Daily total
+	warnRainSevereMM  = 100.0
+	warnGustKph       = 75.0 // Strongest gust of the day
+	warnGustSevereKph = 100.0
+	warnForecastDays  = 2 // Today and tomorrow
+)
+
+// forecastWarnings derives warnings from the first days of a forecast.
+func forecastWarnings(days []ForecastDay, now time.Time) []WeatherWarning {
+	var warnings []WeatherWarning
+	add := func(d ForecastDay, event, severity, headline string) {
+		end := time.Date(d.Date.Year(), d.Date.Month(), d.Date.Day()+1, 0, 0, 0, 0, now.Location())
+		warnings = append(warnings, WeatherWarning{
+			Headline: headline + " on " + d.Date.Format("Mon Jan 2"),
+			Event:    event + " on " + d.Date.Format("Mon"),
+			Severity: severity,
+			Expires:  end.Format(time.RFC3339),
+		})
+	}
+	severity := func(value, severe float64) string {
+		if value >= severe {
+			return "Severe"
+		}
+		return "Moderate"
+	}
+	for _, d := range days[:min(warnForecastDays, len(days))] {
+		if d.Kind == WeatherThunder {
+			level := "Moderate"
+			if strings.Contains(strings.ToLower(d.Condition), "hail") {
+				level = "Severe"
+			}
+			add(d, "Thunderstorms", level, d.Condition+" forecast")
+		}
+		if d.MaxC >= warnHeatC {
+			add(d, "Extreme heat", severity(d.MaxC, warnHeatSevereC), fmt.Sprintf("Highs of %.0f°C forecast", d.MaxC))
+		}
+		if d.PrecipMM >= warnRainMM {
+			add(d, "Heavy rain, flooding possible", severity(d.PrecipMM, warnRainSevereMM), fmt.Sprintf("%.0f mm of rain forecast", d.PrecipMM))
+		}
+		if d.GustKph >= warnGustKph {
+			event := "Strong wind"
+			if d.GustKph >= warnGustSevereKph {
+				event = "Storm"
+			}
+			add(d, event, severity(d.GustKph, warnGustSevereKph), fmt.Sprintf("Gusts of %.0f km/h forecast", d.GustKph))
+		}
+	}
+	return warnings
+}
+
+// expiry parses Expires, zero when it is missing or unreadable.
+func (w WeatherWarning) expiry() time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04"} {
+		if t, err := time.Parse(layout, w.Expires); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// severe reports whether a warning is at the top of its scale.
+func (w WeatherWarning) severe() bool {
+	s := strings.ToLower(w.Severity)
+	return s == "severe" || s == "extreme"
+}
+
+// activeWarnings drops the warnings that have expired, e.g. in a report
+// from the weather cache.
+func activeWarnings(warnings []WeatherWarning, now time.Time) []WeatherWarning {
+	var active []WeatherWarning
+	for _, w := range warnings {
+		if exp := w.expiry(); exp.IsZero() || exp.After(now) {
+			active = append(active, w)
+		}
+	}
+	return active
+}
+
+// renderWeatherWarnings draws the banner at the top of the weather panel.
+func renderWeatherWarnings(warnings []WeatherWarning, theme Theme) string {
+	dimC := colorTag(theme.Dim)
+	var sb strings.Builder
+	for _, w := range activeWarnings(warnings, time.Now()) {
+		color := "[yellow::b]"
+		if w.severe() {
+			color = strings.TrimSuffix(notificationColor("error", theme), "]") + "::b]"
+		}
+		sb.WriteString(fmt.Sprintf("%s⚠ %s[-:-:-]", color, tview.Escape(w.title())))
+		if exp := w.expiry(); !exp.IsZero() {
+			sb.WriteString(fmt.Sprintf(" %suntil %s[-:-:-]", dimC, exp.Local().Format("Mon 15:04")))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
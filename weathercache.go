@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// --- Weather Cache ---
+//
+// The last successful report for each location is kept in
+// ~/.baseline/weather_cache.json. It fills the weather panel at startup,
+// before the first fetch returns, and stands in whenever a fetch fails (no
+// network, provider down, no API key), marked "updated 43m ago (cached)"
+// instead of an error or the sample data.
+
+const weatherCacheFileName = "weather_cache.json"
+
+// weatherCacheKey is how a location is looked up in the cache.
+func weatherCacheKey(location string) string {
+	return strings.ToLower(strings.TrimSpace(location))
+}
+
+// loadWeatherCache reads the cache and shows it until the first fetch.
+// Called from NewBaseline after loadWeatherLocations.
+func (b *Baseline) loadWeatherCache() {
+	b.weatherCache = map[string]WeatherInfo{}
+	data, err := os.ReadFile(filepath.Join(b.configDir, weatherCacheFileName))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			b.addNotification(fmt.Sprintf("Error loading %s: %v", weatherCacheFileName, err), "error")
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &b.weatherCache); err != nil {
+		b.addNotification(fmt.Sprintf("Error parsing %s: %v", weatherCacheFileName, err), "error")
+		b.weatherCache = map[string]WeatherInfo{}
+		return
+	}
+
+	all := make([]WeatherInfo, len(b.weatherLocs))
+	for i, location := range b.weatherLocs {
+		all[i] = b.cachedWeather(WeatherInfo{Location: location}, location)
+	}
+	b.weatherAll = all
+	b.showWeatherLocation(b.weatherIndex)
+}
+
+// cachedWeather returns the cached report for location, marked as cached,
+// or info when there is none. Called with b.mu held.
+func (b *Baseline) cachedWeather(info WeatherInfo, location string) WeatherInfo {
+	cached, ok := b.weatherCache[weatherCacheKey(location)]
+	if !ok {
+		return info
+	}
+	cached.Cached = true
+	return cached
+}
+
+// cacheWeather stores the successful reports in all and writes the cache,
+// then swaps failed ones for their cached report. Called with b.mu held.
+func (b *Baseline) cacheWeather(locations []string, all []WeatherInfo) {
+	changed := false
+	for i, info := range all {
+		if info.Error == "" {
+			b.weatherCache[weatherCacheKey(locations[i])] = info
+			changed = true
+		} else {
+			all[i] = b.cachedWeather(info, locations[i])
+		}
+	}
+	if !changed {
+		return
+	}
+	data, err := json.Marshal(b.weatherCache)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(b.configDir, weatherCacheFileName), data, 0640)
+	}
+	if err != nil {
+		b.addNotification(fmt.Sprintf("Error saving %s: %v", weatherCacheFileName, err), "error")
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// --- Weather Art ---
+//
+// wttr.in-style pictures of the current condition, drawn beside the
+// current weather. Each WeatherKind has one; the sun and lightning take the
+// theme's bright color, clouds its main color and rain, snow and fog its dim
+// color. In the art, {s}, {c} and {p} switch to those colors.
+
+const weatherArtWidth = 15
+
+var weatherArtSun = []string{
+	"{s}    \\   /    ",
+	"{s}     .-.     ",
+	"{s}  ― (   ) ―  ",
+	"{s}     `-’     ",
+	"{s}    /   \\    ",
+}
+
+var weatherArtMoon = []string{
+	"{s}     .--.    ",
+	"{s}    / .-'    ",
+	"{s}   | (       ",
+	"{s}    \\ '-.    ",
+	"{s}     '--'    ",
+}
+
+var weatherArt = map[WeatherKind][]string{
+	WeatherPartlyCloudy: {
+		"{s}   \\  /      ",
+		"{s} _ /\"\"{c}.-.    ",
+		"{s}   \\_{c}(   ).  ",
+		"{s}   /{c}(___(__) ",
+		"             ",
+	},
+	WeatherCloudy: {
+		"             ",
+		"{c}     .--.    ",
+		"{c}  .-(    ).  ",
+		"{c} (___.__)__) ",
+		"             ",
+	},
+	WeatherFog: {
+		"             ",
+		"{p} _ - _ - _ - ",
+		"{p}  _ - _ - _  ",
+		"{p} _ - _ - _ - ",
+		"             ",
+	},
+	WeatherDrizzle: {
+		"{c}     .-.     ",
+		"{c}    (   ).   ",
+		"{c}   (___(__)  ",
+		"{p}    ‘ ‘ ‘ ‘  ",
+		"{p}   ‘ ‘ ‘ ‘   ",
+	},
+	WeatherRain: {
+		"{c}     .-.     ",
+		"{c}    (   ).   ",
+		"{c}   (___(__)  ",
+		"{p}  ‚‘‚‘‚‘‚‘   ",
+		"{p}  ‚’‚’‚’‚’   ",
+	},
+	WeatherSleet: {
+		"{c}     .-.     ",
+		"{c}    (   ).   ",
+		"{c}   (___(__)  ",
+		"{p}    ‘ * ‘ *  ",
+		"{p}   * ‘ * ‘   ",
+	},
+	WeatherSnow: {
+		"{c}     .-.     ",
+		"{c}    (   ).   ",
+		"{c}   (___(__)  ",
+		"{p}    *  *  *  ",
+		"{p}   *  *  *   ",
+	},
+	WeatherThunder: {
+		"{c}     .-.     ",
+		"{c}    (   ).   ",
+		"{c}   (___(__)  ",
+		"{p}  ‚‘{s}ϟ{p}‘‚{s}ϟ{p}‚‘   ",
+		"{p}  ‚’‚’{s}ϟ{p}’‚’   ",
+	},
+	WeatherUnknown: {
+		"{c}    .-.      ",
+		"{c}     __)     ",
+		"{c}    (        ",
+		"{c}     `-’     ",
+		"{c}      •      ",
+	},
+}
+
+// renderWeatherArt returns the five lines of the picture for a condition,
+// each weatherArtWidth cells wide and colored for theme.
+func renderWeatherArt(kind WeatherKind, isDay bool, theme Theme) []string {
+	art, ok := weatherArt[kind]
+	if kind == WeatherClear {
+		art, ok = weatherArtSun, true
+		if !isDay {
+			art = weatherArtMoon
+		}
+	}
+	if !ok {
+		art = weatherArt[WeatherUnknown]
+	}
+	colors := strings.NewReplacer("{s}", colorTag(theme.Bright), "{c}", colorTag(theme.Main), "{p}", colorTag(theme.Dim))
+	lines := make([]string, len(art))
+	for i, line := range art {
+		line = colors.Replace(line)
+		if w := tview.TaggedStringWidth(line); w < weatherArtWidth {
+			line += strings.Repeat(" ", weatherArtWidth-w)
+		}
+		lines[i] = line + "[-:-:-]"
+	}
+	return lines
+}
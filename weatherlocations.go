@@ -0,0 +1,284 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- Multiple Weather Locations ---
+//
+// `weather add Berlin` adds a location; all of them are fetched together.
+// In "rotate" mode the weather panel cycles through them every
+// rotate_every; in "split" mode it shows them side by side in columns.
+// The list is kept in ~/.baseline/weather.json and replaces WEATHER_LOCATION
+// once it exists, along with the coordinates of places picked from a search
+// (see weathersearch.go).
+
+const (
+	weatherFileName      = "weather.json"
+	weatherRotateDefault = 5 * time.Minute
+	weatherRotateCheck   = 5 * time.Second
+	weatherColumnMin     = 18 // Narrowest useful split column
+)
+
+type weatherPlaces struct {
+	Locations []string `json:"locations"`
+	Mode      string   `json:"mode"`         // "rotate" or "split"
+	Rotate    string   `json:"rotate_every"` // Go duration
+	Units     string   `json:"units,omitempty"`
+
+	Places map[string]geoPlace `json:"places,omitempty"` // Searched locations by name
+}
+
+// loadWeatherLocations reads weather.json, falling back to the single
+// configured location. Called from NewBaseline.
+func (b *Baseline) loadWeatherLocations() {
+	b.weatherLocs = []string{b.weatherLocation}
+	b.weatherMode = "rotate"
+	b.weatherRotate = weatherRotateDefault
+	b.weatherCoords = map[string]geoPlace{}
+
+	data, err := os.ReadFile(filepath.Join(b.configDir, weatherFileName))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			b.addNotification(fmt.Sprintf("Error loading %s: %v", weatherFileName, err), "error")
+		}
+		return
+	}
+	var places weatherPlaces
+	if err := json.Unmarshal(data, &places); err != nil {
+		b.addNotification(fmt.Sprintf("Error parsing %s: %v", weatherFileName, err), "error")
+		return
+	}
+	if len(places.Locations) > 0 {
+		b.weatherLocs = places.Locations
+		b.weatherLocation = places.Locations[0]
+	}
+	if places.Mode == "split" {
+		b.weatherMode = "split"
+	}
+	if d, err := time.ParseDuration(places.Rotate); err == nil && d >= time.Minute {
+		b.weatherRotate = d
+	}
+	for name, p := range places.Places {
+		b.weatherCoords[name] = p
+	}
+	if u, ok := parseWeatherUnits(places.Units); ok {
+		b.weatherUnits = u // Set with `weather units`
+	}
+}
+
+// saveWeatherLocations writes weather.json. Called with b.mu held.
+func (b *Baseline) saveWeatherLocations() {
+	coords := map[string]geoPlace{}
+	for _, loc := range b.weatherLocs {
+		if p, ok := b.weatherCoords[loc]; ok {
+			coords[loc] = p // Removed locations are dropped
+		}
+	}
+	data, err := json.MarshalIndent(weatherPlaces{
+		Locations: b.weatherLocs,
+		Mode:      b.weatherMode,
+		Rotate:    b.weatherRotate.String(),
+		Units:     string(b.weatherUnits),
+		Places:    coords,
+	}, "", "  ")
+	if err == nil {
+		err = os.WriteFile(filepath.Join(b.configDir, weatherFileName), data, 0640)
+	}
+	if err != nil {
+		b.addNotification(fmt.Sprintf("Error saving %s: %v", weatherFileName, err), "error")
+	}
+}
+
+// showWeatherLocation makes location i the one in the weather panel.
+// Called with b.mu held.
+func (b *Baseline) showWeatherLocation(i int) {
+	b.weatherIndex = i
+	b.weatherLocation = b.weatherLocs[i]
+	b.weatherShown = time.Now()
+	if i < len(b.weatherAll) {
+		b.weatherInfo = b.weatherAll[i]
+	}
+}
+
+// rotateWeather moves to the next location once rotate_every has passed.
+// Scheduled every weatherRotateCheck.
+func (b *Baseline) rotateWeather() {
+	b.mu.Lock()
+	if b.weatherMode != "rotate" || len(b.weatherLocs) < 2 || time.Since(b.weatherShown) < b.weatherRotate {
+		b.mu.Unlock()
+		return
+	}
+	b.showWeatherLocation((b.weatherIndex + 1) % len(b.weatherLocs))
+	b.mu.Unlock()
+	b.updateWeather()
+}
+
+// findWeatherLocation matches a 1-based index or a location name.
+func (b *Baseline) findWeatherLocation(arg string) int {
+	if n, err := strconv.Atoi(arg); err == nil {
+		if n >= 1 && n <= len(b.weatherLocs) {
+			return n - 1
+		}
+		return -1
+	}
+	for i, loc := range b.weatherLocs {
+		if strings.EqualFold(loc, arg) {
+			return i
+		}
+	}
+	return -1
+}
+
+// weatherCommand handles the weather subcommands; words keep their case so
+// location names are stored as typed. Called from processCommand with b.mu
+// held. fetch asks for a new fetch, redraw for just redrawing the panel.
+func (b *Baseline) weatherCommand(words []string) (fetch, redraw bool) {
+	usage := "Usage: weather set|add|remove <location> | list | next | split | rotate [interval] | units [f|c]"
+	if len(words) == 0 {
+		b.addNotification(usage, "error")
+		return false, false
+	}
+	sub, rest := strings.ToLower(words[0]), strings.Join(words[1:], " ")
+	switch sub {
+	case "set", "add":
+		if rest == "" {
+			break
+		}
+		index := b.weatherIndex
+		if sub == "add" {
+			if b.findWeatherLocation(rest) >= 0 {
+				b.addNotification(fmt.Sprintf("%s is already in the list", rest), "info")
+				return false, false
+			}
+			index = -1
+		}
+		if _, _, ok := parseLatLon(rest); ok {
+			return b.storeWeatherLocation(index, rest, nil), false
+		}
+		b.addNotification(fmt.Sprintf("Looking up %s...", rest), "info")
+		go b.searchWeatherLocation(rest, index) // Stores it, or opens the picker
+		return false, false
+	case "remove", "rm":
+		i := b.findWeatherLocation(rest)
+		switch {
+		case i < 0:
+			b.addNotification(fmt.Sprintf("Unknown weather location: %s", rest), "error")
+		case len(b.weatherLocs) == 1:
+			b.addNotification("Can't remove the only weather location (use weather set)", "error")
+		default:
+			removed := b.weatherLocs[i]
+			b.weatherLocs = append(b.weatherLocs[:i], b.weatherLocs[i+1:]...)
+			if i < len(b.weatherAll) {
+				b.weatherAll = append(b.weatherAll[:i], b.weatherAll[i+1:]...)
+			}
+			b.showWeatherLocation(min(b.weatherIndex, len(b.weatherLocs)-1))
+			b.saveWeatherLocations()
+			b.addNotification(fmt.Sprintf("Removed weather location %s", removed), "success")
+			return false, true
+		}
+		return false, false
+	case "list":
+		var names []string
+		for i, loc := range b.weatherLocs {
+			if i == b.weatherIndex {
+				loc += " (shown)"
+			}
+			names = append(names, fmt.Sprintf("%d. %s", i+1, loc))
+		}
+		b.addNotification(fmt.Sprintf("Weather (%s): %s", b.weatherMode, strings.Join(names, ", ")), "info")
+		return false, false
+	case "next":
+		b.showWeatherLocation((b.weatherIndex + 1) % len(b.weatherLocs))
+		return false, true
+	case "split":
+		b.weatherMode = "split"
+		b.saveWeatherLocations()
+		b.addNotification("Weather panel shows all locations side by side", "success")
+		return false, true
+	case "units":
+		return false, b.weatherUnitsCommand(rest)
+	case "rotate":
+		if rest != "" {
+			d, err := time.ParseDuration(rest)
+			if err != nil || d < time.Minute {
+				b.addNotification("Rotation interval must be a duration of at least 1m (e.g. 5m)", "error")
+				return false, false
+			}
+			b.weatherRotate = d
+		}
+		b.weatherMode = "rotate"
+		b.weatherShown = time.Now()
+		b.saveWeatherLocations()
+		b.addNotification(fmt.Sprintf("Weather panel rotates locations every %s", b.weatherRotate), "success")
+		return false, true
+	}
+	b.addNotification(usage, "error")
+	return false, false
+}
+
+// renderWeatherColumns draws one column per location for split mode.
+func renderWeatherColumns(infos []WeatherInfo, width int, units WeatherUnits, theme Theme) string {
+	mainC := colorTag(theme.Main)
+	dimC := colorTag(theme.Dim)
+	brightC := colorTag(theme.Bright)
+
+	perRow := max(1, min(len(infos), width/weatherColumnMin))
+	colWidth := max(weatherColumnMin, width/perRow)
+	pad := func(s string) string {
+		if w := tview.TaggedStringWidth(s); w < colWidth {
+			return s + strings.Repeat(" ", colWidth-w)
+		}
+		return s
+	}
+	clip := func(s string) string {
+		if r := []rune(s); len(r) > colWidth-1 {
+			return string(r[:colWidth-2]) + "…"
+		}
+		return s
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sWEATHER REPORT[-:-:-]\n", brightC+"[::b]"))
+	for start := 0; start < len(infos); start += perRow {
+		group := infos[start:min(start+perRow, len(infos))]
+		var rows [5][]string
+		for _, info := range group {
+			lines := [5]string{
+				brightC + tview.Escape(clip(info.Location)),
+			}
+			if info.Cached {
+				lines[0] = brightC + tview.Escape(clip(info.Location+" (cached)"))
+			}
+			if info.Error != "" {
+				lines[1] = "[red]" + tview.Escape(clip(info.Error))
+			} else {
+				lines[1] = mainC + units.Temp(info.TempC)
+				lines[2] = mainC + tview.Escape(clip(info.Condition))
+				lines[3] = fmt.Sprintf("%s%d%% %s", dimC, info.Humidity, units.Wind(info.WindKph))
+				if len(info.Days) > 0 {
+					lines[4] = fmt.Sprintf("%s%.0f°/%.0f° %d%%", dimC, units.Degrees(info.Days[0].MinC), units.Degrees(info.Days[0].MaxC), info.Days[0].RainChance)
+				}
+				if warnings := activeWarnings(info.Warnings, time.Now()); len(warnings) > 0 {
+					lines[4] = "[red::b]⚠ " + tview.Escape(clip(warnings[0].title())) // Instead of the forecast
+				}
+			}
+			for i, line := range lines {
+				rows[i] = append(rows[i], pad(line+"[-:-:-]"))
+			}
+		}
+		sb.WriteString("\n")
+		for _, row := range rows {
+			sb.WriteString(strings.TrimRight(strings.Join(row, ""), " ") + "\n")
+		}
+	}
+	return sb.String()
+}
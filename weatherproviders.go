@@ -0,0 +1,613 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// --- Weather Providers ---
+//
+// The weather panel can use weatherapi.com, Open-Meteo (no account needed)
+// or OpenWeatherMap. WEATHER_PROVIDER, or provider in config.toml's
+// [weather] section, picks one:
+//
+//	[weather]
+//	provider = "open-meteo"   # weatherapi, open-meteo or openweathermap
+//	api_key = "..."           # Or WEATHER_API_KEY; not needed for Open-Meteo
+//	units = "imperial"        # Or WEATHER_UNITS, see weatherunits.go
+//
+// Without a choice, weatherapi.com is used when WEATHER_API_KEY is set and
+// Open-Meteo otherwise. Every provider's condition codes are mapped to a
+// WeatherKind, which picks the icons.
+
+// WeatherKind is a provider independent weather condition.
+type WeatherKind string
+
+const (
+	WeatherClear        WeatherKind = "clear"
+	WeatherPartlyCloudy WeatherKind = "partly-cloudy"
+	WeatherCloudy       WeatherKind = "cloudy"
+	WeatherFog          WeatherKind = "fog"
+	WeatherDrizzle      WeatherKind = "drizzle"
+	WeatherRain         WeatherKind = "rain"
+	WeatherSleet        WeatherKind = "sleet" // Also freezing rain and ice pellets
+	WeatherSnow         WeatherKind = "snow"
+	WeatherThunder      WeatherKind = "thunder"
+	WeatherUnknown      WeatherKind = ""
+)
+
+// WeatherProvider fetches current conditions and the forecast for a location,
+// and looks up places by name (see weathersearch.go).
+type WeatherProvider interface {
+	Name() string
+	Forecast(location string) (WeatherInfo, error)
+	SearchLocations(query string) ([]geoPlace, error)
+}
+
+// WeatherConfig is the [weather] section of config.toml.
+type WeatherConfig struct {
+	Provider string `toml:"provider"`
+	APIKey   string `toml:"api_key"`
+	Units    string `toml:"units"`
+}
+
+// weatherProviderFor picks the provider. needsKey names the setting to fill
+// in when the provider can't work without a key; an unknown provider name is
+// reported as an error and Open-Meteo is used instead.
+func weatherProviderFor(cfg WeatherConfig) (p WeatherProvider, needsKey string, err error) {
+	key := cfg.APIKey
+	if key == "" {
+		key = os.Getenv("WEATHER_API_KEY")
+	}
+	if key == "YOUR_API_KEY" {
+		key = "" // The placeholder from the example .env
+	}
+	name := strings.ToLower(strings.TrimSpace(os.Getenv("WEATHER_PROVIDER")))
+	if name == "" {
+		name = strings.ToLower(strings.TrimSpace(cfg.Provider))
+	}
+	if name == "" {
+		name = "open-meteo"
+		if key != "" {
+			name = "weatherapi"
+		}
+	}
+
+	client := http.Client{Timeout: 10 * time.Second}
+	switch name {
+	case "weatherapi", "weatherapi.com":
+		p = &weatherAPIProvider{key: key, client: client}
+	case "openweathermap", "owm":
+		p = &openWeatherMapProvider{key: key, client: client}
+	case "open-meteo", "openmeteo":
+		return &openMeteoProvider{client: client, places: map[string]geoPlace{}}, "", nil
+	default:
+		return &openMeteoProvider{client: client, places: map[string]geoPlace{}}, "",
+			fmt.Errorf("unknown weather provider %q (weatherapi, open-meteo or openweathermap), using Open-Meteo", name)
+	}
+	if key == "" {
+		needsKey = "WEATHER_API_KEY"
+	}
+	return p, needsKey, nil
+}
+
+// sampleWeather stands in while a provider has no API key.
+func sampleWeather(location string) WeatherInfo {
+	return WeatherInfo{
+		Location:  location,
+		TempC:     22.0,
+		Condition: "Partly Cloudy (Sample)",
+		Kind:      WeatherPartlyCloudy,
+		IsDay:     true,
+		Humidity:  65,
+		WindKph:   8.0,
+		Error:     "API Key not set",
+	}
+}
+
+// getWeatherJSON fetches url into out. apiError extracts the provider's
+// error message from a failed response.
+func getWeatherJSON(client http.Client, endpoint string, out interface{}, apiError func(*json.Decoder) string) error {
+	resp, err := client.Get(endpoint)
+	if err != nil {
+		return fmt.Errorf("HTTP error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if msg := apiError(json.NewDecoder(resp.Body)); msg != "" {
+			return fmt.Errorf("API error: %s (%d)", msg, resp.StatusCode)
+		}
+		return fmt.Errorf("API error: Status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("JSON parse error: %w", err)
+	}
+	return nil
+}
+
+// --- weatherapi.com ---
+
+type weatherAPIProvider struct {
+	key    string
+	client http.Client
+}
+
+func (w *weatherAPIProvider) Name() string { return "weatherapi.com" }
+
+func weatherAPIError(dec *json.Decoder) string {
+	var errResp struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	dec.Decode(&errResp)
+	return errResp.Error.Message
+}
+
+func (w *weatherAPIProvider) Forecast(location string) (WeatherInfo, error) {
+	if w.key == "" {
+		return sampleWeather(location), nil
+	}
+	endpoint := fmt.Sprintf("https://api.weatherapi.com/v1/forecast.json?key=%s&q=%s&days=%d&alerts=yes",
+		url.QueryEscape(w.key), url.QueryEscape(location), forecastDays) // Locations may contain spaces
+	var data struct {
+		Location struct {
+			Name string `json:"name"`
+		} `json:"location"`
+		Current struct {
+			TempC     float64 `json:"temp_c"`
+			IsDay     int     `json:"is_day"`
+			Condition struct {
+				Text string `json:"text"`
+				Code int    `json:"code"`
+			} `json:"condition"`
+			Humidity int     `json:"humidity"`
+			WindKph  float64 `json:"wind_kph"`
+			PrecipMM float64 `json:"precip_mm"`
+		} `json:"current"`
+		Forecast forecastResponse `json:"forecast"`
+		Alerts   struct {
+			Alert []WeatherWarning `json:"alert"`
+		} `json:"alerts"`
+	}
+	if err := getWeatherJSON(w.client, endpoint, &data, weatherAPIError); err != nil {
+		return WeatherInfo{}, err
+	}
+
+	info := WeatherInfo{
+		Location:  data.Location.Name, // Use name from API response
+		TempC:     data.Current.TempC,
+		Condition: data.Current.Condition.Text,
+		Kind:      weatherAPIKind(data.Current.Condition.Code),
+		IsDay:     data.Current.IsDay == 1,
+		Humidity:  data.Current.Humidity,
+		WindKph:   data.Current.WindKph,
+		PrecipMM:  data.Current.PrecipMM,
+		Warnings:  data.Alerts.Alert,
+	}
+	info.Days, info.Hours = data.Forecast.forecast(time.Now())
+	return info, nil
+}
+
+// weatherAPIKind maps a weatherapi.com condition code.
+func weatherAPIKind(code int) WeatherKind {
+	switch code {
+	case 1000:
+		return WeatherClear
+	case 1003:
+		return WeatherPartlyCloudy
+	case 1006, 1009:
+		return WeatherCloudy
+	case 1030, 1135, 1147:
+		return WeatherFog
+	case 1087, 1273, 1276, 1279, 1282:
+		return WeatherThunder
+	case 1066, 1114, 1117, 1210, 1213, 1216, 1219, 1222, 1225, 1255, 1258:
+		return WeatherSnow
+	case 1069, 1072, 1168, 1171, 1198, 1201, 1204, 1207, 1237, 1249, 1252, 1261, 1264:
+		return WeatherSleet
+	case 1150, 1153:
+		return WeatherDrizzle
+	}
+	if code >= 1063 && code <= 1246 {
+		return WeatherRain
+	}
+	return WeatherUnknown
+}
+
+// --- Open-Meteo ---
+
+// geoPlace is a geocoded location; Open-Meteo only takes coordinates.
+type geoPlace struct {
+	Name    string  `json:"name"`
+	Region  string  `json:"region,omitempty"` // State or province
+	Country string  `json:"country,omitempty"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+}
+
+// parseLatLon reads a "lat,lon" location.
+func parseLatLon(location string) (lat, lon float64, ok bool) {
+	la, lo, found := strings.Cut(location, ",")
+	if !found {
+		return 0, 0, false
+	}
+	lat, err1 := strconv.ParseFloat(strings.TrimSpace(la), 64)
+	lon, err2 := strconv.ParseFloat(strings.TrimSpace(lo), 64)
+	return lat, lon, err1 == nil && err2 == nil
+}
+
+type openMeteoProvider struct {
+	client http.Client
+
+	mu     sync.Mutex
+	places map[string]geoPlace // Geocoding results by location
+}
+
+func (o *openMeteoProvider) Name() string { return "Open-Meteo" }
+
+// place resolves a location name, or "lat,lon", to coordinates.
+func (o *openMeteoProvider) place(location string) (geoPlace, error) {
+	if lat, lon, ok := parseLatLon(location); ok {
+		return geoPlace{Name: location, Lat: lat, Lon: lon}, nil
+	}
+	o.mu.Lock()
+	p, ok := o.places[location]
+	o.mu.Unlock()
+	if ok {
+		return p, nil
+	}
+
+	found, err := searchOpenMeteo(o.client, location, 1)
+	if err != nil {
+		return geoPlace{}, err
+	}
+	if len(found) == 0 {
+		return geoPlace{}, fmt.Errorf("location %q not found", location)
+	}
+	p = found[0]
+	o.mu.Lock()
+	o.places[location] = p
+	o.mu.Unlock()
+	return p, nil
+}
+
+func openMeteoError(dec *json.Decoder) string {
+	var errResp struct {
+		Reason string `json:"reason"`
+	}
+	dec.Decode(&errResp)
+	return errResp.Reason
+}
+
+func (o *openMeteoProvider) Forecast(location string) (WeatherInfo, error) {
+	place, err := o.place(location)
+	if err != nil {
+		return WeatherInfo{}, err
+	}
+	query := url.Values{}
+	query.Set("latitude", strconv.FormatFloat(place.Lat, 'f', 4, 64))
+	query.Set("longitude", strconv.FormatFloat(place.Lon, 'f', 4, 64))
+	query.Set("current", "temperature_2m,relative_humidity_2m,weather_code,wind_speed_10m,precipitation,is_day")
+	query.Set("hourly", "temperature_2m,precipitation_probability,weather_code,is_day")
+	query.Set("daily", "weather_code,temperature_2m_max,temperature_2m_min,precipitation_probability_max,precipitation_sum,wind_gusts_10m_max")
+	query.Set("forecast_days", strconv.Itoa(forecastDays))
+	query.Set("timezone", "auto")
+	query.Set("timeformat", "unixtime")
+
+	var data struct {
+		Current struct {
+			Temp     float64 `json:"temperature_2m"`
+			Humidity int     `json:"relative_humidity_2m"`
+			Code     int     `json:"weather_code"`
+			Wind     float64 `json:"wind_speed_10m"` // km/h by default
+			Precip   float64 `json:"precipitation"`  // mm by default
+			IsDay    int     `json:"is_day"`
+		} `json:"current"`
+		Hourly struct {
+			Time  []int64   `json:"time"`
+			Temp  []float64 `json:"temperature_2m"`
+			Rain  []int     `json:"precipitation_probability"`
+			Code  []int     `json:"weather_code"`
+			IsDay []int     `json:"is_day"`
+		} `json:"hourly"`
+		Daily struct {
+			Time []int64   `json:"time"`
+			Code []int     `json:"weather_code"`
+			Max  []float64 `json:"temperature_2m_max"`
+			Min  []float64 `json:"temperature_2m_min"`
+			Rain []int     `json:"precipitation_probability_max"`
+			Sum  []float64 `json:"precipitation_sum"`
+			Gust []float64 `json:"wind_gusts_10m_max"` // km/h
+		} `json:"daily"`
+	}
+	if err := getWeatherJSON(o.client, "https://api.open-meteo.com/v1/forecast?"+query.Encode(), &data, openMeteoError); err != nil {
+		return WeatherInfo{}, err
+	}
+
+	info := WeatherInfo{
+		Location:  place.Name,
+		TempC:     data.Current.Temp,
+		Condition: wmoConditions[data.Current.Code],
+		Kind:      wmoKind(data.Current.Code),
+		IsDay:     data.Current.IsDay == 1,
+		Humidity:  data.Current.Humidity,
+		WindKph:   data.Current.Wind,
+		PrecipMM:  data.Current.Precip,
+	}
+	at := func(values []int, i int) int { // Missing values come back as null or short arrays
+		if i < len(values) {
+			return values[i]
+		}
+		return 0
+	}
+	now := time.Now()
+	d := data.Daily
+	for i := range d.Time {
+		if i >= len(d.Max) || i >= len(d.Min) {
+			break
+		}
+		code := at(d.Code, i)
+		day := time.Unix(d.Time[i], 0)
+		precip, gust := 0.0, 0.0
+		if i < len(d.Sum) {
+			precip = d.Sum[i]
+		}
+		if i < len(d.Gust) {
+			gust = d.Gust[i]
+		}
+		info.Days = append(info.Days, ForecastDay{
+			Date:       time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, now.Location()),
+			MinC:       d.Min[i],
+			MaxC:       d.Max[i],
+			RainChance: at(d.Rain, i),
+			PrecipMM:   precip,
+			GustKph:    gust,
+			Condition:  wmoConditions[code],
+			Kind:       wmoKind(code),
+		})
+	}
+	h := data.Hourly
+	for i := range h.Time {
+		t := time.Unix(h.Time[i], 0)
+		if i >= len(h.Temp) || t.Before(now.Truncate(time.Hour)) || len(info.Hours) >= forecastHours*forecastHourEvery {
+			continue
+		}
+		code := at(h.Code, i)
+		info.Hours = append(info.Hours, ForecastHour{
+			Time:       t,
+			TempC:      h.Temp[i],
+			RainChance: at(h.Rain, i),
+			Condition:  wmoConditions[code],
+			Kind:       wmoKind(code),
+			IsDay:      at(h.IsDay, i) == 1,
+		})
+	}
+	info.Warnings = forecastWarnings(info.Days, now)
+	return info, nil
+}
+
+// wmoConditions names the WMO weather interpretation codes Open-Meteo uses.
+var wmoConditions = map[int]string{
+	0: "Clear", 1: "Mainly clear", 2: "Partly cloudy", 3: "Overcast",
+	45: "Fog", 48: "Rime fog",
+	51: "Light drizzle", 53: "Drizzle", 55: "Dense drizzle", 56: "Freezing drizzle", 57: "Freezing drizzle",
+	61: "Light rain", 63: "Rain", 65: "Heavy rain", 66: "Freezing rain", 67: "Freezing rain",
+	71: "Light snow", 73: "Snow", 75: "Heavy snow", 77: "Snow grains",
+	80: "Rain showers", 81: "Rain showers", 82: "Violent rain showers", 85: "Snow showers", 86: "Snow showers",
+	95: "Thunderstorm", 96: "Thunderstorm with hail", 99: "Thunderstorm with hail",
+}
+
+func wmoKind(code int) WeatherKind {
+	switch {
+	case code <= 1:
+		return WeatherClear
+	case code == 2:
+		return WeatherPartlyCloudy
+	case code == 3:
+		return WeatherCloudy
+	case code == 45 || code == 48:
+		return WeatherFog
+	case code == 56 || code == 57 || code == 66 || code == 67:
+		return WeatherSleet
+	case code >= 51 && code <= 55:
+		return WeatherDrizzle
+	case code >= 61 && code <= 65, code >= 80 && code <= 82:
+		return WeatherRain
+	case code >= 71 && code <= 77, code == 85 || code == 86:
+		return WeatherSnow
+	case code >= 95:
+		return WeatherThunder
+	}
+	return WeatherUnknown
+}
+
+// --- OpenWeatherMap ---
+
+// openWeatherMapProvider uses the free current weather and 5 day / 3 hour
+// forecast APIs. Daily values are gathered from the 3-hourly steps; with no
+// weather warnings on the free plan, they are derived from the forecast.
+type openWeatherMapProvider struct {
+	key    string
+	client http.Client
+}
+
+func (o *openWeatherMapProvider) Name() string { return "OpenWeatherMap" }
+
+type owmCondition struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"` // Ends in "d" by day, "n" by night
+}
+
+// owmPrecip is the rain or snow volume in mm, absent when there is none.
+type owmPrecip struct {
+	OneHour    float64 `json:"1h"`
+	ThreeHours float64 `json:"3h"`
+}
+
+func owmError(dec *json.Decoder) string {
+	var errResp struct {
+		Message string `json:"message"`
+	}
+	dec.Decode(&errResp)
+	return errResp.Message
+}
+
+func (o *openWeatherMapProvider) Forecast(location string) (WeatherInfo, error) {
+	if o.key == "" {
+		return sampleWeather(location), nil
+	}
+	query := url.Values{}
+	if lat, lon, ok := parseLatLon(location); ok {
+		query.Set("lat", strconv.FormatFloat(lat, 'f', 4, 64))
+		query.Set("lon", strconv.FormatFloat(lon, 'f', 4, 64))
+	} else {
+		query.Set("q", location)
+	}
+	query.Set("units", "metric")
+	query.Set("appid", o.key)
+
+	var current struct {
+		Name    string         `json:"name"`
+		Weather []owmCondition `json:"weather"`
+		Main    struct {
+			Temp     float64 `json:"temp"`
+			Humidity int     `json:"humidity"`
+		} `json:"main"`
+		Wind struct {
+			Speed float64 `json:"speed"` // m/s
+		} `json:"wind"`
+		Rain owmPrecip `json:"rain"`
+		Snow owmPrecip `json:"snow"`
+	}
+	if err := getWeatherJSON(o.client, "https://api.openweathermap.org/data/2.5/weather?"+query.Encode(), &current, owmError); err != nil {
+		return WeatherInfo{}, err
+	}
+	var forecast struct {
+		List []struct {
+			Time    int64          `json:"dt"`
+			Weather []owmCondition `json:"weather"`
+			Pop     float64        `json:"pop"` // 0..1
+			Main    struct {
+				Temp float64 `json:"temp"`
+			} `json:"main"`
+			Wind struct {
+				Gust float64 `json:"gust"` // m/s
+			} `json:"wind"`
+			Rain owmPrecip `json:"rain"`
+			Snow owmPrecip `json:"snow"`
+		} `json:"list"`
+	}
+	if err := getWeatherJSON(o.client, "https://api.openweathermap.org/data/2.5/forecast?"+query.Encode(), &forecast, owmError); err != nil {
+		return WeatherInfo{}, err
+	}
+
+	first := func(w []owmCondition) owmCondition {
+		if len(w) == 0 {
+			return owmCondition{}
+		}
+		return w[0]
+	}
+	cond := first(current.Weather)
+	info := WeatherInfo{
+		Location:  current.Name,
+		TempC:     current.Main.Temp,
+		Condition: capitalize(cond.Description),
+		Kind:      owmKind(cond.ID),
+		IsDay:     !strings.HasSuffix(cond.Icon, "n"),
+		Humidity:  current.Main.Humidity,
+		WindKph:   current.Wind.Speed * 3.6,
+		PrecipMM:  current.Rain.OneHour + current.Snow.OneHour,
+	}
+
+	now := time.Now()
+	days := map[string]*ForecastDay{}
+	middays := map[string]int{} // Hours from noon of the step that named the day
+	for _, step := range forecast.List {
+		t := time.Unix(step.Time, 0)
+		c := first(step.Weather)
+		rain := int(step.Pop*100 + 0.5)
+		if !t.Before(now.Truncate(time.Hour)) && len(info.Hours) < forecastHours {
+			info.Hours = append(info.Hours, ForecastHour{
+				Time:       t,
+				TempC:      step.Main.Temp,
+				RainChance: rain,
+				Condition:  capitalize(c.Description),
+				Kind:       owmKind(c.ID),
+				IsDay:      !strings.HasSuffix(c.Icon, "n"),
+			})
+		}
+
+		key := t.Format("2006-01-02")
+		day, ok := days[key]
+		if !ok {
+			date, _ := time.ParseInLocation("2006-01-02", key, now.Location())
+			day = &ForecastDay{Date: date, MinC: step.Main.Temp, MaxC: step.Main.Temp}
+			days[key] = day
+			middays[key] = 24
+		}
+		day.MinC = min(day.MinC, step.Main.Temp)
+		day.MaxC = max(day.MaxC, step.Main.Temp)
+		day.RainChance = max(day.RainChance, rain)
+		day.PrecipMM += step.Rain.ThreeHours + step.Snow.ThreeHours
+		day.GustKph = max(day.GustKph, step.Wind.Gust*3.6)
+		if fromNoon := abs(t.Hour() - 12); fromNoon < middays[key] {
+			middays[key] = fromNoon
+			day.Condition, day.Kind = capitalize(c.Description), owmKind(c.ID)
+		}
+	}
+	for _, day := range days {
+		info.Days = append(info.Days, *day)
+	}
+	sort.Slice(info.Days, func(i, j int) bool { return info.Days[i].Date.Before(info.Days[j].Date) })
+	if len(info.Days) > forecastDays {
+		info.Days = info.Days[:forecastDays]
+	}
+	info.Warnings = forecastWarnings(info.Days, now)
+	return info, nil
+}
+
+// owmKind maps an OpenWeatherMap condition id.
+func owmKind(id int) WeatherKind {
+	switch {
+	case id >= 200 && id < 300:
+		return WeatherThunder
+	case id >= 300 && id < 400:
+		return WeatherDrizzle
+	case id == 511:
+		return WeatherSleet
+	case id >= 500 && id < 600:
+		return WeatherRain
+	case id >= 611 && id <= 616:
+		return WeatherSleet
+	case id >= 600 && id < 700:
+		return WeatherSnow
+	case id >= 700 && id < 800:
+		return WeatherFog
+	case id == 800:
+		return WeatherClear
+	case id == 801 || id == 802:
+		return WeatherPartlyCloudy
+	case id > 802:
+		return WeatherCloudy
+	}
+	return WeatherUnknown
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	return strings.ToUpper(string(r[0])) + string(r[1:])
+}
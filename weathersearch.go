@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// --- Weather Location Search ---
+//
+// `weather set` and `weather add` look the name up with the provider's
+// location search (Open-Meteo's geocoding when it has no search or no API
+// key). A single match is taken as is; several open a picker, e.g.
+// "Springfield, Illinois, US" and "Springfield, Missouri, US". The chosen
+// place is stored in weather.json with its coordinates, which are what the
+// provider is asked for from then on, so the name can't resolve to another
+// town later. "lat,lon" locations skip the search.
+
+const weatherSearchMax = 10 // Places offered by the picker
+
+// label is how a place is listed and stored: "Springfield, Illinois, US".
+func (p geoPlace) label() string {
+	parts := []string{p.Name}
+	for _, s := range []string{p.Region, p.Country} {
+		if s != "" && s != parts[len(parts)-1] {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// searchOpenMeteo asks Open-Meteo's geocoding API for up to count places.
+func searchOpenMeteo(client http.Client, query string, count int) ([]geoPlace, error) {
+	var data struct {
+		Results []struct {
+			Name        string  `json:"name"`
+			Admin1      string  `json:"admin1"`
+			CountryCode string  `json:"country_code"`
+			Lat         float64 `json:"latitude"`
+			Lon         float64 `json:"longitude"`
+		} `json:"results"`
+	}
+	endpoint := "https://geocoding-api.open-meteo.com/v1/search?count=" + strconv.Itoa(count) + "&name=" + url.QueryEscape(query)
+	if err := getWeatherJSON(client, endpoint, &data, openMeteoError); err != nil {
+		return nil, err
+	}
+	places := make([]geoPlace, len(data.Results))
+	for i, r := range data.Results {
+		places[i] = geoPlace{Name: r.Name, Region: r.Admin1, Country: r.CountryCode, Lat: r.Lat, Lon: r.Lon}
+	}
+	return places, nil
+}
+
+func (o *openMeteoProvider) SearchLocations(query string) ([]geoPlace, error) {
+	return searchOpenMeteo(o.client, query, weatherSearchMax)
+}
+
+func (w *weatherAPIProvider) SearchLocations(query string) ([]geoPlace, error) {
+	if w.key == "" {
+		return searchOpenMeteo(w.client, query, weatherSearchMax)
+	}
+	var places []geoPlace // Same field names: name, region, country, lat, lon
+	endpoint := fmt.Sprintf("https://api.weatherapi.com/v1/search.json?key=%s&q=%s", url.QueryEscape(w.key), url.QueryEscape(query))
+	if err := getWeatherJSON(w.client, endpoint, &places, weatherAPIError); err != nil {
+		return nil, err
+	}
+	return places[:min(weatherSearchMax, len(places))], nil
+}
+
+func (o *openWeatherMapProvider) SearchLocations(query string) ([]geoPlace, error) {
+	if o.key == "" {
+		return searchOpenMeteo(o.client, query, weatherSearchMax)
+	}
+	var data []struct {
+		Name    string  `json:"name"`
+		State   string  `json:"state"`
+		Country string  `json:"country"`
+		Lat     float64 `json:"lat"`
+		Lon     float64 `json:"lon"`
+	}
+	params := url.Values{"q": {query}, "limit": {"5"}, "appid": {o.key}} // 5 is the API's maximum
+	if err := getWeatherJSON(o.client, "https://api.openweathermap.org/geo/1.0/direct?"+params.Encode(), &data, owmError); err != nil {
+		return nil, err
+	}
+	places := make([]geoPlace, len(data))
+	for i, r := range data {
+		places[i] = geoPlace{Name: r.Name, Region: r.State, Country: r.Country, Lat: r.Lat, Lon: r.Lon}
+	}
+	return places, nil
+}
+
+// weatherQuery is what the provider is asked for: the coordinates of a place
+// picked from a search, otherwise the location as typed. Called with b.mu
+// held.
+func (b *Baseline) weatherQuery(location string) string {
+	if p, ok := b.weatherCoords[location]; ok {
+		return strconv.FormatFloat(p.Lat, 'f', 4, 64) + "," + strconv.FormatFloat(p.Lon, 'f', 4, 64)
+	}
+	return location
+}
+
+// searchWeatherLocation looks query up and stores the match, or opens the
+// picker when there are several. index is the location to replace, -1 to
+// add one. Runs in the background.
+func (b *Baseline) searchWeatherLocation(query string, index int) {
+	b.mu.RLock()
+	provider := b.weatherProvider
+	b.mu.RUnlock()
+
+	places, err := provider.SearchLocations(query)
+	switch {
+	case err != nil:
+		b.notify("weather", fmt.Sprintf("Could not look up %s (%v), using it as typed", query, err), "error")
+		b.chooseWeatherLocation(index, query, nil)
+	case len(places) == 0:
+		b.notify("weather", fmt.Sprintf("No place called %s was found", query), "error")
+	case len(places) == 1:
+		b.chooseWeatherLocation(index, places[0].label(), &places[0])
+	default:
+		b.app.QueueUpdateDraw(func() { b.openPlacePicker(query, index, places) })
+	}
+}
+
+// chooseWeatherLocation stores a location and fetches its weather.
+func (b *Baseline) chooseWeatherLocation(index int, location string, place *geoPlace) {
+	b.mu.Lock()
+	fetch := b.storeWeatherLocation(index, location, place)
+	b.mu.Unlock()
+	if fetch {
+		b.fetchWeather()
+	}
+}
+
+// storeWeatherLocation replaces location index, or adds one for -1, and saves
+// the list. place holds the coordinates of a searched location. Reports
+// whether there is new weather to fetch. Called with b.mu held.
+func (b *Baseline) storeWeatherLocation(index int, location string, place *geoPlace) bool {
+	if place != nil {
+		b.weatherCoords[location] = *place
+	}
+	if index < 0 {
+		if b.findWeatherLocation(location) >= 0 {
+			b.addNotification(fmt.Sprintf("%s is already in the list", location), "info")
+			return false
+		}
+		b.weatherLocs = append(b.weatherLocs, location)
+		b.saveWeatherLocations()
+		b.addNotification(fmt.Sprintf("Added weather location %s. Fetching...", location), "success")
+		return true
+	}
+	index = min(index, len(b.weatherLocs)-1) // A location may have been removed meanwhile
+	b.weatherLocs[index] = location
+	if index == b.weatherIndex {
+		b.weatherLocation = location
+	}
+	b.saveWeatherLocations()
+	b.addNotification(fmt.Sprintf("Weather location set to: %s. Fetching...", location), "success")
+	return true
+}
+
+// openPlacePicker lists the places matching query. Runs on the UI goroutine.
+func (b *Baseline) openPlacePicker(query string, index int, places []geoPlace) {
+	table := tview.NewTable().SetSelectable(true, false)
+	table.SetBorder(true).SetTitle(fmt.Sprintf(" Which %s? (Enter to pick, Esc to cancel) ", tview.Escape(query)))
+	table.SetBorderColor(b.theme.Bright)
+	table.SetTitleColor(b.theme.Bright)
+	table.SetSelectedStyle(b.theme.selection())
+	for row, p := range places {
+		table.SetCell(row, 0, tview.NewTableCell(p.label()).SetTextColor(b.theme.Main).SetExpansion(1))
+		table.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("%.2f, %.2f", p.Lat, p.Lon)).SetTextColor(b.theme.Dim).SetAlign(tview.AlignRight))
+	}
+	table.SetSelectedFunc(func(row, _ int) {
+		b.closeOverlay("places")
+		p := places[row]
+		go b.chooseWeatherLocation(index, p.label(), &p)
+	})
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' {
+			b.closeOverlay("places")
+			go b.notify("weather", "Weather location unchanged", "info")
+			return nil
+		}
+		return event
+	})
+	b.showOverlay("places", table, 70, len(places)+2)
+}
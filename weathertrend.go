@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// --- Weather Trend ---
+//
+// Every fetched temperature is kept in metrics.db next to the system
+// history, in a bucket per location, and dropped with the [history]
+// retention. The weather panel draws the last 24 hours as a sparkline of
+// hourly averages with the day's low and high, to show how the day warmed up
+// or cooled down. Cached reports and sample data aren't recorded.
+
+const (
+	weatherTrendWindow = 24 * time.Hour
+	weatherTrendBins   = 24 // One per hour
+)
+
+var weatherBucket = []byte("weather")
+
+// tempSample is one fetched temperature.
+type tempSample struct {
+	Time  time.Time
+	TempC float64
+}
+
+// AppendTemperature stores a temperature for location.
+func (m *MetricsStore) AppendTemperature(location string, s tempSample) error {
+	return m.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.Bucket(weatherBucket).CreateBucketIfNotExists([]byte(location))
+		if err != nil {
+			return err
+		}
+		value := make([]byte, 8)
+		binary.BigEndian.PutUint64(value, math.Float64bits(s.TempC))
+		return bucket.Put(MetricSample{Time: s.Time}.key(), value)
+	})
+}
+
+// Temperatures returns the temperatures of location since from, oldest first.
+func (m *MetricsStore) Temperatures(location string, from time.Time) ([]tempSample, error) {
+	var samples []tempSample
+	err := m.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(weatherBucket).Bucket([]byte(location))
+		if bucket == nil {
+			return nil
+		}
+		c := bucket.Cursor()
+		for k, v := c.Seek(MetricSample{Time: from}.key()); k != nil; k, v = c.Next() {
+			if len(k) == 8 && len(v) == 8 {
+				samples = append(samples, tempSample{
+					Time:  time.Unix(0, int64(binary.BigEndian.Uint64(k))),
+					TempC: math.Float64frombits(binary.BigEndian.Uint64(v)),
+				})
+			}
+		}
+		return nil
+	})
+	return samples, err
+}
+
+// compactTemperatures drops temperatures older than cutoff. Part of Compact.
+func compactTemperatures(tx *bolt.Tx, cutoff time.Time) error {
+	key := MetricSample{Time: cutoff}.key()
+	parent := tx.Bucket(weatherBucket)
+	var locations [][]byte
+	parent.ForEach(func(k, v []byte) error {
+		if v == nil { // Nested bucket
+			locations = append(locations, append([]byte(nil), k...))
+		}
+		return nil
+	})
+	for _, location := range locations {
+		bucket := parent.Bucket(location)
+		var old [][]byte
+		c := bucket.Cursor()
+		for k, _ := c.First(); k != nil && bytes.Compare(k, key) < 0; k, _ = c.Next() {
+			old = append(old, append([]byte(nil), k...))
+		}
+		for _, k := range old {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// loadWeatherTrend reads the last day of temperatures of every location.
+// Called from loadSystemHistory with b.mu held.
+func (b *Baseline) loadWeatherTrend() {
+	from := time.Now().Add(-weatherTrendWindow)
+	for _, location := range b.weatherLocs {
+		key := weatherCacheKey(location)
+		samples, err := b.metrics.Temperatures(key, from)
+		if err != nil {
+			b.addNotification(fmt.Sprintf("Error loading the temperature history: %v", err), "error")
+			return
+		}
+		b.weatherTemps[key] = samples
+	}
+}
+
+// recordTemperatures keeps the temperatures of a fetch and returns them by
+// location for saveTemperatures. Called from fetchWeather with b.mu held.
+func (b *Baseline) recordTemperatures(locations []string, all []WeatherInfo) map[string]tempSample {
+	if b.weatherNeedsKey != "" {
+		return nil // Sample data
+	}
+	fetched := map[string]tempSample{}
+	cutoff := time.Now().Add(-weatherTrendWindow)
+	for i, info := range all {
+		if info.Cached || info.Error != "" {
+			continue
+		}
+		key := weatherCacheKey(locations[i])
+		sample := tempSample{Time: time.Now(), TempC: info.TempC}
+		samples := append(b.weatherTemps[key], sample)
+		for len(samples) > 0 && samples[0].Time.Before(cutoff) {
+			samples = samples[1:]
+		}
+		b.weatherTemps[key] = samples
+		fetched[key] = sample
+	}
+	return fetched
+}
+
+// saveTemperatures writes what recordTemperatures returned to metrics.db.
+// Called without b.mu, a write waits for the disk.
+func (b *Baseline) saveTemperatures(fetched map[string]tempSample) {
+	if b.metrics == nil {
+		return
+	}
+	for key, sample := range fetched {
+		if err := b.metrics.AppendTemperature(key, sample); err != nil {
+			b.notify("weather", fmt.Sprintf("Error saving the temperature history: %v", err), "error")
+			return
+		}
+	}
+}
+
+// renderWeatherTrend draws the last day's temperatures as hourly averages,
+// with a dot for hours without any, "" until there are two hours to compare.
+func renderWeatherTrend(samples []tempSample, now time.Time, units WeatherUnits, theme Theme) string {
+	var sums, counts [weatherTrendBins]float64
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, s := range samples {
+		age := now.Sub(s.Time)
+		if age < 0 || age >= weatherTrendWindow {
+			continue
+		}
+		bin := weatherTrendBins - 1 - int(age/time.Hour)
+		sums[bin] += s.TempC
+		counts[bin]++
+		lo, hi = min(lo, s.TempC), max(hi, s.TempC)
+	}
+	var hourly []float64
+	for i := range sums {
+		if counts[i] > 0 {
+			hourly = append(hourly, sums[i]/counts[i])
+		}
+	}
+	if len(hourly) < 2 {
+		return ""
+	}
+	// Spread the hours that have a sparkline block over the whole day
+	blocks := []rune(blockSparkline(hourly, weatherTrendBins))
+	mainC, dimC := colorTag(theme.Main), colorTag(theme.Dim)
+	var line strings.Builder
+	for i := range counts {
+		if counts[i] == 0 {
+			line.WriteString(dimC + "·" + mainC)
+			continue
+		}
+		line.WriteRune(blocks[0])
+		blocks = blocks[1:]
+	}
+	symbol := units.TempSymbol()
+	return fmt.Sprintf("%s24h: %s%s %s%.0f%s / %.0f%s[-:-:-]\n",
+		dimC, mainC, line.String(),
+		dimC, units.Degrees(lo), symbol, units.Degrees(hi), symbol)
+}
+
+// weatherTrend returns the recorded temperatures of location. Called with
+// b.mu held.
+func (b *Baseline) weatherTrend(location string) []tempSample {
+	return append([]tempSample(nil), b.weatherTemps[weatherCacheKey(location)]...)
+}
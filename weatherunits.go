@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// --- Weather Units ---
+//
+// Providers report metric values; the weather panel converts them for
+// display. WEATHER_UNITS or units in config.toml's [weather] section picks
+// metric (°C, km/h, mm) or imperial (°F, mph, inches). `weather units f|c`
+// switches at runtime and is remembered in weather.json.
+
+type WeatherUnits string
+
+const (
+	UnitsMetric   WeatherUnits = "metric"
+	UnitsImperial WeatherUnits = "imperial"
+)
+
+// parseWeatherUnits accepts metric/imperial and the c/f shorthands.
+func parseWeatherUnits(s string) (WeatherUnits, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "metric", "c", "celsius", "si":
+		return UnitsMetric, true
+	case "imperial", "f", "fahrenheit", "us":
+		return UnitsImperial, true
+	}
+	return UnitsMetric, false
+}
+
+// weatherUnitsFor reads WEATHER_UNITS, then the config, metric by default.
+func weatherUnitsFor(cfg WeatherConfig) WeatherUnits {
+	name := os.Getenv("WEATHER_UNITS")
+	if name == "" {
+		name = cfg.Units
+	}
+	if name == "" {
+		return UnitsMetric
+	}
+	u, ok := parseWeatherUnits(name)
+	if !ok {
+		slog.Warn("Unknown weather units (metric or imperial), using metric", "units", name)
+	}
+	return u
+}
+
+// Degrees converts a temperature in °C.
+func (u WeatherUnits) Degrees(c float64) float64 {
+	if u == UnitsImperial {
+		return c*9/5 + 32
+	}
+	return c
+}
+
+// TempSymbol is "°C" or "°F".
+func (u WeatherUnits) TempSymbol() string {
+	if u == UnitsImperial {
+		return "°F"
+	}
+	return "°C"
+}
+
+// Temp formats a temperature in °C with one decimal, e.g. "71.6°F".
+func (u WeatherUnits) Temp(c float64) string {
+	return fmt.Sprintf("%.1f%s", u.Degrees(c), u.TempSymbol())
+}
+
+// Wind formats a speed in km/h.
+func (u WeatherUnits) Wind(kph float64) string {
+	if u == UnitsImperial {
+		return fmt.Sprintf("%.1f mph", kph/1.609344)
+	}
+	return fmt.Sprintf("%.1f km/h", kph)
+}
+
+// Precip formats an amount of precipitation in mm.
+func (u WeatherUnits) Precip(mm float64) string {
+	if u == UnitsImperial {
+		return fmt.Sprintf("%.2f in", mm/25.4)
+	}
+	return fmt.Sprintf("%.1f mm", mm)
+}
+
+// weatherUnitsCommand handles "weather units [f|c|metric|imperial]". Called
+// with b.mu held; reports whether the panel needs redrawing.
+func (b *Baseline) weatherUnitsCommand(arg string) bool {
+	if arg == "" {
+		b.addNotification(fmt.Sprintf("Weather units: %s (%s)", b.weatherUnits, b.weatherUnits.TempSymbol()), "info")
+		return false
+	}
+	u, ok := parseWeatherUnits(arg)
+	if !ok {
+		b.addNotification("Usage: weather units f|c (or imperial|metric)", "error")
+		return false
+	}
+	b.weatherUnits = u
+	b.saveWeatherLocations()
+	b.addNotification(fmt.Sprintf("Weather units set to %s (%s)", u, u.TempSymbol()), "success")
+	return true
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// --- Web Mirror ---
+//
+// With [web] in config.toml the dashboard also serves a read-only page with
+// the system stats, the CPU and memory history, the todos and the weather,
+// e.g. for a phone on the LAN:
+//
+//	[web]
+//	listen = "0.0.0.0:7421"
+//	token = "..."   # Default BASELINE_TOKEN or ~/.baseline/server.token
+//
+// The page gets a new state over a WebSocket every system refresh, and polls
+// /state.json where WebSockets don't get through. Every request needs the
+// token, which is kept in a cookie after opening http://host:7421/?token=...
+// `web` shows that address.
+
+const (
+	webCookieName     = "baseline_token"
+	webHistoryPoints  = 150 // History samples sent to the page
+	webWriteTimeout   = 10 * time.Second
+	webSocketGUID     = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11" // RFC 6455
+	webSocketMaxFrame = 4096                                   // The page only sends close frames
+)
+
+//go:embed webmirror.html
+var webMirrorPage []byte
+
+// WebConfig is the [web] section of config.toml.
+type WebConfig struct {
+	Listen string `toml:"listen"`
+	Token  string `toml:"token"`
+}
+
+// WebState is what the page shows: a snapshot as `baseline snapshot` prints
+// it, plus the history and the theme colors.
+type WebState struct {
+	Snapshot
+	History SystemHistory     `json:"history"`
+	Units   string            `json:"units"` // Weather units, "metric" or "imperial"
+	Colors  map[string]string `json:"colors"`
+}
+
+// webState copies what the dashboard currently shows.
+func (b *Baseline) webState() WebState {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	tail := func(n int) int { return max(0, n-webHistoryPoints) }
+	h := b.systemHistory
+	state := WebState{
+		Snapshot: Snapshot{Time: time.Now(), System: b.latestSystem, Todos: append([]TodoItem{}, b.todoItems...)},
+		History: SystemHistory{
+			CPU:        slices.Clone(h.CPU[tail(len(h.CPU)):]),
+			Memory:     slices.Clone(h.Memory[tail(len(h.Memory)):]),
+			Timestamps: slices.Clone(h.Timestamps[tail(len(h.Timestamps)):]),
+		},
+		Units: string(b.weatherUnits),
+		Colors: map[string]string{
+			"background": cssColor(b.theme.background(), ""),
+			"panel":      cssColor(b.theme.panelBackground(), ""),
+			"main":       cssColor(b.theme.Main, ""),
+			"bright":     cssColor(b.theme.Bright, ""),
+			"dim":        cssColor(b.theme.Dim, ""),
+			"border":     cssColor(b.theme.border(), ""),
+		},
+	}
+	if b.weatherInfo.Error != "" {
+		state.WeatherError = b.weatherInfo.Error
+	} else if !b.weatherInfo.LastUpdated.IsZero() {
+		state.Weather = newWeatherSnapshot(b.weatherInfo)
+	}
+	return state
+}
+
+// webAuthorized checks the token from the query, the cookie or an
+// Authorization header.
+func webAuthorized(r *http.Request, token string) bool {
+	got := r.URL.Query().Get("token")
+	if got == "" {
+		if c, err := r.Cookie(webCookieName); err == nil {
+			got = c.Value
+		}
+	}
+	if got == "" {
+		got = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+	return subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
+
+// webHandler serves the page, /state.json and the /ws WebSocket.
+func (b *Baseline) webHandler(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		if t := r.URL.Query().Get("token"); t != "" {
+			http.SetCookie(w, &http.Cookie{Name: webCookieName, Value: t, Path: "/", HttpOnly: true, SameSite: http.SameSiteStrictMode, MaxAge: 365 * 24 * 3600})
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(webMirrorPage)
+	})
+	mux.HandleFunc("/state.json", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, b.webState())
+	})
+	mux.HandleFunc("/ws", b.serveWebSocket)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "read-only", http.StatusMethodNotAllowed)
+			return
+		}
+		if !webAuthorized(r, token) {
+			http.Error(w, "invalid token, open /?token=...", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// serveWebSocket pushes the state every system refresh until the page goes
+// away or the dashboard quits.
+func (b *Baseline) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, rw, err := upgradeWebSocket(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		readWebSocketUntilClose(rw.Reader)
+	}()
+
+	b.mu.RLock()
+	interval := b.intervals.System // Changed by a config reload
+	b.mu.RUnlock()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		data, err := json.Marshal(b.webState())
+		if err != nil {
+			slog.Error("Could not encode the web state", "err", err)
+			return
+		}
+		conn.SetWriteDeadline(time.Now().Add(webWriteTimeout))
+		if err := writeWebSocketFrame(conn, 0x1, data); err != nil {
+			return
+		}
+		select {
+		case <-ticker.C:
+		case <-gone:
+			writeWebSocketFrame(conn, 0x8, nil) // Answer the close
+			return
+		case <-b.life.Done():
+			writeWebSocketFrame(conn, 0x8, nil)
+			return
+		}
+	}
+}
+
+// upgradeWebSocket answers the opening handshake and takes over the
+// connection. Requests that can't be upgraded get an error response.
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (net.Conn, *bufio.ReadWriter, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") || key == "" {
+		http.Error(w, "not a WebSocket request", http.StatusBadRequest)
+		return nil, nil, errors.New("not a WebSocket request")
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "connection can't be upgraded", http.StatusInternalServerError)
+		return nil, nil, errors.New("connection can't be upgraded")
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	sum := sha1.Sum([]byte(key + webSocketGUID))
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	return conn, rw, nil
+}
+
+// writeWebSocketFrame sends one unfragmented, unmasked frame.
+func writeWebSocketFrame(w io.Writer, opcode byte, payload []byte) error {
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 126, byte(n>>8), byte(n))
+	default:
+		header = binary.BigEndian.AppendUint64(append(header, 127), uint64(n))
+	}
+	_, err := w.Write(append(header, payload...))
+	return err
+}
+
+// readWebSocketUntilClose discards the page's frames (pings are answered by
+// the next state) and returns on a close frame or a broken connection.
+func readWebSocketUntilClose(r *bufio.Reader) {
+	for {
+		var head [2]byte
+		if _, err := io.ReadFull(r, head[:]); err != nil {
+			return
+		}
+		n := uint64(head[1] & 0x7F)
+		switch n {
+		case 126:
+			var ext [2]byte
+			if _, err := io.ReadFull(r, ext[:]); err != nil {
+				return
+			}
+			n = uint64(binary.BigEndian.Uint16(ext[:]))
+		case 127:
+			return // Far bigger than anything the page sends
+		}
+		if head[1]&0x80 != 0 {
+			n += 4 // Masking key
+		}
+		if head[0]&0x0F == 0x8 || n > webSocketMaxFrame {
+			return
+		}
+		if _, err := r.Discard(int(n)); err != nil {
+			return
+		}
+	}
+}
+
+// startWebMirror serves the web mirror until the dashboard quits. Called
+// from Run when [web] listen is set.
+func (b *Baseline) startWebMirror() {
+	cfg := b.config.Web
+	token, err := serverToken(b.configDir, cfg.Token, true)
+	if err != nil {
+		b.notify("web", fmt.Sprintf("Web mirror not started: %v", err), "error")
+		return
+	}
+	ln, err := net.Listen("tcp", cfg.Listen)
+	if err != nil {
+		b.notify("web", fmt.Sprintf("Web mirror not started: %v", err), "error")
+		return
+	}
+	b.mu.Lock()
+	b.webURL = webMirrorURL(ln.Addr())
+	b.mu.Unlock()
+	slog.Info("Serving the web mirror", "address", ln.Addr().String())
+
+	srv := &http.Server{Handler: b.webHandler(token), ReadHeaderTimeout: remoteTimeout}
+	go func() {
+		<-b.life.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+		defer cancel()
+		srv.Shutdown(ctx) // Hijacked WebSockets close themselves on Done
+	}()
+	go func() {
+		if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+			b.notify("web", fmt.Sprintf("Web mirror stopped: %v", err), "error")
+		}
+	}()
+}
+
+// webMirrorURL is the address to open on another device: a LAN address
+// when the server listens on all of them. The token is left out, it would
+// end up in the notification log.
+func webMirrorURL(addr net.Addr) string {
+	host, port, _ := net.SplitHostPort(addr.String())
+	if ip := net.ParseIP(host); ip == nil || ip.IsUnspecified() {
+		host = "localhost"
+		if addrs, err := net.InterfaceAddrs(); err == nil {
+			for _, a := range addrs {
+				if ipnet, ok := a.(*net.IPNet); ok && !ipnet.IP.IsLoopback() && ipnet.IP.To4() != nil {
+					host = ipnet.IP.String()
+					break
+				}
+			}
+		}
+	}
+	return "http://" + net.JoinHostPort(host, port) + "/"
+}
+
+// webCommand shows the address of the web mirror.
+func (b *Baseline) webCommand() {
+	if b.webURL == "" {
+		b.addNotification("The web mirror is off: set listen in the [web] section of config.toml", "info")
+		return
+	}
+	b.addNotification(fmt.Sprintf("Web mirror: %s (add ?token= with the web token, BASELINE_TOKEN or %s)", b.webURL, serverTokenName), "info")
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	"github.com/rivo/tview"
+)
+
+// --- Optional Widget Panels ---
+//
+// Widgets are extra panels that only appear when configured. They live in a
+// column to the right of the core panels (below them on narrow terminals,
+// see layout.go) and are styled by applyTheme.
+
+type widgetPanel struct {
+	view   *tview.TextView
+	render func() // Re-renders the panel content (e.g. after a theme change)
+}
+
+// newPanel creates a bordered, scrollable TextView matching the core panels.
+func newPanel(title string) *tview.TextView {
+	tv := tview.NewTextView()
+	tv.SetDynamicColors(true).
+		SetScrollable(true).
+		SetBorder(true).
+		SetTitle(title)
+	return tv
+}
+
+// addWidgetPanel creates a widget panel and places it in the widget column.
+func (b *Baseline) addWidgetPanel(title string, render func()) *tview.TextView {
+	tv := newPanel(title)
+	b.widgetPanels = append(b.widgetPanels, widgetPanel{view: tv, render: render})
+	b.widgetColumn.AddItem(tv, 0, 1, false)
+	return tv
+}
+
+// addRuntimePanel adds a widget panel after startup (e.g. `log open`), showing
+// the widget column if it was empty. Must run on the UI goroutine.
+func (b *Baseline) addRuntimePanel(title string, render func()) *tview.TextView {
+	if len(b.widgetPanels) == 0 {
+		b.mainContent.AddItem(b.widgetColumn, 0, b.arrangement.widgetColumnWidth(), false)
+	}
+	tv := b.addWidgetPanel(title, render)
+	tv.SetBorderColor(b.theme.border())
+	tv.SetTitleColor(b.theme.Main)
+	tv.SetTextColor(b.theme.Main)
+	tv.SetBackgroundColor(b.theme.panelBackground())
+	return tv
+}
+
+// removeRuntimePanel takes a panel added by addRuntimePanel off the screen.
+// Must run on the UI goroutine.
+func (b *Baseline) removeRuntimePanel(tv *tview.TextView) {
+	for i, w := range b.widgetPanels {
+		if w.view == tv {
+			b.widgetPanels = append(b.widgetPanels[:i], b.widgetPanels[i+1:]...)
+			break
+		}
+	}
+	b.widgetColumn.RemoveItem(tv)
+	b.forgetPanel(tv)
+	if len(b.widgetPanels) == 0 {
+		b.mainContent.RemoveItem(b.widgetColumn)
+	}
+}
+
+// setupWidgets creates panels for every configured widget. Called from setupLayout.
+func (b *Baseline) setupWidgets() {
+	b.widgetColumn = tview.NewFlex().SetDirection(tview.FlexRow)
+
+	if b.jira != nil {
+		b.jiraPanel = b.addWidgetPanel(" Issues ", b.updateJira)
+	}
+	if b.pihole != nil {
+		b.piholePanel = b.addWidgetPanel(" Pi-hole ", b.updatePihole)
+	}
+	if b.ha != nil {
+		b.haPanel = b.addWidgetPanel(" Home Assistant ", b.updateHA)
+	}
+	if b.vpn != nil {
+		b.vpnPanel = b.addWidgetPanel(" VPN ", b.updateVPN)
+	}
+	if b.conn != nil {
+		b.connPanel = b.addWidgetPanel(" Connectivity ", b.updateConnectivity)
+	}
+	if len(b.gitRepos) > 0 {
+		b.gitPanel = b.addWidgetPanel(" Git ", b.updateGitRepos)
+	}
+	if b.lanScan {
+		b.lanPanel = b.addWidgetPanel(" Network Devices ", b.updateLAN)
+	}
+	if b.btEnabled {
+		b.btPanel = b.addWidgetPanel(" Bluetooth ", b.updateBluetooth)
+	}
+	if b.containers != nil {
+		b.ctrPanel = b.addWidgetPanel(" Containers ", b.updateContainers)
+	}
+	if len(b.services) > 0 {
+		b.svcPanel = b.addWidgetPanel(" Services ", b.updateServices)
+	}
+	if len(b.hosts) > 0 {
+		b.hostsPanel = b.addWidgetPanel(" Hosts ", b.updateHosts)
+	}
+	if b.notesFile != "" {
+		b.notesPanel = b.addWidgetPanel(" Notes ", b.updateNotes)
+	}
+	if b.clipMax > 0 {
+		b.clipPanel = b.addWidgetPanel(" Clipboard ", b.updateClipboard)
+	}
+	if b.quote != nil {
+		b.quotePanel = b.addWidgetPanel(" Quote ", b.updateQuote)
+	}
+	if b.news != nil {
+		b.newsPanel = b.addWidgetPanel(" News ", b.updateNews)
+	}
+	if b.markets != nil {
+		b.marketsPanel = b.addWidgetPanel(" Markets ", b.updateMarkets)
+	}
+	if len(b.habits) > 0 {
+		b.habitsPanel = b.addWidgetPanel(" Habits ", b.updateHabits)
+	}
+	if b.worldMap {
+		b.worldPanel = b.addWidgetPanel(" World ", b.updateWorldMap)
+	}
+	if b.volume != nil {
+		b.volumePanel = b.addWidgetPanel(" Volume ", b.updateVolume)
+	}
+	if b.brightness != nil {
+		b.brightPanel = b.addWidgetPanel(" Brightness ", b.updateBrightness)
+	}
+	if b.historyGraph {
+		b.historyPanel = b.addWidgetPanel(" History ", b.updateHistoryGraph)
+	}
+	if b.cronOn {
+		b.cronPanel = b.addWidgetPanel(" Cron ", b.updateCron)
+	}
+	if b.about {
+		b.aboutPanel = b.addWidgetPanel(" About ", b.updateAbout)
+	}
+	for _, p := range b.plugins {
+		p.view = b.addWidgetPanel(" "+tview.Escape(p.Name)+" ", func() { b.updatePlugin(p) })
+	}
+}
+
+// startWidgets kicks off the background refresh of every configured widget. Called from Run.
+func (b *Baseline) startWidgets() {
+	if b.jira != nil {
+		b.schedule(jiraRefreshInterval, b.fetchJira)
+	}
+	if b.pihole != nil {
+		b.schedule(piholeRefreshInterval, b.fetchPihole)
+	}
+	if b.ha != nil {
+		b.schedule(haRefreshInterval, b.fetchHA)
+	}
+	if b.vpn != nil {
+		b.schedule(vpnRefreshInterval, b.fetchVPN)
+	}
+	if b.conn != nil {
+		b.schedule(connectivityRefreshInterval, b.fetchConnectivity)
+	}
+	if len(b.gitRepos) > 0 {
+		b.schedule(gitRefreshInterval, b.fetchGitRepos)
+	}
+	if b.lanScan {
+		b.schedule(lanRefreshInterval, b.scanLAN)
+	}
+	if b.btEnabled {
+		b.schedule(bluetoothRefreshInterval, b.fetchBluetooth)
+	}
+	if b.containers != nil {
+		b.schedule(containerRefreshInterval, b.fetchContainers)
+	}
+	if len(b.services) > 0 {
+		b.schedule(serviceRefreshInterval, b.fetchServices)
+	}
+	b.schedule(hostRefreshInterval, b.fetchHosts) // Idle until `host add`
+	if b.notesFile != "" {
+		b.schedule(notesRefreshInterval, b.updateNotes)
+	}
+	if b.clipMax > 0 {
+		b.schedule(clipboardRefreshInterval, b.fetchClipboard)
+	}
+	if b.quote != nil {
+		b.schedule(quoteRefreshInterval, b.fetchQuote)
+	}
+	if b.news != nil {
+		b.schedule(newsRefreshInterval, b.fetchNews)
+		b.schedule(newsRotateCheck, b.rotateNews)
+	}
+	if b.markets != nil {
+		b.schedule(b.markets.interval, b.fetchMarkets)
+	}
+	if len(b.habits) > 0 {
+		b.schedule(habitsRefreshInterval, b.updateHabits)
+	}
+	if b.volume != nil {
+		b.schedule(volumeRefreshInterval, b.fetchVolume)
+	}
+	if b.brightness != nil {
+		b.schedule(brightnessRefreshInterval, b.fetchBrightness)
+	}
+	if b.cronOn {
+		b.schedule(cronRefreshInterval, b.fetchCron)
+	}
+	if b.about {
+		go b.fetchAbout() // Static, gathered once
+	}
+	for _, p := range b.plugins {
+		b.schedule(p.Interval, func() { b.runPlugin(p) })
+	}
+}
+
+// openBrowser opens a URL with the platform's default handler.
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	case "darwin":
+		cmd = exec.Command("open", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("could not open browser: %w", err)
+	}
+	go cmd.Wait() // Reap the process
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- World Clock ---
+//
+// TIMEZONES lists extra zones shown under the local clock, comma separated,
+// each a zone name or "Label=Zone": "UTC,US/Pacific,Tokyo=Asia/Tokyo".
+// Each line gives the time there, the day offset from the local date and
+// the hours ahead of or behind local time.
+
+type ClockZone struct {
+	Label string
+	Zone  *time.Location
+}
+
+func clockZonesFromEnv() []ClockZone {
+	var zones []ClockZone
+	for _, entry := range strings.Split(os.Getenv("TIMEZONES"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		label, name, hasLabel := strings.Cut(entry, "=")
+		if !hasLabel {
+			name = entry
+			label = strings.ReplaceAll(name[strings.LastIndex(name, "/")+1:], "_", " ")
+		}
+		tz, err := time.LoadLocation(strings.TrimSpace(name))
+		if err != nil {
+			slog.Warn("TIMEZONES: unknown time zone", "zone", name)
+			continue
+		}
+		zones = append(zones, ClockZone{Label: strings.TrimSpace(label), Zone: tz})
+	}
+	return zones
+}
+
+// dayOffset returns "+1d", "-1d" etc. when t falls on another date than local.
+func dayOffset(t, local time.Time) string {
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := local.Date()
+	days := int(time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC).Sub(time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+	if days == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%+dd", days)
+}
+
+// zoneDifference formats how far t's zone is ahead of local's, e.g. "+9h",
+// "-7h" or "+5:30h".
+func zoneDifference(t, local time.Time) string {
+	_, offset := t.Zone()
+	_, localOffset := local.Zone()
+	diff := offset - localOffset
+	sign := "+"
+	if diff < 0 {
+		sign, diff = "-", -diff
+	}
+	if diff%3600 != 0 {
+		return fmt.Sprintf("%s%d:%02dh", sign, diff/3600, diff%3600/60)
+	}
+	return fmt.Sprintf("%s%dh", sign, diff/3600)
+}
+
+// renderWorldClocks draws one line per zone for the time panel.
+func renderWorldClocks(now time.Time, zones []ClockZone, theme Theme) string {
+	mainC := colorTag(theme.Main)
+	dimC := colorTag(theme.Dim)
+	brightC := colorTag(theme.Bright)
+
+	var sb strings.Builder
+	for _, z := range zones {
+		t := now.In(z.Zone)
+		sb.WriteString(fmt.Sprintf("%s%-12s %s%s %s%-3s %s[-:-:-]\n",
+			mainC, tview.Escape(z.Label),
+			brightC, t.Format("15:04"),
+			mainC, dayOffset(t, now),
+			dimC+zoneDifference(t, now),
+		))
+	}
+	return sb.String()
+}
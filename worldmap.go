@@ -0,0 +1,196 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// --- World Map Daylight Widget ---
+//
+// An equirectangular ASCII map (5° per column, 10° per row) shaded by the
+// day/night terminator, scaled to the panel width on every time tick.
+
+//go:embed worldmap.txt
+var worldMapText string
+
+type WorldLocation struct {
+	Label    string
+	Zone     *time.Location
+	Lat, Lon float64
+	Placed   bool // Has coordinates, so it gets a marker on the map
+}
+
+// worldLocationsFromEnv parses WORLD_MAP_LOCATIONS, entries separated by ";":
+// "London=Europe/London@51.5,-0.13". The zone and the coordinates are each
+// optional; without coordinates the location is only listed below the map.
+func worldLocationsFromEnv() []WorldLocation {
+	var locs []WorldLocation
+	for _, entry := range strings.Split(os.Getenv("WORLD_MAP_LOCATIONS"), ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		loc := WorldLocation{Zone: time.Local}
+		if at := strings.LastIndex(entry, "@"); at >= 0 {
+			lat, lon, ok := parseCoordinates(entry[at+1:])
+			if !ok {
+				slog.Warn("WORLD_MAP_LOCATIONS: bad coordinates", "entry", entry)
+				continue
+			}
+			loc.Lat, loc.Lon, loc.Placed = lat, lon, true
+			entry = entry[:at]
+		}
+		label, zone, hasZone := strings.Cut(entry, "=")
+		loc.Label = strings.TrimSpace(label)
+		if hasZone {
+			tz, err := time.LoadLocation(strings.TrimSpace(zone))
+			if err != nil {
+				slog.Warn("WORLD_MAP_LOCATIONS: unknown time zone", "zone", zone)
+				continue
+			}
+			loc.Zone = tz
+		}
+		locs = append(locs, loc)
+	}
+	return locs
+}
+
+// parseCoordinates parses "lat,lon" in decimal degrees.
+func parseCoordinates(s string) (lat, lon float64, ok bool) {
+	latStr, lonStr, found := strings.Cut(s, ",")
+	if !found {
+		return 0, 0, false
+	}
+	lat, err1 := strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+	lon, err2 := strconv.ParseFloat(strings.TrimSpace(lonStr), 64)
+	if err1 != nil || err2 != nil || math.Abs(lat) > 90 || math.Abs(lon) > 180 {
+		return 0, 0, false
+	}
+	return lat, lon, true
+}
+
+// worldMapEnabled reports whether WORLD_MAP is set or locations are configured.
+func worldMapEnabled() bool {
+	v := strings.ToLower(os.Getenv("WORLD_MAP"))
+	return v == "true" || v == "1" || v == "yes" || os.Getenv("WORLD_MAP_LOCATIONS") != ""
+}
+
+// subsolarPoint returns the latitude and longitude where the sun is overhead,
+// good to about a degree (no equation of time).
+func subsolarPoint(t time.Time) (lat, lon float64) {
+	t = t.UTC()
+	lat = -23.44 * math.Cos(2*math.Pi/365*float64(t.YearDay()+10))
+	hours := float64(t.Hour()) + float64(t.Minute())/60 + float64(t.Second())/3600
+	lon = -15 * (hours - 12)
+	return lat, lon
+}
+
+// sunElevation returns the sun's elevation in degrees at lat/lon.
+func sunElevation(lat, lon, sunLat, sunLon float64) float64 {
+	rad := math.Pi / 180
+	cosZenith := math.Sin(lat*rad)*math.Sin(sunLat*rad) +
+		math.Cos(lat*rad)*math.Cos(sunLat*rad)*math.Cos((lon-sunLon)*rad)
+	return math.Asin(cosZenith) / rad
+}
+
+// renderWorldMap draws the map width columns wide, land in the theme colour by
+// day and dimmed at night, the sun as a yellow "O" and locations as their
+// initial.
+func renderWorldMap(now time.Time, width int, locs []WorldLocation, theme Theme) string {
+	mainC := colorTag(theme.Main)
+	dimC := colorTag(theme.Dim)
+	brightC := colorTag(theme.Bright)
+
+	src := strings.Split(strings.TrimRight(worldMapText, "\n"), "\n")
+	srcW := 72
+	if width < 18 {
+		width = 18
+	}
+	if width > srcW*2 {
+		width = srcW * 2
+	}
+	height := len(src) * width / srcW
+	if height < 6 {
+		height = 6
+	}
+
+	cellLat := func(row int) float64 { return 90 - (float64(row)+0.5)*180/float64(height) }
+	cellLon := func(col int) float64 { return -180 + (float64(col)+0.5)*360/float64(width) }
+	toCell := func(lat, lon float64) (int, int) {
+		row := int((90 - lat) / 180 * float64(height))
+		col := int((lon + 180) / 360 * float64(width))
+		return min(max(row, 0), height-1), min(max(col, 0), width-1)
+	}
+
+	sunLat, sunLon := subsolarPoint(now)
+	markers := map[[2]int]string{}
+	sunRow, sunCol := toCell(sunLat, sunLon)
+	markers[[2]int{sunRow, sunCol}] = "[yellow::b]O"
+	for _, loc := range locs {
+		if loc.Placed && loc.Label != "" {
+			r, c := toCell(loc.Lat, loc.Lon)
+			markers[[2]int{r, c}] = brightC + "[::b]" + tview.Escape(string([]rune(loc.Label)[:1]))
+		}
+	}
+
+	var sb strings.Builder
+	for row := 0; row < height; row++ {
+		line := src[min(row*len(src)/height, len(src)-1)]
+		for col := 0; col < width; col++ {
+			if m, ok := markers[[2]int{row, col}]; ok {
+				sb.WriteString(m + "[-:-:-]")
+				continue
+			}
+			srcCol := col * srcW / width
+			land := srcCol < len(line) && line[srcCol] != ' '
+			day := sunElevation(cellLat(row), cellLon(col), sunLat, sunLon) > -6 // Civil twilight counts as day
+			switch {
+			case land && day:
+				sb.WriteString(mainC + "#")
+			case land:
+				sb.WriteString(dimC + "#")
+			case day:
+				sb.WriteString(" ")
+			default:
+				sb.WriteString(dimC + ".")
+			}
+		}
+		sb.WriteString("[-:-:-]\n")
+	}
+
+	for _, loc := range locs {
+		local := now.In(loc.Zone)
+		state := ""
+		if loc.Placed {
+			state = "night"
+			if sunElevation(loc.Lat, loc.Lon, sunLat, sunLon) > 0 {
+				state = "day"
+			}
+		}
+		sb.WriteString(fmt.Sprintf("%s%-12s %s%s %s%s[-:-:-]\n",
+			mainC, tview.Escape(loc.Label),
+			brightC, local.Format("15:04 Mon"),
+			dimC, state,
+		))
+	}
+	return sb.String()
+}
+
+// updateWorldMap redraws the map. Called on every time tick; the width is read
+// on the UI goroutine so the map follows terminal resizes, and setPanel only
+// redraws when the map has moved.
+func (b *Baseline) updateWorldMap() {
+	now := time.Now()
+	b.app.QueueUpdate(func() {
+		_, _, width, _ := b.worldPanel.GetInnerRect()
+		b.setPanel(b.worldPanel, renderWorldMap(now, width, b.worldLocs, b.theme))
+	})
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Yank ---
+//
+// `yank todo [n]` copies a todo's text (the selected one by default, also
+// `Y` with the task list focused), `yank system` a plain text summary of the
+// machine and `yank notification` the latest notification, ready to paste
+// into a chat or a ticket. copyText picks the clipboard: the platform helper
+// (pbcopy, xclip, xsel, wl-copy, clip.exe) or OSC 52 through the terminal.
+
+var yankTargets = []string{"todo", "system", "notification"}
+
+// yankCommand handles "yank <what>". Called from processCommand with b.mu
+// held.
+func (b *Baseline) yankCommand(args []string) {
+	if len(args) == 0 {
+		b.addNotification("Usage: yank todo [n] | system | notification", "error")
+		return
+	}
+	switch args[0] {
+	case "todo", "task":
+		if len(args) == 1 {
+			if i, ok := b.selectedTodo(); ok {
+				b.yankTodo(i)
+			}
+			return
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 || n > len(b.todoItems) {
+			b.addNotification(fmt.Sprintf("Invalid todo index: %s", args[1]), "error")
+			return
+		}
+		b.yankTodo(n - 1)
+	case "system", "sys":
+		go func() { // Measuring the CPU takes a moment
+			b.copyText("the system summary", systemSummary(collectSystem(statusSampleTime)))
+		}()
+	case "notification", "notif", "last":
+		b.notifMu.Lock()
+		n := len(b.notifications)
+		var latest Notification
+		if n > 0 {
+			latest = b.notifications[n-1]
+		}
+		b.notifMu.Unlock()
+		if n == 0 {
+			b.addNotification("No notifications to copy", "info")
+			return
+		}
+		go b.copyText("the last notification", fmt.Sprintf("[%s] %s", latest.Time.Format("2006-01-02 15:04:05"), latest.Message))
+	default:
+		b.addNotification(fmt.Sprintf("Unknown yank target: %s (%s)", args[0], strings.Join(yankTargets, ", ")), "error")
+	}
+}
+
+// yankTodo copies the text of todo i. Called with b.mu held.
+func (b *Baseline) yankTodo(i int) {
+	go b.copyText(fmt.Sprintf("todo #%d", i+1), b.todoItems[i].Text)
+}
+
+// systemSummary is a short plain text report of sys, e.g. for a ticket.
+func systemSummary(sys SystemSnapshot) string {
+	lines := []string{
+		fmt.Sprintf("%s (%s), up %s", sys.Host, sys.OS, formatDuration(time.Duration(sys.UptimeSeconds)*time.Second)),
+		fmt.Sprintf("CPU %.1f%%, memory %.1f%%, disk %.1f%%", sys.CPU, sys.Memory, sys.Disk),
+	}
+	if len(sys.Load) == 3 {
+		lines = append(lines, fmt.Sprintf("Load %.2f %.2f %.2f", sys.Load[0], sys.Load[1], sys.Load[2]))
+	}
+	var temps []string
+	for _, cat := range sensorCategories {
+		if t, ok := sys.Temperatures[cat]; ok {
+			temps = append(temps, fmt.Sprintf("%s %.0f°C", cat, t))
+		}
+	}
+	if len(temps) > 0 {
+		lines = append(lines, "Temperatures "+strings.Join(temps, ", "))
+	}
+	return strings.Join(lines, "\n")
+}